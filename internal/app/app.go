@@ -4,24 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jmespath/go-jmespath"
 
-	"github.com/godynamo/internal/dynamo"
-	"github.com/godynamo/internal/models"
-	"github.com/godynamo/internal/query"
 	"github.com/godynamo/internal/ui"
 	"github.com/godynamo/internal/ui/textarea"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+	"github.com/godynamo/pkg/query"
 )
 
 // Messages
@@ -34,21 +34,33 @@ type (
 	continuousScanMsg struct {
 		result       *dynamo.ContinuousScanResult
 		totalScanned int64
+		resumed      bool // continueScan: result includes the earlier items
 	}
 	scanProgressMsg struct {
 		itemsFound   int
 		totalScanned int64
+		ch           chan tea.Msg
 	}
-	itemSavedMsg      struct{}
-	itemDeletedMsg    struct{}
-	tableCreatedMsg   struct{}
+	itemSavedMsg struct {
+		item map[string]types.AttributeValue
+		prev map[string]types.AttributeValue
+	}
+	itemDeletedMsg    struct{ found bool }
+	itemExportedMsg   struct{ path string }
+	tableCreatedMsg   struct{ table string }
 	connectionTestMsg struct {
 		success bool
 		err     error
-		client  *dynamo.Client
+		client  dynamo.API
+		profile string // what the connection was made for
 		region  string
 	}
-	regionsDiscoveredMsg struct{ regions []dynamo.RegionInfo }
+	regionScanStartedMsg struct{ ch <-chan dynamo.RegionInfo }
+	regionFoundMsg       struct {
+		ch     <-chan dynamo.RegionInfo
+		region dynamo.RegionInfo
+	}
+	regionsDiscoveredMsg struct{ ch <-chan dynamo.RegionInfo }
 )
 
 // View modes
@@ -69,6 +81,34 @@ const (
 	viewConfirmContinueScan
 	viewExport
 	viewSchema
+	viewItemHistory
+	viewExportItem
+	viewS3Export
+	viewS3Import
+	viewPartiQL
+	viewConfirmCostlyScan
+	viewScanSegment
+	viewStreams
+	viewInsights
+	viewAutoScaling
+	viewSetup
+	viewBulkRename
+	viewSeed
+	viewTableCopy
+	viewJQ
+	viewFailedWrites
+	viewPlannedOps
+	viewNotifications
+	viewErrorDetail
+	viewErrorLog
+	viewCopyCode
+	viewConfirmOverwrite
+	viewPartitions
+	viewSnapshots
+	viewSnapshotDiff
+	viewOpenWith
+	viewOpStats
+	viewDeleteByKey
 )
 
 // Focus areas
@@ -83,12 +123,13 @@ const (
 // Model is the main application model
 type Model struct {
 	// DynamoDB client
-	client *dynamo.Client
+	client dynamo.API
 
 	// Connection settings
 	connections []models.Connection
 
 	// Current state
+	config    Config
 	view      viewMode
 	focus     focusArea
 	err       error
@@ -97,34 +138,218 @@ type Model struct {
 
 	// Region discovery
 	discoveredRegions  []dynamo.RegionInfo
+	regionCh           <-chan dynamo.RegionInfo // open while discovery is running
 	regionList         ui.List
 	selectedRegion     string
 	selectedRegionIdx  int
 	regionDropdownOpen bool
 
+	// Bulk attribute rename
+	renameInputs   []textinput.Model
+	renameFocus    int
+	renameCh       chan tea.Msg // set while a rename or its dry run runs
+	renameCancel   context.CancelFunc
+	renameDryRun   bool
+	renameProgress dynamo.RenameProgress
+	renamePreview  *renamePreview
+
+	// Synthetic data generator
+	seedCount   textinput.Model
+	seedEditor  textarea.Model
+	seedFor     string // table the template was written for
+	seedFocus   int    // 0 count, 1 template
+	seedCh      chan tea.Msg
+	seedCancel  context.CancelFunc
+	seedWritten int
+	seedTotal   int
+
+	// Copying the table into another, with an optional transform
+	copyDest     textinput.Model
+	copyEditor   textarea.Model
+	copyFocus    int
+	copyCh       chan tea.Msg
+	copyCancel   context.CancelFunc
+	copyProgress dynamo.CopyProgress
+	copySamples  textinput.Model
+	copyReport   *dynamo.VerifyReport // the last verification
+
+	// Items bulk writes gave up on, queued on disk per table
+	failedWrites   []failedWrite
+	failedCursor   int
+	failedCount    int // queued for the current table, for the status bar
+	failedRetrying bool
+
+	// Vim keybindings: the first key of a two-key command (the g of gg) and
+	// the : command line
+	vimPending string
+	vimCmdMode bool
+	vimCmd     textinput.Model
+
+	// Keyboard macros: the keys recorded per register, the register being
+	// recorded into and its keys so far, and the register @@ replays
+	macros         map[string][]tea.KeyMsg
+	macroRecording string
+	macroKeys      []tea.KeyMsg
+	lastMacro      string
+
+	// A replay: the keys left to press, how many macros it replayed, and
+	// whether its next key is on its way
+	macroQueue    []tea.KeyMsg
+	macroReplays  int
+	macroStepping bool
+
+	// Changes a dry run planned instead of making; nil outside one
+	plan       *dynamo.Plan
+	planCursor int
+	planReturn viewMode // the view Esc goes back to
+
+	// What background operations reported, oldest first; the ones from
+	// toastsFrom on may still show as toasts
+	notifications []notification
+	toastsFrom    int
+	notifyCursor  int
+	notifyReturn  viewMode
+
+	// The last AWS error, shown in its own modal
+	errDetail dynamo.ErrorDetail
+	errText   string
+	errReturn viewMode
+
+	// Errors and warnings of the session, oldest first
+	errorLog       []errorLogEntry
+	errorLogCursor int
+	errorLogReturn viewMode
+
+	// Every AWS call of the session's clients, and the view their
+	// statistics were opened from
+	opStats       *dynamo.OpStats
+	opStatsReturn viewMode
+
+	// Delete by key (X): a field per key attribute
+	deleteKeyInputs []textinput.Model
+	deleteKeyFocus  int
+
+	// Copy as code (K)
+	codeTab    int
+	codeReturn viewMode
+
+	// What the table's JSON Schema found wrong with the edited item, and the
+	// lint warnings shown for lintedText
+	schemaViolations []string
+	lintWarnings     []string
+	lintedText       string
+
+	// The item a create would replace, found before saving
+	overwriting map[string]types.AttributeValue
+
+	// Decrypt plugin (D), and what it revealed of the item with decryptedKey
+	decrypter    decrypter
+	decrypted    map[string]interface{}
+	decryptedKey string
+	// b: show base64 and compressed attributes decoded
+	decodePayloads bool
+	// J: show JSON held in strings parsed, and edit it nested; the editor
+	// serializes editorJSONStrings back on save
+	expandJSONStrings bool
+	editorJSONStrings []string
+
+	// Partition key report (P): chart instead of top lists, keys grouped by
+	// their first partitionGroup parts (0 for whole keys), bars by size
+	partitionChart  bool
+	partitionGroup  int
+	partitionBySize bool
+	partitionOffset int
+
+	// Watch mode (Ctrl+W): the table view's read re-run every watchEvery,
+	// watchSeen holding each item's JSON at the last run and watchMarks what
+	// that run found new or changed
+	watching   bool
+	watchSeq   int
+	watchTable string
+	watchEvery time.Duration
+	watchSeen  map[string]string
+	watchMarks map[string]watchChange
+	// the filter matched nothing last run; the first match alerts
+	watchAwaiting bool
+
+	// Named snapshots of the table view's results (D), the one a fresh run
+	// is being read to compare with, and the rendered diff
+	snapshots       []resultSnapshot
+	snapshotCursor  int
+	snapshotNaming  bool
+	snapshotName    textinput.Model
+	pendingDiff     *resultSnapshot
+	snapshotDiff    []string
+	snapshotDiffOff int
+
+	// External tools (o) the open item can be piped to: the one picked and
+	// the output of the last run
+	toolCursor    int
+	toolName      string
+	toolOutput    []string
+	toolOutputOff int
+
+	// jq over the loaded items
+	jqInput  textinput.Model
+	jqFor    string // table the expression and output belong to
+	jqSlurp  bool   // run once over all items, like jq -s
+	jqLines  []string
+	jqOffset int
+
+	// Browsing cached snapshots without a connection
+	offline          bool
+	offlineSnapshots map[string]offlineSnapshot // by table
+
+	// Round trip to the endpoint, measured every latencyInterval
+	pingSeq    int
+	latency    time.Duration
+	latencyErr error
+
+	// AWS profile dropdown
+	profileDropdownOpen bool
+	profiles            []string
+	profileIdx          int
+
 	// Window dimensions
 	width  int
 	height int
+	zoomed bool // the main component has the whole window (Ctrl+Z)
 
 	// Tables
 	tables          []string
 	filteredTables  []string
 	tableFilter     string
 	tableFilterMode bool
+	tableIndex      *ui.FuzzyIndex
+	filteredFor     string // tableFilter value filteredTables was computed for
+	tableFilterSeq  int    // bumped per keystroke; only the latest debounce tick applies
 	tableList       ui.List
 	currentTable    string
 	tableInfo       *dynamo.TableInfo
+	headerCache     *headerCache
+	prefs           tablePrefs // column layout, sort and page size of currentTable
+	columns         []string   // attributes shown, in column order
 
 	// Data view
 	dataTable ui.DataTable
 	items     []map[string]types.AttributeValue
 	lastKey   map[string]types.AttributeValue
 	pageSize  int32
+	capacity  float64 // read capacity units the last scan or query cost
+	typeHints bool    // cells that could pass for another type show theirs (T)
+	keepRow   bool    // the reload after a save puts the cursor back on keptRow
+	keptRow   int
 
 	// Item view
 	selectedItem map[string]types.AttributeValue
 	jsonViewer   *ui.JSONViewer
 	itemViewport viewport.Model
+	compactJSON  bool // item detail renders single-line JSON
+	yamlView     bool // item detail renders YAML (takes precedence over compactJSON)
+
+	// Session edit history, keyed by historyKey (table + primary key)
+	editHistory map[string][]itemSnapshot
+	historyIdx  int
 
 	// Query/Filter
 	filterBuilder ui.FilterBuilder
@@ -135,10 +360,66 @@ type Model struct {
 
 	// Continuous scan state
 	scanCancel       context.CancelFunc
+	scanCh           chan tea.Msg // the running continuous scan, see waitForScan
+	scanStarted      time.Time
+	savedScan        *savedScan // where an earlier filtered scan of the table stopped
 	scanTotalScanned int64
 	scanItemsFound   int
 	scanLastKey      map[string]types.AttributeValue
 
+	// Items a continuous scan moved to disk once scanItemCap was reached
+	scanItemCap int
+	spill       *itemSpill
+	spillView   int // 1-based spilled page on screen; 0 shows the live items
+	spillLive   []map[string]types.AttributeValue
+
+	// Full-table scans of tables above scanWarnBytes wait in costlyScan
+	scanWarnBytes int64
+	costlyScan    *costlyScan
+
+	// Each run of a continuous scan stops once scanBudget is used up
+	scanBudget scanBudget
+
+	// Stream tail: shard picker, then the records of streamReader
+	streamShards  []dynamo.StreamShard
+	streamPicked  map[string]bool
+	streamCursor  int
+	streamStart   dynamo.StreamStart
+	streamAtInput textinput.Model
+	streamReader  *dynamo.StreamReader
+	streamRecords []dynamo.StreamRecord
+
+	// Contributor Insights for the current table, opened from the schema
+	insights       *dynamo.ContributorInsights
+	insightReports []*dynamo.InsightReport
+	insightsWindow int // index into insightWindows
+
+	// The schema view shows policy, the resource policy of the table whose
+	// policyKey is policyFor, instead of the description while showPolicy
+	// is set
+	showPolicy bool
+	policyFor  string
+	policy     string
+
+	// Auto scaling of the table's and GSIs' read/write capacity
+	scalingTargets []dynamo.ScalingTarget
+	scalingCursor  int
+	scalingEditing bool
+	scalingInputs  []textinput.Model
+	scalingFocus   int
+
+	// First-run setup wizard
+	setupStep     int
+	setupCursor   int
+	setupChoices  setupChoices
+	setupProfiles []string
+	setupEndpoint textinput.Model
+
+	// Scans read only scanSegment when it is set
+	scanSegment   *dynamo.ScanSegment
+	segmentInputs []textinput.Model
+	segmentFocus  int
+
 	// Create/Edit item
 	itemEditor textarea.Model
 
@@ -146,6 +427,13 @@ type Model struct {
 	searchInput textinput.Model
 	searchMode  bool
 
+	// Search across the loaded rows of the table view
+	rowSearchInput textinput.Model
+	rowSearchMode  bool
+	rowIdx         *rowIndex
+	rowMatches     []int // matching row numbers, ascending
+	rowMatchIdx    int
+
 	// Editor Visual Mode
 	visualMode        bool
 	visualSelectMode  bool
@@ -155,12 +443,68 @@ type Model struct {
 	// Create table form
 	createTableForm createTableForm
 
+	// A table just created is polled until ACTIVE before the list returns
+	creatingTable string
+	createStarted time.Time
+
+	// Turns while anything is loading; spinning is whether its ticks run
+	spinner  spinner.Model
+	spinning bool
+
 	// Confirm delete
 	deleteTarget string
 
 	// Export
-	exportFormat string
-	exportPath   string
+	exportFormat    string
+	exportPath      string
+	csvOptions      csvOptions
+	csvDelimPending bool // next key press sets the CSV delimiter
+	exportGzip      bool // write <table>.<fmt>.gz
+	exportFull      bool // export the whole table with a parallel scan, not just loaded items
+	exportWorkers   int  // parallel scan segments; 0 means defaultExportWorkers
+	exportCh        chan tea.Msg
+
+	// JMESPath projection applied to each exported item
+	exportProjInput   textinput.Model
+	exportProjEditing bool
+	exportProjExpr    string
+	exportProjection  *jmespath.JMESPath // nil exports items whole
+	exportProjErr     string
+
+	// Single-item export
+	itemExportInput  textinput.Model
+	itemExportDynamo bool // typed DynamoDB JSON instead of plain JSON
+
+	// Native S3 export (ExportTableToPointInTime)
+	s3ExportInputs  []textinput.Model
+	s3ExportFocus   int
+	s3ExportIon     bool
+	s3Export        *dynamo.S3Export // running or last finished export
+	s3ExportFails   int              // describes of it that failed in a row
+	s3ExportPollErr string           // the last of those failures
+
+	// Native S3 import (ImportTable)
+	s3ImportInputs      []textinput.Model
+	s3ImportFocus       int
+	s3ImportFormat      int // index into importFormats
+	s3ImportCompression int // index into importCompressions
+	s3Import            *dynamo.S3Import
+	s3ImportFails       int    // describes of it that failed in a row
+	s3ImportPollErr     string // the last of those failures
+
+	// PartiQL editor
+	partiqlEditor       textarea.Model
+	partiqlReturn       viewMode // view Esc goes back to
+	partiqlFocusResults bool
+	partiqlTable        ui.DataTable
+	partiqlItems        []map[string]types.AttributeValue
+	partiqlStmt         string  // statement the results came from
+	partiqlNext         *string // NextToken for the next page of partiqlStmt
+	partiqlPending      *partiqlWrite
+	partiqlComplete     *partiqlCompletion // set while Tab is cycling candidates
+	partiqlHistory      []partiqlHistoryEntry
+	partiqlHistoryIdx   int
+	partiqlHistoryOpen  bool
 }
 
 type createTableForm struct {
@@ -173,17 +517,24 @@ type createTableForm struct {
 // New creates a new Model
 func New() Model {
 	m := Model{
-		view:      viewConnect,
-		focus:     focusSidebar,
-		pageSize:  500,
-		loading:   true,
-		statusMsg: "Connecting to AWS DynamoDB...",
+		config:        DefaultConfig(),
+		view:          viewConnect,
+		focus:         focusSidebar,
+		pageSize:      500,
+		loading:       true,
+		statusMsg:     "Connecting to AWS DynamoDB...",
+		scanItemCap:   defaultScanItemCap,
+		scanWarnBytes: defaultScanWarnMB << 20,
+		scanBudget:    defaultScanSettings().budget(),
+		watchEvery:    defaultWatchInterval,
+		opStats:       &dynamo.OpStats{},
 	}
 
 	m.initCreateTableForm()
 	m.initFilterBuilder()
 	m.initItemEditor()
 	m.initSearchInput()
+	m.initItemExportInput()
 
 	m.tableList = ui.NewList("Tables", []string{})
 	m.tableList.Height = 30
@@ -194,6 +545,7 @@ func New() Model {
 	m.dataTable = ui.NewDataTable()
 
 	m.itemViewport = viewport.New(80, 20)
+	m.spinner = spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(ui.KeyStyle))
 
 	return m
 }
@@ -254,43 +606,136 @@ func (m *Model) initItemEditor() {
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	// Start discovering regions immediately
+	if m.view == viewSetup {
+		return m.tickClock() // connecting waits for the wizard
+	}
+	return tea.Batch(m.connect(), m.tickClock())
+}
+
+// connect starts from the configured client, endpoint or region. Without any it
+// discovers the regions with tables and connects to the first one found; a
+// configured region is connected to without waiting for the scan.
+func (m *Model) connect() tea.Cmd {
+	switch {
+	case m.config.Client != nil:
+		client, profile := m.config.Client, m.config.Profile
+		return func() tea.Msg { return connectionTestMsg{success: true, client: client, profile: profile} }
+	case m.config.Endpoint != "":
+		return m.connectToEndpoint()
+	case m.config.Region != "":
+		return tea.Batch(m.connectToRegion(m.config.Region), m.discoverRegions())
+	}
 	return m.discoverRegions()
 }
 
+// discoverRegions probes all regions; each one with tables arrives as its own
+// regionFoundMsg, followed by regionsDiscoveredMsg once every probe is done.
 func (m *Model) discoverRegions() tea.Cmd {
 	return func() tea.Msg {
-		regions, err := dynamo.DiscoverRegionsWithTables(context.Background(), "", false, "")
-		if err != nil {
-			return errMsg{err}
+		return regionScanStartedMsg{ch: dynamo.StreamRegionsWithTables(context.Background(), m.connection(""))}
+	}
+}
+
+func waitForRegion(ch <-chan dynamo.RegionInfo) tea.Cmd {
+	return func() tea.Msg {
+		region, ok := <-ch
+		if !ok {
+			return regionsDiscoveredMsg{ch: ch}
 		}
-		return regionsDiscoveredMsg{regions: regions}
+		return regionFoundMsg{ch: ch, region: region}
 	}
 }
 
-// Update handles messages
+// Update handles messages. A status message left by anything but a key
+// press is kept as a notification, so a background operation's outcome
+// survives the next message overwriting it, and errors and warnings go to
+// the error log. Starting to load anything starts the spinner.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prev := m.statusMsg
+	switch v := msg.(type) {
+	case tea.KeyMsg:
+		m.recordMacroKey(v)
+		if len(m.macroQueue) > 0 {
+			// A key pressed during a replay stops it
+			m.statusMsg = "⚠ Stopped replaying @" + m.lastMacro
+			m.stopReplay()
+			return m, nil
+		}
+	case macroStepMsg:
+		key, ok := m.nextMacroKey()
+		if !ok {
+			return m, nil
+		}
+		msg = key
+	}
+	model, cmd := m.update(msg)
+	next, ok := model.(*Model)
+	if v, isValue := model.(Model); isValue {
+		next, ok = &v, true
+	}
+	if !ok {
+		return model, cmd
+	}
+	next.stopReplayOnError(prev)
+	if e, isErr := msg.(errMsg); isErr {
+		next.logErrMsg(e.err)
+	} else {
+		next.logStatusProblem(prev)
+	}
+	if _, isKey := msg.(tea.KeyMsg); !isKey {
+		next.notify(prev)
+	}
+	return next, tea.Batch(cmd, next.stepMacro(), next.keepSpinning())
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// The spinner turns whichever view is open
+	if tick, ok := msg.(spinner.TickMsg); ok {
+		return m, m.handleSpinnerTick(tick)
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "ctrl+z" && zoomViews[m.view] {
+		m.toggleZoom()
+		return m, nil
+	}
+
 	// Handle viewQuery separately to support unicode input
 	if m.view == viewQuery {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			switch keyMsg.String() {
 			case "ctrl+c", "ctrl+q":
+				m.resetSpill()
 				return m, tea.Quit
 			}
 		}
 		return m.updateQuery(msg)
 	}
 
+	// The PartiQL editor needs every key, but its results arrive as messages
+	if m.view == viewPartiQL {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "ctrl+c", "ctrl+q":
+				m.resetSpill()
+				return m, tea.Quit
+			}
+			return m.updatePartiQL(keyMsg)
+		}
+	}
+
 	// Handle item editor views separately to support full textarea functionality (Enter, etc.)
 	if m.view == viewCreateItem || m.view == viewEditItem {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			switch keyMsg.String() {
 			case "ctrl+c", "ctrl+q":
+				m.resetSpill()
 				return m, tea.Quit
 			}
 		}
+		if check, ok := msg.(overwriteCheckMsg); ok && m.view == viewCreateItem {
+			return m, m.handleOverwriteCheck(check)
+		}
 		return m.updateItemEditor(msg)
 	}
 
@@ -298,116 +743,329 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.dataTable.SetSize(msg.Width-35, msg.Height-10)
-		m.tableList.Height = msg.Height - 10
-		m.itemViewport.Width = msg.Width - 40
-		m.itemViewport.Height = msg.Height - 15
-		// Resize item editor based on window
-		m.itemEditor.SetWidth(msg.Width - 20)
-		m.itemEditor.SetHeight(msg.Height - 12)
+		m.layout()
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.vimCmdMode {
+			// The command line takes every key, unmapped
+			return m, m.updateVimCommandLine(msg)
+		}
+		msg = m.remapKey(msg)
+		if m.config.Keybindings == keybindingsEmacs {
+			msg = m.emacsKey(msg)
+		}
+		if m.refusesWriteKey(msg) || m.refusesOnlineKey(msg) {
+			return m, nil
+		}
 		// Global keys
 		switch msg.String() {
 		case "ctrl+c", "ctrl+q":
+			m.resetSpill()
 			return m, tea.Quit
+		case "ctrl+l":
+			if m.plan != nil && m.view != viewPlannedOps {
+				m.openPlannedOps()
+				return m, nil
+			}
+		case "ctrl+y":
+			if m.view != viewNotifications {
+				m.openNotifications()
+				return m, nil
+			}
+		case "ctrl+x":
+			if m.view != viewErrorLog {
+				m.openErrorLog()
+				return m, nil
+			}
+		case "ctrl+f":
+			if m.view != viewOpStats {
+				m.openOpStats()
+				return m, nil
+			}
 		}
 
-		// View-specific handling
-		switch m.view {
-		case viewConnect:
-			return m.updateConnect(msg)
-		case viewSelectRegion:
-			return m.updateSelectRegion(msg)
-		case viewTables:
-			return m.updateTables(msg)
-		case viewTableData:
-			return m.updateTableData(msg)
-		case viewItemDetail:
-			return m.updateItemDetail(msg)
-		case viewCreateTable:
-			return m.updateCreateTable(msg)
-		case viewConfirmDelete:
-			return m.updateConfirmDelete(msg)
-		case viewConfirmSave:
-			return m.updateConfirmSave(msg)
-		case viewConfirmContinueScan:
-			return m.updateConfirmContinueScan(msg)
-		case viewExport:
-			return m.updateExport(msg)
-		case viewSchema:
-			return m.updateSchema(msg)
+		switch m.config.Keybindings {
+		case keybindingsVim:
+			return m.updateVim(msg)
+		case keybindingsEmacs:
+			return m.updateEmacs(msg)
 		}
+		return m.updateKey(msg)
 
 	case errMsg:
 		m.err = msg.err
 		m.loading = false
+		m.scanCh = nil
+		m.creatingTable = ""
 		m.statusMsg = "Error: " + msg.err.Error()
+		if d, ok := dynamo.DescribeError(msg.err); ok {
+			m.statusMsg = "Error: " + d.Code
+			m.openErrorDetail(d, msg.err.Error())
+		}
 		return m, nil
 
 	case tablesLoadedMsg:
 		m.tables = msg.tables
+		m.tableIndex = ui.NewFuzzyIndex(msg.tables)
 		m.filteredTables = msg.tables
 		m.tableFilter = ""
+		m.filteredFor = ""
 		m.tableFilterMode = false
 		m.tableList.SetItems(msg.tables)
 		m.loading = false
 		m.view = viewTables
 		m.statusMsg = fmt.Sprintf("Loaded %d tables", len(msg.tables))
+		if m.config.Table != "" {
+			return m, m.openDeepLink()
+		}
+		return m, nil
+
+	case deepLinkItemMsg:
+		m.showDeepLinkItem(msg)
+		return m, nil
+
+	case tableFilterMsg:
+		if msg.seq == m.tableFilterSeq && m.filteredFor != m.tableFilter {
+			m.applyTableFilter()
+		}
 		return m, nil
 
 	case tableInfoMsg:
 		m.tableInfo = msg.info
 		m.loading = false
+		m.savedScan = loadSavedScan(m.connectionKey(), m.currentTable)
+		return m, watchTable(msg.info)
+
+	case tableWatchMsg:
+		return m, m.refreshTableStatus(msg.table)
+
+	case tableStatusMsg:
+		return m, m.handleTableStatus(msg.info)
+
+	case renameProgressMsg:
+		m.renameProgress = msg.progress
+		return m, waitForRename(m.renameCh)
+
+	case renameDoneMsg:
+		return m, m.handleRenameDone(msg)
+
+	case seedProgressMsg:
+		m.seedWritten = msg.written
+		return m, waitForSeed(m.seedCh)
+
+	case seedDoneMsg:
+		return m, m.handleSeedDone(msg)
+
+	case copyProgressMsg:
+		m.copyProgress = msg.progress
+		return m, waitForCopy(m.copyCh)
+
+	case copyDoneMsg:
+		m.handleCopyDone(msg)
 		return m, nil
 
-	case scanResultMsg:
-		m.handleScanResult(msg.result)
+	case copyVerifiedMsg:
+		m.handleCopyVerified(msg)
 		return m, nil
 
+	case failedRetryMsg:
+		return m, m.handleFailedRetry(msg)
+
+	case latencyMsg:
+		return m, m.handleLatency(msg)
+
+	case latencyTickMsg:
+		if msg.seq != m.pingSeq {
+			return m, nil
+		}
+		return m, m.ping()
+
+	case clockTickMsg:
+		return m, m.tickClock()
+
+	case scanResultMsg:
+		return m, m.handleScanResult(msg.result)
+
+	case watchTickMsg:
+		return m, m.handleWatchTick(msg)
+
+	case scanProgressMsg:
+		return m, m.handleScanProgress(msg)
+
 	case continuousScanMsg:
-		m.handleContinuousScanResult(msg.result)
+		m.scanCh = nil
+		if !msg.resumed {
+			m.resetSpill()
+		}
+		m.spillView, m.spillLive = 0, nil
+		alert := m.handleContinuousScanResult(msg.result)
+		m.recordScanPosition(msg.result)
 		// If timed out and there's more data, ask to continue
 		if msg.result.TimedOut && msg.result.HasMore {
 			m.scanLastKey = msg.result.LastEvaluatedKey
 			m.scanTotalScanned = msg.result.TotalScanned
-			m.scanItemsFound = len(msg.result.Items)
+			m.scanItemsFound = len(m.items)
+			if m.spill != nil {
+				m.scanItemsFound += m.spill.items
+			}
 			m.view = viewConfirmContinueScan
 		}
+		return m, alert
+
+	case streamShardsMsg:
+		m.handleStreamShards(msg.shards)
+		return m, nil
+
+	case streamOpenedMsg:
+		if m.view != viewStreams {
+			return m, nil
+		}
+		m.streamReader = msg.reader
+		return m, pollStream(msg.reader)
+
+	case streamPollMsg:
+		if msg.reader != m.streamReader {
+			return m, nil
+		}
+		return m, pollStream(msg.reader)
+
+	case streamRecordsMsg:
+		return m, m.handleStreamRecords(msg)
+
+	case insightsMsg:
+		m.handleInsights(msg)
+		return m, nil
+
+	case insightsToggledMsg:
+		m.handleInsightsToggled(msg.status)
+		return m, nil
+
+	case resourcePolicyMsg:
+		m.handleResourcePolicy(msg)
+		return m, nil
+
+	case autoScalingMsg:
+		m.handleAutoScaling(msg)
+		return m, nil
+
+	case autoScalingSavedMsg:
+		return m, m.handleAutoScalingSaved(msg.target)
+
+	case sampleResultMsg:
+		m.handleSampleResult(msg.result)
 		return m, nil
 
 	case queryResultMsg:
-		m.handleQueryResult(msg.result)
+		return m, m.handleQueryResult(msg.result)
+
+	case decryptedMsg:
+		m.showDecrypted(msg)
+		return m, nil
+
+	case toolOutputMsg:
+		m.showToolOutput(msg)
 		return m, nil
 
 	case itemSavedMsg:
+		m.recordSave(msg.prev, msg.item)
 		m.statusMsg = "Item saved successfully"
-		m.loading = false
+		m.loading = true // until the table reloads
 		m.view = viewTableData
+		m.keepRow, m.keptRow = true, m.dataTable.SelectedRow
 		return m, m.scanTable()
 
 	case itemDeletedMsg:
+		if !msg.found {
+			m.statusMsg = "⚠ Item not found; nothing was deleted"
+			m.loading = false
+			m.view = viewTableData
+			return m, nil
+		}
 		m.statusMsg = "Item deleted successfully"
-		m.loading = false
+		m.loading = true // until the table reloads
 		m.view = viewTableData
 		return m, m.scanTable()
 
-	case tableCreatedMsg:
-		m.statusMsg = "Table created successfully"
+	case s3ExportMsg:
+		return m, m.handleS3Export(msg.export)
+
+	case s3ExportPollMsg:
+		return m, m.describeS3Export(msg.arn)
+
+	case s3ExportPollFailedMsg:
+		return m, m.handleS3ExportPollFailed(msg)
+
+	case s3ImportMsg:
+		return m, m.handleS3Import(msg.imp)
+
+	case s3ImportPollMsg:
+		return m, m.describeS3Import(msg.arn)
+
+	case s3ImportPollFailedMsg:
+		return m, m.handleS3ImportPollFailed(msg)
+
+	case partiqlResultMsg:
+		m.handlePartiQLResult(msg)
+		return m, nil
+
+	case partiqlPreviewMsg:
 		m.loading = false
-		m.view = viewTables
-		return m, m.loadTables()
+		m.partiqlPending = msg.write
+		m.statusMsg = ""
+		return m, nil
+
+	case partiqlWriteMsg:
+		return m, m.handlePartiQLWrite(msg)
+
+	case exportProgressMsg:
+		m.statusMsg = fmt.Sprintf("Exporting %s: %d items (%d/%d segments done)...", m.currentTable, msg.items, msg.segmentsDone, msg.segments)
+		return m, waitForExport(m.exportCh)
+
+	case exportDoneMsg:
+		m.loading = false
+		m.exportCh = nil
+		m.statusMsg = fmt.Sprintf("Exported %d items to %s", msg.items, msg.path)
+		if m.view == viewExport {
+			m.view = viewTableData
+		}
+		return m, nil
+
+	case itemExportedMsg:
+		m.statusMsg = "✓ Exported item to " + msg.path
+		m.view = viewItemDetail
+		return m, nil
+
+	case tableCreatedMsg:
+		if m.plan != nil {
+			// Nothing was created, so there is nothing to wait for
+			return m, m.finishTableWait("Planned creating " + msg.table + " (dry run; Ctrl+L lists planned operations)")
+		}
+		return m, m.waitForTable(msg.table)
+
+	case createPollMsg:
+		if msg.table != m.creatingTable {
+			return m, nil
+		}
+		return m, m.pollCreatedTable(msg.table)
+
+	case createStatusMsg:
+		return m, m.handleCreateStatus(msg.info)
 
 	case connectionTestMsg:
+		// A profile or region switched away from while it was connecting
+		if msg.profile != m.config.Profile || msg.region != "" && m.selectedRegion != "" && msg.region != m.selectedRegion {
+			return m, nil
+		}
 		if msg.success {
-			m.client = msg.client
+			// Whatever the client came from, a dry run plans its changes
+			m.client = dynamo.DryRun(msg.client, m.plan)
 			if msg.region != "" {
 				m.selectedRegion = msg.region
 			}
 			m.loading = true
 			m.statusMsg = "Connected! Loading tables..."
-			return m, m.loadTables()
+			return m, tea.Batch(m.loadTables(), m.startPinging())
 		} else {
 			m.loading = false
 			m.err = msg.err
@@ -415,24 +1073,132 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case regionScanStartedMsg:
+		m.regionCh = msg.ch
+		m.discoveredRegions = nil
+		return m, waitForRegion(msg.ch)
+
+	case regionFoundMsg:
+		if msg.ch != m.regionCh {
+			return m, nil // from a discovery that was restarted
+		}
+		m.discoveredRegions = append(m.discoveredRegions, msg.region)
+		if msg.region.Region == m.config.Region {
+			m.selectedRegionIdx = len(m.discoveredRegions) - 1
+		}
+		if len(m.discoveredRegions) > 1 || m.config.Region != "" {
+			return m, waitForRegion(msg.ch)
+		}
+		// Connect to the first region that answers; later ones join the
+		// region dropdown as they arrive.
+		m.selectedRegionIdx = 0
+		m.selectedRegion = msg.region.Region
+		m.statusMsg = "Found tables in " + msg.region.Region + ", still scanning other regions..."
+		return m, tea.Batch(m.connectToRegion(msg.region.Region), waitForRegion(msg.ch))
+
 	case regionsDiscoveredMsg:
-		m.loading = false
-		m.discoveredRegions = msg.regions
-		if len(msg.regions) == 0 {
+		if msg.ch != m.regionCh {
+			return m, nil
+		}
+		m.regionCh = nil
+		if len(m.discoveredRegions) == 0 {
+			m.loading = false
 			m.statusMsg = "No regions with tables found"
 			m.err = fmt.Errorf("no DynamoDB tables found in any region")
 			return m, nil
 		}
-		// Connect to first region and show tables with region dropdown
-		m.selectedRegionIdx = 0
-		m.selectedRegion = msg.regions[0].Region
-		m.statusMsg = fmt.Sprintf("Found %d regions with tables", len(msg.regions))
-		return m, m.connectToRegion(msg.regions[0].Region)
+		if m.view == viewConnect || m.view == viewTables {
+			m.statusMsg = fmt.Sprintf("Found %d regions with tables", len(m.discoveredRegions))
+		}
+		return m, nil
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// updateKey hands a key to the current view
+func (m *Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.view {
+	case viewConnect:
+		return m.updateConnect(msg)
+	case viewSelectRegion:
+		return m.updateSelectRegion(msg)
+	case viewTables:
+		return m.updateTables(msg)
+	case viewTableData:
+		return m.updateTableData(msg)
+	case viewItemDetail:
+		return m.updateItemDetail(msg)
+	case viewCreateTable:
+		return m.updateCreateTable(msg)
+	case viewConfirmDelete:
+		return m.updateConfirmDelete(msg)
+	case viewConfirmSave:
+		return m.updateConfirmSave(msg)
+	case viewConfirmContinueScan:
+		return m.updateConfirmContinueScan(msg)
+	case viewConfirmCostlyScan:
+		return m.updateConfirmCostlyScan(msg)
+	case viewExport:
+		return m.updateExport(msg)
+	case viewSchema:
+		return m.updateSchema(msg)
+	case viewItemHistory:
+		return m.updateItemHistory(msg)
+	case viewExportItem:
+		return m.updateExportItem(msg)
+	case viewS3Export:
+		return m.updateS3Export(msg)
+	case viewS3Import:
+		return m.updateS3Import(msg)
+	case viewScanSegment:
+		return m.updateScanSegment(msg)
+	case viewStreams:
+		return m.updateStreams(msg)
+	case viewInsights:
+		return m.updateInsights(msg)
+	case viewAutoScaling:
+		return m.updateAutoScaling(msg)
+	case viewSetup:
+		return m.updateSetup(msg)
+	case viewBulkRename:
+		return m.updateBulkRename(msg)
+	case viewSeed:
+		return m.updateSeed(msg)
+	case viewTableCopy:
+		return m.updateTableCopy(msg)
+	case viewJQ:
+		return m.updateJQ(msg)
+	case viewFailedWrites:
+		return m.updateFailedWrites(msg)
+	case viewPlannedOps:
+		return m.updatePlannedOps(msg)
+	case viewNotifications:
+		return m.updateNotifications(msg)
+	case viewErrorDetail:
+		return m.updateErrorDetail(msg)
+	case viewErrorLog:
+		return m.updateErrorLog(msg)
+	case viewCopyCode:
+		return m.updateCopyCode(msg)
+	case viewConfirmOverwrite:
+		return m.updateConfirmOverwrite(msg)
+	case viewPartitions:
+		return m.updatePartitions(msg)
+	case viewSnapshots:
+		return m.updateSnapshots(msg)
+	case viewSnapshotDiff:
+		return m.updateSnapshotDiff(msg)
+	case viewOpenWith:
+		return m.updateOpenWith(msg)
+	case viewOpStats:
+		return m.updateOpStats(msg)
+	case viewDeleteByKey:
+		return m.updateDeleteByKey(msg)
+	}
+	return m, nil
+}
+
 func (m *Model) updateConnect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter", "r":
@@ -440,12 +1206,35 @@ func (m *Model) updateConnect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.loading = true
 		m.err = nil
 		m.statusMsg = "Scanning regions..."
-		return m, m.discoverRegions()
+		return m, m.connect()
+	case "o":
+		if !m.loading && m.err != nil {
+			m.goOffline()
+		}
 	}
 	return m, nil
 }
 
+// openTable shows a table from the list, from its offline snapshot when
+// there is no connection
+func (m *Model) openTable(name string) tea.Cmd {
+	m.currentTable = name
+	m.loadTablePrefs()
+	m.loadFailedCount()
+	if m.offline {
+		m.openOfflineTable()
+		return nil
+	}
+	m.loading = true
+	m.view = viewTableData
+	return tea.Batch(m.describeTable(), m.scanTable())
+}
+
 func (m *Model) updateTables(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.profileDropdownOpen {
+		return m.updateProfileDropdown(msg)
+	}
+
 	// Handle region dropdown
 	if m.regionDropdownOpen {
 		switch msg.String() {
@@ -481,12 +1270,12 @@ func (m *Model) updateTables(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.applyTableFilter()
 		case "enter":
 			m.tableFilterMode = false
+			if m.filteredFor != m.tableFilter {
+				m.applyTableFilter() // a debounced keystroke is still pending
+			}
 			// Select current item
 			if m.tableList.Selected >= 0 && m.tableList.Selected < len(m.filteredTables) {
-				m.currentTable = m.filteredTables[m.tableList.Selected]
-				m.loading = true
-				m.view = viewTableData
-				return m, tea.Batch(m.describeTable(), m.scanTable())
+				return m, m.openTable(m.filteredTables[m.tableList.Selected])
 			}
 		case "up":
 			m.tableList.MoveUp()
@@ -495,7 +1284,7 @@ func (m *Model) updateTables(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "backspace":
 			if len(m.tableFilter) > 0 {
 				m.tableFilter = m.tableFilter[:len(m.tableFilter)-1]
-				m.applyTableFilter()
+				return m, m.scheduleTableFilter()
 			}
 		case "ctrl+u":
 			m.tableFilter = ""
@@ -507,12 +1296,16 @@ func (m *Model) updateTables(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.createTableForm.focusIndex = 0
 		case "ctrl+r":
 			m.tableFilterMode = false
+			if m.offline {
+				return m, m.goOnline()
+			}
+			m.invalidateSchemaCache()
 			return m, m.loadTables()
 		default:
 			// Add character to filter
 			if len(msg.String()) == 1 {
 				m.tableFilter += msg.String()
-				m.applyTableFilter()
+				return m, m.scheduleTableFilter()
 			}
 		}
 		return m, nil
@@ -525,17 +1318,28 @@ func (m *Model) updateTables(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.tableList.MoveDown()
 	case "enter":
 		if m.tableList.Selected >= 0 && m.tableList.Selected < len(m.filteredTables) {
-			m.currentTable = m.filteredTables[m.tableList.Selected]
-			m.loading = true
-			m.view = viewTableData
-			return m, tea.Batch(m.describeTable(), m.scanTable())
+			return m, m.openTable(m.filteredTables[m.tableList.Selected])
 		}
 	case "ctrl+n":
 		m.view = viewCreateTable
 		m.createTableForm.inputs[0].Focus()
 		m.createTableForm.focusIndex = 0
+	case "ctrl+o":
+		return m, m.openS3Import()
+	case "ctrl+e":
+		table := ""
+		if m.tableList.Selected >= 0 && m.tableList.Selected < len(m.filteredTables) {
+			table = m.filteredTables[m.tableList.Selected]
+		}
+		return m, m.openPartiQL(table)
 	case "ctrl+r":
+		if m.offline {
+			return m, m.goOnline()
+		}
+		m.invalidateSchemaCache()
 		return m, m.loadTables()
+	case "ctrl+p":
+		m.openProfileDropdown()
 	case "/":
 		// Enter filter mode
 		m.tableFilterMode = true
@@ -569,11 +1373,36 @@ func (m *Model) updateTables(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Table lists at least this long filter after a short pause in typing
+// instead of on every keystroke.
+const (
+	tableFilterDebounceAt = 2000
+	tableFilterDebounce   = 80 * time.Millisecond
+)
+
+type tableFilterMsg struct{ seq int }
+
+// scheduleTableFilter applies the filter right away for short lists and
+// debounces it for long ones.
+func (m *Model) scheduleTableFilter() tea.Cmd {
+	if len(m.tables) < tableFilterDebounceAt {
+		m.applyTableFilter()
+		return nil
+	}
+	m.tableFilterSeq++
+	seq := m.tableFilterSeq
+	return tea.Tick(tableFilterDebounce, func(time.Time) tea.Msg { return tableFilterMsg{seq: seq} })
+}
+
 func (m *Model) applyTableFilter() {
+	m.filteredFor = m.tableFilter
 	if m.tableFilter == "" {
 		m.filteredTables = m.tables
 	} else {
-		matches := ui.FuzzyFind(m.tableFilter, m.tables)
+		if m.tableIndex == nil {
+			m.tableIndex = ui.NewFuzzyIndex(m.tables)
+		}
+		matches := m.tableIndex.Find(m.tableFilter)
 		m.filteredTables = make([]string, len(matches))
 		for i, match := range matches {
 			m.filteredTables[i] = match.Text
@@ -584,7 +1413,19 @@ func (m *Model) applyTableFilter() {
 }
 
 func (m *Model) updateTableData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.rowSearchMode {
+		return m.updateRowSearch(msg)
+	}
+
 	switch msg.String() {
+	case "/":
+		return m, m.openRowSearch()
+	case "ctrl+n":
+		m.nextRowMatch(1)
+	case "ctrl+p":
+		m.nextRowMatch(-1)
+	case "ctrl+e":
+		return m, m.openPartiQL(m.currentTable)
 	case "up", "k":
 		m.dataTable.MoveUp()
 	case "down", "j":
@@ -629,12 +1470,14 @@ func (m *Model) updateTableData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.view = viewItemDetail
 		}
 	case "n":
+		m.editorJSONStrings = nil
 		m.itemEditor.SetValue("{\n  \n}")
 		m.view = viewCreateItem
 		m.itemEditor.Focus()
 	case "e":
 		if m.dataTable.SelectedRow < len(m.items) {
 			m.selectedItem = m.items[m.dataTable.SelectedRow]
+			m.editorJSONStrings = nil
 			jsonStr, _ := models.ItemToJSON(m.selectedItem, true)
 			m.itemEditor.SetValue(jsonStr)
 			m.view = viewEditItem
@@ -643,14 +1486,22 @@ func (m *Model) updateTableData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "d":
 		if m.dataTable.SelectedRow < len(m.items) {
 			m.selectedItem = m.items[m.dataTable.SelectedRow]
+			if !m.config.ConfirmDelete {
+				return m, m.deleteItem()
+			}
 			m.view = viewConfirmDelete
 		}
+	case "X":
+		return m, m.openDeleteByKey()
 	case "y":
 		// Copy selected cell value
 		row := m.dataTable.GetSelectedRow()
 		if row != nil && m.dataTable.SelectedCol < len(row) {
 			value := row[m.dataTable.SelectedCol]
-			if err := clipboard.WriteAll(value); err == nil {
+			if v, ok := m.selectedValue(); ok {
+				value = models.FormatValue(v, 0) // not the cell's ∅, "" or cut-off text
+			}
+			if err := copyToClipboard(value); err == nil {
 				m.statusMsg = "✓ Copied cell value to clipboard"
 			} else {
 				m.statusMsg = "✗ Failed to copy: " + err.Error()
@@ -662,42 +1513,135 @@ func (m *Model) updateTableData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			item := m.items[m.dataTable.SelectedRow]
 			jsonStr, err := models.ItemToJSON(item, true)
 			if err == nil {
-				if err := clipboard.WriteAll(jsonStr); err == nil {
+				if err := copyToClipboard(jsonStr); err == nil {
 					m.statusMsg = "✓ Copied row as JSON to clipboard"
 				} else {
 					m.statusMsg = "✗ Failed to copy: " + err.Error()
 				}
 			}
 		}
+	case "A":
+		m.copyTableArn()
+	case "O":
+		m.copyConsoleLink(nil)
 	case "f":
 		m.view = viewQuery
 		// FilterBuilder auto-focuses on init
+	case "S":
+		return m, m.sampleTable()
+	case "P":
+		m.openPartitions()
+	case "R":
+		return m, m.resumeScan()
+	case "t":
+		return m, m.openStreams()
+	case "g":
+		return m, m.openScanSegment()
+	case "M":
+		return m, m.openBulkRename()
+	case "ctrl+g":
+		return m, m.openSeed()
+	case "ctrl+t":
+		return m, m.openTableCopy()
+	case "J":
+		return m, m.openJQ()
+	case "F":
+		m.openFailedWrites()
 	case "s":
+		m.showPolicy = false
 		m.prepareSchemaView()
 		m.view = viewSchema
 	case "x":
 		m.view = viewExport
+	case "pgup", "ctrl+b":
+		if m.spill != nil {
+			if m.spillView == 0 {
+				m.showSpillPage(len(m.spill.pages))
+			} else {
+				m.showSpillPage(m.spillView - 1)
+			}
+		}
 	case "pgdown", "ctrl+d":
-		if m.lastKey != nil {
+		if m.spillView > 0 {
+			m.showSpillPage(m.spillView + 1)
+		} else if m.lastKey != nil {
 			return m, m.scanTableNext()
 		}
 	case "r":
 		m.lastKey = nil
 		return m, m.scanTable()
+	case "ctrl+r":
+		// Re-read the schema too, e.g. after adding a GSI elsewhere
+		m.invalidateSchemaCache()
+		m.lastKey = nil
+		m.loading = true
+		return m, tea.Batch(m.describeTable(), m.scanTable())
 	case "q", "esc":
+		if msg.String() == "esc" && m.dataTable.Highlight != "" {
+			m.clearRowSearch()
+			return m, nil
+		}
+		m.clearRowSearch()
 		m.view = viewTables
 		m.currentTable = ""
+		m.resetSpill()
 		m.items = nil
 		m.lastKey = nil
+		m.scanSegment = nil
+		m.savedScan = nil
 		// Clear filter when leaving table
 		m.filterBuilder.Clear()
 		m.filterExpr = ""
 		m.filterNames = nil
 		m.filterValues = nil
+	case "o":
+		m.cycleSort()
+	case "c":
+		m.hideColumn()
+	case "C":
+		m.showAllColumns()
+	case "<":
+		m.moveColumn(-1)
+	case ">":
+		m.moveColumn(1)
+	case "W":
+		m.cycleWidthMode()
+	case "ctrl+w":
+		return m, m.toggleWatch()
+	case "D":
+		m.openSnapshots()
+	case "K":
+		m.openCopyCode()
+	case "#":
+		m.dataTable.ShowRowNums = !m.dataTable.ShowRowNums
+		if m.prefs.widthMode() == widthEqual {
+			m.dataTable.EqualColWidths() // share out the width the column gave up or took
+		}
+	case "T":
+		m.typeHints = !m.typeHints
+		m.redrawItems()
+		if m.typeHints {
+			m.statusMsg = "Type hints on: values that could pass for another type show it, e.g. 123 (S)"
+		} else {
+			m.statusMsg = "Type hints off"
+		}
+	case "w":
+		m.dataTable.WrapSelected = !m.dataTable.WrapSelected
+		if m.dataTable.WrapSelected {
+			m.statusMsg = "Row wrap on: the selected row shows its values in full"
+		} else {
+			m.statusMsg = "Row wrap off"
+		}
+	case ",":
+		m.resizeColumn(-4)
+	case ".":
+		m.resizeColumn(4)
 	case "+", "=":
 		// Increase page size
 		if m.pageSize < 1000 {
 			m.pageSize += 100
+			m.prefs.PageSize = m.pageSize
+			m.saveTablePrefs()
 			m.statusMsg = fmt.Sprintf("Page size: %d items", m.pageSize)
 		}
 	case "-", "_":
@@ -707,6 +1651,8 @@ func (m *Model) updateTableData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.pageSize < 50 {
 				m.pageSize = 50
 			}
+			m.prefs.PageSize = m.pageSize
+			m.saveTablePrefs()
 			m.statusMsg = fmt.Sprintf("Page size: %d items", m.pageSize)
 		}
 	case "tab":
@@ -815,22 +1761,59 @@ func (m *Model) updateItemDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.scrollToCurrentMatch()
 		}
 	case "e":
-		jsonStr, _ := models.ItemToJSON(m.selectedItem, true)
-		m.itemEditor.SetValue(jsonStr)
+		m.itemEditor.SetValue(m.editItemJSON())
 		m.view = viewEditItem
 		m.itemEditor.Focus()
 	case "d":
+		if !m.config.ConfirmDelete {
+			return m, m.deleteItem()
+		}
 		m.view = viewConfirmDelete
+	case "h":
+		m.openItemHistory()
+	case "x":
+		return m, m.openItemExport()
+	case "c":
+		m.compactJSON = !m.compactJSON
+		m.jsonViewer.Compact = m.compactJSON
+		m.updateItemViewContent()
+		m.itemViewport.GotoTop()
+	case "v":
+		m.yamlView = !m.yamlView
+		m.jsonViewer.YAML = m.yamlView
+		m.updateItemViewContent()
+		m.itemViewport.GotoTop()
 	case "y", "Y":
-		// Copy item as JSON
-		jsonStr, err := models.ItemToJSON(m.selectedItem, true)
+		// Copy item as JSON; Y copies the single-line form for logs and CLIs
+		compact := msg.String() == "Y"
+		jsonStr, err := models.ItemToJSON(m.selectedItem, !compact)
 		if err == nil {
-			if err := clipboard.WriteAll(jsonStr); err == nil {
-				m.statusMsg = "✓ Copied item as JSON to clipboard"
+			if err := copyToClipboard(jsonStr); err == nil {
+				if compact {
+					m.statusMsg = "✓ Copied item as compact JSON to clipboard"
+				} else {
+					m.statusMsg = "✓ Copied item as JSON to clipboard"
+				}
 			} else {
 				m.statusMsg = "✗ Failed to copy: " + err.Error()
 			}
 		}
+	case "A":
+		m.copyTableArn()
+	case "O":
+		m.copyConsoleLink(m.selectedItem)
+	case "K":
+		m.openCopyCode()
+	case "D":
+		return m, m.decryptItem()
+	case "o":
+		return m, m.openWith()
+	case "b":
+		m.toggleDecode()
+		m.itemViewport.GotoTop()
+	case "J":
+		m.toggleJSONStrings()
+		m.itemViewport.GotoTop()
 	case "up", "k":
 		m.itemViewport.LineUp(1)
 	case "down", "j":
@@ -847,8 +1830,22 @@ func (m *Model) updateItemViewContent() {
 	if m.jsonViewer == nil {
 		return
 	}
-	content := m.jsonViewer.Render()
-	m.itemViewport.SetContent(content)
+	// The viewport only keeps the scroll position and its bounds; the visible
+	// lines are drawn from the viewer's cache by itemViewportContent, so a
+	// large item is never pushed through the viewport as one string.
+	m.jsonViewer.Width = m.itemViewport.Width
+	m.itemViewport.SetContent(strings.Repeat("\n", len(m.jsonViewer.Lines())-1))
+}
+
+// itemViewportContent renders the visible window of the item, sized like
+// viewport.View would.
+func (m Model) itemViewportContent() string {
+	if m.jsonViewer == nil {
+		return m.itemViewport.View()
+	}
+	w, h := m.itemViewport.Width, m.itemViewport.Height
+	return lipgloss.NewStyle().Width(w).Height(h).MaxWidth(w).MaxHeight(h).
+		Render(m.jsonViewer.View(m.itemViewport.YOffset, h))
 }
 
 // Helper to get logical cursor position
@@ -929,9 +1926,60 @@ func getSortedSelection(startRow, startCol, currRow, currCol int) (int, int, int
 	return sR, sC, eR, eC
 }
 
+// submitItemEditor saves the edited item, asking first when the config
+// says so
+func (m *Model) submitItemEditor() tea.Cmd {
+	// Validate JSON before showing confirmation
+	item, err := models.JSONToItem(m.editorText())
+	if err != nil {
+		m.statusMsg = "Invalid JSON: " + err.Error()
+		return nil
+	}
+	if err := checkItemKey(m.tableInfo, item); err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+	if size := models.ItemSize(item); size > models.MaxItemSize {
+		m.statusMsg = fmt.Sprintf("✗ The item is %s, over DynamoDB's 400 KB limit", formatBytes(int64(size)))
+		return nil
+	}
+	if !m.checkItemSchema() || !m.lintItem() {
+		return nil
+	}
+	if m.view == viewCreateItem && m.client != nil && !m.offline && m.tableInfo != nil {
+		return m.checkOverwrite(item)
+	}
+	return m.confirmSave()
+}
+
+// confirmSave saves the checked item, or asks first when the config says so
+func (m *Model) confirmSave() tea.Cmd {
+	if !m.config.ConfirmSave {
+		return m.saveItem()
+	}
+	m.view = viewConfirmSave
+	return nil
+}
+
+// syncSelection stretches the visual selection to the cursor after a move
+func (m *Model) syncSelection() {
+	if m.visualSelectMode {
+		currRow, currCol := getCursorPos(m.itemEditor)
+		sR, sC, eR, eC := getSortedSelection(m.selectionStartRow, m.selectionStartCol, currRow, currCol)
+		m.itemEditor.SetSelection(sR, sC, eR, eC)
+	} else {
+		m.itemEditor.ClearSelection()
+	}
+}
+
 func (m *Model) updateItemEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.config.Keybindings == keybindingsVim {
+			if handled, cmd := m.updateEditorVim(msg); handled {
+				return m, cmd
+			}
+		}
 		// Toggle Vim Mode (Standard Vim Navigation)
 		if msg.String() == "ctrl+b" {
 			m.visualMode = !m.visualMode
@@ -987,9 +2035,11 @@ func (m *Model) updateItemEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
 				currRow, currCol := getCursorPos(m.itemEditor)
 				sR, sC, eR, eC := getSortedSelection(m.selectionStartRow, m.selectionStartCol, currRow, currCol)
 				text := extractText(m.itemEditor.Value(), sR, sC, eR, eC)
-				clipboard.WriteAll(text)
+				copyToClipboard(text)
 
-				m.visualMode = false
+				// The vim layer stays in normal mode, like vim after a yank
+				m.visualMode = m.config.Keybindings == keybindingsVim
+				m.visualSelectMode = false
 				m.itemEditor.ClearSelection()
 				m.statusMsg = "Yanked: " + text
 				if len(m.statusMsg) > 50 {
@@ -998,7 +2048,8 @@ func (m *Model) updateItemEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "p":
 				m.itemEditor, cmd = m.itemEditor.Update(tea.KeyMsg{Type: tea.KeyCtrlV})
-				m.visualMode = false
+				m.visualMode = m.config.Keybindings == keybindingsVim
+				m.visualSelectMode = false
 				m.itemEditor.ClearSelection()
 				m.statusMsg = "Pasted"
 				return m, cmd
@@ -1010,31 +2061,18 @@ func (m *Model) updateItemEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			// After move, update selection range
-			if m.visualSelectMode {
-				currRow, currCol := getCursorPos(m.itemEditor)
-				sR, sC, eR, eC := getSortedSelection(m.selectionStartRow, m.selectionStartCol, currRow, currCol)
-				m.itemEditor.SetSelection(sR, sC, eR, eC)
-			} else {
-				m.itemEditor.ClearSelection()
-			}
+			m.syncSelection()
 			return m, cmd
 		}
 
 		// Normal Mode keys
 		switch msg.String() {
 		case "esc":
+			m.schemaViolations, m.lintWarnings = nil, nil
 			m.view = viewTableData
 			return m, nil
 		case "ctrl+s":
-			// Validate JSON before showing confirmation
-			_, err := models.JSONToItem(m.itemEditor.Value())
-			if err != nil {
-				m.statusMsg = "Invalid JSON: " + err.Error()
-				return m, nil
-			}
-			m.view = viewConfirmSave
-			return m, nil
+			return m, m.submitItemEditor()
 		}
 	}
 	// Pass all messages to the textarea (including Enter key for new lines)
@@ -1044,6 +2082,13 @@ func (m *Model) updateItemEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateCreateTable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.creatingTable != "" {
+		if msg.String() == "esc" {
+			// Stop waiting; the table keeps being created in the background
+			return m, m.finishTableWait(m.creatingTable + " is still being created")
+		}
+		return m, nil
+	}
 	switch msg.String() {
 	case "esc":
 		m.view = viewTables
@@ -1091,14 +2136,19 @@ func (m *Model) updateQuery(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Confirm operator selection
 				m.filterBuilder.NextField()
 			} else {
-				// Execute filter
-				expr, names, values := m.filterBuilder.BuildExpression()
-				m.filterExpr = expr
-				m.filterNames = names
-				m.filterValues = values
-				m.view = viewTableData
-				m.lastKey = nil
-				return m, m.scanTable()
+				// Execute filter, relative times read as of now
+				conds, err := query.ExpandTimes(m.filterBuilder.QueryConditions(), time.Now(), m.timeEncoding)
+				if err != nil {
+					m.statusMsg = "✗ " + err.Error()
+					return m, nil
+				}
+				expr, names, values := query.BuildExpression(conds)
+				if s := m.checkScanCost(expr, names, values); s != nil {
+					m.costlyScan = s
+					m.view = viewConfirmCostlyScan
+					return m, nil
+				}
+				return m, m.applyFilter(expr, names, values)
 			}
 			return m, nil
 		case "tab":
@@ -1127,6 +2177,9 @@ func (m *Model) updateQuery(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+d":
 			m.filterBuilder.RemoveCondition()
 			return m, nil
+		case "ctrl+t":
+			m.convertFilterDate()
+			return m, nil
 		case "ctrl+c":
 			m.filterBuilder.Clear()
 			m.filterExpr = ""
@@ -1150,6 +2203,7 @@ func (m *Model) updateSelectRegion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		if m.regionList.Selected >= 0 && m.regionList.Selected < len(m.discoveredRegions) {
 			region := m.discoveredRegions[m.regionList.Selected].Region
+			m.selectedRegion = region
 			m.loading = true
 			m.statusMsg = fmt.Sprintf("Connecting to %s...", region)
 			return m, m.connectToRegion(region)
@@ -1184,48 +2238,137 @@ func (m *Model) updateConfirmSave(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.exportProjEditing {
+		return m.updateExportProjection(msg)
+	}
+	if m.csvDelimPending {
+		m.csvDelimPending = false
+		switch {
+		case msg.Type == tea.KeyTab:
+			m.csvOptions.delimiter = '\t'
+		case msg.Type == tea.KeySpace:
+			m.csvOptions.delimiter = ' '
+		case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] != '"':
+			m.csvOptions.delimiter = msg.Runes[0]
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "esc":
 		m.view = viewTableData
+	case "d":
+		m.csvDelimPending = true
+	case "h":
+		m.csvOptions.noHeader = !m.csvOptions.noHeader
+	case "g":
+		m.exportGzip = !m.exportGzip
+	case "p":
+		return m, m.openExportProjection()
+	case "s":
+		return m, m.openS3Export()
+	case "f":
+		m.exportFull = !m.exportFull
+	case "+", "=":
+		if m.workers() < dynamo.MaxScanSegments {
+			m.exportWorkers = m.workers() + 1
+		}
+	case "-":
+		if m.workers() > 1 {
+			m.exportWorkers = m.workers() - 1
+		}
 	case "j":
 		m.exportFormat = "json"
 		return m, m.exportData()
 	case "c":
 		m.exportFormat = "csv"
 		return m, m.exportData()
+	case "t":
+		m.exportFormat = "tsv"
+		return m, m.exportData()
+	case "x":
+		m.exportFormat = "xlsx"
+		return m, m.exportData()
+	case "y":
+		m.exportFormat = "yaml"
+		return m, m.exportData()
 	}
 	return m, nil
 }
 
 // Commands
 
+// connection is how the session connects to region
+func (m *Model) connection(region string) dynamo.ConnectionConfig {
+	return dynamo.ConnectionConfig{
+		Endpoint: m.config.Endpoint,
+		Region:   region,
+		Profile:  m.config.Profile,
+		Network:  m.config.Network(),
+		Stats:    m.opStats,
+	}
+}
+
+// connectToRegion connects to region with the active profile, at the
+// configured endpoint if there is one
 func (m *Model) connectToRegion(region string) tea.Cmd {
+	conn := m.connection(region)
 	return func() tea.Msg {
-		cfg := dynamo.ConnectionConfig{
-			Region:   region,
-			UseLocal: false,
+		client, err := dynamo.RegionClient(conn)
+		if err != nil {
+			return connectionTestMsg{success: false, err: err, profile: conn.Profile, region: region}
 		}
 
-		client, err := dynamo.NewClient(cfg)
+		return connectionTestMsg{success: true, client: client, profile: conn.Profile, region: region}
+	}
+}
+
+// connectToEndpoint connects to the configured DynamoDB-compatible endpoint;
+// there are no regions to discover behind it
+func (m *Model) connectToEndpoint() tea.Cmd {
+	conn := m.connection(m.config.Region)
+	return func() tea.Msg {
+		client, err := dynamo.EndpointClient(context.Background(), conn)
 		if err != nil {
-			return connectionTestMsg{success: false, err: err}
+			return connectionTestMsg{success: false, err: err, profile: conn.Profile, region: conn.Region}
 		}
-
-		return connectionTestMsg{success: true, client: client, region: region}
+		return connectionTestMsg{success: true, client: client, profile: conn.Profile, region: conn.Region}
 	}
 }
 
 func (m *Model) loadTables() tea.Cmd {
+	timeout := m.config.Timeouts.ListTables
 	return func() tea.Msg {
-		tables, err := m.client.ListTables(context.Background())
+		ctx, cancel := requestContext(timeout)
+		defer cancel()
+		tables, err := m.client.ListTables(ctx)
 		if err != nil {
-			return errMsg{err}
+			return errMsg{timedOut(ctx, err, "Listing tables", "list_tables", timeout)}
 		}
 		sort.Strings(tables)
 		return tablesLoadedMsg{tables}
 	}
 }
 
+// invalidateSchemaCache makes the next DescribeTable go to DynamoDB
+func (m *Model) invalidateSchemaCache() {
+	if m.client != nil {
+		m.client.InvalidateSchemaCache()
+	}
+}
+
+// scanInput scans the current table with the filter applied, from startKey
+func (m *Model) scanInput(limit int32, startKey map[string]types.AttributeValue) dynamo.ScanInput {
+	return dynamo.ScanInput{
+		TableName:                m.currentTable,
+		FilterExpression:         m.filterExpr,
+		ExpressionAttributeNames: m.filterNames,
+		ExpressionValues:         m.filterValues,
+		Limit:                    limit,
+		StartKey:                 startKey,
+	}
+}
+
 func (m *Model) describeTable() tea.Cmd {
 	return func() tea.Msg {
 		info, err := m.client.DescribeTable(context.Background(), m.currentTable)
@@ -1236,10 +2379,34 @@ func (m *Model) describeTable() tea.Cmd {
 	}
 }
 
+// readPlan is how scanTable will read the applied filter: a Query when its
+// first condition is an equality on the table or a GSI partition key,
+// otherwise a Scan. filterAdvice puts the same decision into words.
+func (m *Model) readPlan() query.Plan {
+	return query.BuildPlan(m.tableInfo, m.filterExpr, m.filterNames, m.filterValues)
+}
+
+func (m *Model) filterAdvice() query.Advice {
+	return query.Advise(m.tableInfo, m.readPlan(), m.filterNames)
+}
+
 func (m *Model) scanTable() tea.Cmd {
-	return func() tea.Msg {
-		plan := query.BuildPlan(m.tableInfo, m.filterExpr, m.filterNames, m.filterValues)
+	plan := m.readPlan()
+	timeouts := m.config.Timeouts
+
+	// Scan mode with a filter: continuous scan, reporting progress until the
+	// scan budget runs out.
+	if plan.Mode != query.ModeQuery && m.filterExpr != "" {
+		return m.startContinuousScan(0, 0, func(ctx context.Context, opts dynamo.ScanOptions) tea.Msg {
+			result, err := m.client.ScanTableContinuous(ctx, m.scanInput(m.pageSize, nil), opts)
+			if err != nil {
+				return errMsg{err}
+			}
+			return continuousScanMsg{result: result, totalScanned: result.TotalScanned}
+		})
+	}
 
+	return func() tea.Msg {
 		// Query mode: filter's first condition is an equals on the PK / GSI PK.
 		if plan.Mode == query.ModeQuery {
 			queryInput := dynamo.QueryInput{
@@ -1252,63 +2419,67 @@ func (m *Model) scanTable() tea.Cmd {
 				Limit:                    m.pageSize,
 				ScanIndexForward:         true,
 			}
-			result, err := m.client.QueryTable(context.Background(), queryInput)
+			ctx, cancel := requestContext(timeouts.Query)
+			defer cancel()
+			result, err := m.client.QueryTable(ctx, queryInput)
 			if err != nil {
-				return errMsg{err}
+				return errMsg{timedOut(ctx, err, "Query", "query", timeouts.Query)}
 			}
 			return queryResultMsg{result}
 		}
 
-		// Scan mode with a filter: continuous scan with a 3-minute timeout.
-		if m.filterExpr != "" {
-			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-			m.scanCancel = cancel
-
-			result, err := m.client.ScanTableContinuous(ctx, m.currentTable, int(m.pageSize), nil, m.filterExpr, m.filterNames, m.filterValues)
-			cancel()
-
-			if err != nil {
-				return errMsg{err}
-			}
-			return continuousScanMsg{result: result, totalScanned: result.TotalScanned}
-		}
-
 		// No filter: simple scan.
-		result, err := m.client.ScanTable(context.Background(), m.currentTable, m.pageSize, nil, m.filterExpr, m.filterNames, m.filterValues)
+		ctx, cancel := requestContext(timeouts.ScanPage)
+		defer cancel()
+		result, err := m.client.ScanTable(ctx, m.scanInput(m.pageSize, nil), m.scanOptions())
 		if err != nil {
-			return errMsg{err}
+			return errMsg{timedOut(ctx, err, "Scan", "scan_page", timeouts.ScanPage)}
 		}
 		return scanResultMsg{result}
 	}
 }
 
 func (m *Model) scanTableNext() tea.Cmd {
+	timeout := m.config.Timeouts.ScanPage
 	return func() tea.Msg {
-		result, err := m.client.ScanTable(context.Background(), m.currentTable, m.pageSize, m.lastKey, m.filterExpr, m.filterNames, m.filterValues)
+		ctx, cancel := requestContext(timeout)
+		defer cancel()
+		result, err := m.client.ScanTable(ctx, m.scanInput(m.pageSize, m.lastKey), m.scanOptions())
 		if err != nil {
-			return errMsg{err}
+			return errMsg{timedOut(ctx, err, "Scan", "scan_page", timeout)}
 		}
 		return scanResultMsg{result}
 	}
 }
 
-func (m *Model) handleScanResult(result *dynamo.ScanResult) {
+func (m *Model) handleScanResult(result *dynamo.ScanResult) tea.Cmd {
+	m.resetSpill()
 	m.items = result.Items
 	m.lastKey = result.LastEvaluatedKey
+	m.capacity = result.ConsumedCapacity
 	m.loading = false
 	m.statusMsg = fmt.Sprintf("Loaded %d items (page size: %d)", result.Count, m.pageSize)
 
 	// Convert to table format
-	headers, rows := m.itemsToTable(result.Items)
-	m.dataTable.SetData(headers, rows)
+	m.showItems(result.Items)
+	alert := m.afterRead()
+	m.saveOfflineSnapshot()
+	return alert
 }
 
-func (m *Model) handleContinuousScanResult(result *dynamo.ContinuousScanResult) {
+func (m *Model) handleContinuousScanResult(result *dynamo.ContinuousScanResult) tea.Cmd {
 	m.items = result.Items
 	m.lastKey = result.LastEvaluatedKey
+	m.capacity = result.ConsumedCapacity
 	m.loading = false
 
-	statusParts := []string{fmt.Sprintf("Found %d items", len(result.Items))}
+	spillErr := m.spillOverflow()
+
+	found := len(m.items)
+	if m.spill != nil {
+		found += m.spill.items
+	}
+	statusParts := []string{fmt.Sprintf("Found %d items", found)}
 	statusParts = append(statusParts, fmt.Sprintf("(scanned %d records)", result.TotalScanned))
 
 	if result.TimedOut {
@@ -1317,22 +2488,38 @@ func (m *Model) handleContinuousScanResult(result *dynamo.ContinuousScanResult)
 	if result.HasMore {
 		statusParts = append(statusParts, "- More data available")
 	}
+	if spillErr != nil {
+		statusParts = append(statusParts, "- ✗ "+spillErr.Error())
+	}
 
 	m.statusMsg = strings.Join(statusParts, " ")
 
 	// Convert to table format
-	headers, rows := m.itemsToTable(result.Items)
-	m.dataTable.SetData(headers, rows)
+	m.showItems(m.items)
+	alert := m.afterRead()
+	m.saveOfflineSnapshot()
+	return alert
 }
 
-func (m *Model) handleQueryResult(result *dynamo.QueryResult) {
+func (m *Model) handleQueryResult(result *dynamo.QueryResult) tea.Cmd {
+	m.resetSpill()
 	m.items = result.Items
 	m.lastKey = result.LastEvaluatedKey
+	m.capacity = result.ConsumedCapacity
 	m.loading = false
 	m.statusMsg = fmt.Sprintf("Query returned %d items", result.Count)
 
-	headers, rows := m.itemsToTable(result.Items)
+	m.showItems(result.Items)
+	return m.afterRead()
+}
+
+// setTableData replaces the rows on screen, re-running an active row search
+// against them.
+func (m *Model) setTableData(headers []string, rows [][]string) {
 	m.dataTable.SetData(headers, rows)
+	if q := m.dataTable.Highlight; q != "" {
+		m.runRowSearch(q)
+	}
 }
 
 func (m *Model) itemsToTable(items []map[string]types.AttributeValue) ([]string, [][]string) {
@@ -1340,15 +2527,117 @@ func (m *Model) itemsToTable(items []map[string]types.AttributeValue) ([]string,
 		return []string{}, [][]string{}
 	}
 
-	// Collect all unique keys
+	headers, column := m.tableHeaders(items)
+
+	// Build rows on one backing array; the capacity limit keeps rows apart
+	n := len(headers)
+	cells := make([]string, len(items)*n)
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := cells[i*n : (i+1)*n : (i+1)*n]
+		for k, v := range item {
+			row[column[k]] = m.cellText(v)
+		}
+		rows[i] = row
+	}
+
+	return headers, rows
+}
+
+// cellText is how a value reads in a table cell. NULL shows as a dim ∅ and
+// an empty string as "", so both stand apart from a missing attribute,
+// which is left blank. With type hints on, values that could pass for
+// another type get their type after them.
+func (m *Model) cellText(v types.AttributeValue) string {
+	switch v := v.(type) {
+	case *types.AttributeValueMemberNULL:
+		return ui.NullCell
+	case *types.AttributeValueMemberS:
+		if v.Value == "" {
+			return `""`
+		}
+		if m.typeHints && !looksTyped(v.Value) {
+			return models.FormatValue(v, 50) // plain text reads as a string anyway
+		}
+	}
+	text := models.FormatValue(v, 50)
+	if m.typeHints {
+		text += " (" + models.GetAttributeType(v) + ")"
+	}
+	return text
+}
+
+// looksTyped reports whether a string would read as a number, a boolean,
+// null or JSON in a cell
+func looksTyped(s string) bool {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	switch s {
+	case "true", "false", "null":
+		return true
+	}
+	return strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{")
+}
+
+// headerCache remembers the column order of the last page shown, so the next
+// page of the same table with the same attributes skips collecting and
+// sorting the key set.
+type headerCache struct {
+	table, pk, sk string
+	headers       []string
+	column        map[string]int
+}
+
+// tableHeaders returns the ordered headers for items and each header's column
+func (m *Model) tableHeaders(items []map[string]types.AttributeValue) ([]string, map[string]int) {
+	var pk, sk string
+	if m.tableInfo != nil {
+		pk, sk = m.tableInfo.PartitionKey, m.tableInfo.SortKey
+	}
+	c := m.headerCache
+	if c != nil && c.table == m.currentTable && c.pk == pk && c.sk == sk && c.covers(items) {
+		return c.headers, c.column
+	}
+
 	keySet := make(map[string]bool)
 	for _, item := range items {
 		for k := range item {
 			keySet[k] = true
 		}
 	}
+	headers := m.orderHeaders(keySet)
+	column := make(map[string]int, len(headers))
+	for i, h := range headers {
+		column[h] = i
+	}
+	m.headerCache = &headerCache{table: m.currentTable, pk: pk, sk: sk, headers: headers, column: column}
+	return headers, column
+}
+
+// covers reports whether items use exactly the cached attribute names. It
+// only reads c, which Model copies and export goroutines share.
+func (c *headerCache) covers(items []map[string]types.AttributeValue) bool {
+	seen := make([]bool, len(c.headers))
+	found := 0
+	for _, item := range items {
+		for k := range item {
+			i, ok := c.column[k]
+			if !ok {
+				return false
+			}
+			if !seen[i] {
+				seen[i] = true
+				found++
+			}
+		}
+	}
+	return found == len(c.headers)
+}
 
-	// Sort keys, but put partition and sort keys first
+// orderHeaders sorts attribute names, but puts partition and sort keys first.
+// Keys the items don't have, e.g. after an export projection, are left out.
+func (m *Model) orderHeaders(keySet map[string]bool) []string {
 	var headers []string
 	var otherKeys []string
 
@@ -1361,40 +2650,28 @@ func (m *Model) itemsToTable(items []map[string]types.AttributeValue) ([]string,
 	sort.Strings(otherKeys)
 
 	if m.tableInfo != nil {
-		headers = append(headers, m.tableInfo.PartitionKey)
-		if m.tableInfo.SortKey != "" {
-			headers = append(headers, m.tableInfo.SortKey)
+		if keySet[m.tableInfo.PartitionKey] {
+			headers = append(headers, m.tableInfo.PartitionKey)
 		}
-	}
-	headers = append(headers, otherKeys...)
-
-	// Build rows
-	rows := make([][]string, len(items))
-	for i, item := range items {
-		row := make([]string, len(headers))
-		for j, h := range headers {
-			if v, ok := item[h]; ok {
-				row[j] = models.FormatValue(v, 50)
-			} else {
-				row[j] = ""
-			}
+		if m.tableInfo.SortKey != "" && keySet[m.tableInfo.SortKey] {
+			headers = append(headers, m.tableInfo.SortKey)
 		}
-		rows[i] = row
 	}
-
-	return headers, rows
+	return append(headers, otherKeys...)
 }
 
 func (m *Model) prepareItemView() {
 	item := models.NewItem(m.selectedItem)
-	m.jsonViewer = ui.NewJSONViewer(item.Attributes)
-	content := m.jsonViewer.Render()
-	m.itemViewport.SetContent(content)
+	m.jsonViewer = ui.NewJSONViewer(m.withJSONStrings(m.withDecoded(m.withDecrypted(item.Attributes))))
+	m.jsonViewer.Compact = m.compactJSON
+	m.jsonViewer.YAML = m.yamlView
+	m.updateItemViewContent()
 }
 
 func (m *Model) saveItem() tea.Cmd {
+	m.loading = true
 	return func() tea.Msg {
-		jsonStr := m.itemEditor.Value()
+		jsonStr := m.editorText()
 		item, err := models.JSONToItem(jsonStr)
 		if err != nil {
 			return errMsg{err}
@@ -1405,11 +2682,18 @@ func (m *Model) saveItem() tea.Cmd {
 			return errMsg{err}
 		}
 
-		return itemSavedMsg{}
+		// The item being edited is the previous version only when the save
+		// targets the same primary key (a create or a key change is a new item).
+		var prev map[string]types.AttributeValue
+		if m.selectedItem != nil && m.historyKey(m.selectedItem) == m.historyKey(item) {
+			prev = m.selectedItem
+		}
+		return itemSavedMsg{item: item, prev: prev}
 	}
 }
 
 func (m *Model) deleteItem() tea.Cmd {
+	m.loading = true
 	return func() tea.Msg {
 		if m.tableInfo == nil {
 			return errMsg{fmt.Errorf("table info not loaded")}
@@ -1425,12 +2709,12 @@ func (m *Model) deleteItem() tea.Cmd {
 			}
 		}
 
-		err := m.client.DeleteItem(context.Background(), m.currentTable, key)
+		found, err := m.client.DeleteItem(context.Background(), m.currentTable, key)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		return itemDeletedMsg{}
+		return itemDeletedMsg{found}
 	}
 }
 
@@ -1450,63 +2734,32 @@ func (m *Model) createTable() tea.Cmd {
 			return errMsg{err}
 		}
 
-		return tableCreatedMsg{}
+		return tableCreatedMsg{input.TableName}
 	}
 }
 
 func (m *Model) exportData() tea.Cmd {
+	if m.exportFull {
+		return m.exportFullTable()
+	}
 	return func() tea.Msg {
-		filename := fmt.Sprintf("%s.%s", m.currentTable, m.exportFormat)
+		path, compress := m.exportFilePath()
 
-		var data []byte
-		var err error
-
-		if m.exportFormat == "json" {
-			var items []map[string]interface{}
-			for _, item := range m.items {
-				converted := make(map[string]interface{})
-				for k, v := range item {
-					converted[k] = models.AttributeValueToInterface(v)
-				}
-				items = append(items, converted)
-			}
-			data, err = json.MarshalIndent(items, "", "  ")
-		} else {
-			// CSV format
-			headers, rows := m.itemsToTable(m.items)
-			var b strings.Builder
-			b.WriteString(strings.Join(headers, ",") + "\n")
-			for _, row := range rows {
-				// Escape commas and quotes
-				escapedRow := make([]string, len(row))
-				for i, cell := range row {
-					if strings.ContainsAny(cell, ",\"\n") {
-						escapedRow[i] = "\"" + strings.ReplaceAll(cell, "\"", "\"\"") + "\""
-					} else {
-						escapedRow[i] = cell
-					}
-				}
-				b.WriteString(strings.Join(escapedRow, ",") + "\n")
-			}
-			data = []byte(b.String())
+		items, err := projectItems(m.exportProjection, m.items)
+		if err != nil {
+			return errMsg{err}
 		}
-
+		data, err := m.encodeExport(m.exportFormat, items)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		// Get current directory
-		cwd, _ := os.Getwd()
-		filepath := filepath.Join(cwd, filename)
-
-		err = os.WriteFile(filepath, data, 0644)
+		err = writeExportFile(path, data, compress)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		m.statusMsg = fmt.Sprintf("Exported to %s", filepath)
-		m.view = viewTableData
-		return nil
+		return exportDoneMsg{path: path, items: len(items)}
 	}
 }
 
@@ -1515,7 +2768,28 @@ func (m Model) View() string {
 	if m.width == 0 {
 		return "Loading..."
 	}
+	view, zoomed := m.viewZoomed()
+	if !zoomed {
+		view = m.viewCurrent()
+	}
+	if m.vimCmdMode {
+		view = withCommandLine(view, m.vimCmd.View())
+	}
+	if m.config.Accessible {
+		// toasts would land in the middle of lines being read out; Ctrl+Y
+		// still lists them
+		view = ui.PlainText(view)
+	} else {
+		view = m.withToasts(view)
+	}
+	if m.config.ASCII {
+		view = ui.ASCIIText(view)
+	}
+	return view
+}
 
+// viewCurrent renders the current view
+func (m Model) viewCurrent() string {
 	switch m.view {
 	case viewConnect:
 		return m.viewConnect()
@@ -1539,10 +2813,66 @@ func (m Model) View() string {
 		return m.viewConfirmSave()
 	case viewConfirmContinueScan:
 		return m.viewConfirmContinueScan()
+	case viewConfirmCostlyScan:
+		return m.viewConfirmCostlyScan()
+	case viewScanSegment:
+		return m.viewScanSegment()
+	case viewStreams:
+		return m.viewStreams()
+	case viewInsights:
+		return m.viewInsights()
+	case viewAutoScaling:
+		return m.viewAutoScaling()
+	case viewSetup:
+		return m.viewSetup()
+	case viewBulkRename:
+		return m.viewBulkRename()
+	case viewSeed:
+		return m.viewSeed()
+	case viewTableCopy:
+		return m.viewTableCopy()
+	case viewJQ:
+		return m.viewJQ()
+	case viewFailedWrites:
+		return m.viewFailedWrites()
+	case viewPlannedOps:
+		return m.viewPlannedOps()
+	case viewNotifications:
+		return m.viewNotifications()
+	case viewErrorDetail:
+		return m.viewErrorDetail()
+	case viewErrorLog:
+		return m.viewErrorLog()
+	case viewCopyCode:
+		return m.viewCopyCode()
+	case viewConfirmOverwrite:
+		return m.viewConfirmOverwrite()
+	case viewPartitions:
+		return m.viewPartitions()
+	case viewSnapshots:
+		return m.viewSnapshots()
+	case viewSnapshotDiff:
+		return m.viewSnapshotDiff()
+	case viewOpenWith:
+		return m.viewOpenWith()
+	case viewOpStats:
+		return m.viewOpStats()
+	case viewDeleteByKey:
+		return m.viewDeleteByKey()
 	case viewExport:
 		return m.viewExport()
 	case viewSchema:
 		return m.viewSchema()
+	case viewItemHistory:
+		return m.viewItemHistory()
+	case viewExportItem:
+		return m.viewExportItem()
+	case viewS3Export:
+		return m.viewS3Export()
+	case viewS3Import:
+		return m.viewS3Import()
+	case viewPartiQL:
+		return m.viewPartiQL()
 	}
 
 	return ""
@@ -1565,12 +2895,15 @@ func (m Model) viewConnect() string {
 
 	if m.loading {
 		statusContent.WriteString("\n")
-		statusContent.WriteString(ui.WarningStyle.Render("🔍 Scanning regions for DynamoDB tables..."))
+		statusContent.WriteString(m.spinner.View() + ui.WarningStyle.Render(" Scanning regions for DynamoDB tables..."))
 		statusContent.WriteString("\n\n")
 		statusContent.WriteString(ui.HelpStyle.Render("Using credentials from ~/.aws or environment"))
 		statusContent.WriteString("\n\n")
 		statusContent.WriteString(ui.HelpStyle.Render("This may take a few seconds"))
 		statusContent.WriteString("\n")
+		for _, r := range m.discoveredRegions {
+			statusContent.WriteString("\n" + ui.SuccessStyle.Render(fmt.Sprintf("✓ %s (%d tables)", r.Region, r.TableCount)))
+		}
 	} else if m.err != nil {
 		statusContent.WriteString("\n")
 		statusContent.WriteString(ui.ErrorStyle.Render("❌ Connection Failed"))
@@ -1580,15 +2913,19 @@ func (m Model) viewConnect() string {
 		statusContent.WriteString(ui.HelpStyle.Render("Check your AWS credentials and try again"))
 		statusContent.WriteString("\n\n")
 		statusContent.WriteString(ui.ButtonFocusedStyle.Render(" Retry "))
+		if m.statusMsg != "" && strings.HasPrefix(m.statusMsg, "✗") {
+			statusContent.WriteString("\n\n" + ui.ErrorStyle.Render(m.statusMsg))
+		}
 	}
 
 	b.WriteString(lipgloss.Place(m.width, 0, lipgloss.Center, lipgloss.Top, content.Render(statusContent.String())))
 
 	// Help
-	help := ui.RenderHelp([]ui.KeyBinding{
-		{Key: "Enter", Desc: "Retry"},
-		{Key: "Ctrl+Q", Desc: "Quit"},
-	})
+	keys := []ui.KeyBinding{{Key: "Enter", Desc: "Retry"}}
+	if m.err != nil && !m.loading {
+		keys = append(keys, ui.KeyBinding{Key: "o", Desc: "Browse offline snapshots"})
+	}
+	help := ui.RenderHelp(append(keys, ui.KeyBinding{Key: "Ctrl+Q", Desc: "Quit"}))
 	b.WriteString("\n\n")
 	b.WriteString(lipgloss.Place(m.width, 0, lipgloss.Center, lipgloss.Bottom, help))
 
@@ -1701,6 +3038,17 @@ func (m Model) viewTables() string {
 		b.WriteString(ui.HelpStyle.Render("Region: "))
 		b.WriteString(ui.BadgeStyle.Render(" 🌍 " + m.selectedRegion + " "))
 	}
+	if m.config.ReadOnly {
+		b.WriteString(" " + ui.WarningStyle.Render("🔒 Read-only"))
+	}
+	if m.plan != nil {
+		b.WriteString(" " + ui.WarningStyle.Render(fmt.Sprintf("🧪 Dry run: %d planned (Ctrl+L)", m.plan.Len())))
+	}
+	if m.offline {
+		b.WriteString(" " + ui.WarningStyle.Render("⚠ Offline"))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.viewProfileDropdown())
 	b.WriteString("\n\n")
 
 	// Search/Filter box
@@ -1778,7 +3126,7 @@ func (m Model) viewTables() string {
 
 	// Status
 	if m.statusMsg != "" && !m.tableFilterMode {
-		b.WriteString(ui.HelpStyle.Render(m.statusMsg))
+		b.WriteString(ui.HelpStyle.Render(m.statusMsg) + m.latencyStatus())
 		b.WriteString("\n")
 	}
 
@@ -1795,8 +3143,14 @@ func (m Model) viewTables() string {
 		if len(m.discoveredRegions) > 1 {
 			helpBindings = append(helpBindings, ui.KeyBinding{Key: "Tab", Desc: "Region"})
 		}
+		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+P", Desc: "Profile"})
 		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+N", Desc: "Create"})
+		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+O", Desc: "Import S3"})
+		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+E", Desc: "PartiQL"})
 		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+R", Desc: "Refresh"})
+		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+Y", Desc: "Notifications"})
+		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+X", Desc: "Error log"})
+		helpBindings = append(helpBindings, ui.KeyBinding{Key: "Ctrl+F", Desc: "Call stats"})
 		helpBindings = append(helpBindings, ui.KeyBinding{Key: "q", Desc: "Back"})
 	}
 
@@ -1821,8 +3175,16 @@ func (m Model) viewTableData() string {
 	b.WriteString(header)
 	b.WriteString("\n\n")
 
-	if m.loading {
-		b.WriteString(ui.ContentStyle.Render("Loading..."))
+	if m.rowSearchMode {
+		b.WriteString(ui.InputFocusedStyle.Render(m.rowSearchInput.View()))
+		b.WriteString(ui.HelpStyle.Render(" Enter/^N: next • ^P: prev • Esc: close"))
+		b.WriteString("\n")
+	}
+
+	if m.loading && m.scanCh != nil {
+		b.WriteString(m.viewScanProgress())
+	} else if m.loading {
+		b.WriteString(ui.ContentStyle.Render(m.spinner.View() + " Loading..."))
 	} else if len(m.items) == 0 {
 		b.WriteString(ui.ContentStyle.Render("No items found. Press 'n' to create one."))
 	} else {
@@ -1831,23 +3193,7 @@ func (m Model) viewTableData() string {
 
 	b.WriteString("\n\n")
 
-	// Status bar
-	status := m.statusMsg
-
-	// Show column position
-	if len(m.dataTable.Headers) > 0 {
-		colInfo := fmt.Sprintf(" | Col %d/%d", m.dataTable.SelectedCol+1, len(m.dataTable.Headers))
-		status += ui.HelpStyle.Render(colInfo)
-	}
-
-	filterSummary := m.filterBuilder.GetFilterSummary()
-	if filterSummary != "" {
-		status += ui.WarningStyle.Render(" | Filter: " + filterSummary)
-	}
-	if m.lastKey != nil {
-		status += ui.HelpStyle.Render(" | More items available (PgDown)")
-	}
-	b.WriteString(ui.StatusBarStyle.Render(status))
+	b.WriteString(m.statusBar())
 	b.WriteString("\n")
 
 	// Help
@@ -1859,9 +3205,35 @@ func (m Model) viewTableData() string {
 		{Key: "n", Desc: "New"},
 		{Key: "e", Desc: "Edit"},
 		{Key: "d", Desc: "Delete"},
+		{Key: "X", Desc: "Delete by key"},
+		{Key: "M", Desc: "Rename attr"},
+		{Key: "Ctrl+G", Desc: "Generate"},
+		{Key: "Ctrl+T", Desc: "Copy table"},
+		{Key: "F", Desc: "Failed writes"},
 		{Key: "f", Desc: "Filter"},
+		{Key: "/", Desc: "Search"},
+		{Key: "J", Desc: "jq"},
+		{Key: "o", Desc: "Sort"},
+		{Key: "c/C", Desc: "Hide/Show cols"},
+		{Key: "</>", Desc: "Move col"},
+		{Key: ",/.", Desc: "Col width"},
+		{Key: "W", Desc: "Width mode"},
+		{Key: "Ctrl+W", Desc: "Watch"},
+		{Key: "D", Desc: "Snapshots/Diff"},
+		{Key: "w", Desc: "Wrap row"},
+		{Key: "T", Desc: "Type hints"},
+		{Key: "#", Desc: "Row numbers"},
+		{Key: "S", Desc: "Sample"},
+		{Key: "P", Desc: "Partition keys"},
+		{Key: "g", Desc: "Segment"},
+		{Key: "t", Desc: "Stream"},
 		{Key: "x", Desc: "Export"},
 		{Key: "s", Desc: "Schema"},
+		{Key: "A/O", Desc: "Copy ARN/Console link"},
+		{Key: "K", Desc: "Copy as code"},
+		{Key: "Ctrl+E", Desc: "PartiQL"},
+		{Key: "Ctrl+R", Desc: "Reload"},
+		{Key: "Ctrl+Z", Desc: "Zoom"},
 		{Key: "q", Desc: "Back"},
 	})
 	b.WriteString(help)
@@ -1890,19 +3262,31 @@ func (m Model) viewItemDetail() string {
 		}
 	} else {
 		// Just help text
-		b.WriteString(ui.HelpStyle.Render("Press / to search • n/N to next/prev • e to edit • d to delete"))
+		b.WriteString(ui.HelpStyle.Render("Press / to search • n/N to next/prev • e to edit • d to delete • h for history"))
 	}
 	b.WriteString("\n")
+	b.WriteString(m.itemNotes())
 
 	// Content
-	b.WriteString(ui.ContentNoBorderStyle.Width(m.width - 6).Render(m.itemViewport.View()))
+	b.WriteString(ui.ContentNoBorderStyle.Width(m.width - 6).Render(m.itemViewportContent()))
 
 	// Footer Help
 	help := ui.RenderHelp([]ui.KeyBinding{
 		{Key: "q/Esc", Desc: "Back"},
 		{Key: "y", Desc: "Copy JSON"},
+		{Key: "Y", Desc: "Copy compact"},
+		{Key: "O", Desc: "Copy console link"},
+		{Key: "K", Desc: "Copy as code"},
+		{Key: "D", Desc: "Decrypt"},
+		{Key: "o", Desc: "Open with"},
+		{Key: "b", Desc: "Decode"},
+		{Key: "J", Desc: "Parse JSON strings"},
+		{Key: "c", Desc: "Compact/Pretty"},
+		{Key: "v", Desc: "JSON/YAML"},
 		{Key: "e", Desc: "Edit"},
 		{Key: "d", Desc: "Delete"},
+		{Key: "h", Desc: "History"},
+		{Key: "x", Desc: "Export"},
 	})
 	b.WriteString("\n")
 	b.WriteString(lipgloss.Place(m.width, 0, lipgloss.Left, lipgloss.Bottom, help))
@@ -1934,19 +3318,41 @@ func (m Model) viewItemEditor() string {
 
 	// Use style without borders for clean copy/paste with mouse
 	b.WriteString(ui.ContentNoBorderStyle.Width(m.width - 10).Render(m.itemEditor.View()))
+	b.WriteString("\n")
+	b.WriteString(m.sizeMeter())
 	b.WriteString("\n\n")
 
 	if m.err != nil {
 		b.WriteString(ui.ErrorStyle.Render("Error: " + m.err.Error()))
 		b.WriteString("\n\n")
 	}
+	if len(m.schemaViolations) > 0 {
+		b.WriteString(ui.WarningStyle.Render("Schema violations:"))
+		b.WriteString("\n")
+		for _, v := range m.schemaViolations {
+			b.WriteString(ui.ErrorStyle.Render("  • " + v))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(m.lintWarnings) > 0 {
+		b.WriteString(ui.WarningStyle.Render("⚠ Before saving, note:"))
+		b.WriteString("\n")
+		for _, w := range m.lintWarnings {
+			b.WriteString(ui.HelpStyle.Render("  • " + w))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
 
 	help := ui.RenderHelp([]ui.KeyBinding{
 		{Key: "Ctrl+S", Desc: "Save"},
 		{Key: "Ctrl+B", Desc: "Visual Mode"},
 		{Key: "Esc", Desc: "Cancel"},
 	})
-	if m.visualMode {
+	if m.config.Keybindings == keybindingsVim {
+		help = m.editorVimHelp()
+	} else if m.visualMode {
 		help = ui.RenderHelp([]ui.KeyBinding{
 			{Key: "h/j/k/l", Desc: "Select"},
 			{Key: "y", Desc: "Copy"},
@@ -1988,6 +3394,11 @@ func (m Model) viewCreateTable() string {
 	b.WriteString(ui.ButtonFocusedStyle.Render(" Create Table "))
 	b.WriteString("\n\n")
 
+	if m.creatingTable != "" {
+		b.WriteString(m.viewCreateWait())
+		b.WriteString("\n\n")
+	}
+
 	if m.err != nil {
 		b.WriteString(ui.ErrorStyle.Render("Error: " + m.err.Error()))
 		b.WriteString("\n\n")
@@ -2009,11 +3420,28 @@ func (m Model) viewQuery() string {
 	b.WriteString(m.filterBuilder.View())
 	b.WriteString("\n\n")
 
+	// Say what Enter will do before it does it
+	expr, names, values := m.filterBuilder.BuildExpression()
+	advice := query.Advise(m.tableInfo, query.BuildPlan(m.tableInfo, expr, names, values), names)
+	planStyle := ui.SuccessStyle
+	if advice.FullScan {
+		planStyle = ui.WarningStyle
+	}
+	b.WriteString(ui.HelpStyle.Render("Plan: ") + planStyle.Render("this will "+advice.Summary))
+	if advice.Hint != "" {
+		b.WriteString("\n" + ui.HelpStyle.Render("Tip: "+advice.Hint))
+	}
+	if hint := m.filterDateHint(); hint != "" {
+		b.WriteString("\n" + ui.HelpStyle.Render(hint))
+	}
+	b.WriteString("\n\n")
+
 	help := ui.RenderHelp([]ui.KeyBinding{
 		{Key: "Tab", Desc: "Next"},
 		{Key: "↑↓", Desc: "Operator"},
 		{Key: "Ctrl+A", Desc: "Add"},
 		{Key: "Ctrl+D", Desc: "Remove"},
+		{Key: "Ctrl+T", Desc: "Date → timestamp"},
 		{Key: "Enter", Desc: "Apply"},
 		{Key: "Ctrl+C", Desc: "Clear"},
 		{Key: "Esc", Desc: "Cancel"},
@@ -2028,7 +3456,8 @@ func (m Model) viewConfirmDelete() string {
 
 	content := ui.ModalStyle.Render(
 		ui.TitleStyle.Render("⚠️ Confirm Delete") + "\n\n" +
-			ui.WarningStyle.Render("Are you sure you want to delete this item?") + "\n\n" +
+			ui.WarningStyle.Render("Are you sure you want to delete this item?") + "\n" +
+			ui.ItemStyle.Render(m.historyKey(m.selectedItem)) + "\n\n" +
 			ui.HelpStyle.Render("Press Y to confirm, N to cancel"),
 	)
 
@@ -2056,12 +3485,12 @@ func (m Model) viewConfirmContinueScan() string {
 	var b strings.Builder
 
 	content := ui.ModalStyle.Render(
-		ui.TitleStyle.Render("⏱️ Scan Timeout") + "\n\n" +
-			ui.WarningStyle.Render("The scan has been running for 3 minutes.") + "\n\n" +
+		ui.TitleStyle.Render("⏱️ Scan Budget Reached") + "\n\n" +
+			ui.WarningStyle.Render("The scan used its budget of "+m.scanBudget.String()+".") + "\n\n" +
 			ui.ItemStyle.Render(fmt.Sprintf("Found: %d items", m.scanItemsFound)) + "\n" +
 			ui.ItemStyle.Render(fmt.Sprintf("Scanned: %d records", m.scanTotalScanned)) + "\n\n" +
 			ui.HelpStyle.Render("The table has more data to scan.") + "\n\n" +
-			ui.HelpStyle.Render("Press Y to continue scanning (3 more minutes)") + "\n" +
+			ui.HelpStyle.Render("Press Y to continue scanning (up to "+m.scanBudget.String()+" more)") + "\n" +
 			ui.HelpStyle.Render("Press N to stop with current results"),
 	)
 
@@ -2087,21 +3516,19 @@ func (m *Model) updateConfirmContinueScan(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) continueScan() tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-		defer cancel()
-
+	return m.startContinuousScan(m.scanItemsFound, m.scanTotalScanned, func(ctx context.Context, opts dynamo.ScanOptions) tea.Msg {
 		// Continue from where we left off, but we want to accumulate more items
-		targetCount := m.scanItemsFound + int(m.pageSize)
+		targetCount := int32(m.scanItemsFound) + m.pageSize
 
-		result, err := m.client.ScanTableContinuous(ctx, m.currentTable, targetCount, m.scanLastKey, m.filterExpr, m.filterNames, m.filterValues)
+		result, err := m.client.ScanTableContinuous(ctx, m.scanInput(targetCount, m.scanLastKey), opts)
 		if err != nil {
 			return errMsg{err}
 		}
 
 		// Append new items to existing ones
-		allItems := make([]map[string]types.AttributeValue, 0, len(m.items)+len(result.Items))
-		allItems = append(allItems, m.items...)
+		live := m.liveItems()
+		allItems := make([]map[string]types.AttributeValue, 0, len(live)+len(result.Items))
+		allItems = append(allItems, live...)
 		allItems = append(allItems, result.Items...)
 
 		// Create a combined result
@@ -2111,20 +3538,64 @@ func (m *Model) continueScan() tea.Cmd {
 			TotalScanned:     m.scanTotalScanned + result.TotalScanned,
 			HasMore:          result.HasMore,
 			TimedOut:         result.TimedOut,
+			ConsumedCapacity: result.ConsumedCapacity,
 		}
 
-		return continuousScanMsg{result: combinedResult, totalScanned: combinedResult.TotalScanned}
-	}
+		return continuousScanMsg{result: combinedResult, totalScanned: combinedResult.TotalScanned, resumed: true}
+	})
 }
 
 func (m Model) viewExport() string {
 	var b strings.Builder
 
+	delim := m.csvOptions.delimiterLabel()
+	if m.csvDelimPending {
+		delim = "press the new delimiter key…"
+	}
+	header := "on"
+	if m.csvOptions.noHeader {
+		header = "off"
+	}
+	scope := fmt.Sprintf("Export %d loaded items from %s", len(m.items), m.currentTable)
+	if m.exportFull {
+		scope = fmt.Sprintf("Export the whole %s table (parallel scan, %d workers)", m.currentTable, m.workers())
+	}
+	full := "off (loaded items only)"
+	if m.exportFull {
+		full = "on"
+	}
+	gzip := "off"
+	if m.exportGzip {
+		gzip = "on (.gz, not applied to xlsx)"
+	}
+	projection := "none (whole items)"
+	if m.exportProjExpr != "" {
+		projection = m.exportProjExpr
+	}
+	if m.exportProjEditing {
+		projection = "\n" + ui.InputFocusedStyle.Render(m.exportProjInput.View())
+		if m.exportProjErr != "" {
+			projection += "\n" + ui.ErrorStyle.Render("✗ "+m.exportProjErr)
+		}
+		projection += "\n" + ui.HelpStyle.Render("JMESPath per item • Enter: apply (empty clears) • Esc: keep the old one")
+	}
+
 	content := ui.ModalStyle.Render(
 		ui.TitleStyle.Render("📦 Export Data") + "\n\n" +
-			ui.ItemStyle.Render(fmt.Sprintf("Export %d items from %s", len(m.items), m.currentTable)) + "\n\n" +
+			ui.ItemStyle.Render(scope) + "\n\n" +
 			ui.ButtonStyle.Render("J") + " JSON format\n" +
-			ui.ButtonStyle.Render("C") + " CSV format\n\n" +
+			ui.ButtonStyle.Render("C") + " CSV format\n" +
+			ui.ButtonStyle.Render("T") + " TSV format\n" +
+			ui.ButtonStyle.Render("X") + " Excel (xlsx)\n" +
+			ui.ButtonStyle.Render("Y") + " YAML format\n\n" +
+			ui.ItemStyle.Render("CSV options") + "\n" +
+			ui.ButtonStyle.Render("D") + " Delimiter: " + delim + "\n" +
+			ui.ButtonStyle.Render("H") + " Header row: " + header + "\n\n" +
+			ui.ButtonStyle.Render("G") + " Gzip: " + gzip + "\n" +
+			ui.ButtonStyle.Render("P") + " Projection: " + projection + "\n\n" +
+			ui.ButtonStyle.Render("F") + " Full table: " + full + "\n" +
+			ui.ButtonStyle.Render("+/-") + " Workers: " + fmt.Sprintf("%d", m.workers()) + "\n\n" +
+			ui.ButtonStyle.Render("S") + " Native export to S3 (PITR, for huge tables)\n\n" +
 			ui.HelpStyle.Render("Press Esc to cancel"),
 	)
 
@@ -2138,12 +3609,24 @@ func (m *Model) updateSchema(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "q", "esc":
 		m.view = viewTableData
 	case "y":
+		if m.showPolicy && m.policy != "" {
+			if err := copyToClipboard(m.policy); err == nil {
+				m.statusMsg = "✓ Copied resource policy to clipboard"
+			}
+			return m, nil
+		}
 		// Copy schema as JSON
 		if m.tableInfo != nil && m.tableInfo.RawJSON != "" {
-			if err := clipboard.WriteAll(m.tableInfo.RawJSON); err == nil {
+			if err := copyToClipboard(m.tableInfo.RawJSON); err == nil {
 				m.statusMsg = "✓ Copied schema to clipboard"
 			}
 		}
+	case "p":
+		return m, m.toggleResourcePolicy()
+	case "i":
+		return m, m.openInsights()
+	case "a":
+		return m, m.openAutoScaling()
 	case "up", "k":
 		m.itemViewport.LineUp(3)
 	case "down", "j":
@@ -2174,7 +3657,11 @@ func (m Model) viewSchema() string {
 	var b strings.Builder
 
 	// Title
-	b.WriteString(ui.TitleStyle.Render("📋 Table Schema: " + m.currentTable))
+	title := "📋 Table Schema: "
+	if m.showPolicy {
+		title = "🔐 Resource Policy: "
+	}
+	b.WriteString(ui.TitleStyle.Render(title + m.currentTable))
 	b.WriteString("\n\n")
 
 	if m.tableInfo == nil {
@@ -2183,8 +3670,12 @@ func (m Model) viewSchema() string {
 	}
 
 	// Quick info header
+	status := m.tableInfo.Status
+	if t := m.tableInfo.Transition(); t != "" {
+		status = "⏳ " + t
+	}
 	quickInfo := fmt.Sprintf("Status: %s │ Items: %d │ Size: %s",
-		m.tableInfo.Status,
+		status,
 		m.tableInfo.ItemCount,
 		formatBytes(m.tableInfo.SizeBytes))
 	b.WriteString(ui.HelpStyle.Render(quickInfo))
@@ -2206,6 +3697,9 @@ func (m Model) viewSchema() string {
 		{Key: "↑/↓", Desc: "Scroll"},
 		{Key: "PgUp/PgDn", Desc: "Page"},
 		{Key: "y", Desc: "Copy JSON"},
+		{Key: "p", Desc: "Schema/Policy"},
+		{Key: "i", Desc: "Contributor Insights"},
+		{Key: "a", Desc: "Auto Scaling"},
 		{Key: "q/Esc", Desc: "Back"},
 	})
 	b.WriteString(help)
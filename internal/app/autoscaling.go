@@ -0,0 +1,286 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// Fields of the auto scaling editor
+const (
+	scalingFieldMin = iota
+	scalingFieldMax
+	scalingFieldTarget
+	scalingFieldCount
+)
+
+type (
+	autoScalingMsg struct {
+		targets []dynamo.ScalingTarget
+		done    string // status to show instead of the count, after a save
+	}
+	autoScalingSavedMsg struct{ target dynamo.ScalingTarget }
+)
+
+// openAutoScaling shows the auto scaling of a provisioned table's capacity
+func (m *Model) openAutoScaling() tea.Cmd {
+	if m.tableInfo == nil {
+		return nil
+	}
+	if m.tableInfo.BillingMode == "PAY_PER_REQUEST" {
+		m.statusMsg = "✗ " + m.currentTable + " is on-demand; auto scaling only applies to provisioned capacity"
+		return nil
+	}
+	if m.scalingInputs == nil {
+		for range scalingFieldCount {
+			in := textinput.New()
+			in.CharLimit = 6
+			in.Width = 10
+			m.scalingInputs = append(m.scalingInputs, in)
+		}
+		m.scalingInputs[scalingFieldTarget].Placeholder = "off"
+	}
+	m.view = viewAutoScaling
+	m.scalingTargets = nil
+	m.scalingCursor = 0
+	m.scalingEditing = false
+	return m.loadAutoScaling("")
+}
+
+func (m *Model) loadAutoScaling(done string) tea.Cmd {
+	m.loading = true
+	m.statusMsg = "Reading auto scaling..."
+	info := m.tableInfo
+	return func() tea.Msg {
+		targets, err := m.client.DescribeAutoScaling(context.Background(), info)
+		if err != nil {
+			return errMsg{err}
+		}
+		return autoScalingMsg{targets, done}
+	}
+}
+
+// handleAutoScaling lists read and write capacity of the table and every
+// GSI, whether or not it is auto scaled yet, so any of them can be set up.
+func (m *Model) handleAutoScaling(msg autoScalingMsg) {
+	m.loading = false
+	targets := msg.targets
+	registered := make(map[string]dynamo.ScalingTarget, len(targets))
+	for _, t := range targets {
+		registered[scalingKey(t)] = t
+	}
+	indexes := []string{""}
+	for _, gsi := range m.tableInfo.GSIs {
+		indexes = append(indexes, gsi.Name)
+	}
+	m.scalingTargets = nil
+	for _, idx := range indexes {
+		for _, write := range []bool{false, true} {
+			t := dynamo.ScalingTarget{Index: idx, Write: write}
+			if r, ok := registered[scalingKey(t)]; ok {
+				t = r
+			}
+			m.scalingTargets = append(m.scalingTargets, t)
+		}
+	}
+	m.statusMsg = fmt.Sprintf("%d of %d capacities auto scaled", len(targets), len(m.scalingTargets))
+	if msg.done != "" {
+		m.statusMsg = msg.done
+	}
+}
+
+func scalingKey(t dynamo.ScalingTarget) string {
+	return fmt.Sprintf("%s/%t", t.Index, t.Write)
+}
+
+// scalingLabel names the capacity a target scales
+func scalingLabel(t dynamo.ScalingTarget) string {
+	label := "Table"
+	if t.Index != "" {
+		label = "GSI " + t.Index
+	}
+	if t.Write {
+		return label + " write"
+	}
+	return label + " read"
+}
+
+func (m *Model) updateAutoScaling(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scalingEditing {
+		return m.updateAutoScalingEditor(msg)
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.view = viewSchema
+	case "up", "k":
+		if m.scalingCursor > 0 {
+			m.scalingCursor--
+		}
+	case "down", "j":
+		if m.scalingCursor < len(m.scalingTargets)-1 {
+			m.scalingCursor++
+		}
+	case "r":
+		return m, m.loadAutoScaling("")
+	case "enter", "e":
+		if m.scalingCursor >= len(m.scalingTargets) || m.loading {
+			return m, nil
+		}
+		t := m.scalingTargets[m.scalingCursor]
+		values := []string{"", "", ""}
+		if t.Min > 0 {
+			values = []string{strconv.Itoa(int(t.Min)), strconv.Itoa(int(t.Max)), ""}
+		}
+		if t.TargetUtilization > 0 {
+			values[scalingFieldTarget] = strconv.FormatFloat(t.TargetUtilization, 'f', -1, 64)
+		}
+		for i, v := range values {
+			m.scalingInputs[i].SetValue(v)
+		}
+		m.scalingEditing = true
+		return m, m.focusScalingField(scalingFieldMin)
+	}
+	return m, nil
+}
+
+func (m *Model) focusScalingField(field int) tea.Cmd {
+	m.scalingFocus = field
+	var cmd tea.Cmd
+	for i := range m.scalingInputs {
+		if i == field {
+			cmd = m.scalingInputs[i].Focus()
+		} else {
+			m.scalingInputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *Model) updateAutoScalingEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.scalingEditing = false
+		return m, nil
+	case "tab", "down":
+		return m, m.focusScalingField((m.scalingFocus + 1) % scalingFieldCount)
+	case "shift+tab", "up":
+		return m, m.focusScalingField((m.scalingFocus + scalingFieldCount - 1) % scalingFieldCount)
+	case "enter":
+		t, err := m.parseScalingTarget()
+		if err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return m, nil
+		}
+		m.scalingEditing = false
+		m.loading = true
+		m.statusMsg = "Updating " + scalingLabel(t) + " auto scaling..."
+		table := m.currentTable
+		return m, func() tea.Msg {
+			if err := m.client.UpdateAutoScaling(context.Background(), table, t); err != nil {
+				return errMsg{err}
+			}
+			return autoScalingSavedMsg{t}
+		}
+	}
+	var cmd tea.Cmd
+	m.scalingInputs[m.scalingFocus], cmd = m.scalingInputs[m.scalingFocus].Update(msg)
+	return m, cmd
+}
+
+// parseScalingTarget applies the editor's fields to the selected target
+func (m *Model) parseScalingTarget() (dynamo.ScalingTarget, error) {
+	t := m.scalingTargets[m.scalingCursor]
+	minCap, err := strconv.Atoi(strings.TrimSpace(m.scalingInputs[scalingFieldMin].Value()))
+	if err != nil {
+		return t, fmt.Errorf("minimum capacity must be a number")
+	}
+	maxCap, err := strconv.Atoi(strings.TrimSpace(m.scalingInputs[scalingFieldMax].Value()))
+	if err != nil {
+		return t, fmt.Errorf("maximum capacity must be a number")
+	}
+	t.Min, t.Max = int32(minCap), int32(maxCap)
+	t.TargetUtilization = 0
+	if s := strings.TrimSuffix(strings.TrimSpace(m.scalingInputs[scalingFieldTarget].Value()), "%"); s != "" && s != "off" {
+		if t.TargetUtilization, err = strconv.ParseFloat(s, 64); err != nil {
+			return t, fmt.Errorf("target utilization must be a percentage")
+		}
+	}
+	if t.TargetUtilization == 0 && t.Policy != "" {
+		// Removing a policy is not something this editor does
+		return t, fmt.Errorf("%s has policy %s; keep a target utilization", scalingLabel(t), t.Policy)
+	}
+	return t, t.Validate()
+}
+
+// handleAutoScalingSaved re-reads the targets so a new policy shows under
+// the name AWS stored it with
+func (m *Model) handleAutoScalingSaved(t dynamo.ScalingTarget) tea.Cmd {
+	return m.loadAutoScaling(fmt.Sprintf("✓ %s scales between %d and %d", scalingLabel(t), t.Min, t.Max))
+}
+
+func (m Model) viewAutoScaling() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("📈 Auto Scaling: " + m.currentTable))
+	b.WriteString("\n\n")
+	if info := m.tableInfo; info != nil {
+		b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("Provisioned: %d RCU │ %d WCU", info.ReadCapacity, info.WriteCapacity)))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(ui.TableHeaderStyle.Render(fmt.Sprintf("%-32s %8s %8s %8s", "Capacity", "Min", "Max", "Target")))
+	b.WriteString("\n")
+	for i, t := range m.scalingTargets {
+		line := fmt.Sprintf("%-32s %8s %8s %8s", ui.Truncate(scalingLabel(t), 32), "-", "-", "off")
+		if t.Min > 0 {
+			target := "off"
+			if t.TargetUtilization > 0 {
+				target = strconv.FormatFloat(t.TargetUtilization, 'f', -1, 64) + "%"
+			}
+			line = fmt.Sprintf("%-32s %8d %8d %8s", ui.Truncate(scalingLabel(t), 32), t.Min, t.Max, target)
+		}
+		if i == m.scalingCursor {
+			b.WriteString(ui.SelectedStyle.Render(line))
+		} else {
+			b.WriteString(ui.ItemStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+
+	if m.scalingEditing && m.scalingCursor < len(m.scalingTargets) {
+		b.WriteString("\n" + ui.KeyStyle.Render("Edit "+scalingLabel(m.scalingTargets[m.scalingCursor])) + "\n")
+		labels := []string{"Min capacity", "Max capacity", fmt.Sprintf("Target utilization %% (%d-%d)", dynamo.MinTargetUtilization, dynamo.MaxTargetUtilization)}
+		for i, in := range m.scalingInputs {
+			style := ui.InputStyle
+			if i == m.scalingFocus {
+				style = ui.InputFocusedStyle
+			}
+			b.WriteString(ui.ItemStyle.Render(labels[i]+":") + "\n" + style.Render(in.View()) + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	keys := []ui.KeyBinding{
+		{Key: "↑/↓", Desc: "Select"},
+		{Key: "Enter", Desc: "Edit"},
+		{Key: "r", Desc: "Refresh"},
+		{Key: "q/Esc", Desc: "Back"},
+	}
+	if m.scalingEditing {
+		keys = []ui.KeyBinding{
+			{Key: "Tab", Desc: "Next field"},
+			{Key: "Enter", Desc: "Save"},
+			{Key: "Esc", Desc: "Cancel"},
+		}
+	}
+	b.WriteString(ui.RenderHelp(keys))
+	return b.String()
+}
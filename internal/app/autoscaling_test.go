@@ -0,0 +1,76 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func scalingModel() Model {
+	m := populatedModel()
+	m.view = viewSchema
+	m.tableInfo.BillingMode = "PROVISIONED"
+	m.tableInfo.GSIs = []dynamo.IndexInfo{{Name: "byEmail"}}
+	m = drive(m, keyRunes("a"))
+	return drive(m, autoScalingMsg{targets: []dynamo.ScalingTarget{
+		{Min: 5, Max: 100, Policy: "reads", TargetUtilization: 70},
+	}})
+}
+
+func TestAutoScalingListsEveryCapacity(t *testing.T) {
+	m := scalingModel()
+	if m.view != viewAutoScaling || len(m.scalingTargets) != 4 {
+		t.Fatalf("view = %d, targets = %+v", m.view, m.scalingTargets)
+	}
+	out := m.View()
+	for _, want := range []string{"Table read", "70%", "GSI byEmail write"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(m.statusMsg, "1 of 4") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
+
+func TestAutoScalingOnDemandTable(t *testing.T) {
+	m := populatedModel()
+	m.view = viewSchema
+	m.tableInfo.BillingMode = "PAY_PER_REQUEST"
+	m = drive(m, keyRunes("a"))
+	if m.view != viewSchema || !strings.Contains(m.statusMsg, "on-demand") {
+		t.Fatalf("view = %d, status = %q", m.view, m.statusMsg)
+	}
+}
+
+func TestAutoScalingEditorValidates(t *testing.T) {
+	m := drive(scalingModel(), tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.scalingEditing || m.scalingInputs[scalingFieldMax].Value() != "100" || m.scalingInputs[scalingFieldTarget].Value() != "70" {
+		t.Fatalf("editor not filled from the target: %v", m.scalingInputs)
+	}
+
+	m.scalingInputs[scalingFieldTarget].SetValue("95")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.scalingEditing || !strings.Contains(m.statusMsg, "between 20 and 90") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	m.scalingInputs[scalingFieldTarget].SetValue("")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.statusMsg, "keep a target utilization") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m.scalingInputs[scalingFieldTarget].SetValue("60%")
+	got, err := m.parseScalingTarget()
+	if err != nil || got.TargetUtilization != 60 || got.Max != 100 || got.Policy != "reads" {
+		t.Fatalf("got %+v, %v", got, err)
+	}
+
+	m = drive(m, autoScalingMsg{targets: nil, done: "✓ saved"})
+	if m.statusMsg != "✓ saved" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// Fields of the bulk rename panel
+const (
+	renameFieldFrom = iota
+	renameFieldTo
+	renameFieldCount
+)
+
+type (
+	renameProgressMsg struct{ progress dynamo.RenameProgress }
+	renameDoneMsg     struct {
+		progress dynamo.RenameProgress
+		dryRun   bool
+		err      error
+	}
+)
+
+// openBulkRename renames an attribute across the items the current filter
+// matches, starting from the selected column
+func (m *Model) openBulkRename() tea.Cmd {
+	if m.tableInfo == nil {
+		return nil
+	}
+	if m.renameInputs == nil {
+		for _, placeholder := range []string{"attribute", "new name"} {
+			in := textinput.New()
+			in.Placeholder = placeholder
+			in.CharLimit = 255
+			in.Width = 30
+			m.renameInputs = append(m.renameInputs, in)
+		}
+	}
+	m.renameInputs[renameFieldFrom].SetValue(m.selectedColumn())
+	m.renameInputs[renameFieldTo].SetValue("")
+	m.renamePreview = nil
+	m.renameProgress = dynamo.RenameProgress{}
+	m.view = viewBulkRename
+	return m.focusRenameField(renameFieldTo)
+}
+
+func (m *Model) focusRenameField(field int) tea.Cmd {
+	m.renameFocus = field
+	var cmd tea.Cmd
+	for i := range m.renameInputs {
+		if i == field {
+			cmd = m.renameInputs[i].Focus()
+		} else {
+			m.renameInputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *Model) renameInput() dynamo.RenameInput {
+	in := dynamo.RenameInput{
+		Table:            m.currentTable,
+		Key:              []string{m.tableInfo.PartitionKey},
+		From:             strings.TrimSpace(m.renameInputs[renameFieldFrom].Value()),
+		To:               strings.TrimSpace(m.renameInputs[renameFieldTo].Value()),
+		FilterExpression: m.filterExpr,
+		ExpressionNames:  m.filterNames,
+		ExpressionValues: m.filterValues,
+	}
+	if m.tableInfo.SortKey != "" {
+		in.Key = append(in.Key, m.tableInfo.SortKey)
+	}
+	return in
+}
+
+func (m *Model) updateBulkRename(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.renameCh != nil {
+		if msg.String() == "esc" {
+			m.renameCancel()
+			m.statusMsg = "Stopping rename..."
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc":
+		m.view = viewTableData
+		return m, nil
+	case "tab", "down", "shift+tab", "up":
+		return m, m.focusRenameField((m.renameFocus + 1) % renameFieldCount)
+	case "enter":
+		in := m.renameInput()
+		if err := in.Validate(); err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return m, nil
+		}
+		// The first Enter is always a dry run; the next one renames what it found
+		in.DryRun = m.renamePreview == nil || m.renamePreview.from != in.From || m.renamePreview.to != in.To
+		return m, m.startRename(in)
+	}
+
+	var cmd tea.Cmd
+	m.renameInputs[m.renameFocus], cmd = m.renameInputs[m.renameFocus].Update(msg)
+	return m, cmd
+}
+
+// renamePreview is a dry run's result and the names it was run for
+type renamePreview struct {
+	from, to string
+	progress dynamo.RenameProgress
+}
+
+func (m *Model) startRename(in dynamo.RenameInput) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 1)
+	m.renameCh, m.renameCancel = ch, cancel
+	m.renameProgress = dynamo.RenameProgress{}
+	m.renameDryRun = in.DryRun
+	if in.DryRun {
+		m.statusMsg = "Dry run: counting items with " + in.From + "..."
+	} else {
+		m.statusMsg = "Renaming " + in.From + " to " + in.To + "..."
+	}
+
+	client := m.client
+	go func() {
+		defer cancel()
+		p, err := client.RenameAttribute(ctx, in, func(p dynamo.RenameProgress) {
+			ch <- renameProgressMsg{p}
+		})
+		ch <- renameDoneMsg{progress: p, dryRun: in.DryRun, err: err}
+	}()
+	return waitForRename(ch)
+}
+
+func waitForRename(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func (m *Model) handleRenameDone(msg renameDoneMsg) tea.Cmd {
+	m.renameCh, m.renameCancel = nil, nil
+	m.renameProgress = msg.progress
+	in := m.renameInput()
+	switch {
+	case msg.err != nil && msg.dryRun:
+		m.statusMsg = "✗ " + msg.err.Error()
+	case msg.err != nil:
+		// Items renamed before the error stay renamed
+		m.statusMsg = fmt.Sprintf("✗ Stopped after renaming %d items: %s", msg.progress.Renamed, msg.err)
+		m.renamePreview = nil
+	case msg.dryRun:
+		m.renamePreview = &renamePreview{from: in.From, to: in.To, progress: msg.progress}
+		m.statusMsg = fmt.Sprintf("Dry run: %d items have %s; Enter renames them", msg.progress.Matched, in.From)
+	default:
+		m.renamePreview = nil
+		m.view = viewTableData
+		m.statusMsg = fmt.Sprintf("✓ Renamed %s to %s on %d items", in.From, in.To, msg.progress.Renamed)
+		if msg.progress.Skipped > 0 {
+			m.statusMsg += fmt.Sprintf(" (%d skipped: changed meanwhile or already had %s)", msg.progress.Skipped, in.To)
+		}
+		m.lastKey = nil
+		m.loading = true
+		return m.scanTable()
+	}
+	return nil
+}
+
+func (m Model) viewBulkRename() string {
+	var body strings.Builder
+	scope := "every item in " + m.currentTable
+	if m.filterExpr != "" {
+		scope = "items matching the current filter"
+	}
+	body.WriteString(ui.HelpStyle.Render("Renames the attribute on "+scope+",") + "\n")
+	body.WriteString(ui.HelpStyle.Render("one UpdateItem per item (SET new = old, REMOVE old).") + "\n\n")
+	labels := []string{"Attribute", "New name"}
+	for i, in := range m.renameInputs {
+		style := ui.InputStyle
+		if i == m.renameFocus {
+			style = ui.InputFocusedStyle
+		}
+		body.WriteString(ui.ItemStyle.Render(labels[i]+":") + "\n" + style.Render(in.View()) + "\n")
+	}
+	body.WriteString("\n")
+
+	p := m.renameProgress
+	switch {
+	case m.renameCh != nil && m.renameDryRun:
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("Dry run: scanned %d, %d items to rename...", p.Scanned, p.Matched)))
+	case m.renameCh != nil:
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("Renamed %d of %d found so far (scanned %d)...", p.Renamed, p.Matched, p.Scanned)))
+	case m.renamePreview != nil:
+		body.WriteString(ui.SuccessStyle.Render(fmt.Sprintf("%d items would change (scanned %d)", m.renamePreview.progress.Matched, m.renamePreview.progress.Scanned)))
+	}
+	body.WriteString("\n" + ui.StatusBarStyle.Render(m.statusMsg) + "\n\n")
+
+	help := "Tab: next field • Enter: dry run • Esc: cancel"
+	switch {
+	case m.renameCh != nil:
+		help = "Esc: stop"
+	case m.renamePreview != nil:
+		help = "Tab: next field • Enter: rename • Esc: cancel"
+	}
+	body.WriteString(ui.HelpStyle.Render(help))
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("✏️  Rename Attribute") + "\n\n" + body.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
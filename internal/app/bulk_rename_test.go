@@ -0,0 +1,49 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestBulkRenameDryRunsBeforeRenaming(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.dataTable.SelectedCol = 1 // name
+	m = drive(m, keyRunes("M"))
+	if m.view != viewBulkRename || m.renameInputs[renameFieldFrom].Value() != "name" {
+		t.Fatalf("view %d, from %q", m.view, m.renameInputs[renameFieldFrom].Value())
+	}
+
+	// Without a new name nothing runs
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("a rename without a new name must not start")
+	}
+	m = drive(m, keyRunes("fullName"))
+	if in := m.renameInput(); in.To != "fullName" || len(in.Key) != 1 || in.Key[0] != "id" {
+		t.Fatalf("input = %+v", in)
+	}
+
+	m = drive(m, renameDoneMsg{progress: dynamo.RenameProgress{Scanned: 2, Matched: 2}, dryRun: true})
+	if m.renamePreview == nil || !strings.Contains(m.View(), "2 items would change") {
+		t.Fatal("a dry run should show what would change")
+	}
+
+	m = drive(m, renameDoneMsg{progress: dynamo.RenameProgress{Scanned: 2, Matched: 2, Renamed: 1, Skipped: 1}})
+	if m.view != viewTableData || !strings.HasPrefix(m.statusMsg, "✓ Renamed name to fullName on 1 items (1 skipped") {
+		t.Fatalf("view %d, status %q", m.view, m.statusMsg)
+	}
+}
+
+func TestBulkRenameIsAWrite(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.config.ReadOnly = true
+	m = drive(m, keyRunes("M"))
+	if m.view != viewTableData || !strings.Contains(m.statusMsg, "renaming attributes") {
+		t.Fatalf("read-only mode should refuse a rename, status %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Seams for tests: the system clipboard and the terminal OSC52 is written to.
+var (
+	writeSystemClipboard           = clipboard.WriteAll
+	osc52Out             io.Writer = os.Stderr
+	getenv                         = os.Getenv
+)
+
+// copyToClipboard puts text on the system clipboard. When that is unavailable
+// (SSH sessions, headless boxes without xclip/xsel/wl-copy) it falls back to an
+// OSC52 escape sequence, which asks the terminal emulator itself to set the
+// clipboard and works through tmux and screen when they pass it along.
+func copyToClipboard(text string) error {
+	if err := writeSystemClipboard(text); err == nil {
+		return nil
+	}
+	seq := osc52.New(text)
+	switch {
+	case getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(osc52Out)
+	return err
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubClipboard swaps the clipboard seams for the duration of a test.
+func stubClipboard(t *testing.T, sysErr error, env map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	oldWrite, oldOut, oldEnv := writeSystemClipboard, osc52Out, getenv
+	writeSystemClipboard = func(string) error { return sysErr }
+	osc52Out = &buf
+	getenv = func(k string) string { return env[k] }
+	t.Cleanup(func() { writeSystemClipboard, osc52Out, getenv = oldWrite, oldOut, oldEnv })
+	return &buf
+}
+
+func TestCopyToClipboardSystemOK(t *testing.T) {
+	buf := stubClipboard(t, nil, nil)
+	if err := copyToClipboard("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("OSC52 written although system clipboard worked: %q", buf.String())
+	}
+}
+
+func TestCopyToClipboardFallsBackToOSC52(t *testing.T) {
+	buf := stubClipboard(t, errors.New("no xclip"), nil)
+	if err := copyToClipboard("hello"); err != nil {
+		t.Fatal(err)
+	}
+	want := base64.StdEncoding.EncodeToString([]byte("hello"))
+	if out := buf.String(); !strings.HasPrefix(out, "\x1b]52;c;") || !strings.Contains(out, want) {
+		t.Fatalf("unexpected OSC52 sequence %q", out)
+	}
+}
+
+func TestCopyToClipboardWrapsForTmux(t *testing.T) {
+	buf := stubClipboard(t, errors.New("no xclip"), map[string]string{"TMUX": "/tmp/tmux-0/default,1,0"})
+	if err := copyToClipboard("hello"); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, "\x1bPtmux;") {
+		t.Fatalf("tmux passthrough missing: %q", out)
+	}
+}
+
+func TestCopyCellUsesFallback(t *testing.T) {
+	buf := stubClipboard(t, errors.New("no xclip"), nil)
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, keyRunes("y"))
+	if !strings.HasPrefix(m.statusMsg, "✓") {
+		t.Fatalf("statusMsg = %q, want success via OSC52", m.statusMsg)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("no OSC52 sequence written")
+	}
+}
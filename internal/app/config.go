@@ -0,0 +1,397 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// configFile holds the user's defaults, next to the other files godynamo
+// keeps in its config directory
+const configFile = "config.yaml"
+
+// Config is the defaults read from config.yaml. Command line flags override
+// them before they reach NewWithConfig.
+type Config struct {
+	PageSize      int32  `yaml:"page_size"`
+	Region        string `yaml:"region"` // connect here instead of picking the first region with tables
+	Theme         string `yaml:"theme"`
+	ConfirmDelete bool   `yaml:"confirm_delete"`
+	ConfirmSave   bool   `yaml:"confirm_save"`
+	ExportDir     string `yaml:"export_dir"`  // "" exports to the working directory
+	Profile       string `yaml:"profile"`     // AWS profile; "" uses the default credential chain
+	Endpoint      string `yaml:"endpoint"`    // DynamoDB-compatible endpoint, e.g. DynamoDB Local
+	ReadOnly      bool   `yaml:"read_only"`   // refuse every change to tables and items
+	RowNumbers    bool   `yaml:"row_numbers"` // the data table's # column; # toggles it
+	Proxy         string `yaml:"proxy"`       // HTTP(S) proxy for AWS requests
+	CABundle      string `yaml:"ca_bundle"`   // PEM file of extra CAs to trust, e.g. a corporate one
+	Keybindings   string `yaml:"keybindings"` // "vim" or "emacs" layers that editor's keys over the built-in ones
+
+	// Accessible switches to the high-contrast theme and renders plain text
+	// for screen readers: no emoji, no box drawing, no overlays, and panes
+	// stacked in reading order instead of side by side
+	Accessible bool `yaml:"accessible"`
+
+	// NoColor drops colors; the NO_COLOR environment variable sets it too
+	NoColor bool `yaml:"no_color"`
+
+	// ASCII draws arrows, marks and borders in ASCII, for terminals and
+	// fonts that show the Unicode ones as garbage
+	ASCII bool `yaml:"ascii"`
+
+	// Setup runs the first-run wizard before connecting; set when there is
+	// no config file yet
+	Setup bool `yaml:"-"`
+
+	// DryRun plans every change in a panel instead of making it, to
+	// rehearse risky fixes; only the --dry-run flag sets it
+	DryRun bool `yaml:"-"`
+
+	// Table and Key open a table, and an item in it, once connected; only
+	// the --table and --key flags set them
+	Table string `yaml:"-"`
+	Key   string `yaml:"-"` // e.g. "pk=123,sk=2024"
+
+	// Client is used instead of connecting to AWS, e.g. a dynamotest.Fake
+	// in tests; only code sets it
+	Client dynamo.API `yaml:"-"`
+
+	// Timeouts bound ListTables, each scanned page and queries
+	Timeouts Timeouts `yaml:"timeouts"`
+
+	// Scan bounds scans: each run of a continuous scan, the items it keeps
+	// in memory and the table size a full scan asks about
+	Scan ScanSettings `yaml:"scan"`
+
+	// WatchInterval is the pause between runs of a watched read
+	WatchInterval time.Duration `yaml:"watch_interval"`
+
+	// StatusBar lays out the table view's status bar
+	StatusBar StatusBarLayout `yaml:"status_bar"`
+
+	// Panes are the widths [ and ] resize; resizing saves them here
+	Panes Panes `yaml:"panes"`
+
+	// Decrypt is the plugin D runs to show client-side encrypted attributes
+	Decrypt DecryptPlugin `yaml:"decrypt"`
+
+	// OpenWith are the external tools o pipes the open item to
+	OpenWith []ExternalTool `yaml:"open_with"`
+
+	// Schemas associates tables with JSON Schema files; saving an item in
+	// one validates it first, e.g. orders: ~/schemas/order.json
+	Schemas map[string]string `yaml:"schemas"`
+
+	// Keymap binds extra keys to built-in ones, e.g. "x": "d" makes x delete
+	// like d does. It applies outside text inputs only.
+	Keymap map[string]string `yaml:"keymap"`
+}
+
+// LocalEndpoint is where DynamoDB Local listens by default
+const LocalEndpoint = "http://localhost:8000"
+
+// DefaultConfig is what godynamo uses without a config file
+func DefaultConfig() Config {
+	return Config{
+		PageSize:      500,
+		Theme:         "neon",
+		ConfirmDelete: true,
+		ConfirmSave:   true,
+		RowNumbers:    true,
+		Timeouts:      defaultTimeouts(),
+		Scan:          defaultScanSettings(),
+		WatchInterval: defaultWatchInterval,
+		StatusBar:     defaultStatusBar(),
+		Panes:         defaultPanes(),
+	}
+}
+
+// ConfigPath is where LoadConfig reads the config from
+func ConfigPath() (string, error) {
+	return appDataPath(configFile)
+}
+
+// LoadConfig reads config.yaml over the defaults. A missing file is not an
+// error; a malformed one is, so a typo doesn't silently fall back.
+func LoadConfig() (Config, error) {
+	cfg := DefaultConfig()
+	path, err := ConfigPath()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ApplyEnv overrides the config with GODYNAMO_* environment variables, for
+// wrappers and containers that can't pass flags. Flags still win over them.
+func (c *Config) ApplyEnv() error {
+	if v := getenv("GODYNAMO_PAGE_SIZE"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("GODYNAMO_PAGE_SIZE: %q is not a number", v)
+		}
+		c.PageSize = int32(n)
+	}
+	for name, field := range map[string]*string{
+		"GODYNAMO_REGION":      &c.Region,
+		"GODYNAMO_THEME":       &c.Theme,
+		"GODYNAMO_EXPORT_DIR":  &c.ExportDir,
+		"GODYNAMO_PROFILE":     &c.Profile,
+		"GODYNAMO_ENDPOINT":    &c.Endpoint,
+		"GODYNAMO_PROXY":       &c.Proxy,
+		"GODYNAMO_CA_BUNDLE":   &c.CABundle,
+		"GODYNAMO_KEYBINDINGS": &c.Keybindings,
+	} {
+		if v := getenv(name); v != "" {
+			*field = v
+		}
+	}
+	for name, field := range map[string]*time.Duration{
+		"GODYNAMO_SCAN_TIMEOUT":   &c.Scan.Timeout,
+		"GODYNAMO_WATCH_INTERVAL": &c.WatchInterval,
+	} {
+		if v := getenv(name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not a duration such as 90s", name, v)
+			}
+			*field = d
+		}
+	}
+	for name, field := range map[string]*int{
+		"GODYNAMO_SCAN_MAX_PAGES":   &c.Scan.MaxPages,
+		"GODYNAMO_SCAN_MAX_SCANNED": &c.Scan.MaxScanned,
+		"GODYNAMO_SCAN_ITEM_CAP":    &c.Scan.ItemCap,
+		"GODYNAMO_SCAN_WARN_MB":     &c.Scan.WarnMB,
+	} {
+		if v := getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not a number", name, v)
+			}
+			*field = n
+		}
+	}
+	for name, field := range map[string]*bool{
+		"GODYNAMO_READONLY":       &c.ReadOnly,
+		"GODYNAMO_CONFIRM_DELETE": &c.ConfirmDelete,
+		"GODYNAMO_CONFIRM_SAVE":   &c.ConfirmSave,
+		"GODYNAMO_ACCESSIBLE":     &c.Accessible,
+		"GODYNAMO_ASCII":          &c.ASCII,
+		"GODYNAMO_ROW_NUMBERS":    &c.RowNumbers,
+	} {
+		if v := getenv(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not true or false", name, v)
+			}
+			*field = b
+		}
+	}
+	if getenv("NO_COLOR") != "" { // https://no-color.org: set to anything but empty
+		c.NoColor = true
+	}
+	return nil
+}
+
+// Validate checks the values a config file or flag can get wrong, and
+// expands a leading ~/ in the export directory, CA bundle and schema files
+func (c *Config) Validate() error {
+	if c.PageSize < 1 || c.PageSize > 1000 {
+		return fmt.Errorf("page_size must be between 1 and 1000, got %d", c.PageSize)
+	}
+	if _, ok := ui.Themes[c.Theme]; !ok {
+		return fmt.Errorf("unknown theme %q", c.Theme)
+	}
+	switch c.Keybindings {
+	case "", keybindingsVim, keybindingsEmacs:
+	default:
+		return fmt.Errorf("keybindings must be vim, emacs or empty, got %q", c.Keybindings)
+	}
+	if err := c.Timeouts.validate(); err != nil {
+		return err
+	}
+	if err := c.Scan.validate(); err != nil {
+		return err
+	}
+	if c.WatchInterval <= 0 {
+		return fmt.Errorf("watch_interval must be positive, got %s", c.WatchInterval)
+	}
+	if err := c.StatusBar.validate(); err != nil {
+		return err
+	}
+	if err := c.Panes.validate(); err != nil {
+		return err
+	}
+	if c.Key != "" {
+		if c.Table == "" {
+			return fmt.Errorf("--key needs --table")
+		}
+		if _, err := parseKeyArg(c.Key); err != nil {
+			return err
+		}
+	}
+	for from, to := range c.Keymap {
+		if from == "" || to == "" {
+			return fmt.Errorf("keymap entries need a key and the key it acts as")
+		}
+	}
+	for name, path := range map[string]*string{"export_dir": &c.ExportDir, "ca_bundle": &c.CABundle} {
+		if rest, ok := strings.CutPrefix(*path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			*path = filepath.Join(home, rest)
+		}
+	}
+	for _, tool := range c.OpenWith {
+		if tool.Name == "" || len(tool.Command) == 0 {
+			return fmt.Errorf("open_with entries need a name and a command")
+		}
+	}
+	for table, path := range c.Schemas {
+		if path == "" {
+			return fmt.Errorf("schemas: %s needs a JSON Schema file", table)
+		}
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("schemas: %w", err)
+			}
+			c.Schemas[table] = filepath.Join(home, rest)
+		}
+	}
+	return nil
+}
+
+// Network is the transport the proxy and CA bundle settings call for
+func (c Config) Network() dynamo.Network {
+	return dynamo.Network{Proxy: c.Proxy, CABundle: c.CABundle}
+}
+
+// theme is the theme in use, which accessible mode decides
+func (c Config) theme() string {
+	if c.Accessible {
+		return "high-contrast"
+	}
+	return c.Theme
+}
+
+// NewWithConfig creates a Model that starts from cfg instead of the defaults
+func NewWithConfig(cfg Config) (Model, error) {
+	if err := cfg.Validate(); err != nil {
+		return Model{}, err
+	}
+	if err := ui.SetTheme(cfg.theme()); err != nil {
+		return Model{}, err
+	}
+	ui.SetNoColor(cfg.NoColor)
+	if err := cfg.Network().Validate(); err != nil {
+		return Model{}, err
+	}
+	m := New()
+	if cfg.DryRun {
+		m.plan = &dynamo.Plan{}
+	}
+	m.config = cfg
+	m.pageSize = cfg.PageSize
+	m.scanBudget = cfg.Scan.budget()
+	m.scanItemCap = cfg.Scan.ItemCap
+	m.scanWarnBytes = int64(cfg.Scan.WarnMB) << 20
+	m.watchEvery = cfg.WatchInterval
+	m.dataTable.ShowRowNums = cfg.RowNumbers
+	if len(cfg.Decrypt.Command) > 0 {
+		m.decrypter = cfg.Decrypt
+	}
+	switch {
+	case cfg.Setup:
+		m.startSetup()
+	case cfg.Endpoint != "":
+		m.statusMsg = "Connecting to " + cfg.Endpoint + "..."
+	case cfg.Region != "":
+		m.selectedRegion = cfg.Region
+		m.statusMsg = "Connecting to " + cfg.Region + "..."
+	}
+	return m, nil
+}
+
+// exportDir is where exports are written by default
+func (m *Model) exportDir() string {
+	if m.config.ExportDir != "" {
+		return m.config.ExportDir
+	}
+	cwd, _ := os.Getwd()
+	return cwd
+}
+
+// remapKey translates a key bound in the config's keymap to the built-in
+// key it stands for. Views that are typing into an input keep every key.
+func (m *Model) remapKey(msg tea.KeyMsg) tea.KeyMsg {
+	to, ok := m.config.Keymap[msg.String()]
+	if !ok || !m.acceptsKeymap() {
+		return msg
+	}
+	if key, ok := keyMsgFor(to); ok {
+		return key
+	}
+	return msg
+}
+
+// keyMsgFor is the key press a built-in key's name stands for: a named key
+// or a single character
+func keyMsgFor(name string) (tea.KeyMsg, bool) {
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t}, true
+	}
+	if r := []rune(name); len(r) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: r}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// keyTypes are the named keys a keymap entry can act as
+var keyTypes = map[string]tea.KeyType{
+	"enter": tea.KeyEnter, "esc": tea.KeyEsc, "tab": tea.KeyTab,
+	"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight,
+	"home": tea.KeyHome, "end": tea.KeyEnd, "pgup": tea.KeyPgUp, "pgdown": tea.KeyPgDown,
+	"ctrl+e": tea.KeyCtrlE, "ctrl+n": tea.KeyCtrlN, "ctrl+p": tea.KeyCtrlP, "ctrl+s": tea.KeyCtrlS,
+	"ctrl+g": tea.KeyCtrlG, "ctrl+o": tea.KeyCtrlO, "ctrl+r": tea.KeyCtrlR, "ctrl+t": tea.KeyCtrlT,
+}
+
+func (m *Model) acceptsKeymap() bool {
+	switch m.view {
+	case viewTables:
+		return !m.tableFilterMode && !m.regionDropdownOpen
+	case viewTableData:
+		return !m.rowSearchMode
+	case viewItemDetail:
+		return !m.searchMode
+	case viewSchema, viewSelectRegion, viewInsights:
+		return true
+	}
+	return false
+}
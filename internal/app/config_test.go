@@ -0,0 +1,278 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godynamo/internal/ui"
+)
+
+func writeConfig(t *testing.T, body string) {
+	t.Helper()
+	dir := filepath.Join(stubConfigDir(t), "godynamo")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, configFile), []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigWithoutFileUsesDefaults(t *testing.T) {
+	stubConfigDir(t)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PageSize != 500 || !cfg.ConfirmDelete || !cfg.ConfirmSave || cfg.Theme != "neon" {
+		t.Errorf("defaults = %+v", cfg)
+	}
+}
+
+func TestLoadConfigOverridesDefaults(t *testing.T) {
+	writeConfig(t, "page_size: 100\nregion: eu-west-1\nconfirm_delete: false\nexport_dir: /tmp/exports\nrow_numbers: false\nkeymap:\n  x: d\n")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PageSize != 100 || cfg.Region != "eu-west-1" || cfg.ConfirmDelete || !cfg.ConfirmSave {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.pageSize != 100 || m.exportDir() != "/tmp/exports" || m.dataTable.ShowRowNums {
+		t.Errorf("page size %d, export dir %q, row numbers %v", m.pageSize, m.exportDir(), m.dataTable.ShowRowNums)
+	}
+	m.view = viewTableData
+	if m = drive(m, keyRunes("#")); !m.dataTable.ShowRowNums {
+		t.Error("# should bring the row numbers back")
+	}
+}
+
+func TestLoadConfigRejectsBadValues(t *testing.T) {
+	for _, body := range []string{"page_size: 5000\n", "theme: plaid\n", "keybindings: nano\n", "status_bar:\n  right: [weather]\n", "panes:\n  sidebar: 5\n", "page_size: [\n"} {
+		writeConfig(t, body)
+		if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), configFile) {
+			t.Errorf("%q: err = %v, want one naming the file", body, err)
+		}
+	}
+}
+
+func TestConfigWithoutDeleteConfirmationDeletesAtOnce(t *testing.T) {
+	m := populatedModel()
+	m.config.ConfirmDelete = false
+	m.view = viewTableData
+	m2 := drive(m, keyRunes("d"))
+	if m2.view == viewConfirmDelete {
+		t.Fatal("confirm_delete: false should skip the confirmation")
+	}
+	if _, cmd := m.Update(keyRunes("d")); cmd == nil {
+		t.Fatal("deleting should return a command")
+	}
+}
+
+func TestKeymapOnlyOutsideInputs(t *testing.T) {
+	m := populatedModel()
+	m.config.Keymap = map[string]string{"x": "d", "J": "down"}
+	m.view = viewTableData
+	m = drive(m, keyRunes("J"))
+	if m.dataTable.SelectedRow != 1 {
+		t.Fatalf("J mapped to down should move the cursor, row = %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, keyRunes("x"))
+	if m.view != viewConfirmDelete {
+		t.Fatalf("x mapped to d should ask to delete, view = %d", m.view)
+	}
+
+	m.view = viewTableData
+	m.rowSearchMode = true
+	if got := m.remapKey(keyRunes("x")); got.String() != "x" {
+		t.Errorf("searching should keep x, got %q", got.String())
+	}
+}
+
+func TestApplyEnvOverridesConfig(t *testing.T) {
+	env := map[string]string{
+		"GODYNAMO_REGION":   "ap-south-1",
+		"GODYNAMO_ENDPOINT": "http://localhost:8000",
+		"GODYNAMO_PROFILE":  "staging",
+		"GODYNAMO_READONLY": "1",
+		"NO_COLOR":          "1",
+	}
+	old := getenv
+	getenv = func(k string) string { return env[k] }
+	t.Cleanup(func() { getenv = old })
+
+	cfg := DefaultConfig()
+	cfg.Region = "eu-west-1"
+	if err := cfg.ApplyEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Region != "ap-south-1" || cfg.Endpoint != "http://localhost:8000" || cfg.Profile != "staging" || !cfg.ReadOnly || !cfg.NoColor {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	env["GODYNAMO_READONLY"] = "sometimes"
+	if err := cfg.ApplyEnv(); err == nil || !strings.Contains(err.Error(), "GODYNAMO_READONLY") {
+		t.Errorf("err = %v, want one naming the variable", err)
+	}
+}
+
+func TestApplyEnvOverridesScanAndWatchSettings(t *testing.T) {
+	env := map[string]string{
+		"GODYNAMO_SCAN_ITEM_CAP":  "1000",
+		"GODYNAMO_SCAN_WARN_MB":   "0",
+		"GODYNAMO_WATCH_INTERVAL": "30s",
+	}
+	old := getenv
+	getenv = func(k string) string { return env[k] }
+	t.Cleanup(func() { getenv = old })
+
+	cfg := DefaultConfig()
+	if err := cfg.ApplyEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Scan.ItemCap != 1000 || cfg.Scan.WarnMB != 0 || cfg.WatchInterval != 30*time.Second {
+		t.Fatalf("scan = %+v, watch_interval = %s", cfg.Scan, cfg.WatchInterval)
+	}
+
+	// Bad values are errors instead of silently keeping the default
+	for name, v := range map[string]string{"GODYNAMO_SCAN_ITEM_CAP": "lots", "GODYNAMO_WATCH_INTERVAL": "5"} {
+		env[name] = v
+		if err := cfg.ApplyEnv(); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s=%s: err = %v, want one naming the variable", name, v, err)
+		}
+		delete(env, name)
+	}
+	for setting, v := range map[string]string{"scan.item_cap": "GODYNAMO_SCAN_ITEM_CAP=0", "scan.warn_mb": "GODYNAMO_SCAN_WARN_MB=-1", "watch_interval": "GODYNAMO_WATCH_INTERVAL=-5s"} {
+		cfg := DefaultConfig()
+		name, value, _ := strings.Cut(v, "=")
+		env[name] = value
+		if err := cfg.ApplyEnv(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), setting) {
+			t.Errorf("%s: err = %v, want one naming %s", v, err, setting)
+		}
+		delete(env, name)
+	}
+}
+
+func TestLoadConfigReadsWatchInterval(t *testing.T) {
+	writeConfig(t, "watch_interval: 1m\nscan:\n  item_cap: 200\n")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.watchEvery != time.Minute || m.scanItemCap != 200 {
+		t.Errorf("watchEvery = %s, scanItemCap = %d", m.watchEvery, m.scanItemCap)
+	}
+}
+
+func TestReadOnlyRefusesChanges(t *testing.T) {
+	m := populatedModel()
+	m.config.ReadOnly = true
+	m.view = viewTableData
+	for _, key := range []string{"n", "e", "d"} {
+		m2 := drive(m, keyRunes(key))
+		if m2.view != viewTableData || !strings.Contains(m2.statusMsg, "Read-only") {
+			t.Errorf("%s: view %d, status %q", key, m2.view, m2.statusMsg)
+		}
+	}
+	if !strings.Contains(m.View(), "🔒 Read-only") {
+		t.Error("the table view should say it is read-only")
+	}
+
+	// Searching rows still takes every letter
+	m.rowSearchMode = true
+	if m2 := drive(m, keyRunes("d")); strings.Contains(m2.statusMsg, "Read-only") {
+		t.Error("typing a search must not be refused")
+	}
+}
+
+func TestLoadConfigReadsTimeouts(t *testing.T) {
+	writeConfig(t, "timeouts:\n  list_tables: 5s\n  scan_page: 2m\n")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Timeouts{ListTables: 5 * time.Second, ScanPage: 2 * time.Minute, Query: 30 * time.Second}
+	if cfg.Timeouts != want {
+		t.Errorf("timeouts = %+v, want %+v", cfg.Timeouts, want)
+	}
+
+	writeConfig(t, "timeouts:\n  query: -1s\n")
+	if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "timeouts.query") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestTimedOutNamesTheSetting(t *testing.T) {
+	ctx, cancel := requestContext(time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	err := timedOut(ctx, ctx.Err(), "Scan", "scan_page", time.Nanosecond)
+	if !strings.Contains(err.Error(), "Scan timed out") || !strings.Contains(err.Error(), "timeouts.scan_page") {
+		t.Errorf("err = %v", err)
+	}
+
+	other := errors.New("AccessDenied")
+	if got := timedOut(context.Background(), other, "Scan", "scan_page", time.Second); got != other {
+		t.Errorf("other errors should pass through, got %v", got)
+	}
+}
+
+func TestNewWithConfigRejectsBadProxy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Proxy = "proxy.corp:3128"
+	if _, err := NewWithConfig(cfg); err == nil || !strings.Contains(err.Error(), "proxy") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestAccessibleModeRendersPlainText(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Accessible = true
+	if _, err := NewWithConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ui.SetTheme("neon") })
+	if ui.ColorPrimary != ui.Themes["high-contrast"].Primary {
+		t.Errorf("accessible mode should use the high-contrast theme, primary %v", ui.ColorPrimary)
+	}
+
+	m := populatedModel()
+	m.config.Accessible = true
+	m.view = viewTableData
+	m = drive(m, errMsg{errors.New("throttled")})
+	out := m.View()
+	if strings.ContainsAny(out, "⚡╭│─") {
+		t.Errorf("no emoji or box drawing in accessible mode:\n%s", out)
+	}
+	if strings.Contains(strings.Split(out, "\n")[0], "throttled") {
+		t.Error("toasts should not overlay the first line")
+	}
+}
+
+func TestASCIIModeDrawsNoUnicodeGlyphs(t *testing.T) {
+	m := populatedModel()
+	m.config.ASCII = true
+	m.view = viewTables
+	m.tables = []string{"Users", "Orders"}
+	out := m.View()
+	if strings.ContainsAny(out, "▸◀▶🌍╭─│") {
+		t.Errorf("ASCII mode drew a Unicode glyph:\n%s", out)
+	}
+}
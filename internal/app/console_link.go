@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/models"
+	"github.com/godynamo/pkg/query"
+)
+
+// consoleHosts maps ARN partitions to their console domain
+var consoleHosts = map[string]string{
+	"aws":        "console.aws.amazon.com",
+	"aws-cn":     "console.amazonaws.cn",
+	"aws-us-gov": "console.amazonaws-us-gov.com",
+}
+
+// consoleURL links to a table in the AWS console, or to one of its items
+// when key is given. The partition and region come from the table ARN, so
+// DynamoDB Local tables, which have no console, are refused.
+func consoleURL(arn string, key map[string]types.AttributeValue, pk, sk string) (string, error) {
+	base, table, err := consoleBase(arn)
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		return base + "#table?name=" + url.QueryEscape(table), nil
+	}
+
+	q := url.Values{"table": {table}, "itemMode": {"2"}, "route": {"ROUTE_ITEM_EXPLORER"}}
+	for param, attr := range map[string]string{"pk": pk, "sk": sk} {
+		if attr == "" {
+			continue
+		}
+		v, ok := key[attr]
+		if !ok {
+			return "", fmt.Errorf("item has no %s", attr)
+		}
+		q.Set(param, fmt.Sprint(models.AttributeValueToInterface(v)))
+	}
+	return base + "#edit-item?" + q.Encode(), nil
+}
+
+// consoleBase is the console home of the table's region, and the table's
+// name, from its ARN
+func consoleBase(arn string) (base, table string, err error) {
+	// arn:partition:dynamodb:region:account:table/name
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || !strings.HasPrefix(parts[5], "table/") {
+		return "", "", fmt.Errorf("not a table ARN: %q", arn)
+	}
+	partition, region, table := parts[1], parts[3], strings.TrimPrefix(parts[5], "table/")
+	host, ok := consoleHosts[partition]
+	if !ok || region == "ddblocal" {
+		return "", "", fmt.Errorf("%s has no AWS console", table)
+	}
+	return fmt.Sprintf("https://%s.%s/dynamodbv2/home?region=%s", region, host, region), table, nil
+}
+
+// explorerURL links to the console's item explorer running plan's read. The
+// explorer takes a Query's partition key and index but no filter
+// expression; carried is false when plan has one that is left behind.
+func explorerURL(arn string, plan query.Plan) (link string, carried bool, err error) {
+	base, table, err := consoleBase(arn)
+	if err != nil {
+		return "", false, err
+	}
+	q := url.Values{"table": {table}, "operation": {"SCAN"}}
+	if plan.Mode == query.ModeQuery {
+		q.Set("operation", "QUERY")
+		for p, v := range plan.Values {
+			if strings.HasSuffix(plan.KeyConditionExpression, p) {
+				q.Set("pk", fmt.Sprint(v))
+			}
+		}
+		if plan.IndexName != "" {
+			q.Set("index", plan.IndexName)
+		}
+	}
+	return base + "#item-explorer?" + q.Encode(), plan.FilterExpression == "", nil
+}
+
+// copyTableArn copies the open table's ARN
+func (m *Model) copyTableArn() {
+	if m.tableInfo == nil || m.tableInfo.Arn == "" {
+		m.statusMsg = "✗ Table ARN unknown"
+		return
+	}
+	if err := copyToClipboard(m.tableInfo.Arn); err != nil {
+		m.statusMsg = "✗ Failed to copy: " + err.Error()
+		return
+	}
+	m.statusMsg = "✓ Copied table ARN to clipboard"
+}
+
+// copyConsoleLink copies the console link of the open table, or of item
+// when it is not nil. With a filter applied, the table link opens the item
+// explorer on the same read for teammates using the console.
+func (m *Model) copyConsoleLink(item map[string]types.AttributeValue) {
+	if m.tableInfo == nil {
+		return
+	}
+	if item == nil && m.filterExpr != "" {
+		m.copyExplorerLink()
+		return
+	}
+	link, err := consoleURL(m.tableInfo.Arn, item, m.tableInfo.PartitionKey, m.tableInfo.SortKey)
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	if err := copyToClipboard(link); err != nil {
+		m.statusMsg = "✗ Failed to copy: " + err.Error()
+		return
+	}
+	what := "table"
+	if item != nil {
+		what = "item"
+	}
+	m.statusMsg = "✓ Copied console link to " + what
+}
+
+func (m *Model) copyExplorerLink() {
+	link, carried, err := explorerURL(m.tableInfo.Arn, m.readPlan())
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	if err := copyToClipboard(link); err != nil {
+		m.statusMsg = "✗ Failed to copy: " + err.Error()
+		return
+	}
+	if carried {
+		m.statusMsg = "✓ Copied console link to the filtered items"
+	} else {
+		m.statusMsg = "⚠ Copied console link; the console link can't carry filters, add them there: " + m.filterBuilder.GetFilterSummary()
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/query"
+)
+
+func TestConsoleURL(t *testing.T) {
+	arn := "arn:aws:dynamodb:eu-west-1:111122223333:table/Users"
+	got, err := consoleURL(arn, nil, "id", "")
+	if err != nil || got != "https://eu-west-1.console.aws.amazon.com/dynamodbv2/home?region=eu-west-1#table?name=Users" {
+		t.Fatalf("table link = %q, %v", got, err)
+	}
+
+	item := map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "user#1"},
+		"ts": &types.AttributeValueMemberN{Value: "42"},
+	}
+	got, err = consoleURL(arn, item, "id", "ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, frag, _ := strings.Cut(got, "#edit-item?")
+	q, _ := url.ParseQuery(frag)
+	if q.Get("pk") != "user#1" || q.Get("sk") != "42" || q.Get("table") != "Users" {
+		t.Fatalf("item link = %q", got)
+	}
+
+	if got, _ := consoleURL("arn:aws-cn:dynamodb:cn-north-1:1:table/T", nil, "id", ""); !strings.HasPrefix(got, "https://cn-north-1.console.amazonaws.cn/") {
+		t.Errorf("China link = %q", got)
+	}
+	if _, err := consoleURL("arn:aws:dynamodb:ddblocal:000000000000:table/Users", nil, "id", ""); err == nil {
+		t.Error("DynamoDB Local has no console")
+	}
+}
+
+func TestCopyArnAndConsoleLink(t *testing.T) {
+	stubClipboard(t, nil, nil)
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, keyRunes("A"))
+	if !strings.Contains(m.statusMsg, "ARN unknown") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m.tableInfo.Arn = "arn:aws:dynamodb:us-east-1:111122223333:table/Users"
+	m = drive(m, keyRunes("A"))
+	if m.statusMsg != "✓ Copied table ARN to clipboard" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("O"))
+	if m.statusMsg != "✓ Copied console link to item" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
+
+func TestExplorerURLCarriesTheQuery(t *testing.T) {
+	arn := "arn:aws:dynamodb:eu-west-1:111122223333:table/orders"
+	link, carried, err := explorerURL(arn, query.Plan{
+		Mode: query.ModeQuery, IndexName: "by-customer", KeyConditionExpression: "#pk = :val0",
+		Values: map[string]interface{}{":val0": "c-42"},
+	})
+	if err != nil || !carried {
+		t.Fatalf("err %v, carried %v", err, carried)
+	}
+	want := "https://eu-west-1.console.aws.amazon.com/dynamodbv2/home?region=eu-west-1#item-explorer?index=by-customer&operation=QUERY&pk=c-42&table=orders"
+	if link != want {
+		t.Errorf("link = %s\nwant   %s", link, want)
+	}
+
+	_, carried, _ = explorerURL(arn, query.Plan{Mode: query.ModeScan, FilterExpression: "#attr0 > :val0"})
+	if carried {
+		t.Error("a scan filter can't go in the link and should be reported as left out")
+	}
+}
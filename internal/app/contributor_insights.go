@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// insightTopKeys is how many keys each Contributor Insights rule lists
+const insightTopKeys = 10
+
+// insightWindows are the report windows "w" cycles through
+var insightWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+type (
+	insightsMsg struct {
+		info    *dynamo.ContributorInsights
+		reports []*dynamo.InsightReport
+	}
+	insightsToggledMsg struct{ status string }
+)
+
+// openInsights shows the table's Contributor Insights from the schema view
+func (m *Model) openInsights() tea.Cmd {
+	m.view = viewInsights
+	m.insights = nil
+	m.insightReports = nil
+	return m.loadInsights()
+}
+
+// loadInsights describes the table's Contributor Insights and, when they
+// are on, reads the top keys of each of its rules.
+func (m *Model) loadInsights() tea.Cmd {
+	m.loading = true
+	m.statusMsg = "Reading Contributor Insights..."
+	table, window := m.currentTable, insightWindows[m.insightsWindow]
+	return func() tea.Msg {
+		ctx := context.Background()
+		info, err := m.client.DescribeContributorInsights(ctx, table)
+		if err != nil {
+			return errMsg{err}
+		}
+		var reports []*dynamo.InsightReport
+		if info.Status == "ENABLED" {
+			for _, rule := range info.Rules {
+				r, err := m.client.TopContributors(ctx, rule, window, insightTopKeys)
+				if err != nil {
+					return errMsg{err}
+				}
+				reports = append(reports, r)
+			}
+		}
+		return insightsMsg{info, reports}
+	}
+}
+
+func (m *Model) handleInsights(msg insightsMsg) {
+	m.loading = false
+	m.insights = msg.info
+	m.insightReports = msg.reports
+	m.statusMsg = "Contributor Insights " + msg.info.Status
+}
+
+func (m *Model) updateInsights(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.view = viewSchema
+	case "r":
+		return m, m.loadInsights()
+	case "w":
+		m.insightsWindow = (m.insightsWindow + 1) % len(insightWindows)
+		if len(m.insightReports) > 0 {
+			return m, m.loadInsights()
+		}
+	case "e":
+		if m.insights == nil || m.loading {
+			return m, nil
+		}
+		enable := m.insights.Status == "DISABLED" || m.insights.Status == "FAILED"
+		if !enable && m.insights.Status != "ENABLED" {
+			m.statusMsg = "✗ Contributor Insights are still " + m.insights.Status + ", try again shortly"
+			return m, nil
+		}
+		m.loading = true
+		table := m.currentTable
+		return m, func() tea.Msg {
+			status, err := m.client.SetContributorInsights(context.Background(), table, enable)
+			if err != nil {
+				return errMsg{err}
+			}
+			return insightsToggledMsg{status}
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) handleInsightsToggled(status string) {
+	m.loading = false
+	m.insights.Status = status
+	m.insightReports = nil
+	m.statusMsg = "✓ Contributor Insights " + status
+}
+
+func (m Model) viewInsights() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("🔥 Contributor Insights: " + m.currentTable))
+	b.WriteString("\n\n")
+
+	toggle := "Enable"
+	switch {
+	case m.insights == nil:
+		b.WriteString(ui.HelpStyle.Render("Loading..."))
+	case m.insights.Status != "ENABLED":
+		b.WriteString(ui.ContentStyle.Render("Status: " + m.insights.Status))
+		if m.insights.Failure != "" {
+			b.WriteString("\n" + ui.ErrorStyle.Render(m.insights.Failure))
+		}
+		b.WriteString("\n\n" + ui.HelpStyle.Render("Contributor Insights count requests per key in CloudWatch, which is billed per event. Turning them on takes a few minutes."))
+	default:
+		toggle = "Disable"
+		b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("Status: ENABLED │ Last %s", shortDuration(insightWindows[m.insightsWindow]))))
+		b.WriteString("\n")
+		for _, r := range m.insightReports {
+			b.WriteString("\n" + m.viewInsightReport(r) + "\n")
+		}
+		if len(m.insightReports) == 0 && !m.loading {
+			b.WriteString("\n" + ui.HelpStyle.Render("No rules reported yet."))
+		}
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "e", Desc: toggle},
+		{Key: "w", Desc: "Window"},
+		{Key: "r", Desc: "Refresh"},
+		{Key: "q/Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
+
+// viewInsightReport draws one rule's top keys as bars scaled to the busiest
+func (m Model) viewInsightReport(r *dynamo.InsightReport) string {
+	var b strings.Builder
+	b.WriteString(ui.KeyStyle.Render(dynamo.InsightRuleLabel(r.Rule)))
+	b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  %.0f requests, ~%d distinct keys", r.Total, r.UniqueKeys)))
+	if len(r.Contributors) == 0 {
+		return b.String() + "\n" + ui.HelpStyle.Render("  none in this window")
+	}
+	const barWidth = 24
+	top := r.Contributors[0].Count
+	keyWidth := max(m.width-barWidth-40, 20)
+	for _, c := range r.Contributors {
+		n := 0
+		if top > 0 {
+			n = max(int(c.Count/top*barWidth), 1)
+		}
+		share := ""
+		if r.Total > 0 {
+			share = fmt.Sprintf(" %4.1f%%", c.Count/r.Total*100)
+		}
+		key := ui.Truncate(strings.Join(c.Keys, " │ "), keyWidth)
+		b.WriteString(fmt.Sprintf("\n  %-*s %s %.0f%s", keyWidth, key, strings.Repeat("█", n)+strings.Repeat("░", barWidth-n), c.Count, share))
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func insightsModel(status string) Model {
+	m := populatedModel()
+	m.view = viewInsights
+	m.width, m.height = 120, 40
+	msg := insightsMsg{info: &dynamo.ContributorInsights{Status: status}}
+	if status == "ENABLED" {
+		msg.info.Rules = []string{"DynamoDBContributorInsights-PKC-Users-1"}
+		msg.reports = []*dynamo.InsightReport{{
+			Rule:  msg.info.Rules[0],
+			Total: 1000, UniqueKeys: 12,
+			Contributors: []dynamo.Contributor{{Keys: []string{"user#hot"}, Count: 800}, {Keys: []string{"user#2"}, Count: 50}},
+		}}
+	}
+	return drive(m, msg)
+}
+
+func TestInsightsShowTopKeys(t *testing.T) {
+	out := insightsModel("ENABLED").View()
+	for _, want := range []string{"Most accessed partition keys", "user#hot", "80.0%", "Disable"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestInsightsToggleWaitsForTransition(t *testing.T) {
+	m := insightsModel("ENABLING")
+	m = drive(m, keyRunes("e"))
+	if m.loading || !strings.Contains(m.statusMsg, "still ENABLING") {
+		t.Fatalf("loading = %v, status = %q", m.loading, m.statusMsg)
+	}
+
+	m = insightsModel("DISABLED")
+	if !strings.Contains(m.View(), "Enable") {
+		t.Fatal("a disabled table should offer to enable")
+	}
+	m = drive(m, insightsToggledMsg{"ENABLING"})
+	if m.insights.Status != "ENABLING" || !strings.Contains(m.statusMsg, "✓") {
+		t.Fatalf("status = %q / %q", m.insights.Status, m.statusMsg)
+	}
+
+	m = drive(m, keyRunes("q"))
+	if m.view != viewSchema {
+		t.Fatalf("view = %d, want the schema view", m.view)
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+	"github.com/godynamo/pkg/query"
+)
+
+// codeTab is one kind of code K writes from what the table or item view shows
+type codeTab struct {
+	name string
+	gen  func(m *Model) (string, error)
+}
+
+var readTabs = []codeTab{
+	{"Go SDK", readSnippet(query.LangGo)},
+	{"boto3", readSnippet(query.LangPython)},
+	{"Node.js", readSnippet(query.LangNode)},
+}
+
+var codeTabs = append(readTabs,
+	codeTab{"Go struct", goStruct},
+	codeTab{"TypeScript", tsInterface},
+)
+
+// readSnippet writes the table view's current read, filter and index
+// included, for lang
+func readSnippet(lang string) func(m *Model) (string, error) {
+	return func(m *Model) (string, error) {
+		return query.Snippet(lang, m.currentTable, m.readPlan())
+	}
+}
+
+func goStruct(m *Model) (string, error) {
+	return models.GoStruct(m.currentTable, m.itemShape())
+}
+
+// itemShape is the shape of the loaded items, or of the open item when K was
+// pressed on one, keys first
+func (m *Model) itemShape() models.Shape {
+	var keys []string
+	if info := m.tableInfo; info != nil {
+		keys = []string{info.PartitionKey, info.SortKey}
+	}
+	return models.InferShape(m.shapeItems(), keys...)
+}
+
+func tsInterface(m *Model) (string, error) {
+	return models.TypeScriptInterface(m.currentTable, m.itemShape()), nil
+}
+
+func (m *Model) shapeItems() []map[string]types.AttributeValue {
+	if m.codeReturn == viewItemDetail {
+		return []map[string]types.AttributeValue{m.selectedItem}
+	}
+	return m.items
+}
+
+func (m *Model) openCopyCode() {
+	if m.view == viewItemDetail && m.codeTab < len(readTabs) {
+		m.codeTab = len(readTabs) // an item's reads are the table's
+	}
+	m.codeReturn = m.view
+	m.view = viewCopyCode
+}
+
+func (m *Model) updateCopyCode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.view = m.codeReturn
+	case "tab", "right", "l":
+		m.codeTab = (m.codeTab + 1) % len(codeTabs)
+	case "shift+tab", "left", "h":
+		m.codeTab = (m.codeTab + len(codeTabs) - 1) % len(codeTabs)
+	case "enter", "y":
+		tab := codeTabs[m.codeTab]
+		code, err := tab.gen(m)
+		if err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			break
+		}
+		if err := copyToClipboard(code); err != nil {
+			m.statusMsg = "✗ Failed to copy: " + err.Error()
+			break
+		}
+		m.statusMsg = "✓ Copied " + tab.name + " code to clipboard"
+		m.view = m.codeReturn
+	}
+	return m, nil
+}
+
+func (m Model) viewCopyCode() string {
+	tabs := ui.Tabs{Items: make([]string, len(codeTabs)), Active: m.codeTab}
+	for i, t := range codeTabs {
+		tabs.Items[i] = t.name
+	}
+	tab := codeTabs[m.codeTab]
+	code, err := tab.gen(&m)
+	if err != nil {
+		code = ui.ErrorStyle.Render(err.Error())
+	}
+	lines := strings.Split(strings.TrimRight(code, "\n"), "\n")
+	if limit := max(m.height-14, 5); len(lines) > limit {
+		lines = append(lines[:limit], "…")
+	}
+
+	content := ui.ModalStyle.Render(
+		ui.TitleStyle.Render("Copy as code: "+m.currentTable) + "\n" +
+			tabs.View() + "\n\n" +
+			strings.Join(lines, "\n") + "\n\n" +
+			ui.StatusBarStyle.Render(m.statusMsg) + "\n" +
+			ui.HelpStyle.Render("Tab switches • y/Enter copies • Esc closes"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCopyAsCodeWritesTheAppliedQuery(t *testing.T) {
+	stubClipboard(t, nil, nil)
+	var copied string
+	writeSystemClipboard = func(s string) error { copied = s; return nil }
+
+	m := populatedModel()
+	m.view = viewTableData
+	m.filterExpr = "#attr0 = :val0"
+	m.filterNames = map[string]string{"#attr0": "id"}
+	m.filterValues = map[string]interface{}{":val0": "1"}
+
+	m = drive(m, keyRunes("K"))
+	if m.view != viewCopyCode || !strings.Contains(m.View(), "QueryInput") {
+		t.Fatalf("view %d: K should open the Go query", m.view)
+	}
+	m = drive(m, keyRunes("l"))
+	m = drive(m, keyRunes("y"))
+	if m.view != viewTableData {
+		t.Errorf("view %d: copying should go back to the table", m.view)
+	}
+	if !strings.Contains(copied, "response = client.query(") || !strings.Contains(copied, `KeyConditionExpression="#pk = :val0"`) {
+		t.Errorf("copied %q, want the boto3 query", copied)
+	}
+}
+
+func TestCopyAsCodeFromAnItemWritesItsGoStruct(t *testing.T) {
+	stubClipboard(t, nil, nil)
+	var copied string
+	writeSystemClipboard = func(s string) error { copied = s; return nil }
+
+	m := populatedModel()
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("K"))
+	m = drive(m, keyRunes("y"))
+	want := "type Users struct {\n\tID   string `dynamodbav:\"id\"`\n\tName string `dynamodbav:\"name\"`\n}\n"
+	if copied != want || m.view != viewItemDetail {
+		t.Errorf("view %d, copied\n%s\nwant\n%s", m.view, copied, want)
+	}
+}
+
+func TestCopyAsCodeTypeScriptMarksOptionalFields(t *testing.T) {
+	m := populatedModel()
+	delete(m.items[1], "name")
+	m.view = viewTableData
+	m = drive(m, keyRunes("K"))
+	for range 4 {
+		m = drive(m, keyRunes("l"))
+	}
+	if out := m.View(); !strings.Contains(out, "name?: string; // in 1 of 2 items") {
+		t.Errorf("the TypeScript tab should mark name optional:\n%s", out)
+	}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// createPollInterval is the pause between looks at a table being created;
+// a variable so tests need not wait.
+var createPollInterval = 2 * time.Second
+
+// createWaitLimit is how long to wait for a new table before returning to
+// the list anyway
+const createWaitLimit = 5 * time.Minute
+
+type (
+	createPollMsg   struct{ table string }
+	createStatusMsg struct{ info *dynamo.TableInfo }
+)
+
+// waitForTable keeps the create form busy until the new table is ACTIVE,
+// so it can be opened as soon as it shows up in the list.
+func (m *Model) waitForTable(name string) tea.Cmd {
+	m.creatingTable = name
+	m.createStarted = time.Now()
+	m.loading = true
+	m.statusMsg = "Table created, waiting for it to become ACTIVE..."
+	return m.pollCreatedTable(name)
+}
+
+func (m *Model) pollCreatedTable(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.client.RefreshTable(context.Background(), name)
+		if err != nil {
+			return errMsg{err}
+		}
+		return createStatusMsg{info}
+	}
+}
+
+func (m *Model) handleCreateStatus(info *dynamo.TableInfo) tea.Cmd {
+	if info.Name != m.creatingTable {
+		return nil
+	}
+	if info.Status == "ACTIVE" {
+		return m.finishTableWait("✓ Table " + info.Name + " is ACTIVE")
+	}
+	if time.Since(m.createStarted) > createWaitLimit {
+		return m.finishTableWait(fmt.Sprintf("%s is still %s; it can be opened once ACTIVE", info.Name, info.Status))
+	}
+	name := info.Name
+	return tea.Tick(createPollInterval, func(time.Time) tea.Msg { return createPollMsg{name} })
+}
+
+// finishTableWait stops waiting and goes back to the refreshed table list
+func (m *Model) finishTableWait(status string) tea.Cmd {
+	m.creatingTable = ""
+	m.loading = false
+	m.statusMsg = status
+	m.view = viewTables
+	return m.loadTables()
+}
+
+func (m Model) viewCreateWait() string {
+	return fmt.Sprintf("%s Waiting for %s to become ACTIVE (%s)",
+		m.spinner.View(), m.creatingTable, time.Since(m.createStarted).Truncate(time.Second))
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestCreatedTableIsPolledUntilActive(t *testing.T) {
+	m := New()
+	m.width, m.height = 100, 40
+	m.view = viewCreateTable
+	m = drive(m, tableCreatedMsg{"Orders"})
+	if m.creatingTable != "Orders" || !m.loading || !strings.Contains(m.View(), "Waiting for Orders to become ACTIVE") {
+		t.Fatalf("creating = %q, loading = %v", m.creatingTable, m.loading)
+	}
+
+	// Typing into the form does nothing while waiting
+	m = drive(m, keyRunes("x"))
+	if m.view != viewCreateTable || m.creatingTable == "" {
+		t.Fatal("the form should stay busy until the table is ACTIVE")
+	}
+
+	if _, cmd := m.Update(createStatusMsg{&dynamo.TableInfo{Name: "Orders", Status: "CREATING"}}); cmd == nil {
+		t.Fatal("a CREATING table should be polled again")
+	}
+	m = drive(m, createStatusMsg{&dynamo.TableInfo{Name: "Orders", Status: "ACTIVE"}})
+	if m.view != viewTables || m.creatingTable != "" || m.statusMsg != "✓ Table Orders is ACTIVE" {
+		t.Fatalf("view = %d, status = %q", m.view, m.statusMsg)
+	}
+
+	// A poll for a wait that already ended is dropped
+	if _, cmd := m.Update(createPollMsg{"Orders"}); cmd != nil {
+		t.Fatal("stale poll should not describe the table")
+	}
+}
+
+func TestCreateWaitGivesUp(t *testing.T) {
+	m := New()
+	m.view = viewCreateTable
+	m = drive(m, tableCreatedMsg{"Orders"})
+	m.createStarted = time.Now().Add(-createWaitLimit - time.Second)
+	m = drive(m, createStatusMsg{&dynamo.TableInfo{Name: "Orders", Status: "CREATING"}})
+	if m.view != viewTables || !strings.Contains(m.statusMsg, "still CREATING") {
+		t.Fatalf("view = %d, status = %q", m.view, m.statusMsg)
+	}
+
+	m = New()
+	m.view = viewCreateTable
+	m = drive(drive(m, tableCreatedMsg{"Orders"}), tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTables || m.creatingTable != "" {
+		t.Fatal("Esc should stop waiting")
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// DecryptPlugin is a command that decrypts items written with client-side
+// encryption, such as the DynamoDB Encryption Client, which GoDynamo can't
+// do itself. It reads a request on stdin:
+//
+//	{"table": "orders", "kms_key_id": "arn:aws:kms:...", "item": {DynamoDB JSON}}
+//
+// and writes the decrypted attributes to stdout as plain JSON, e.g.
+// {"ssn": "123-45-6789"}. The whole item is sent because the encryption
+// client's material description and signature live in other attributes.
+type DecryptPlugin struct {
+	Command  []string `yaml:"command"`    // program and arguments, run without a shell
+	KMSKeyID string   `yaml:"kms_key_id"` // passed on to the command
+}
+
+// decrypter reveals an item's encrypted attributes. What it returns is only
+// ever shown: it never reaches the editor, the clipboard or a write.
+type decrypter interface {
+	Decrypt(ctx context.Context, table string, item map[string]types.AttributeValue) (map[string]interface{}, error)
+}
+
+// decryptTimeout bounds one run of the plugin, which may call KMS
+const decryptTimeout = 30 * time.Second
+
+func (p DecryptPlugin) Decrypt(ctx context.Context, table string, item map[string]types.AttributeValue) (map[string]interface{}, error) {
+	itemJSON, err := models.ItemToDynamoJSON(item, false)
+	if err != nil {
+		return nil, err
+	}
+	req, err := json.Marshal(map[string]interface{}{
+		"table":      table,
+		"kms_key_id": p.KMSKeyID,
+		"item":       json.RawMessage(itemJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(req)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", p.Command[0], err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", p.Command[0], err)
+	}
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(out, &attrs); err != nil {
+		return nil, fmt.Errorf("%s: want a JSON object of decrypted attributes: %w", p.Command[0], err)
+	}
+	return attrs, nil
+}
+
+// encryptionMarkers are the attributes client-side encryption libraries add
+// next to the ones they encrypt
+var encryptionMarkers = []string{
+	"*amzn-ddb-map-desc*", "*amzn-ddb-map-sig*", // DynamoDB Encryption Client
+	"aws_dbe_head", "aws_dbe_foot", // AWS Database Encryption SDK
+}
+
+// encryptedAttributes lists an item's binary attributes when it carries an
+// encryption library's markers, which is how those libraries store
+// ciphertext
+func encryptedAttributes(item map[string]types.AttributeValue) []string {
+	if !slices.ContainsFunc(encryptionMarkers, func(m string) bool { return item[m] != nil }) {
+		return nil
+	}
+	var names []string
+	for name, v := range item {
+		if _, ok := v.(*types.AttributeValueMemberB); ok && !slices.Contains(encryptionMarkers, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// decryptedMsg is the plugin's answer for the item whose history key is key
+type decryptedMsg struct {
+	key   string
+	attrs map[string]interface{}
+	err   error
+}
+
+// decryptItem asks the plugin to decrypt the open item
+func (m *Model) decryptItem() tea.Cmd {
+	if m.decrypter == nil {
+		m.statusMsg = "✗ No decrypt plugin: set decrypt.command in config.yaml"
+		return nil
+	}
+	d, table, item, key := m.decrypter, m.currentTable, m.selectedItem, m.historyKey(m.selectedItem)
+	m.statusMsg = "Decrypting..."
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), decryptTimeout)
+		defer cancel()
+		attrs, err := d.Decrypt(ctx, table, item)
+		return decryptedMsg{key: key, attrs: attrs, err: err}
+	}
+}
+
+func (m *Model) showDecrypted(msg decryptedMsg) {
+	if msg.err != nil {
+		m.statusMsg = "✗ Decrypt failed: " + msg.err.Error()
+		return
+	}
+	if m.view != viewItemDetail || msg.key != m.historyKey(m.selectedItem) {
+		return // moved on to another item
+	}
+	m.decrypted, m.decryptedKey = msg.attrs, msg.key
+	m.prepareItemView()
+	m.statusMsg = fmt.Sprintf("🔓 Decrypted %d attribute(s) for display", len(msg.attrs))
+}
+
+// decryptedSuffix marks a decrypted value's name in the item view
+const decryptedSuffix = " (decrypted)"
+
+// withDecrypted shows the decrypted values of the open item in place of
+// their ciphertext, under marked names. Another item drops them.
+func (m *Model) withDecrypted(attrs map[string]interface{}) map[string]interface{} {
+	if m.decrypted == nil || m.decryptedKey != m.historyKey(m.selectedItem) {
+		m.decrypted, m.decryptedKey = nil, ""
+		return attrs
+	}
+	for name, v := range m.decrypted {
+		delete(attrs, name)
+		attrs[name+decryptedSuffix] = v
+	}
+	return attrs
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func encryptedItem() map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id":                  &types.AttributeValueMemberS{Value: "1"},
+		"ssn":                 &types.AttributeValueMemberB{Value: []byte{0x01, 0x02}},
+		"*amzn-ddb-map-desc*": &types.AttributeValueMemberB{Value: []byte{0x03}},
+		"*amzn-ddb-map-sig*":  &types.AttributeValueMemberB{Value: []byte{0x04}},
+	}
+}
+
+func TestDecryptPluginRunsTheCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	p := DecryptPlugin{
+		Command:  []string{"sh", "-c", `grep -q '"kms_key_id":"alias/app"' && grep -q '"ssn":{"B"' ; echo '{"ssn": "123-45-6789"}'`},
+		KMSKeyID: "alias/app",
+	}
+	attrs, err := p.Decrypt(context.Background(), "users", encryptedItem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attrs["ssn"] != "123-45-6789" {
+		t.Errorf("attrs = %v", attrs)
+	}
+
+	p.Command = []string{"sh", "-c", "echo key not found >&2; exit 3"}
+	if _, err := p.Decrypt(context.Background(), "users", encryptedItem()); err == nil || !strings.Contains(err.Error(), "key not found") {
+		t.Errorf("err = %v, want the plugin's stderr", err)
+	}
+}
+
+type fakeDecrypter map[string]interface{}
+
+func (f fakeDecrypter) Decrypt(context.Context, string, map[string]types.AttributeValue) (map[string]interface{}, error) {
+	return f, nil
+}
+
+func TestDecryptedValuesAreShownOnly(t *testing.T) {
+	m := populatedModel()
+	m.decrypter = fakeDecrypter{"ssn": "123-45-6789"}
+	m.selectedItem = encryptedItem()
+	m.prepareItemView()
+	m.view = viewItemDetail
+	if got := encryptedAttributes(m.selectedItem); !slices.Equal(got, []string{"ssn"}) {
+		t.Fatalf("encrypted attributes %v", got)
+	}
+
+	next, cmd := m.Update(keyRunes("D"))
+	m = *next.(*Model)
+	m = drive(m, cmd())
+	out := m.View()
+	if !strings.Contains(out, "ssn (decrypted)") || !strings.Contains(out, "123-45-6789") {
+		t.Errorf("the decrypted value should be shown and marked:\n%s", out)
+	}
+	if _, ok := m.selectedItem["ssn"].(*types.AttributeValueMemberB); !ok {
+		t.Error("the item itself must keep its ciphertext")
+	}
+
+	m.selectedItem = m.items[1]
+	m.prepareItemView()
+	if m.decrypted != nil {
+		t.Error("another item should drop the decrypted values")
+	}
+}
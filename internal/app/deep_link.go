@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// deepLinkItemMsg is the item --key names, fetched once the table opened
+type deepLinkItemMsg struct {
+	item map[string]types.AttributeValue
+	err  error
+}
+
+// parseKeyArg splits a --key value such as "pk=123,sk=2024" into attribute
+// names and values, in the order given
+func parseKeyArg(s string) ([][2]string, error) {
+	var pairs [][2]string
+	for part := range strings.SplitSeq(s, ",") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("key %q: want name=value pairs separated by commas, e.g. pk=123,sk=2024", s)
+		}
+		pairs = append(pairs, [2]string{name, value})
+	}
+	return pairs, nil
+}
+
+// typedKey turns the --key pairs into the table's key, typed as its schema
+// says. It needs the partition key, and the sort key when the table has one.
+func typedKey(info *dynamo.TableInfo, pairs [][2]string) (map[string]types.AttributeValue, error) {
+	keyTypes := map[string]string{info.PartitionKey: info.PartitionType}
+	if info.SortKey != "" {
+		keyTypes[info.SortKey] = info.SortKeyType
+	}
+	key := make(map[string]types.AttributeValue, len(pairs))
+	for _, p := range pairs {
+		typ, ok := keyTypes[p[0]]
+		if !ok {
+			return nil, fmt.Errorf("%s is not part of %s's key (%s)", p[0], info.Name, keyNames(info))
+		}
+		v, err := keyValue(typ, p[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p[0], err)
+		}
+		key[p[0]] = v
+	}
+	for name := range keyTypes {
+		if _, ok := key[name]; !ok {
+			return nil, fmt.Errorf("the key needs %s (%s)", name, keyNames(info))
+		}
+	}
+	return key, nil
+}
+
+func keyValue(typ, s string) (types.AttributeValue, error) {
+	switch typ {
+	case "N":
+		return &types.AttributeValueMemberN{Value: s}, nil
+	case "B":
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("a binary key is given in base64: %w", err)
+		}
+		return &types.AttributeValueMemberB{Value: b}, nil
+	}
+	return &types.AttributeValueMemberS{Value: s}, nil
+}
+
+func keyNames(info *dynamo.TableInfo) string {
+	if info.SortKey == "" {
+		return "key " + info.PartitionKey
+	}
+	return "keys " + info.PartitionKey + " and " + info.SortKey
+}
+
+// openDeepLink opens the table --table names, once, and fetches the item
+// --key names in it
+func (m *Model) openDeepLink() tea.Cmd {
+	table, keyArg := m.config.Table, m.config.Key
+	m.config.Table, m.config.Key = "", ""
+	if !slices.Contains(m.tables, table) {
+		m.statusMsg = fmt.Sprintf("✗ No table %s in %s", table, m.selectedRegion)
+		return nil
+	}
+	cmd := m.openTable(table)
+	if keyArg == "" || m.offline {
+		return cmd
+	}
+	client := m.client
+	return tea.Batch(cmd, func() tea.Msg {
+		pairs, err := parseKeyArg(keyArg)
+		if err != nil {
+			return deepLinkItemMsg{err: err}
+		}
+		ctx := context.Background()
+		info, err := client.DescribeTable(ctx, table)
+		if err != nil {
+			return errMsg{err}
+		}
+		key, err := typedKey(info, pairs)
+		if err != nil {
+			return deepLinkItemMsg{err: err}
+		}
+		item, err := client.GetItem(ctx, table, key)
+		if err != nil {
+			return errMsg{err}
+		}
+		if item == nil {
+			return deepLinkItemMsg{err: fmt.Errorf("no item in %s with %s", table, keyArg)}
+		}
+		return deepLinkItemMsg{item: item}
+	})
+}
+
+// showDeepLinkItem opens the fetched item as if it had been picked from the
+// table, which stays behind it
+func (m *Model) showDeepLinkItem(msg deepLinkItemMsg) {
+	if msg.err != nil {
+		m.statusMsg = "✗ " + msg.err.Error()
+		return
+	}
+	m.selectedItem = msg.item
+	m.prepareItemView()
+	m.view = viewItemDetail
+}
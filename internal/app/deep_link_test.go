@@ -0,0 +1,72 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestTypedKeyFollowsTheSchema(t *testing.T) {
+	info := &dynamo.TableInfo{Name: "orders", PartitionKey: "pk", PartitionType: "S", SortKey: "sk", SortKeyType: "N"}
+	pairs, err := parseKeyArg("pk=123,sk=2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := typedKey(info, pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk, ok := key["pk"].(*types.AttributeValueMemberS); !ok || pk.Value != "123" {
+		t.Errorf("pk = %#v, want the string 123", key["pk"])
+	}
+	if sk, ok := key["sk"].(*types.AttributeValueMemberN); !ok || sk.Value != "2024" {
+		t.Errorf("sk = %#v, want the number 2024", key["sk"])
+	}
+
+	for arg, want := range map[string]string{
+		"pk=123":             "needs sk",
+		"pk=1,sk=2,colour=3": "colour is not part of orders's key",
+	} {
+		pairs, _ := parseKeyArg(arg)
+		if _, err := typedKey(info, pairs); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", arg, err, want)
+		}
+	}
+	if _, err := parseKeyArg("pk123"); err == nil {
+		t.Error("a pair without = should be refused")
+	}
+}
+
+func TestDeepLinkOpensTheTableOnce(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Table, cfg.Key = "orders", "pk=1"
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.width, m.height = 120, 40
+
+	next, cmd := m.Update(tablesLoadedMsg{tables: []string{"orders", "users"}})
+	m = *next.(*Model)
+	if m.view != viewTableData || m.currentTable != "orders" || cmd == nil {
+		t.Fatalf("view %d, table %q: the linked table should open", m.view, m.currentTable)
+	}
+	if m.config.Table != "" {
+		t.Error("the link should only be followed on the first connection")
+	}
+
+	item := map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "1"}}
+	m = drive(m, deepLinkItemMsg{item: item})
+	if m.view != viewItemDetail || m.selectedItem["pk"] == nil {
+		t.Errorf("view %d: the fetched item should open", m.view)
+	}
+
+	cfg.Key = "pk=1"
+	cfg.Table = ""
+	if err := cfg.Validate(); err == nil {
+		t.Error("--key without --table should be refused")
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// openDeleteByKey opens the form deleting an item by its key, one field for
+// the partition key and one for the sort key when the table has one
+func (m *Model) openDeleteByKey() tea.Cmd {
+	if m.tableInfo == nil {
+		m.statusMsg = "✗ The table's key schema hasn't loaded yet"
+		return nil
+	}
+	names := []string{m.tableInfo.PartitionKey}
+	if m.tableInfo.SortKey != "" {
+		names = append(names, m.tableInfo.SortKey)
+	}
+	m.deleteKeyInputs = make([]textinput.Model, len(names))
+	for i, name := range names {
+		in := textinput.New()
+		in.Placeholder = name // also which attribute the field is
+		in.Width = 40
+		m.deleteKeyInputs[i] = in
+	}
+	m.view = viewDeleteByKey
+	return m.focusDeleteKeyField(0)
+}
+
+func (m *Model) focusDeleteKeyField(field int) tea.Cmd {
+	m.deleteKeyFocus = field
+	var cmd tea.Cmd
+	for i := range m.deleteKeyInputs {
+		if i == field {
+			cmd = m.deleteKeyInputs[i].Focus()
+		} else {
+			m.deleteKeyInputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *Model) updateDeleteByKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.deleteKeyInputs)
+	switch msg.String() {
+	case "esc":
+		m.view = viewTableData
+		return m, nil
+	case "tab", "down":
+		return m, m.focusDeleteKeyField((m.deleteKeyFocus + 1) % n)
+	case "shift+tab", "up":
+		return m, m.focusDeleteKeyField((m.deleteKeyFocus + n - 1) % n)
+	case "enter":
+		var pairs [][2]string // an empty field is a missing key
+		for _, in := range m.deleteKeyInputs {
+			if v := in.Value(); v != "" {
+				pairs = append(pairs, [2]string{in.Placeholder, v})
+			}
+		}
+		key, err := typedKey(m.tableInfo, pairs)
+		if err == nil {
+			err = checkItemKey(m.tableInfo, key)
+		}
+		if err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return m, nil
+		}
+		// deleteItem and its confirmation only read the key of the item
+		m.selectedItem = key
+		if !m.config.ConfirmDelete {
+			return m, m.deleteItem()
+		}
+		m.view = viewConfirmDelete
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.deleteKeyInputs[m.deleteKeyFocus], cmd = m.deleteKeyInputs[m.deleteKeyFocus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewDeleteByKey() string {
+	var body strings.Builder
+	body.WriteString(ui.HelpStyle.Render("Delete an item of "+m.currentTable+" by its key, without loading it.") + "\n")
+	body.WriteString(ui.HelpStyle.Render("Deleting a key no item has changes nothing.") + "\n\n")
+	keyTypes := []string{m.tableInfo.PartitionType, m.tableInfo.SortKeyType}
+	for i, in := range m.deleteKeyInputs {
+		style := ui.InputStyle
+		if i == m.deleteKeyFocus {
+			style = ui.InputFocusedStyle
+		}
+		typ := typeName(keyTypes[i])
+		if keyTypes[i] == "B" {
+			typ += ", in base64"
+		}
+		body.WriteString(ui.ItemStyle.Render(in.Placeholder+" ("+typ+"):") + "\n" + style.Render(in.View()) + "\n")
+	}
+	body.WriteString("\n" + ui.StatusBarStyle.Render(m.statusMsg) + "\n")
+	body.WriteString(ui.HelpStyle.Render("Tab: next field • Enter: delete • Esc: cancel"))
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("🗑 Delete by Key") + "\n\n" + body.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/dynamo/dynamotest"
+)
+
+func TestDeleteByKeyWithoutLoadingTheItem(t *testing.T) {
+	order := func(customer, id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"customer": &types.AttributeValueMemberS{Value: customer},
+			"order":    &types.AttributeValueMemberN{Value: id},
+		}
+	}
+	fake := dynamotest.New()
+	fake.AddTable(dynamo.TableInfo{Name: "Orders", PartitionKey: "customer", PartitionType: "S", SortKey: "order", SortKeyType: "N"},
+		order("alice", "1"), order("alice", "2"), order("bob", "1"))
+	m := openFakeTable(t, DefaultConfig(), fake)
+
+	m = settle(m, keyRunes("X"))
+	if m.view != viewDeleteByKey || len(m.deleteKeyInputs) != 2 || !strings.Contains(m.View(), "order (a number)") {
+		t.Fatalf("X should open a field per key, view %d", m.view)
+	}
+	m = settle(m, keyRunes("alice"))
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewDeleteByKey || !strings.Contains(m.statusMsg, "the key needs") {
+		t.Fatalf("a missing sort key should be refused, status %q", m.statusMsg)
+	}
+	m = settle(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = settle(m, keyRunes("2"))
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewConfirmDelete || !strings.Contains(m.View(), "customer=alice") {
+		t.Fatalf("Enter should ask to confirm, view %d", m.view)
+	}
+	m = settle(m, keyRunes("y"))
+	if left := fake.Items("Orders"); len(left) != 2 || m.view != viewTableData {
+		t.Fatalf("items left %v, view %d, status %q", left, m.view, m.statusMsg)
+	}
+	for _, item := range fake.Items("Orders") {
+		if item["customer"].(*types.AttributeValueMemberS).Value == "alice" && item["order"].(*types.AttributeValueMemberN).Value == "2" {
+			t.Error("alice's order 2 should be gone")
+		}
+	}
+
+	m = settle(m, keyRunes("X"))
+	m = settle(m, keyRunes("alice"))
+	m = settle(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = settle(m, keyRunes("2"))
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = settle(m, keyRunes("y"))
+	if !strings.Contains(m.statusMsg, "not found") {
+		t.Fatalf("deleting a key no item has should say so, status %q", m.statusMsg)
+	}
+}
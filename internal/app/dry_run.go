@@ -0,0 +1,158 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// plannedOpLine is how a saved plan records an operation; items and keys
+// are DynamoDB JSON so they can be replayed as they were
+type plannedOpLine struct {
+	At     time.Time       `json:"at"`
+	Op     string          `json:"op"`
+	Table  string          `json:"table,omitempty"`
+	Item   json.RawMessage `json:"item,omitempty"`
+	Key    json.RawMessage `json:"key,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+}
+
+func (m *Model) openPlannedOps() {
+	m.planReturn = m.view
+	m.planCursor = max(m.plan.Len()-1, 0) // the latest change is the one to check
+	m.view = viewPlannedOps
+}
+
+func (m *Model) updatePlannedOps(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := m.plan.Len()
+	switch msg.String() {
+	case "esc", "q", "ctrl+l":
+		m.view = m.planReturn
+	case "up", "k":
+		m.planCursor = max(m.planCursor-1, 0)
+	case "down", "j":
+		m.planCursor = max(min(m.planCursor+1, n-1), 0)
+	case "home", "g":
+		m.planCursor = 0
+	case "end", "G":
+		m.planCursor = max(n-1, 0)
+	case "c":
+		m.plan.Clear()
+		m.planCursor = 0
+		m.statusMsg = "Cleared the planned operations"
+	case "s":
+		m.savePlan()
+	}
+	return m, nil
+}
+
+// savePlan writes the planned operations as JSON lines next to the exports
+func (m *Model) savePlan() {
+	ops := m.plan.Ops()
+	if len(ops) == 0 {
+		m.statusMsg = "✗ Nothing planned yet"
+		return
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, op := range ops {
+		line := plannedOpLine{At: op.At, Op: op.Op, Table: op.Table, Detail: op.Detail}
+		for _, attrs := range []struct {
+			item map[string]types.AttributeValue
+			dst  *json.RawMessage
+		}{{op.Item, &line.Item}, {op.Key, &line.Key}} {
+			if attrs.item == nil {
+				continue
+			}
+			text, err := models.ItemToDynamoJSON(attrs.item, false)
+			if err != nil {
+				m.statusMsg = "✗ Failed to save: " + err.Error()
+				return
+			}
+			*attrs.dst = json.RawMessage(text)
+		}
+		if err := enc.Encode(line); err != nil {
+			m.statusMsg = "✗ Failed to save: " + err.Error()
+			return
+		}
+	}
+	path := filepath.Join(m.exportDir(), "dry-run-"+time.Now().Format("20060102-150405")+".jsonl")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		m.statusMsg = "✗ Failed to save: " + err.Error()
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✓ Saved %d planned operations to %s", len(ops), path)
+}
+
+// dryRunStatus reminds the table data status bar that nothing is written
+func (m Model) dryRunStatus() string {
+	if m.plan == nil {
+		return ""
+	}
+	return ui.WarningStyle.Render(fmt.Sprintf(" | 🧪 Dry run: %d planned (Ctrl+L)", m.plan.Len()))
+}
+
+// plannedOpSummary is the part of an operation after its time, name and table
+func plannedOpSummary(op dynamo.PlannedOp) string {
+	var parts []string
+	for _, attrs := range []map[string]types.AttributeValue{op.Key, op.Item} {
+		if attrs == nil {
+			continue
+		}
+		if text, err := models.ItemToJSON(attrs, false); err == nil {
+			parts = append(parts, text)
+		}
+	}
+	if op.Detail != "" {
+		parts = append(parts, op.Detail)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m Model) viewPlannedOps() string {
+	ops := m.plan.Ops()
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render(fmt.Sprintf("🧪 Planned Operations (%d)", len(ops))))
+	b.WriteString("\n")
+	b.WriteString(ui.HelpStyle.Render("Dry run: these changes were recorded instead of being made."))
+	b.WriteString("\n\n")
+
+	if len(ops) == 0 {
+		b.WriteString(ui.HelpStyle.Render("Nothing planned yet."))
+		b.WriteString("\n")
+	}
+	visible := max(m.height-9, 3)
+	start := max(0, min(m.planCursor-visible/2, len(ops)-visible))
+	for i := start; i < len(ops) && i < start+visible; i++ {
+		op := ops[i]
+		line := fmt.Sprintf("%s  %-11s %-14s %s", op.At.Format("15:04:05"), op.Op, op.Table, plannedOpSummary(op))
+		line = ui.Truncate(line, max(m.width-4, 20))
+		if i == m.planCursor {
+			b.WriteString(ui.SelectedStyle.Render("▸ " + line))
+		} else {
+			b.WriteString(ui.ItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑↓", Desc: "Select"},
+		{Key: "s", Desc: "Save as JSON lines"},
+		{Key: "c", Desc: "Clear"},
+		{Key: "Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
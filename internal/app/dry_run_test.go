@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/dynamo/dynamotest"
+)
+
+func TestDryRunPlansAndListsChanges(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	m := populatedModel()
+	m.view = viewTableData
+	m.config.ExportDir = t.TempDir()
+	m.plan = &dynamo.Plan{}
+
+	// Nothing listens there: a change that was sent would fail
+	endpoint, err := dynamo.EndpointClient(context.Background(), dynamo.ConnectionConfig{Region: "us-east-1", Endpoint: "http://127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = drive(m, connectionTestMsg{success: true, client: endpoint})
+	client := m.client
+	item := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "3"}}
+	if err := client.PutItem(context.Background(), "Users", item); err != nil {
+		t.Fatalf("a dry run put should not be sent: %v", err)
+	}
+	if !strings.Contains(m.View(), "Dry run: 1 planned") {
+		t.Error("the status bar should count planned operations")
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlL})
+	if m.view != viewPlannedOps {
+		t.Fatalf("Ctrl+L opened view %d", m.view)
+	}
+	if out := m.View(); !strings.Contains(out, "PutItem") || !strings.Contains(out, `"id":"3"`) {
+		t.Fatalf("the panel should list the put:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("s"))
+	saved, _ := filepath.Glob(filepath.Join(m.config.ExportDir, "dry-run-*.jsonl"))
+	if len(saved) != 1 {
+		t.Fatalf("status %q, saved %v", m.statusMsg, saved)
+	}
+	data, _ := os.ReadFile(saved[0])
+	if !strings.Contains(string(data), `"op":"PutItem"`) || !strings.Contains(string(data), `{"id":{"S":"3"}}`) {
+		t.Errorf("saved plan: %s", data)
+	}
+
+	m = drive(m, keyRunes("c"))
+	if m.plan.Len() != 0 {
+		t.Error("c should clear the plan")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTableData {
+		t.Errorf("Esc went to view %d", m.view)
+	}
+}
+
+func TestDryRunPlansChangesOfAnInjectedClient(t *testing.T) {
+	fake := dynamotest.New()
+	fake.AddTable(dynamo.TableInfo{Name: "Users", PartitionKey: "id", PartitionType: "S"})
+	cfg := DefaultConfig()
+	cfg.DryRun = true
+	m := openFakeTable(t, cfg, fake)
+
+	ctx := context.Background()
+	item := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "3"}}
+	if err := m.client.PutItem(ctx, "Users", item); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.client.CopyTable(ctx, dynamo.CopyInput{Source: "Users", Dest: "Users2"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.client.ExecuteStatement(ctx, `DELETE FROM "Users" WHERE id = '1'`, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(fake.Items("Users")); n != 0 || m.plan.Len() != 3 {
+		t.Fatalf("%d items written, %d planned", n, m.plan.Len())
+	}
+}
+
+func TestDryRunSkipsWaitingForCreatedTable(t *testing.T) {
+	m := populatedModel()
+	m.plan = &dynamo.Plan{}
+	m.view = viewCreateTable
+	m = drive(m, tableCreatedMsg{table: "New"})
+	if m.view != viewTables || m.creatingTable != "" || !strings.Contains(m.statusMsg, "Planned creating New") {
+		t.Errorf("view %d, waiting for %q, status %q", m.view, m.creatingTable, m.statusMsg)
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// emacsKeys maps emacs keys, per view, to the built-in keys doing the same.
+// What C-n and C-p did moves to M-n and M-p.
+var emacsKeys = map[viewMode]map[string]string{
+	viewTables:     {"ctrl+n": "down", "ctrl+p": "up", "alt+n": "ctrl+n", "alt+p": "ctrl+p", "ctrl+s": "/"},
+	viewTableData:  {"ctrl+n": "down", "ctrl+p": "up", "alt+n": "ctrl+n", "alt+p": "ctrl+p", "ctrl+s": "/"},
+	viewItemDetail: {"ctrl+n": "down", "ctrl+p": "up", "ctrl+s": "/"},
+}
+
+// emacsSearchKeys apply while typing a row or item search, which is already
+// incremental: C-s and C-r step to the next and previous match, C-g quits
+var emacsSearchKeys = map[string]string{"ctrl+s": "ctrl+n", "ctrl+r": "ctrl+p", "ctrl+g": "esc"}
+
+// emacsFilterKeys apply while typing the table filter
+var emacsFilterKeys = map[string]string{"ctrl+n": "down", "ctrl+p": "up", "alt+n": "ctrl+n", "ctrl+g": "esc"}
+
+// emacsKey translates an emacs key to the built-in key it stands for in the
+// current view. It runs before read-only mode looks at the key, so C-n
+// moving down is never mistaken for creating a table.
+func (m *Model) emacsKey(msg tea.KeyMsg) tea.KeyMsg {
+	keys := emacsKeys[m.view]
+	switch {
+	case m.view == viewTables && m.tableFilterMode:
+		keys = emacsFilterKeys
+	case m.view == viewTableData && m.rowSearchMode, m.view == viewItemDetail && m.searchMode:
+		keys = emacsSearchKeys
+	case !m.acceptsKeymap():
+		return msg
+	}
+	if key, ok := keyMsgFor(keys[msg.String()]); ok {
+		return key
+	}
+	return msg
+}
+
+// updateEmacs adds M-< and M-> to the list views, jumping to the first and
+// last row; other keys arrive translated by emacsKey
+func (m *Model) updateEmacs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if _, ok := emacsKeys[m.view]; ok && m.acceptsKeymap() {
+		switch msg.String() {
+		case "alt+<":
+			m.jumpTo(true)
+			return m, nil
+		case "alt+>":
+			m.jumpTo(false)
+			return m, nil
+		}
+	}
+	return m.updateKey(msg)
+}
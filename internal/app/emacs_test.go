@@ -0,0 +1,72 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func emacsModel() Model {
+	m := populatedModel()
+	m.config.Keybindings = keybindingsEmacs
+	m.view = viewTableData
+	return m
+}
+
+func TestEmacsNavigation(t *testing.T) {
+	m := emacsModel()
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.dataTable.SelectedRow != 1 {
+		t.Fatalf("C-n: row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.dataTable.SelectedRow != 0 {
+		t.Fatalf("C-p: row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">"), Alt: true})
+	if m.dataTable.SelectedRow != 1 {
+		t.Fatalf("M->: row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("<"), Alt: true})
+	if m.dataTable.SelectedRow != 0 {
+		t.Fatalf("M-<: row %d", m.dataTable.SelectedRow)
+	}
+}
+
+func TestEmacsIncrementalSearch(t *testing.T) {
+	m := emacsModel()
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !m.rowSearchMode {
+		t.Fatal("C-s should open the row search")
+	}
+	m.runRowSearch("alice")
+	m.rowMatches = []int{0, 1} // as if both rows matched
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.dataTable.SelectedRow != 1 {
+		t.Errorf("C-s in the search should step to the next match, row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if m.dataTable.SelectedRow != 0 {
+		t.Errorf("C-r in the search should step back, row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.rowSearchMode {
+		t.Error("C-g should quit the search")
+	}
+}
+
+func TestEmacsMovesDisplacedKeysToMeta(t *testing.T) {
+	m := emacsModel()
+	m.view = viewTables
+	m.config.ReadOnly = true
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	if m.view != viewTables || strings.Contains(m.statusMsg, "Read-only") {
+		t.Errorf("C-n should move down, not be refused as creating a table: %q", m.statusMsg)
+	}
+	m.config.ReadOnly = false
+	m = drive(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n"), Alt: true})
+	if m.view != viewCreateTable {
+		t.Errorf("M-n should create a table, got view %d", m.view)
+	}
+}
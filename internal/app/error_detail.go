@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// openErrorDetail shows what AWS said about a refused request in a modal,
+// text being the whole error
+func (m *Model) openErrorDetail(d dynamo.ErrorDetail, text string) {
+	if m.view != viewErrorDetail {
+		m.errReturn = m.view
+	}
+	m.errDetail, m.errText = d, text
+	m.view = viewErrorDetail
+}
+
+// errorReport is the modal's content as plain text, for pasting into a
+// ticket or a chat
+func (m Model) errorReport() string {
+	return m.errDetail.String() + "Error: " + m.errText + "\n"
+}
+
+func (m *Model) updateErrorDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.view = m.errReturn
+	case "y":
+		if err := copyToClipboard(m.errorReport()); err != nil {
+			m.statusMsg = "✗ Failed to copy: " + err.Error()
+		} else {
+			m.statusMsg = "✓ Copied error detail to clipboard"
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewErrorDetail() string {
+	d := m.errDetail
+	width := min(max(m.width-10, 40), 100)
+	var rows strings.Builder
+	for _, f := range []struct{ name, value string }{
+		{"Operation", d.Operation},
+		{"Code", d.Code},
+		{"Message", d.Message},
+		{"Request ID", d.RequestID},
+	} {
+		if f.value != "" {
+			rows.WriteString(ui.KeyStyle.Render(fmt.Sprintf("%-12s", f.name)) + ui.ItemStyle.Render(f.value) + "\n")
+		}
+	}
+	if d.HTTPStatus != 0 {
+		rows.WriteString(ui.KeyStyle.Render(fmt.Sprintf("%-12s", "HTTP status")) + ui.ItemStyle.Render(fmt.Sprint(d.HTTPStatus)) + "\n")
+	}
+
+	content := ui.ModalStyle.Width(width).Render(
+		ui.TitleStyle.Render("✗ AWS Error") + "\n\n" +
+			rows.String() + "\n" +
+			ui.HelpStyle.Render(m.errText) + "\n\n" +
+			ui.StatusBarStyle.Render(m.statusMsg) + "\n" +
+			ui.HelpStyle.Render("y copies the detail • Esc closes"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
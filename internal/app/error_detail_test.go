@@ -0,0 +1,64 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// awsError is err as the SDK returns it when AWS refuses a request
+func awsError(op, code, message, requestID string) error {
+	return fmt.Errorf("failed to %s: %w", strings.ToLower(op), &smithy.OperationError{
+		ServiceID: "DynamoDB", OperationName: op,
+		Err: &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 400}},
+				Err:      &smithy.GenericAPIError{Code: code, Message: message},
+			},
+			RequestID: requestID,
+		},
+	})
+}
+
+func TestAWSErrorOpensDetail(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, errMsg{awsError("Scan", "ValidationException", "Invalid FilterExpression", "REQ123")})
+	if m.view != viewErrorDetail || m.statusMsg != "Error: ValidationException" {
+		t.Fatalf("view %d, status %q", m.view, m.statusMsg)
+	}
+	out := m.View()
+	for _, want := range []string{"Scan", "Invalid FilterExpression", "REQ123"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the modal lacks %q", want)
+		}
+	}
+
+	var copied string
+	stubClipboard(t, nil, nil)
+	writeSystemClipboard = func(s string) error { copied = s; return nil }
+	m = drive(m, keyRunes("y"))
+	if !strings.Contains(copied, "Request ID: REQ123") || !strings.Contains(copied, "Code: ValidationException") {
+		t.Errorf("copied %q", copied)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTableData {
+		t.Errorf("Esc went to view %d", m.view)
+	}
+}
+
+func TestOtherErrorsStayInStatusBar(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, errMsg{errors.New("dial tcp: connection refused")})
+	if m.view != viewTableData || m.statusMsg != "Error: dial tcp: connection refused" {
+		t.Errorf("view %d, status %q", m.view, m.statusMsg)
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// maxErrorLog is how many errors and warnings the session keeps; the oldest
+// go first
+const maxErrorLog = 500
+
+// errorLogEntry is one error or warning; detail is set for errors AWS
+// answered, which reopen their detail modal
+type errorLogEntry struct {
+	at     time.Time
+	text   string
+	detail *dynamo.ErrorDetail
+}
+
+// logError keeps an error or warning for the error log panel
+func (m *Model) logError(text string, detail *dynamo.ErrorDetail) {
+	m.errorLog = append(m.errorLog, errorLogEntry{at: time.Now(), text: text, detail: detail})
+	if over := len(m.errorLog) - maxErrorLog; over > 0 {
+		m.errorLog = m.errorLog[over:]
+	}
+}
+
+// logStatusProblem logs the status message when it changed to an error or a
+// warning
+func (m *Model) logStatusProblem(prev string) {
+	if m.statusMsg == prev {
+		return
+	}
+	if k := kindOf(m.statusMsg); k == statusError || k == statusWarning {
+		m.logError(m.statusMsg, nil)
+	}
+}
+
+// logErrMsg logs an error message with its full text, which the status bar
+// cuts down to the code for AWS errors
+func (m *Model) logErrMsg(err error) {
+	if d, ok := dynamo.DescribeError(err); ok {
+		m.logError("Error: "+err.Error(), &d)
+		return
+	}
+	m.logError("Error: "+err.Error(), nil)
+}
+
+func (m *Model) openErrorLog() {
+	m.errorLogReturn = m.view
+	m.errorLogCursor = 0 // newest first
+	m.view = viewErrorLog
+}
+
+func (m *Model) updateErrorLog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.errorLog)
+	switch msg.String() {
+	case "esc", "q", "ctrl+x":
+		m.view = m.errorLogReturn
+	case "up", "k":
+		m.errorLogCursor = max(m.errorLogCursor-1, 0)
+	case "down", "j":
+		m.errorLogCursor = max(min(m.errorLogCursor+1, n-1), 0)
+	case "home", "g":
+		m.errorLogCursor = 0
+	case "end", "G":
+		m.errorLogCursor = max(n-1, 0)
+	case "enter":
+		if n == 0 {
+			break
+		}
+		e := m.errorLog[n-1-m.errorLogCursor]
+		if e.detail != nil {
+			m.openErrorDetail(*e.detail, strings.TrimPrefix(e.text, "Error: "))
+		}
+	case "y":
+		var b strings.Builder
+		for _, e := range m.errorLog {
+			fmt.Fprintf(&b, "%s  %s\n", e.at.Format(time.RFC3339), e.text)
+		}
+		if err := copyToClipboard(b.String()); err != nil {
+			m.statusMsg = "✗ Failed to copy: " + err.Error()
+		} else {
+			m.statusMsg = fmt.Sprintf("✓ Copied %d log entries to clipboard", n)
+		}
+	case "c":
+		m.errorLog = nil
+		m.errorLogCursor = 0
+	}
+	return m, nil
+}
+
+func (m Model) viewErrorLog() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render(fmt.Sprintf("🪵 Error Log (%d)", len(m.errorLog))))
+	b.WriteString("\n")
+	b.WriteString(ui.HelpStyle.Render("Errors and warnings of this session, newest first."))
+	b.WriteString("\n\n")
+
+	n := len(m.errorLog)
+	if n == 0 {
+		b.WriteString(ui.HelpStyle.Render("No errors or warnings."))
+		b.WriteString("\n")
+	}
+	visible := max(m.height-9, 3)
+	start := max(0, min(m.errorLogCursor-visible/2, n-visible))
+	for i := start; i < n && i < start+visible; i++ {
+		e := m.errorLog[n-1-i]
+		line := e.at.Format("15:04:05") + "  " + ansi.Truncate(e.text, max(m.width-16, 20), "…")
+		if i == m.errorLogCursor {
+			b.WriteString(ui.SelectedStyle.Render("▸ " + line))
+		} else {
+			b.WriteString(notificationStyle(e.text).Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑↓", Desc: "Select"},
+		{Key: "Enter", Desc: "AWS detail"},
+		{Key: "y", Desc: "Copy log"},
+		{Key: "c", Desc: "Clear"},
+		{Key: "Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestErrorLogKeepsTheSessionsErrors(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, errMsg{awsError("Scan", "ProvisionedThroughputExceededException", "Rate exceeded", "REQ1")})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m = drive(m, errMsg{errors.New("connection reset")})
+	m = drive(m, keyRunes("r")) // a reload says "Loading...", which is no problem
+
+	if len(m.errorLog) != 2 || !strings.Contains(m.errorLog[0].text, "Rate exceeded") || m.errorLog[0].detail == nil {
+		t.Fatalf("error log = %+v", m.errorLog)
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlX})
+	if m.view != viewErrorLog || !strings.Contains(m.View(), "connection reset") {
+		t.Fatalf("Ctrl+X should open the log, view %d", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewErrorLog {
+		t.Fatal("an error AWS never answered has no detail to open")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewErrorDetail || !strings.Contains(m.View(), "REQ1") {
+		t.Fatalf("Enter should open the AWS detail, view %d", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTableData || len(m.errorLog) != 2 {
+		t.Errorf("view %d, %d entries", m.view, len(m.errorLog))
+	}
+}
@@ -0,0 +1,241 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// defaultExportWorkers is the parallel scan width for full-table exports
+const defaultExportWorkers = 4
+
+type (
+	exportProgressMsg struct {
+		items        int
+		segmentsDone int
+		segments     int
+	}
+	exportDoneMsg struct {
+		path  string
+		items int
+	}
+)
+
+func (m *Model) workers() int {
+	if m.exportWorkers <= 0 {
+		return defaultExportWorkers
+	}
+	return m.exportWorkers
+}
+
+// exportFilePath is <export dir>/<table>.<format>[.gz] and whether to gzip it.
+func (m *Model) exportFilePath() (string, bool) {
+	filename := fmt.Sprintf("%s.%s", m.currentTable, m.exportFormat)
+	compress := m.exportGzip && exportCompressible(m.exportFormat)
+	if compress {
+		filename += ".gz"
+	}
+	return filepath.Join(m.exportDir(), filename), compress
+}
+
+// waitForExport delivers the next message from a running full-table export.
+func waitForExport(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// exportFullTable reads the entire table with a segmented parallel scan and
+// writes it in the selected format. Progress is funnelled through one
+// channel, so the counts the status line shows only ever go up.
+func (m *Model) exportFullTable() tea.Cmd {
+	ch := make(chan tea.Msg, 1)
+	m.exportCh = ch
+	m.loading = true
+	m.view = viewTableData
+
+	client, table, format, workers := m.client, m.currentTable, m.exportFormat, m.workers()
+	projection := m.exportProjection
+	path, compress := m.exportFilePath()
+
+	go func() {
+		f, err := createExportFile(path, compress)
+		if err != nil {
+			ch <- errMsg{err}
+			return
+		}
+		w := m.newExportWriter(format, f)
+		defer func() {
+			if err != nil {
+				w.Abort()
+				f.Close()
+				os.Remove(path)
+			}
+		}()
+
+		count, done := 0, 0
+		err = client.ParallelScan(context.Background(), table, workers, func(p dynamo.ScanPage) error {
+			items, err := projectItems(projection, p.Items)
+			if err != nil {
+				return err
+			}
+			if err := w.WriteItems(items); err != nil {
+				return err
+			}
+			count += len(items)
+			if p.SegmentDone {
+				done++
+			}
+			ch <- exportProgressMsg{items: count, segmentsDone: done, segments: workers}
+			return nil
+		})
+		if err == nil {
+			err = w.Close()
+		}
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			ch <- errMsg{err}
+			return
+		}
+		ch <- exportDoneMsg{path: path, items: count}
+	}()
+
+	return waitForExport(ch)
+}
+
+// encodeExport renders items in the given export format.
+func (m *Model) encodeExport(format string, items []map[string]types.AttributeValue) ([]byte, error) {
+	switch format {
+	case "json":
+		var converted []map[string]interface{}
+		for _, item := range items {
+			row := make(map[string]interface{})
+			for k, v := range item {
+				row[k] = models.AttributeValueToInterface(v)
+			}
+			converted = append(converted, row)
+		}
+		return json.MarshalIndent(converted, "", "  ")
+	case "yaml":
+		return models.ItemsToYAML(items)
+	case "xlsx":
+		headers, rows := m.itemsToTable(items)
+		return encodeXLSX(m.currentTable, headers, rows)
+	case "tsv":
+		opts := m.csvOptions
+		opts.delimiter = '\t'
+		headers, rows := m.itemsToTable(items)
+		return encodeDelimited(headers, rows, opts)
+	default:
+		headers, rows := m.itemsToTable(items)
+		return encodeDelimited(headers, rows, m.csvOptions)
+	}
+}
+
+// csvOptions controls the delimited (CSV/TSV) export. The zero value is a
+// comma-separated file with a header row.
+type csvOptions struct {
+	delimiter rune // 0 means ','
+	noHeader  bool
+}
+
+func (o csvOptions) comma() rune {
+	if o.delimiter == 0 {
+		return ','
+	}
+	return o.delimiter
+}
+
+// delimiterLabel is the delimiter as shown in the export dialog.
+func (o csvOptions) delimiterLabel() string {
+	switch o.comma() {
+	case '\t':
+		return "Tab"
+	case ' ':
+		return "Space"
+	default:
+		return string(o.comma())
+	}
+}
+
+// encodeDelimited writes rows with RFC 4180 quoting, so fields containing the
+// delimiter, quotes or newlines survive a round trip through other tools.
+func encodeDelimited(headers []string, rows [][]string, opts csvOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = opts.comma()
+
+	if !opts.noHeader {
+		if err := w.Write(headers); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportCompressible reports whether gzip applies to a format; xlsx is
+// already a zip archive.
+func exportCompressible(format string) bool {
+	return format != "xlsx"
+}
+
+// writeExportFile writes data to path, through gzip when compress is set.
+func writeExportFile(path string, data []byte, compress bool) error {
+	f, err := createExportFile(path, compress)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return f.Close()
+}
+
+// createExportFile opens path for writing, wrapped in gzip when compress is
+// set. Close flushes the gzip stream before closing the file.
+func createExportFile(path string, compress bool) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+	if !compress {
+		return f, nil
+	}
+	return &gzipFile{Writer: gzip.NewWriter(f), f: f}, nil
+}
+
+type gzipFile struct {
+	*gzip.Writer
+	f      *os.File
+	closed bool
+}
+
+func (g *gzipFile) Close() error {
+	if g.closed {
+		return nil
+	}
+	g.closed = true
+	if err := g.Writer.Close(); err != nil {
+		g.f.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return g.f.Close()
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+func (m *Model) initItemExportInput() {
+	ti := textinput.New()
+	ti.Placeholder = "Path to write the item to"
+	ti.CharLimit = 1024
+	ti.Width = 50
+	m.itemExportInput = ti
+}
+
+// openItemExport shows the single-item export prompt, pre-filled with
+// <table>-<key>.json in the working directory.
+func (m *Model) openItemExport() tea.Cmd {
+	name := m.currentTable
+	if m.tableInfo != nil {
+		if v, ok := m.selectedItem[m.tableInfo.PartitionKey]; ok {
+			name += "-" + models.FormatValue(v, 0)
+		}
+		if v, ok := m.selectedItem[m.tableInfo.SortKey]; ok && m.tableInfo.SortKey != "" {
+			name += "-" + models.FormatValue(v, 0)
+		}
+	}
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, name)
+
+	m.itemExportInput.SetValue(filepath.Join(m.exportDir(), name+".json"))
+	m.itemExportInput.CursorEnd()
+	m.view = viewExportItem
+	return m.itemExportInput.Focus()
+}
+
+func (m *Model) updateExportItem(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.itemExportInput.Blur()
+		m.view = viewItemDetail
+		return m, nil
+	case "tab":
+		m.itemExportDynamo = !m.itemExportDynamo
+		return m, nil
+	case "enter":
+		m.itemExportInput.Blur()
+		return m, m.exportItem(m.itemExportInput.Value(), m.itemExportDynamo)
+	}
+
+	var cmd tea.Cmd
+	m.itemExportInput, cmd = m.itemExportInput.Update(msg)
+	return m, cmd
+}
+
+// exportItem writes the selected item to path, as plain JSON or in the typed
+// DynamoDB JSON format.
+func (m *Model) exportItem(path string, dynamoJSON bool) tea.Cmd {
+	item := m.selectedItem
+	return func() tea.Msg {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return errMsg{fmt.Errorf("export path is empty")}
+		}
+		if strings.HasPrefix(path, "~"+string(os.PathSeparator)) || path == "~" {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[1:])
+			}
+		}
+
+		var data string
+		var err error
+		if dynamoJSON {
+			data, err = models.ItemToDynamoJSON(item, true)
+		} else {
+			data, err = models.ItemToJSON(item, true)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+
+		if err := os.WriteFile(path, []byte(data+"\n"), 0644); err != nil {
+			return errMsg{fmt.Errorf("failed to export item: %w", err)}
+		}
+		return itemExportedMsg{path: path}
+	}
+}
+
+func (m Model) viewExportItem() string {
+	plain, typed := "( ) Plain JSON", "( ) DynamoDB JSON"
+	if m.itemExportDynamo {
+		typed = "(•) DynamoDB JSON"
+	} else {
+		plain = "(•) Plain JSON"
+	}
+
+	content := ui.ModalStyle.Render(
+		ui.TitleStyle.Render("📄 Export Item") + "\n\n" +
+			ui.ItemStyle.Render("Path:") + "\n" +
+			ui.InputFocusedStyle.Render(m.itemExportInput.View()) + "\n\n" +
+			ui.ItemStyle.Render(plain) + "\n" +
+			ui.ItemStyle.Render(typed) + "\n\n" +
+			ui.HelpStyle.Render("Tab: switch format • Enter: export • Esc: cancel"),
+	)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOpenItemExportDefaultsToKeyName(t *testing.T) {
+	m := populatedModel()
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("x"))
+	if m.view != viewExportItem {
+		t.Fatalf("view = %v, want viewExportItem", m.view)
+	}
+	if got := filepath.Base(m.itemExportInput.Value()); got != "Users-1.json" {
+		t.Fatalf("default file name = %q", got)
+	}
+	if out := m.View(); !strings.Contains(out, "DynamoDB JSON") {
+		t.Fatal("export modal should offer the DynamoDB JSON format")
+	}
+}
+
+func TestExportItemWritesFormats(t *testing.T) {
+	m := populatedModel()
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "plain.json")
+	msg := m.exportItem(plain, false)()
+	if got, ok := msg.(itemExportedMsg); !ok || got.path != plain {
+		t.Fatalf("unexpected msg %#v", msg)
+	}
+	data, _ := os.ReadFile(plain)
+	if !strings.Contains(string(data), `"name": "alice"`) {
+		t.Fatalf("plain export:\n%s", data)
+	}
+
+	typed := filepath.Join(dir, "typed.json")
+	m.exportItem(typed, true)()
+	data, _ = os.ReadFile(typed)
+	if !strings.Contains(string(data), `"S": "alice"`) {
+		t.Fatalf("DynamoDB JSON export:\n%s", data)
+	}
+}
+
+func TestExportItemTabSwitchesFormat(t *testing.T) {
+	m := populatedModel()
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("x"))
+	m = drive(m, tea.KeyMsg{Type: tea.KeyTab})
+	if !m.itemExportDynamo {
+		t.Fatal("tab should select DynamoDB JSON")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewItemDetail {
+		t.Fatalf("esc should return to the item, view = %v", m.view)
+	}
+}
+
+func TestExportItemBadPathErrors(t *testing.T) {
+	m := populatedModel()
+	if _, ok := m.exportItem(filepath.Join(t.TempDir(), "missing", "x.json"), false)().(errMsg); !ok {
+		t.Fatal("writing into a missing directory should return errMsg")
+	}
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jmespath/go-jmespath"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// projectionValueAttr holds a projection result that isn't an object, so it
+// can still be a row of a CSV or xlsx export
+const projectionValueAttr = "value"
+
+// openExportProjection edits the JMESPath expression exports apply to each
+// item, while the export dialog stays open
+func (m *Model) openExportProjection() tea.Cmd {
+	if m.exportProjInput.Placeholder == "" {
+		in := textinput.New()
+		in.Placeholder = "{id: id, name: profile.name}"
+		in.CharLimit = 1024
+		in.Width = 50
+		m.exportProjInput = in
+	}
+	m.exportProjInput.SetValue(m.exportProjExpr)
+	m.exportProjInput.CursorEnd()
+	m.exportProjEditing = true
+	m.exportProjErr = ""
+	return m.exportProjInput.Focus()
+}
+
+func (m *Model) updateExportProjection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.exportProjEditing = false
+		m.exportProjInput.Blur()
+		return m, nil
+	case "enter":
+		expr := strings.TrimSpace(m.exportProjInput.Value())
+		if expr == "" {
+			m.exportProjExpr, m.exportProjection = "", nil
+		} else {
+			jp, err := jmespath.Compile(expr)
+			if err != nil {
+				m.exportProjErr = err.Error()
+				return m, nil
+			}
+			m.exportProjExpr, m.exportProjection = expr, jp
+		}
+		m.exportProjEditing = false
+		m.exportProjErr = ""
+		m.exportProjInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.exportProjInput, cmd = m.exportProjInput.Update(msg)
+	return m, cmd
+}
+
+// projectItems applies a JMESPath expression to each item. Objects become
+// the exported items; a null result leaves the item out, and any other
+// value is exported under projectionValueAttr. Items are searched as plain
+// JSON, so numbers are float64 as JMESPath expects, but a number the
+// expression passes through unchanged is exported as stored.
+func projectItems(jp *jmespath.JMESPath, items []map[string]types.AttributeValue) ([]map[string]types.AttributeValue, error) {
+	if jp == nil {
+		return items, nil
+	}
+	out := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, item := range items {
+		text, err := models.ItemToJSON(item, false)
+		if err != nil {
+			return nil, err
+		}
+		var data interface{}
+		if err := json.Unmarshal([]byte(text), &data); err != nil {
+			return nil, err
+		}
+		result, err := jp.Search(data)
+		if err != nil {
+			return nil, fmt.Errorf("projection: %w", err)
+		}
+		exact := make(map[float64]json.Number)
+		for _, v := range item {
+			storedNumbers(v, exact)
+		}
+		switch v := exactNumbers(result, exact).(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			out = append(out, models.InterfaceToAttributeValue(v).(*types.AttributeValueMemberM).Value)
+		default:
+			out = append(out, map[string]types.AttributeValue{projectionValueAttr: models.InterfaceToAttributeValue(v)})
+		}
+	}
+	return out, nil
+}
+
+// storedNumbers notes the text of each number in v under its float64. Two
+// numbers that round to the same float64 can't be told apart in a search
+// result, so neither is noted.
+func storedNumbers(v types.AttributeValue, exact map[float64]json.Number) {
+	note := func(text string) {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return
+		}
+		if prev, ok := exact[f]; !ok {
+			exact[f] = json.Number(text)
+		} else if prev != json.Number(text) {
+			exact[f] = ""
+		}
+	}
+	switch v := v.(type) {
+	case *types.AttributeValueMemberN:
+		note(v.Value)
+	case *types.AttributeValueMemberNS:
+		for _, n := range v.Value {
+			note(n)
+		}
+	case *types.AttributeValueMemberM:
+		for _, e := range v.Value {
+			storedNumbers(e, exact)
+		}
+	case *types.AttributeValueMemberL:
+		for _, e := range v.Value {
+			storedNumbers(e, exact)
+		}
+	}
+}
+
+// exactNumbers swaps each float64 in a search result for the stored text
+// of that number
+func exactNumbers(v interface{}, exact map[float64]json.Number) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if n := exact[v]; n != "" {
+			return n
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = exactNumbers(e, exact)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = exactNumbers(e, exact)
+		}
+	}
+	return v
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// exportWriter receives a table page by page and writes it out as it goes,
+// so a full-table export holds at most one page of items in memory.
+type exportWriter interface {
+	WriteItems(items []map[string]types.AttributeValue) error
+	// Close finishes the document. It does not close the underlying writer.
+	Close() error
+	// Abort releases any temp files without finishing the document. It is
+	// safe to call after Close.
+	Abort()
+}
+
+// newExportWriter returns the streaming writer for format. JSON and YAML are
+// written straight through; the tabular formats need every column name before
+// the first row, so they spill rows to a temp file and write on Close.
+func (m *Model) newExportWriter(format string, w io.Writer) exportWriter {
+	switch format {
+	case "json":
+		return &jsonExportWriter{w: bufio.NewWriter(w)}
+	case "yaml":
+		return &yamlExportWriter{w: bufio.NewWriter(w)}
+	default:
+		return &tabularExportWriter{m: m, format: format, out: w, widths: map[string]int{}}
+	}
+}
+
+// jsonExportWriter writes the same indented array encodeExport produces.
+type jsonExportWriter struct {
+	w *bufio.Writer
+	n int
+}
+
+func (j *jsonExportWriter) WriteItems(items []map[string]types.AttributeValue) error {
+	for _, item := range items {
+		data, err := json.MarshalIndent(models.NewItem(item).Attributes, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal item: %w", err)
+		}
+		sep := ",\n  "
+		if j.n == 0 {
+			sep = "[\n  "
+		}
+		j.w.WriteString(sep)
+		j.w.Write(data)
+		j.n++
+	}
+	return nil
+}
+
+func (j *jsonExportWriter) Close() error {
+	if j.n == 0 {
+		j.w.WriteString("[]")
+	} else {
+		j.w.WriteString("\n]")
+	}
+	return j.w.Flush()
+}
+
+func (j *jsonExportWriter) Abort() {}
+
+type yamlExportWriter struct {
+	w *bufio.Writer
+	n int
+}
+
+func (y *yamlExportWriter) WriteItems(items []map[string]types.AttributeValue) error {
+	if len(items) == 0 {
+		return nil
+	}
+	data, err := models.ItemsToYAML(items)
+	if err != nil {
+		return err
+	}
+	y.w.Write(data)
+	y.n += len(items)
+	return nil
+}
+
+func (y *yamlExportWriter) Close() error {
+	if y.n == 0 {
+		y.w.WriteString("[]\n")
+	}
+	return y.w.Flush()
+}
+
+func (y *yamlExportWriter) Abort() {}
+
+// tabularExportWriter handles CSV, TSV and xlsx. Each row is spilled to a
+// temp file as a column→cell map while the column set and widths are
+// collected; Close then writes the header and replays the rows.
+type tabularExportWriter struct {
+	m      *Model
+	format string
+	out    io.Writer
+
+	spill  *os.File
+	buf    *bufio.Writer
+	enc    *json.Encoder
+	widths map[string]int // rune length of the longest cell per column
+}
+
+func (t *tabularExportWriter) WriteItems(items []map[string]types.AttributeValue) error {
+	if t.spill == nil {
+		f, err := os.CreateTemp("", "godynamo-export-*.jsonl")
+		if err != nil {
+			return fmt.Errorf("failed to create export spill file: %w", err)
+		}
+		t.spill = f
+		t.buf = bufio.NewWriter(f)
+		t.enc = json.NewEncoder(t.buf)
+	}
+	for _, item := range items {
+		cells := make(map[string]string, len(item))
+		for k, v := range item {
+			cell := models.FormatValue(v, 50)
+			cells[k] = cell
+			if w, ok := t.widths[k]; !ok || utf8.RuneCountInString(cell) > w {
+				t.widths[k] = utf8.RuneCountInString(cell)
+			}
+		}
+		if err := t.enc.Encode(cells); err != nil {
+			return fmt.Errorf("failed to write export spill file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *tabularExportWriter) Abort() {
+	if t.spill != nil {
+		t.spill.Close()
+		os.Remove(t.spill.Name())
+		t.spill = nil
+	}
+}
+
+func (t *tabularExportWriter) Close() error {
+	if t.spill != nil {
+		defer os.Remove(t.spill.Name())
+		defer t.spill.Close()
+		if err := t.buf.Flush(); err != nil {
+			return fmt.Errorf("failed to write export spill file: %w", err)
+		}
+	}
+
+	keySet := make(map[string]bool, len(t.widths))
+	for k := range t.widths {
+		keySet[k] = true
+	}
+	headers := []string{}
+	if len(keySet) > 0 {
+		headers = t.m.orderHeaders(keySet)
+	}
+
+	replay := func(emit func([]string) error) error {
+		if t.spill == nil {
+			return nil
+		}
+		if _, err := t.spill.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to read export spill file: %w", err)
+		}
+		dec := json.NewDecoder(bufio.NewReader(t.spill))
+		for dec.More() {
+			var cells map[string]string
+			if err := dec.Decode(&cells); err != nil {
+				return fmt.Errorf("failed to read export spill file: %w", err)
+			}
+			row := make([]string, len(headers))
+			for i, h := range headers {
+				row[i] = cells[h]
+			}
+			if err := emit(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if t.format == "xlsx" {
+		widths := make([]int, len(headers))
+		for i, h := range headers {
+			w := t.widths[h]
+			if n := utf8.RuneCountInString(h); n > w {
+				w = n
+			}
+			widths[i] = xlsxClampWidth(w)
+		}
+		return writeXLSX(t.out, t.m.currentTable, headers, widths, replay)
+	}
+
+	opts := t.m.csvOptions
+	if t.format == "tsv" {
+		opts.delimiter = '\t'
+	}
+	cw := csv.NewWriter(t.out)
+	cw.Comma = opts.comma()
+	if !opts.noHeader && len(headers) > 0 {
+		if err := cw.Write(headers); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	err := replay(func(row []string) error { return cw.Write(row) })
+	cw.Flush()
+	if err == nil {
+		err = cw.Error()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
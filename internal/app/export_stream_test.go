@@ -0,0 +1,84 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// streamExport feeds items to the format's stream writer in pages of size n.
+func streamExport(t *testing.T, m *Model, format string, items []map[string]types.AttributeValue, n int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := m.newExportWriter(format, &buf)
+	for len(items) > 0 {
+		page := items[:min(n, len(items))]
+		items = items[len(page):]
+		if err := w.WriteItems(page); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestStreamJSONMatchesEncodeExport(t *testing.T) {
+	m := populatedModel()
+	want, err := m.encodeExport("json", m.items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := streamExport(t, &m, "json", m.items, 1); string(got) != string(want) {
+		t.Fatalf("got %s\nwant %s", got, want)
+	}
+}
+
+func TestStreamCSVCollectsColumnsAcrossPages(t *testing.T) {
+	m := populatedModel()
+	items := append(m.items, map[string]types.AttributeValue{
+		"id":    &types.AttributeValueMemberS{Value: "3"},
+		"email": &types.AttributeValueMemberS{Value: "c@example.com"},
+	})
+	got := string(streamExport(t, &m, "csv", items, 2))
+	want := "id,email,name\n1,,alice\n2,,bob\n3,c@example.com,\n"
+	if got != want {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestStreamXLSX(t *testing.T) {
+	m := populatedModel()
+	data := streamExport(t, &m, "xlsx", m.items, 1)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, _ := f.Open()
+		sheet, _ := io.ReadAll(rc)
+		rc.Close()
+		if !strings.Contains(string(sheet), `<row r="3">`) || !strings.Contains(string(sheet), "bob") {
+			t.Fatalf("sheet missing rows:\n%s", sheet)
+		}
+		return
+	}
+	t.Fatal("missing worksheet")
+}
+
+func TestStreamEmptyTable(t *testing.T) {
+	m := populatedModel()
+	for format, want := range map[string]string{"json": "[]", "yaml": "[]\n", "csv": ""} {
+		if got := string(streamExport(t, &m, format, nil, 1)); got != want {
+			t.Fatalf("%s: got %q, want %q", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jmespath/go-jmespath"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/dynamo/dynamotest"
+)
+
+func TestEncodeDelimitedQuotesRFC4180(t *testing.T) {
+	got, err := encodeDelimited([]string{"id", "note"}, [][]string{{"1", "a,b"}, {"2", "line1\nline2 \"q\""}}, csvOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "id,note\n1,\"a,b\"\n2,\"line1\nline2 \"\"q\"\"\"\n"
+	if string(got) != want {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestEncodeDelimitedCustomDelimiterNoHeader(t *testing.T) {
+	got, err := encodeDelimited([]string{"id", "v"}, [][]string{{"1", "a;b"}}, csvOptions{delimiter: ';', noHeader: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "1;\"a;b\"\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestEncodeExportTSV(t *testing.T) {
+	m := populatedModel()
+	data, err := m.encodeExport("tsv", m.items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 || !strings.Contains(lines[1], "\t") {
+		t.Fatalf("unexpected TSV:\n%s", data)
+	}
+}
+
+func TestUpdateExportDelimiterAndHeaderKeys(t *testing.T) {
+	m := populatedModel()
+	m.view = viewExport
+	m = drive(m, keyRunes("d"))
+	m = drive(m, keyRunes("|"))
+	if m.csvOptions.comma() != '|' || m.csvDelimPending {
+		t.Fatalf("delimiter = %q pending = %v", m.csvOptions.comma(), m.csvDelimPending)
+	}
+	m = drive(m, keyRunes("d"))
+	m = drive(m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.csvOptions.delimiterLabel() != "Tab" {
+		t.Fatalf("delimiter label = %q", m.csvOptions.delimiterLabel())
+	}
+	m = drive(m, keyRunes("h"))
+	if !m.csvOptions.noHeader {
+		t.Fatal("h should turn the header row off")
+	}
+	if out := m.View(); !strings.Contains(out, "Header row: off") {
+		t.Fatal("export dialog should show the header setting")
+	}
+}
+
+func TestEncodeXLSX(t *testing.T) {
+	data, err := encodeXLSX("Users/2024", []string{"id", "note"}, [][]string{{"1", "a < b & c"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(body)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("missing part %s", name)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Users_2024"`) {
+		t.Fatalf("sheet name not sanitised:\n%s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{`state="frozen"`, `r="A1" t="inlineStr" s="1"`, "a &lt; b &amp; c", `<col min="2" max="2" width="11"`} {
+		if !strings.Contains(sheet, want) {
+			t.Fatalf("sheet missing %q:\n%s", want, sheet)
+		}
+	}
+	if err := xml.Unmarshal([]byte(sheet), new(struct{})); err != nil {
+		t.Fatalf("sheet is not well-formed XML: %v", err)
+	}
+}
+
+func TestXLSXColumnNames(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Fatalf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestWriteExportFileGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Users.json.gz")
+	if err := writeExportFile(path, []byte(`[{"id":"1"}]`), true); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("not gzip: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if string(body) != `[{"id":"1"}]` {
+		t.Fatalf("round trip = %q", body)
+	}
+}
+
+func TestUpdateExportGzipToggle(t *testing.T) {
+	m := populatedModel()
+	m.view = viewExport
+	m = drive(m, keyRunes("g"))
+	if !m.exportGzip {
+		t.Fatal("g should enable gzip")
+	}
+	if !strings.Contains(m.View(), "Gzip: on") {
+		t.Fatal("export dialog should show the gzip setting")
+	}
+	if exportCompressible("xlsx") {
+		t.Fatal("xlsx should never be gzipped")
+	}
+}
+
+func TestUpdateExportFullTableOptions(t *testing.T) {
+	m := populatedModel()
+	m.view = viewExport
+	m = drive(m, keyRunes("f"))
+	if !m.exportFull {
+		t.Fatal("f should switch to full-table export")
+	}
+	for i := 0; i < 30; i++ {
+		m = drive(m, keyRunes("+"))
+	}
+	if m.workers() != dynamo.MaxScanSegments {
+		t.Fatalf("workers = %d, want capped at %d", m.workers(), dynamo.MaxScanSegments)
+	}
+	for i := 0; i < 30; i++ {
+		m = drive(m, keyRunes("-"))
+	}
+	if m.workers() != 1 {
+		t.Fatalf("workers = %d, want at least 1", m.workers())
+	}
+	if !strings.Contains(m.View(), "whole Users table") {
+		t.Fatal("export dialog should describe the full-table scope")
+	}
+}
+
+// failingScan delivers the first page of a parallel scan, then fails.
+type failingScan struct {
+	*dynamotest.Fake
+}
+
+func (f failingScan) ParallelScan(ctx context.Context, tableName string, segments int, onPage func(dynamo.ScanPage) error) error {
+	items := f.Items(tableName)
+	if err := onPage(dynamo.ScanPage{Items: items[:1], Scanned: 1}); err != nil {
+		return err
+	}
+	return errors.New("throttled")
+}
+
+func TestExportFullTableCleansUpAfterAFailedScan(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	fake := dynamotest.New()
+	m := populatedModel()
+	fake.AddTable(*m.tableInfo, m.items...)
+	m.client = failingScan{fake}
+	m.config.ExportDir = t.TempDir()
+	m.exportFormat = "csv"
+
+	var last tea.Msg
+	for cmd := m.exportFullTable(); ; {
+		last = cmd()
+		if _, ok := last.(exportProgressMsg); !ok {
+			break
+		}
+	}
+	if msg, ok := last.(errMsg); !ok || !strings.Contains(msg.err.Error(), "throttled") {
+		t.Fatalf("export returned %#v", last)
+	}
+	for _, dir := range []string{m.config.ExportDir, tmp} {
+		if left, _ := os.ReadDir(dir); len(left) != 0 {
+			t.Errorf("%s still holds %v", dir, left)
+		}
+	}
+}
+
+func TestExportDoneMsgReportsPath(t *testing.T) {
+	m := populatedModel()
+	m.view = viewExport
+	m.loading = true
+	m = drive(m, exportDoneMsg{path: "/tmp/Users.json", items: 7})
+	if m.loading || m.view != viewTableData || !strings.Contains(m.statusMsg, "Exported 7 items to /tmp/Users.json") {
+		t.Fatalf("loading = %v view = %v status = %q", m.loading, m.view, m.statusMsg)
+	}
+}
+
+func TestExportProjection(t *testing.T) {
+	m := populatedModel()
+	m.config.ExportDir = t.TempDir()
+	m.view = viewExport
+	m = drive(m, keyRunes("p"))
+	m.exportProjInput.SetValue("{who: name")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.exportProjEditing || m.exportProjErr == "" {
+		t.Fatal("a malformed expression should keep the input open with its error")
+	}
+	m.exportProjInput.SetValue("{who: name}")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.exportProjEditing || m.exportProjExpr != "{who: name}" {
+		t.Fatalf("editing %v, expr %q", m.exportProjEditing, m.exportProjExpr)
+	}
+	if out := m.View(); !strings.Contains(out, "Projection: {who: name}") {
+		t.Error("the dialog should show the projection")
+	}
+
+	m.exportFormat = "csv"
+	msg := m.exportData()()
+	if done, ok := msg.(exportDoneMsg); !ok || done.items != 2 {
+		t.Fatalf("export returned %#v", msg)
+	}
+	data, err := os.ReadFile(filepath.Join(m.config.ExportDir, "Users.csv"))
+	if err != nil || string(data) != "who\nalice\nbob\n" {
+		t.Fatalf("exported %q, %v", data, err)
+	}
+}
+
+func TestProjectItemsShapes(t *testing.T) {
+	m := populatedModel()
+	for expr, want := range map[string]int{"id == '1' && @ || null": 1, "name": 2} {
+		items, err := projectItems(jmespath.MustCompile(expr), m.items)
+		if err != nil || len(items) != want {
+			t.Errorf("%s: %d items, %v", expr, len(items), err)
+		}
+	}
+	items, _ := projectItems(jmespath.MustCompile("name"), m.items)
+	if _, ok := items[0][projectionValueAttr]; !ok {
+		t.Errorf("a scalar result should be exported as %q: %v", projectionValueAttr, items[0])
+	}
+}
+
+func TestProjectItemsKeepsNumbersExact(t *testing.T) {
+	items := []map[string]types.AttributeValue{{
+		"id":    &types.AttributeValueMemberN{Value: "12345678901234567891"},
+		"price": &types.AttributeValueMemberN{Value: "0.10"},
+	}}
+	got, err := projectItems(jmespath.MustCompile("price > `0` && {id: id, price: price} || null"), items)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("%v, %v", got, err)
+	}
+	for attr, want := range map[string]string{"id": "12345678901234567891", "price": "0.10"} {
+		if n, ok := got[0][attr].(*types.AttributeValueMemberN); !ok || n.Value != want {
+			t.Errorf("%s = %#v, want %s", attr, got[0][attr], want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// A minimal SpreadsheetML package: one worksheet, inline strings, and a
+// stylesheet with a bold font for the header row. Written by hand to avoid
+// pulling in a spreadsheet library for a single export format.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+)
+
+// Column widths are measured in characters; cap them so one long JSON cell
+// doesn't produce an unusably wide column.
+const (
+	xlsxMinColWidth = 8
+	xlsxMaxColWidth = 60
+)
+
+// encodeXLSX builds a workbook with a single worksheet named after the table,
+// a bold frozen header row and columns sized to their content.
+func encodeXLSX(sheetName string, headers []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := writeXLSX(&buf, sheetName, headers, xlsxColWidths(headers, rows), func(emit func([]string) error) error {
+		for _, row := range rows {
+			if err := emit(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXLSX streams a workbook to w. Column widths must be known up front
+// (they precede the data in the sheet XML); rows are pulled from the rows
+// callback one at a time so the sheet never has to be held in memory.
+func writeXLSX(w io.Writer, sheetName string, headers []string, widths []int, rows func(emit func([]string) error) error) error {
+	zw := zip.NewWriter(w)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(xlsxSheetName(sheetName)))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		pw, err := zw.Create(p.name)
+		if err != nil {
+			return fmt.Errorf("failed to write xlsx: %w", err)
+		}
+		if _, err := io.WriteString(pw, p.body); err != nil {
+			return fmt.Errorf("failed to write xlsx: %w", err)
+		}
+	}
+
+	sw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	bw := bufio.NewWriter(sw)
+	bw.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	bw.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	bw.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	bw.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	bw.WriteString(`</sheetView></sheetViews>`)
+
+	if len(widths) > 0 {
+		bw.WriteString("<cols>")
+		for i, width := range widths {
+			fmt.Fprintf(bw, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		bw.WriteString("</cols>")
+	}
+
+	bw.WriteString("<sheetData>")
+	xlsxRow(bw, 1, headers, 1)
+	r := 1
+	err = rows(func(row []string) error {
+		r++
+		xlsxRow(bw, r, row, 0)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteString("</sheetData></worksheet>")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+func xlsxRow(w *bufio.Writer, r int, cells []string, style int) {
+	fmt.Fprintf(w, `<row r="%d">`, r)
+	for c, v := range cells {
+		fmt.Fprintf(w, `<c r="%s%d" t="inlineStr"`, xlsxColumn(c), r)
+		if style != 0 {
+			fmt.Fprintf(w, ` s="%d"`, style)
+		}
+		fmt.Fprintf(w, `><is><t xml:space="preserve">%s</t></is></c>`, xmlEscape(v))
+	}
+	w.WriteString("</row>")
+}
+
+func xlsxColWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i := 0; i < len(row) && i < len(widths); i++ {
+			if n := utf8.RuneCountInString(row[i]); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for i := range widths {
+		widths[i] = xlsxClampWidth(widths[i])
+	}
+	return widths
+}
+
+// xlsxClampWidth turns a content length in characters into a column width
+func xlsxClampWidth(n int) int {
+	n += 2
+	if n < xlsxMinColWidth {
+		return xlsxMinColWidth
+	}
+	if n > xlsxMaxColWidth {
+		return xlsxMaxColWidth
+	}
+	return n
+}
+
+// xlsxColumn converts a 0-based column index to its letter name (0 → A, 26 → AA).
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxSheetName strips the characters Excel rejects in sheet names and
+// applies its 31-character limit.
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		return "Sheet1"
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
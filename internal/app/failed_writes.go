@@ -0,0 +1,317 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// failedWritesDir keeps, per connection and table, the items bulk writes
+// (generated data, table copies) gave up on, one JSON line each, so they
+// can be retried later instead of being lost with the job's status line
+const failedWritesDir = "failed_writes"
+
+type failedWrite struct {
+	Item     json.RawMessage `json:"item"` // DynamoDB JSON
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+type failedRetryMsg struct {
+	tried  int
+	failed []dynamo.FailedWrite
+	err    error
+}
+
+func failedWritesPath(connection, table string) (string, error) {
+	dir, err := appDataPath(failedWritesDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return filepath.Join(dir, url.QueryEscape(connection+"/"+table)+".jsonl"), nil
+}
+
+func toFailedWrites(failed []dynamo.FailedWrite) ([]failedWrite, error) {
+	out := make([]failedWrite, len(failed))
+	for i, f := range failed {
+		item, err := models.ItemToDynamoJSON(f.Item, false)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = failedWrite{Item: json.RawMessage(item), Error: f.Err.Error(), FailedAt: time.Now()}
+	}
+	return out, nil
+}
+
+func appendFailedWrites(connection, table string, failed []dynamo.FailedWrite) error {
+	entries, err := toFailedWrites(failed)
+	if err != nil {
+		return err
+	}
+	path, err := failedWritesPath(connection, table)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the failed writes queue: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to queue failed writes: %w", err)
+		}
+	}
+	return f.Close()
+}
+
+func readFailedWrites(connection, table string) ([]failedWrite, error) {
+	path, err := failedWritesPath(connection, table)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the failed writes queue: %w", err)
+	}
+	var entries []failedWrite
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, 1<<20) // an item is at most 400 KB
+	for sc.Scan() {
+		var e failedWrite
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, sc.Err()
+}
+
+// writeFailedWrites replaces the queue; an empty one removes the file
+func writeFailedWrites(connection, table string, entries []failedWrite) error {
+	path, err := failedWritesPath(connection, table)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// queueFailedWrites keeps the items a job gave up on and says so for the
+// status line
+func (m *Model) queueFailedWrites(table string, failed []dynamo.FailedWrite) string {
+	if err := appendFailedWrites(m.connectionKey(), table, failed); err != nil {
+		return fmt.Sprintf(" - ✗ %d items failed and could not be queued: %s", len(failed), err)
+	}
+	if table == m.currentTable {
+		m.failedCount += len(failed)
+	}
+	return fmt.Sprintf(" - ⚠ %d failed writes queued for %s (F in that table retries them)", len(failed), table)
+}
+
+// loadFailedCount refreshes the count the table data status bar shows
+func (m *Model) loadFailedCount() {
+	entries, _ := readFailedWrites(m.connectionKey(), m.currentTable)
+	m.failedCount = len(entries)
+}
+
+func (m *Model) openFailedWrites() {
+	entries, err := readFailedWrites(m.connectionKey(), m.currentTable)
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	if len(entries) == 0 {
+		m.failedCount = 0
+		m.statusMsg = "No failed writes queued for " + m.currentTable
+		return
+	}
+	m.failedWrites = entries
+	m.failedCount = len(entries)
+	m.failedCursor = 0
+	m.view = viewFailedWrites
+	m.statusMsg = fmt.Sprintf("%d failed writes", len(entries))
+}
+
+func (m *Model) updateFailedWrites(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.failedRetrying {
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.view = viewTableData
+	case "up", "k":
+		m.failedCursor = max(m.failedCursor-1, 0)
+	case "down", "j":
+		m.failedCursor = min(m.failedCursor+1, len(m.failedWrites)-1)
+	case "d":
+		if len(m.failedWrites) == 0 {
+			return m, nil
+		}
+		rest := append(m.failedWrites[:m.failedCursor:m.failedCursor], m.failedWrites[m.failedCursor+1:]...)
+		if err := writeFailedWrites(m.connectionKey(), m.currentTable, rest); err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return m, nil
+		}
+		m.failedWrites, m.failedCount = rest, len(rest)
+		m.failedCursor = max(min(m.failedCursor, len(rest)-1), 0)
+		m.statusMsg = fmt.Sprintf("Discarded; %d failed writes left", len(rest))
+	case "r":
+		return m, m.retryFailedWrites()
+	}
+	return m, nil
+}
+
+// retryFailedWrites writes the queued items again; the ones that fail again
+// stay queued with their new error
+func (m *Model) retryFailedWrites() tea.Cmd {
+	if m.refuseWrite("retrying writes") {
+		return nil
+	}
+	if m.offline {
+		m.statusMsg = "✗ Offline: retrying needs a connection"
+		return nil
+	}
+	items := make([]map[string]types.AttributeValue, 0, len(m.failedWrites))
+	for _, e := range m.failedWrites {
+		item, err := models.DynamoJSONToItem(e.Item)
+		if err != nil {
+			m.statusMsg = "✗ Failed to read the queue: " + err.Error()
+			return nil
+		}
+		items = append(items, item)
+	}
+	m.failedRetrying = true
+	m.statusMsg = fmt.Sprintf("Retrying %d writes...", len(items))
+	client, table := m.client, m.currentTable
+	return func() tea.Msg {
+		err := client.WriteItems(context.Background(), table, items)
+		var wf *dynamo.WriteFailures
+		if errors.As(err, &wf) {
+			return failedRetryMsg{tried: len(items), failed: wf.Failed}
+		}
+		return failedRetryMsg{tried: len(items), err: err}
+	}
+}
+
+func (m *Model) handleFailedRetry(msg failedRetryMsg) tea.Cmd {
+	m.failedRetrying = false
+	if msg.err != nil {
+		m.statusMsg = "✗ Retry failed; the queue is kept: " + msg.err.Error()
+		return nil
+	}
+	entries, err := toFailedWrites(msg.failed)
+	if err == nil {
+		err = writeFailedWrites(m.connectionKey(), m.currentTable, entries)
+	}
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+	m.failedWrites, m.failedCount, m.failedCursor = entries, len(entries), 0
+	written := msg.tried - len(entries)
+	m.statusMsg = fmt.Sprintf("✓ Retried %d writes: %d written, %d still failing", msg.tried, written, len(entries))
+	if len(entries) == 0 {
+		m.view = viewTableData
+	}
+	if written == 0 {
+		return nil
+	}
+	m.lastKey = nil
+	m.loading = true
+	return m.scanTable()
+}
+
+// failedWritesStatus points at the queue from the table data status bar
+func (m Model) failedWritesStatus() string {
+	if m.failedCount == 0 {
+		return ""
+	}
+	return ui.WarningStyle.Render(fmt.Sprintf(" | ⚠ %d failed writes (F)", m.failedCount))
+}
+
+// failedWriteLabel names a queued item by its key, or its start without one
+func (m Model) failedWriteLabel(e failedWrite) string {
+	item, err := models.DynamoJSONToItem(e.Item)
+	if err != nil || m.tableInfo == nil {
+		return ui.Truncate(string(e.Item), 40)
+	}
+	parts := []string{}
+	for _, k := range []string{m.tableInfo.PartitionKey, m.tableInfo.SortKey} {
+		if v, ok := item[k]; ok && k != "" {
+			parts = append(parts, k+"="+models.FormatValue(v, 30))
+		}
+	}
+	if len(parts) == 0 {
+		return ui.Truncate(string(e.Item), 40)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m Model) viewFailedWrites() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("⚠ Failed Writes: " + m.currentTable))
+	b.WriteString("\n\n")
+
+	visible := max(m.height-8, 3)
+	start := max(0, min(m.failedCursor-visible/2, len(m.failedWrites)-visible))
+	for i := start; i < len(m.failedWrites) && i < start+visible; i++ {
+		e := m.failedWrites[i]
+		line := fmt.Sprintf("%s  %s  %s", e.FailedAt.Format("2006-01-02 15:04"), m.failedWriteLabel(e), e.Error)
+		line = ui.Truncate(line, max(m.width-4, 20))
+		if i == m.failedCursor {
+			b.WriteString(ui.SelectedStyle.Render("▸ " + line))
+		} else {
+			b.WriteString(ui.ItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑↓", Desc: "Select"},
+		{Key: "r", Desc: "Retry all"},
+		{Key: "d", Desc: "Discard"},
+		{Key: "Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestFailedWritesQueueAndRetry(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	item := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+	m = drive(m, seedDoneMsg{written: 8, failed: []dynamo.FailedWrite{
+		{Item: item("a"), Err: errors.New("throttled")},
+		{Item: item("b"), Err: errors.New("too large")},
+	}})
+	if !strings.Contains(m.statusMsg, "2 failed writes queued for Users") || m.failedCount != 2 {
+		t.Fatalf("status %q, count %d", m.statusMsg, m.failedCount)
+	}
+
+	m = drive(m, keyRunes("F"))
+	if m.view != viewFailedWrites || len(m.failedWrites) != 2 {
+		t.Fatalf("view %d with %d entries", m.view, len(m.failedWrites))
+	}
+	if out := m.View(); !strings.Contains(out, "id=a") || !strings.Contains(out, "too large") {
+		t.Fatalf("the queue should show keys and errors:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("d"))
+	if entries, _ := readFailedWrites(m.connectionKey(), "Users"); len(entries) != 1 || m.failedCount != 1 {
+		t.Fatalf("discard left %d on disk, count %d", len(entries), m.failedCount)
+	}
+
+	// The retry fails again: the entry stays with its new error
+	m = drive(m, failedRetryMsg{tried: 1, failed: []dynamo.FailedWrite{{Item: item("b"), Err: errors.New("still too large")}}})
+	if entries, _ := readFailedWrites(m.connectionKey(), "Users"); len(entries) != 1 || entries[0].Error != "still too large" {
+		t.Fatalf("queue after retry: %+v", entries)
+	}
+	// Then succeeds: the queue is gone
+	m = drive(m, failedRetryMsg{tried: 1})
+	if entries, _ := readFailedWrites(m.connectionKey(), "Users"); len(entries) != 0 || m.view != viewTableData || m.failedCount != 0 {
+		t.Fatalf("queue %d, view %d, count %d", len(entries), m.view, m.failedCount)
+	}
+}
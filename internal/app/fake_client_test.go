@@ -0,0 +1,111 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/dynamo/dynamotest"
+)
+
+// settle feeds msg to m, then runs the commands that follow until none is
+// left. A command still running after a moment, such as a tick, is
+// dropped.
+func settle(m Model, msg tea.Msg) Model {
+	queue := []tea.Msg{msg}
+	for len(queue) > 0 {
+		next, cmd := m.Update(queue[0])
+		queue = queue[1:]
+		switch v := next.(type) {
+		case Model:
+			m = v
+		case *Model:
+			m = *v
+		}
+		queue = append(queue, runCmd(cmd)...)
+	}
+	return m
+}
+
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(50 * time.Millisecond):
+		return nil
+	}
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, c := range msg {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+// openFakeTable connects to fake and opens its first table
+func openFakeTable(t *testing.T, cfg Config, fake *dynamotest.Fake) Model {
+	t.Helper()
+	stubConfigDir(t)
+	cfg.Client = fake
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = drive(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	for _, msg := range runCmd(m.Init()) {
+		m = settle(m, msg)
+	}
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewTableData || m.tableInfo == nil {
+		t.Fatalf("view %d (status %q)", m.view, m.statusMsg)
+	}
+	return m
+}
+
+func TestWorkflowAgainstFakeClient(t *testing.T) {
+	stubConfigDir(t)
+	fake := dynamotest.New()
+	fake.AddTable(dynamo.TableInfo{Name: "Users", PartitionKey: "id", PartitionType: "S"},
+		map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}, "name": &types.AttributeValueMemberS{Value: "alice"}},
+		map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "2"}, "name": &types.AttributeValueMemberS{Value: "bob"}},
+	)
+	cfg := DefaultConfig()
+	cfg.Client = fake
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = drive(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	for _, msg := range runCmd(m.Init()) {
+		m = settle(m, msg)
+	}
+	if m.view != viewTables || len(m.tables) != 1 || m.tables[0] != "Users" {
+		t.Fatalf("view %d, tables %v (status %q)", m.view, m.tables, m.statusMsg)
+	}
+
+	m = settle(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewTableData || len(m.items) != 2 {
+		t.Fatalf("view %d, %d items (status %q)", m.view, len(m.items), m.statusMsg)
+	}
+
+	m = settle(m, keyRunes("d"))
+	m = settle(m, keyRunes("y"))
+	if left := fake.Items("Users"); len(left) != 1 || !strings.Contains(m.View(), "bob") {
+		t.Fatalf("items left %v, status %q", left, m.statusMsg)
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/godynamo/pkg/models"
+	"github.com/godynamo/pkg/query"
+)
+
+// timeSamples is how many loaded items timeEncoding reads an attribute of
+const timeSamples = 200
+
+// timeEncoding guesses how attr stores time from the loaded items
+func (m Model) timeEncoding(attr string) query.TimeEncoding {
+	var samples []interface{}
+	for _, item := range m.items[:min(len(m.items), timeSamples)] {
+		if av, ok := item[attr]; ok {
+			samples = append(samples, models.AttributeValueToInterface(av))
+		}
+	}
+	return query.DetectTimeEncoding(samples)
+}
+
+// convertFilterDate rewrites the date typed in the focused value field, such
+// as 2024-05-01, the way the condition's attribute stores time (Ctrl+T). A
+// relative time like now-24h is fixed at the moment it is converted.
+func (m *Model) convertFilterDate() {
+	fb := &m.filterBuilder
+	if fb.ActiveField != 2 {
+		m.statusMsg = "✗ Ctrl+T converts a date in the value field"
+		return
+	}
+	cond := &fb.Conditions[fb.ActiveCondIdx]
+	attr := strings.TrimSpace(cond.AttributeName.Value())
+	enc := m.timeEncoding(attr)
+	if !enc.Known() {
+		m.statusMsg = fmt.Sprintf("✗ Can't tell how %q stores time: no loaded item has a timestamp in it", attr)
+		return
+	}
+	t, ok := query.RelativeTime(cond.AttributeValue.Value(), time.Now())
+	if !ok {
+		var err error
+		if t, err = query.ParseDate(cond.AttributeValue.Value()); err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return
+		}
+	}
+	value := enc.Encode(t)
+	cond.AttributeValue.SetValue(value)
+	cond.AttributeValue.CursorEnd()
+	m.statusMsg = fmt.Sprintf("✓ %s as %s: %s", t.Format("2006-01-02 15:04:05 MST"), enc, value)
+}
+
+// filterDateHint says what the focused value means when it is a relative
+// time, and offers Ctrl+T when it is a date its attribute doesn't store
+// time as
+func (m Model) filterDateHint() string {
+	fb := m.filterBuilder
+	if fb.ActiveField != 2 || fb.ActiveCondIdx >= len(fb.Conditions) {
+		return ""
+	}
+	cond := fb.Conditions[fb.ActiveCondIdx]
+	value := strings.TrimSpace(cond.AttributeValue.Value())
+	enc := m.timeEncoding(strings.TrimSpace(cond.AttributeName.Value()))
+	if t, ok := query.RelativeTime(value, time.Now()); ok {
+		if !enc.Known() {
+			return fmt.Sprintf("%s is a relative time, but no loaded item shows how this attribute stores time", value)
+		}
+		return fmt.Sprintf("%s is read when the filter runs; now it is %s (%s)", value, enc.Encode(t), enc)
+	}
+	t, err := query.ParseDate(value)
+	if err != nil || !enc.Known() || enc.Encode(t) == value {
+		return ""
+	}
+	return fmt.Sprintf("Tip: Ctrl+T writes this date as %s, like the loaded items: %s", enc, enc.Encode(t))
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+)
+
+func TestFilterDateConvertsToTheTablesEncoding(t *testing.T) {
+	m := populatedModel()
+	m.items[0]["created"] = &types.AttributeValueMemberN{Value: "1714000000"}
+	m.items[1]["created"] = &types.AttributeValueMemberN{Value: "1714100000"}
+	m.view = viewQuery
+	fb := &m.filterBuilder
+	fb.Conditions[0].AttributeName.SetValue("created")
+	fb.Conditions[0].Operator = ui.OpGreaterOrEqual
+	fb.ActiveField = 2
+	fb.Conditions[0].AttributeValue.SetValue("2024-05-01")
+
+	if out := m.View(); !strings.Contains(out, "epoch seconds") {
+		t.Errorf("the builder should offer to convert the date:\n%s", out)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlT})
+	if got := m.filterBuilder.Conditions[0].AttributeValue.Value(); got != "1714521600" {
+		t.Fatalf("value %q, want May 1st in epoch seconds (%s)", got, m.statusMsg)
+	}
+	if _, _, values := m.filterBuilder.BuildExpression(); values[":val0"] != float64(1714521600) {
+		t.Errorf("values %v: the converted date should bind as a number", values)
+	}
+}
+
+func TestFilterDateNeedsTimestamps(t *testing.T) {
+	m := populatedModel()
+	m.view = viewQuery
+	m.filterBuilder.Conditions[0].AttributeName.SetValue("name")
+	m.filterBuilder.ActiveField = 2
+	m.filterBuilder.Conditions[0].AttributeValue.SetValue("2024-05-01")
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlT})
+	if got := m.filterBuilder.Conditions[0].AttributeValue.Value(); got != "2024-05-01" || !strings.Contains(m.statusMsg, "Can't tell") {
+		t.Errorf("value %q, status %q", got, m.statusMsg)
+	}
+}
+
+func TestRelativeTimeFilterExpandsWhenApplied(t *testing.T) {
+	m := populatedModel()
+	m.items[0]["created"] = &types.AttributeValueMemberS{Value: "2024-04-30T10:00:00Z"}
+	m.view = viewQuery
+	fb := &m.filterBuilder
+	fb.Conditions[0].AttributeName.SetValue("created")
+	fb.Conditions[0].Operator = ui.OpGreaterThan
+	fb.Conditions[0].AttributeValue.SetValue("now-24h")
+	fb.ActiveField = 2
+
+	if out := m.View(); !strings.Contains(out, "now-24h is read when the filter runs") {
+		t.Errorf("the builder should explain the relative time:\n%s", out)
+	}
+	before := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	got, err := time.Parse(time.RFC3339, m.filterValues[":val0"].(string))
+	if err != nil || got.Before(before) || got.After(before.Add(time.Minute)) {
+		t.Errorf("filter value %v (%v), want about 24 hours ago", m.filterValues[":val0"], err)
+	}
+	if fb := m.filterBuilder; fb.Conditions[0].AttributeValue.Value() != "now-24h" {
+		t.Error("the builder should keep now-24h for the next run")
+	}
+}
@@ -1,10 +1,14 @@
 package app
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/godynamo/internal/dynamo"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 func TestFormatBytes(t *testing.T) {
@@ -89,3 +93,115 @@ func TestItemsToTableOrdersKeysWithPartitionFirst(t *testing.T) {
 		t.Fatalf("row=%v", rows[0])
 	}
 }
+
+func TestCellsTellNullMissingAndEmptyApart(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.tableInfo = &dynamo.TableInfo{PartitionKey: "id"}
+	m.items = []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "1"}, "note": &types.AttributeValueMemberNULL{Value: true}},
+		{"id": &types.AttributeValueMemberS{Value: "2"}, "note": &types.AttributeValueMemberS{Value: ""}},
+		{"id": &types.AttributeValueMemberS{Value: "3"}, "other": &types.AttributeValueMemberS{Value: "x"}},
+	}
+	m.showItems(m.items)
+	col := slices.Index(m.columns, "note")
+	got := []string{m.dataTable.Rows[0][col], m.dataTable.Rows[1][col], m.dataTable.Rows[2][col]}
+	if want := []string{"∅", `""`, ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NULL, empty and missing cells = %q, want %q", got, want)
+	}
+
+	var copied string
+	stubClipboard(t, nil, nil)
+	writeSystemClipboard = func(s string) error { copied = s; return nil }
+	m.dataTable.SelectedCol = col
+	drive(m, keyRunes("y"))
+	if copied != "null" {
+		t.Errorf("copying a NULL cell gave %q, want the value rather than the marker", copied)
+	}
+}
+
+func TestTypeHintsMarkAmbiguousCells(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.tableInfo = &dynamo.TableInfo{PartitionKey: "id"}
+	m.items = []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "123"}, "v": &types.AttributeValueMemberN{Value: "123"}, "name": &types.AttributeValueMemberS{Value: "alice"}},
+	}
+	m.showItems(m.items)
+	m = drive(m, keyRunes("T"))
+	row := m.dataTable.Rows[0]
+	if got := []string{row[0], row[slices.Index(m.columns, "v")], row[slices.Index(m.columns, "name")]}; !reflect.DeepEqual(got, []string{"123 (S)", "123 (N)", "alice"}) {
+		t.Errorf("cells = %q", got)
+	}
+	m = drive(m, keyRunes("T"))
+	if m.dataTable.Rows[0][0] != "123" {
+		t.Errorf("T again should drop the hints, got %q", m.dataTable.Rows[0][0])
+	}
+}
+
+func TestItemsToTableReusesHeadersAcrossPages(t *testing.T) {
+	m := New()
+	m.currentTable = "users"
+	m.tableInfo = &dynamo.TableInfo{PartitionKey: "id"}
+	page := func(extra string) []map[string]types.AttributeValue {
+		item := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}, "name": &types.AttributeValueMemberS{Value: "a"}}
+		if extra != "" {
+			item[extra] = &types.AttributeValueMemberS{Value: "x"}
+		}
+		return []map[string]types.AttributeValue{item}
+	}
+
+	first, _ := m.itemsToTable(page(""))
+	second, _ := m.itemsToTable(page(""))
+	if &first[0] != &second[0] {
+		t.Fatal("same key set should reuse the cached header order")
+	}
+	third, rows := m.itemsToTable(page("age"))
+	if !reflect.DeepEqual(third, []string{"id", "age", "name"}) || rows[0][1] != "x" {
+		t.Fatalf("new attribute not picked up: %v %v", third, rows)
+	}
+	if fewer, _ := m.itemsToTable(page("")); len(fewer) != 2 {
+		t.Fatalf("dropped attribute still shown: %v", fewer)
+	}
+}
+
+func TestItemsToTableSharesHeaderCacheAcrossCopies(t *testing.T) {
+	m := New()
+	m.currentTable = "users"
+	m.tableInfo = &dynamo.TableInfo{PartitionKey: "id"}
+	items := []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "1"}}}
+	m.itemsToTable(items)
+
+	// Exports read the cache from their own copy of the model while the
+	// table view keeps using it; go test -race catches a write here
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(copy Model) {
+			defer wg.Done()
+			copy.itemsToTable(items)
+		}(m)
+	}
+	m.itemsToTable(items)
+	wg.Wait()
+}
+
+func BenchmarkItemsToTable(b *testing.B) {
+	m := New()
+	m.currentTable = "orders"
+	m.tableInfo = &dynamo.TableInfo{PartitionKey: "pk", SortKey: "sk"}
+	items := make([]map[string]types.AttributeValue, 1000)
+	for i := range items {
+		items[i] = map[string]types.AttributeValue{
+			"pk":     &types.AttributeValueMemberS{Value: fmt.Sprintf("CUSTOMER#%d", i)},
+			"sk":     &types.AttributeValueMemberS{Value: fmt.Sprintf("ORDER#%08d", i)},
+			"total":  &types.AttributeValueMemberN{Value: fmt.Sprint(i * 7)},
+			"status": &types.AttributeValueMemberS{Value: "shipped"},
+			"paid":   &types.AttributeValueMemberBOOL{Value: i%2 == 0},
+		}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.itemsToTable(items)
+	}
+}
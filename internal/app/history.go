@@ -0,0 +1,192 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// itemSnapshot is one version of an item captured during the session.
+type itemSnapshot struct {
+	savedAt  time.Time
+	item     map[string]types.AttributeValue
+	original bool // the pre-edit version, captured on the first save
+}
+
+// historyKey identifies an item across saves: table name plus its primary key
+// values. Without a schema it falls back to the full attribute set, which still
+// groups identical re-saves but cannot follow an item whose attributes change.
+func (m *Model) historyKey(item map[string]types.AttributeValue) string {
+	var parts []string
+	if m.tableInfo != nil && m.tableInfo.PartitionKey != "" {
+		keys := []string{m.tableInfo.PartitionKey}
+		if m.tableInfo.SortKey != "" {
+			keys = append(keys, m.tableInfo.SortKey)
+		}
+		for _, k := range keys {
+			v, ok := item[k]
+			if !ok {
+				return ""
+			}
+			parts = append(parts, k+"="+models.FormatValue(v, 0))
+		}
+	} else {
+		jsonStr, err := models.ItemToJSON(item, false)
+		if err != nil {
+			return ""
+		}
+		parts = append(parts, jsonStr)
+	}
+	return m.currentTable + "|" + strings.Join(parts, "|")
+}
+
+// recordSave appends the saved version of an item to the session history. On
+// the first save of an item the pre-edit version (prev) is stored as well, so
+// the state before any change made in this session can be restored.
+func (m *Model) recordSave(prev, saved map[string]types.AttributeValue) {
+	key := m.historyKey(saved)
+	if key == "" {
+		return
+	}
+	if m.editHistory == nil {
+		m.editHistory = make(map[string][]itemSnapshot)
+	}
+	now := time.Now()
+	if len(m.editHistory[key]) == 0 && prev != nil {
+		m.editHistory[key] = append(m.editHistory[key], itemSnapshot{savedAt: now, item: prev, original: true})
+	}
+	m.editHistory[key] = append(m.editHistory[key], itemSnapshot{savedAt: now, item: saved})
+}
+
+// itemHistory returns the snapshots recorded for the selected item, oldest first.
+func (m *Model) itemHistory() []itemSnapshot {
+	if m.selectedItem == nil {
+		return nil
+	}
+	return m.editHistory[m.historyKey(m.selectedItem)]
+}
+
+func (m *Model) openItemHistory() {
+	snaps := m.itemHistory()
+	if len(snaps) == 0 {
+		m.statusMsg = "No saves recorded for this item in this session"
+		return
+	}
+	m.historyIdx = len(snaps) - 1
+	m.prepareHistoryView()
+	m.view = viewItemHistory
+}
+
+func (m *Model) prepareHistoryView() {
+	snaps := m.itemHistory()
+	if m.historyIdx < 0 || m.historyIdx >= len(snaps) {
+		return
+	}
+	item := models.NewItem(snaps[m.historyIdx].item)
+	m.itemViewport.SetContent(ui.NewJSONViewer(item.Attributes).Render())
+	m.itemViewport.GotoTop()
+}
+
+func (m *Model) updateItemHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	snaps := m.itemHistory()
+	switch msg.String() {
+	case "q", "esc":
+		m.view = viewItemDetail
+		m.updateItemViewContent()
+	case "up", "k":
+		if m.historyIdx > 0 {
+			m.historyIdx--
+			m.prepareHistoryView()
+		}
+	case "down", "j":
+		if m.historyIdx < len(snaps)-1 {
+			m.historyIdx++
+			m.prepareHistoryView()
+		}
+	case "[":
+		m.resizeSidebar(-paneStep)
+	case "]":
+		m.resizeSidebar(paneStep)
+	case "pgup":
+		m.itemViewport.HalfViewUp()
+	case "pgdown":
+		m.itemViewport.HalfViewDown()
+	case "enter", "r":
+		// Restoring opens the editor with the old version; nothing is written
+		// until the user saves (and confirms) it like any other edit.
+		if m.historyIdx >= 0 && m.historyIdx < len(snaps) {
+			jsonStr, err := models.ItemToJSON(snaps[m.historyIdx].item, true)
+			if err != nil {
+				m.statusMsg = "✗ " + err.Error()
+				return m, nil
+			}
+			m.editorJSONStrings = nil
+			m.itemEditor.SetValue(jsonStr)
+			m.view = viewEditItem
+			m.itemEditor.Focus()
+			m.statusMsg = fmt.Sprintf("Restoring version %d — Ctrl+S to save", m.historyIdx+1)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewItemHistory() string {
+	var b strings.Builder
+
+	b.WriteString(ui.TitleStyle.Render("🕘 Item History"))
+	b.WriteString("\n\n")
+
+	snaps := m.itemHistory()
+	var list strings.Builder
+	for i, s := range snaps {
+		label := fmt.Sprintf("#%d  %s", i+1, s.savedAt.Format("15:04:05"))
+		if s.original {
+			label += "  (original)"
+		} else if i == len(snaps)-1 {
+			label += "  (latest)"
+		}
+		if i == m.historyIdx {
+			list.WriteString(ui.SelectedStyle.Render("▸ " + label))
+		} else {
+			list.WriteString(ui.ItemStyle.Render("  " + label))
+		}
+		list.WriteString("\n")
+	}
+
+	listStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorPrimary).
+		Padding(0, 1).
+		Width(m.config.Panes.Sidebar)
+
+	detail := m.itemViewport
+	detail.Width = m.detailWidth()
+	join := lipgloss.JoinHorizontal
+	if m.config.Accessible {
+		join = lipgloss.JoinVertical // read the versions, then the one chosen
+	}
+	body := join(lipgloss.Top,
+		listStyle.Render(list.String()),
+		ui.ContentNoBorderStyle.Width(detail.Width).Render(detail.View()),
+	)
+	b.WriteString(body)
+	b.WriteString("\n")
+
+	help := ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑/↓", Desc: "Version"},
+		{Key: "PgUp/PgDn", Desc: "Scroll"},
+		{Key: "[/]", Desc: "Resize"},
+		{Key: "Enter", Desc: "Restore"},
+		{Key: "q/Esc", Desc: "Back"},
+	})
+	b.WriteString(help)
+
+	return b.String()
+}
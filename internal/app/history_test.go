@@ -0,0 +1,89 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func userItem(id, name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id":   &types.AttributeValueMemberS{Value: id},
+		"name": &types.AttributeValueMemberS{Value: name},
+	}
+}
+
+func TestHistoryKeyUsesPrimaryKey(t *testing.T) {
+	m := populatedModel()
+	if a, b := m.historyKey(userItem("1", "alice")), m.historyKey(userItem("1", "carol")); a != b {
+		t.Fatalf("same key, different attrs should share history: %q vs %q", a, b)
+	}
+	if a, b := m.historyKey(userItem("1", "alice")), m.historyKey(userItem("2", "alice")); a == b {
+		t.Fatalf("different keys collided: %q", a)
+	}
+	if k := m.historyKey(map[string]types.AttributeValue{"name": &types.AttributeValueMemberS{Value: "x"}}); k != "" {
+		t.Fatalf("missing key attribute should give empty key, got %q", k)
+	}
+}
+
+func TestRecordSaveSeedsOriginal(t *testing.T) {
+	m := populatedModel()
+	m.recordSave(userItem("1", "alice"), userItem("1", "alicia"))
+	m.recordSave(userItem("1", "alicia"), userItem("1", "ally"))
+
+	snaps := m.editHistory[m.historyKey(userItem("1", ""))]
+	if len(snaps) != 3 {
+		t.Fatalf("want original + 2 saves, got %d", len(snaps))
+	}
+	if !snaps[0].original || snaps[1].original || snaps[2].original {
+		t.Fatalf("only the first snapshot should be marked original: %+v", snaps)
+	}
+	if got := snaps[2].item["name"].(*types.AttributeValueMemberS).Value; got != "ally" {
+		t.Fatalf("latest snapshot = %q, want ally", got)
+	}
+}
+
+func TestItemSavedMsgRecordsHistory(t *testing.T) {
+	m := populatedModel()
+	m = drive(m, itemSavedMsg{item: userItem("1", "alicia"), prev: userItem("1", "alice")})
+	if n := len(m.editHistory); n != 1 {
+		t.Fatalf("editHistory has %d entries, want 1", n)
+	}
+}
+
+func TestItemHistoryEmptyStaysOnDetail(t *testing.T) {
+	m := populatedModel()
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("h"))
+	if m.view != viewItemDetail {
+		t.Fatalf("view = %v, want viewItemDetail when there is no history", m.view)
+	}
+	if !strings.Contains(m.statusMsg, "No saves") {
+		t.Fatalf("statusMsg = %q", m.statusMsg)
+	}
+}
+
+func TestItemHistoryRestoreOpensEditor(t *testing.T) {
+	m := populatedModel()
+	m.recordSave(userItem("1", "alice"), userItem("1", "alicia"))
+	m.view = viewItemDetail
+
+	m = drive(m, keyRunes("h"))
+	if m.view != viewItemHistory || m.historyIdx != 1 {
+		t.Fatalf("view = %v idx = %d, want history at latest", m.view, m.historyIdx)
+	}
+	if out := m.View(); !strings.Contains(out, "(original)") {
+		t.Fatal("history view should label the original version")
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyUp})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewEditItem {
+		t.Fatalf("view = %v, want viewEditItem after restore", m.view)
+	}
+	if v := m.itemEditor.Value(); !strings.Contains(v, `"alice"`) {
+		t.Fatalf("editor should hold the original version, got %s", v)
+	}
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// partitionTopKeys is how many keys each list of the partition report shows
+const partitionTopKeys = 10
+
+// partitionGroups is how many key parts p cycles through grouping by
+const partitionGroups = 2
+
+// partitionBarWidth is the chart's longest bar in cells
+const partitionBarWidth = 30
+
+// openPartitions reports how the loaded items spread over partition keys
+func (m *Model) openPartitions() {
+	if m.tableInfo == nil {
+		m.statusMsg = "✗ Table schema not loaded yet"
+		return
+	}
+	if len(m.items) == 0 {
+		m.statusMsg = "✗ No items loaded: S samples the table"
+		return
+	}
+	m.partitionOffset = 0
+	m.view = viewPartitions
+}
+
+func (m *Model) updatePartitions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.view = viewTableData
+	case "c":
+		m.partitionChart = !m.partitionChart
+		m.partitionOffset = 0
+	case "p":
+		m.partitionGroup = (m.partitionGroup + 1) % (partitionGroups + 1)
+		m.partitionOffset = 0
+	case "s":
+		m.partitionBySize = !m.partitionBySize
+	case "down", "j":
+		if m.partitionChart && m.partitionOffset < len(m.keyDistribution().Keys)-1 {
+			m.partitionOffset++
+		}
+	case "up", "k":
+		m.partitionOffset = max(m.partitionOffset-1, 0)
+	}
+	return m, nil
+}
+
+// keyDistribution is the loaded items by partition key, grouped as p set
+func (m Model) keyDistribution() models.Distribution {
+	var group func(string) string
+	if parts := m.partitionGroup; parts > 0 {
+		group = func(key string) string { return models.KeyPrefix(key, parts) }
+	}
+	return models.KeyDistribution(m.items, m.tableInfo.PartitionKey, group)
+}
+
+func (m Model) viewPartitions() string {
+	pk := m.tableInfo.PartitionKey
+	d := m.keyDistribution()
+
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("🔥 Partition keys: " + m.currentTable))
+	b.WriteString("\n\n")
+	source := "loaded"
+	if m.lastKey != nil {
+		source = "loaded so far (S samples the whole table)"
+	}
+	grouping := "values"
+	if m.partitionGroup > 0 {
+		grouping = fmt.Sprintf("prefixes (first %d part(s))", m.partitionGroup)
+	}
+	b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("%d items %s │ %d distinct %s %s │ %s",
+		d.Items, source, len(d.Keys), pk, grouping, formatBytes(int64(d.Bytes)))))
+	if d.Missing > 0 {
+		b.WriteString("\n" + ui.HelpStyle.Render(fmt.Sprintf("%d items without %s left out", d.Missing, pk)))
+	}
+	b.WriteString("\n")
+
+	var hot int
+	for _, k := range d.Keys {
+		if d.Hot(k) {
+			hot++
+		}
+	}
+	if hot > 0 {
+		b.WriteString("\n" + ui.WarningStyle.Render(fmt.Sprintf("⚠ %d likely hot partition key(s): they hold far more than their share of the items read", hot)))
+	} else if len(d.Keys) > 0 {
+		b.WriteString("\n" + ui.SuccessStyle.Render("✓ No key stands out: items are spread evenly"))
+	}
+	b.WriteString("\n\n")
+
+	help := []ui.KeyBinding{{Key: "c", Desc: "Chart"}, {Key: "p", Desc: "Group by prefix"}}
+	if m.partitionChart {
+		b.WriteString(m.viewPartitionChart(d))
+		help = []ui.KeyBinding{{Key: "c", Desc: "Top keys"}, {Key: "p", Desc: "Group by prefix"}, {Key: "s", Desc: "Count/Size"}, {Key: "↑/↓", Desc: "Scroll"}}
+	} else {
+		b.WriteString(m.viewPartitionReport(d))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(ui.HelpStyle.Render("A partition serves at most 3,000 reads and 1,000 writes a second. Keys holding far more items than the rest usually take far more traffic too."))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp(append(help, ui.KeyBinding{Key: "q/Esc", Desc: "Back"})))
+	return b.String()
+}
+
+// viewPartitionReport lists the top keys by item count and by size
+func (m Model) viewPartitionReport(d models.Distribution) string {
+	var b strings.Builder
+	b.WriteString(ui.KeyStyle.Render("Top keys by item count") + "\n")
+	b.WriteString(m.viewKeyStats(d, d.Keys, func(k models.KeyStat) string {
+		return fmt.Sprintf("%6d items %5.1f%%", k.Items, share(k.Items, d.Items))
+	}))
+	b.WriteString("\n\n" + ui.KeyStyle.Render("Top keys by size") + "\n")
+	b.WriteString(m.viewKeyStats(d, d.BySize(), func(k models.KeyStat) string {
+		return fmt.Sprintf("%10s %5.1f%%", formatBytes(int64(k.Bytes)), share(k.Bytes, d.Bytes))
+	}))
+	return b.String()
+}
+
+// viewPartitionChart draws every key as a bar scaled to the largest, by
+// item count or by size
+func (m Model) viewPartitionChart(d models.Distribution) string {
+	keys, value := d.Keys, func(k models.KeyStat) int { return k.Items }
+	label, total := func(k models.KeyStat) string { return fmt.Sprintf("%6d", k.Items) }, d.Items
+	if m.partitionBySize {
+		keys, value = d.BySize(), func(k models.KeyStat) int { return k.Bytes }
+		label, total = func(k models.KeyStat) string { return fmt.Sprintf("%10s", formatBytes(int64(k.Bytes))) }, d.Bytes
+	}
+	if len(keys) == 0 {
+		return ui.HelpStyle.Render("No keys to chart")
+	}
+
+	rows := max(m.height-16, 5)
+	offset := min(m.partitionOffset, max(len(keys)-rows, 0))
+	top := value(keys[0])
+	keyWidth := max(m.width-partitionBarWidth-30, 16)
+	lines := make([]string, 0, rows+1)
+	for _, k := range keys[offset:min(offset+rows, len(keys))] {
+		n := 0
+		if top > 0 {
+			n = max(value(k)*partitionBarWidth/top, 1)
+		}
+		line := fmt.Sprintf("  %-*s %s %s %5.1f%%", keyWidth, ui.Truncate(k.Key, keyWidth),
+			strings.Repeat("█", n)+strings.Repeat("░", partitionBarWidth-n), label(k), share(value(k), total))
+		if d.Hot(k) {
+			line = ui.WarningStyle.Render(line + " 🔥")
+		}
+		lines = append(lines, line)
+	}
+	if len(keys) > rows {
+		lines = append(lines, ui.HelpStyle.Render(fmt.Sprintf("  %d-%d of %d keys", offset+1, min(offset+rows, len(keys)), len(keys))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// viewKeyStats lists the first partitionTopKeys of keys, flagging hot ones
+func (m Model) viewKeyStats(d models.Distribution, keys []models.KeyStat, stat func(models.KeyStat) string) string {
+	keyWidth := max(m.width-40, 20)
+	lines := make([]string, 0, partitionTopKeys)
+	for _, k := range keys[:min(len(keys), partitionTopKeys)] {
+		line := fmt.Sprintf("  %-*s %s", keyWidth, ui.Truncate(k.Key, keyWidth), stat(k))
+		if d.Hot(k) {
+			line = ui.WarningStyle.Render(line + "  🔥 hot")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// share is part's percentage of total
+func share(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestPartitionReportFlagsSkew(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	for i := range 30 {
+		m.items = append(m.items, map[string]types.AttributeValue{
+			"id":   &types.AttributeValueMemberS{Value: "1"},
+			"name": &types.AttributeValueMemberS{Value: fmt.Sprint("copy", i)},
+		})
+	}
+	for i := 3; i < 12; i++ {
+		m.items = append(m.items, map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: fmt.Sprint(i)}})
+	}
+
+	m = drive(m, keyRunes("P"))
+	if m.view != viewPartitions {
+		t.Fatalf("view %d, want the partition report", m.view)
+	}
+	out := m.View()
+	for _, want := range []string{"11 distinct id values", "1 likely hot", "🔥 hot", "Top keys by size"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report lacks %q:\n%s", want, out)
+		}
+	}
+
+	m = drive(m, keyRunes("q"))
+	if m.view != viewTableData {
+		t.Errorf("view %d: q should go back to the table", m.view)
+	}
+}
+
+func TestPartitionReportNeedsItems(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.items = nil
+	m = drive(m, keyRunes("P"))
+	if m.view == viewPartitions || !strings.Contains(m.statusMsg, "S samples") {
+		t.Errorf("view %d, status %q", m.view, m.statusMsg)
+	}
+}
+
+func TestPartitionChartGroupsByPrefix(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.items = nil
+	for _, id := range []string{"USER#1", "USER#2", "USER#3", "ORDER#1"} {
+		m.items = append(m.items, map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}})
+	}
+
+	m = drive(m, keyRunes("P"))
+	m = drive(m, keyRunes("c"))
+	if out := m.View(); !strings.Contains(out, "USER#1") || !strings.Contains(out, "█") {
+		t.Fatalf("chart should bar each key:\n%s", out)
+	}
+	m = drive(m, keyRunes("p"))
+	out := m.View()
+	if !strings.Contains(out, "2 distinct id prefixes") || !strings.Contains(out, "75.0%") || strings.Contains(out, "USER#1") {
+		t.Errorf("p should group keys by their first part:\n%s", out)
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// encodedAttributes names the attributes of item holding base64 or
+// compressed text, with the layers b would take off them
+func encodedAttributes(item map[string]types.AttributeValue) []string {
+	var out []string
+	for _, name := range slices.Sorted(maps.Keys(item)) {
+		if _, layers, ok := models.DecodePayload(item[name]); ok {
+			out = append(out, fmt.Sprintf("%s (%s)", name, layers))
+		}
+	}
+	return out
+}
+
+// toggleDecode switches the item view between the stored and the decoded
+// form of encoded attributes
+func (m *Model) toggleDecode() {
+	if !m.decodePayloads && len(encodedAttributes(m.selectedItem)) == 0 {
+		m.statusMsg = "No base64 or compressed attributes in this item"
+		return
+	}
+	m.decodePayloads = !m.decodePayloads
+	m.prepareItemView()
+	if m.decodePayloads {
+		m.statusMsg = "Showing encoded attributes decoded"
+	} else {
+		m.statusMsg = "Showing attributes as stored"
+	}
+}
+
+// withDecoded shows encoded attributes as their text, under names saying
+// how they were stored, while b is on. Like decrypted values they are for
+// reading only.
+func (m *Model) withDecoded(attrs map[string]interface{}) map[string]interface{} {
+	if !m.decodePayloads {
+		return attrs
+	}
+	for name, av := range m.selectedItem {
+		if _, shown := attrs[name]; !shown {
+			continue // decrypted
+		}
+		if text, layers, ok := models.DecodePayload(av); ok {
+			delete(attrs, name)
+			attrs[name+" (decoded "+layers+")"] = text
+		}
+	}
+	return attrs
+}
+
+// itemNotes are the lines under the item view's help saying what the view
+// can reveal, or is revealing
+func (m Model) itemNotes() string {
+	var b strings.Builder
+	if m.decrypted != nil {
+		b.WriteString(ui.WarningStyle.Render("🔓 Decrypted values are shown only: e edits and y copies the stored ciphertext") + "\n")
+	} else if enc := encryptedAttributes(m.selectedItem); len(enc) > 0 {
+		b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("🔒 Encrypted: %s • D decrypts", strings.Join(enc, ", "))) + "\n")
+	}
+	if enc := encodedAttributes(m.selectedItem); len(enc) > 0 {
+		verb := "b decodes"
+		if m.decodePayloads {
+			verb = "b shows them as stored"
+		}
+		b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("📦 Encoded: %s • %s", strings.Join(enc, ", "), verb)) + "\n")
+	}
+	if names := models.JSONStringAttributes(m.selectedItem); len(names) > 0 {
+		verb := "J parses them"
+		if m.expandJSONStrings {
+			verb = "shown parsed; e edits them nested • J shows them as stored"
+		}
+		b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("{ } JSON in strings: %s • %s", strings.Join(names, ", "), verb)) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestDecodeShowsCompressedPayloads(t *testing.T) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte("hello from the payload"))
+	w.Close()
+
+	m := populatedModel()
+	m.selectedItem = map[string]types.AttributeValue{
+		"id":      &types.AttributeValueMemberS{Value: "1"},
+		"payload": &types.AttributeValueMemberS{Value: base64.StdEncoding.EncodeToString(buf.Bytes())},
+	}
+	m.prepareItemView()
+	m.view = viewItemDetail
+	if out := m.View(); !strings.Contains(out, "payload (base64+gzip)") || strings.Contains(out, "hello from the payload") {
+		t.Fatalf("the encoded attribute should be pointed out, not decoded yet:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("b"))
+	if out := m.View(); !strings.Contains(out, "payload (decoded base64+gzip)") || !strings.Contains(out, "hello from the payload") {
+		t.Errorf("b should show the decoded text:\n%s", out)
+	}
+	if _, ok := m.selectedItem["payload"].(*types.AttributeValueMemberS); !ok {
+		t.Error("decoding is for display; the item keeps its stored value")
+	}
+}
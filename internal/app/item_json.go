@@ -0,0 +1,69 @@
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// toggleJSONStrings switches the item view between showing serialized JSON
+// in string attributes as text and as the structure it holds
+func (m *Model) toggleJSONStrings() {
+	if !m.expandJSONStrings && len(models.JSONStringAttributes(m.selectedItem)) == 0 {
+		m.statusMsg = "No JSON in this item's strings"
+		return
+	}
+	m.expandJSONStrings = !m.expandJSONStrings
+	m.prepareItemView()
+	if m.expandJSONStrings {
+		m.statusMsg = "Showing JSON strings parsed; e edits them nested and saves them as strings"
+	} else {
+		m.statusMsg = "Showing JSON strings as stored"
+	}
+}
+
+func (m *Model) withJSONStrings(attrs map[string]interface{}) map[string]interface{} {
+	if !m.expandJSONStrings {
+		return attrs
+	}
+	for _, name := range models.JSONStringAttributes(m.selectedItem) {
+		if _, shown := attrs[name]; !shown {
+			continue
+		}
+		raw, _ := models.JSONString(m.selectedItem[name])
+		var v interface{}
+		if json.Unmarshal(raw, &v) == nil {
+			attrs[name] = v
+		}
+	}
+	return attrs
+}
+
+// editItemJSON is the open item as the editor starts it: with its JSON
+// strings nested when J shows them so, remembering which to serialize back
+func (m *Model) editItemJSON() string {
+	m.editorJSONStrings = nil
+	if m.expandJSONStrings {
+		names := models.JSONStringAttributes(m.selectedItem)
+		if text, err := models.ItemToJSONExpanded(m.selectedItem, names); err == nil {
+			m.editorJSONStrings = names
+			return text
+		}
+	}
+	jsonStr, _ := models.ItemToJSON(m.selectedItem, true)
+	return jsonStr
+}
+
+// editorText is the editor's item as it will be stored, with nested JSON
+// strings serialized back. Text that doesn't parse is returned as typed, for
+// the save to report.
+func (m *Model) editorText() string {
+	text := m.itemEditor.Value()
+	if len(m.editorJSONStrings) == 0 {
+		return text
+	}
+	if collapsed, err := models.CollapseJSONStrings(text, m.selectedItem, m.editorJSONStrings); err == nil {
+		return collapsed
+	}
+	return text
+}
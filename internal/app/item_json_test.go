@@ -0,0 +1,32 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestJSONStringsParseAndSaveBackAsStrings(t *testing.T) {
+	m := populatedModel()
+	m.selectedItem = map[string]types.AttributeValue{
+		"id":       &types.AttributeValueMemberS{Value: "1"},
+		"settings": &types.AttributeValueMemberS{Value: `{"theme":"dark"}`},
+	}
+	m.prepareItemView()
+	m.view = viewItemDetail
+
+	m = drive(m, keyRunes("J"))
+	if out := m.View(); !strings.Contains(out, `"theme": "dark"`) {
+		t.Fatalf("J should render the string's JSON nested:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("e"))
+	if !strings.Contains(m.itemEditor.Value(), `"theme": "dark"`) {
+		t.Fatalf("the editor should start with settings nested:\n%s", m.itemEditor.Value())
+	}
+	m.itemEditor.SetValue(strings.Replace(m.itemEditor.Value(), `"dark"`, `"light"`, 1))
+	if got := m.editorText(); !strings.Contains(got, `"settings": "{\"theme\":\"light\"}"`) {
+		t.Errorf("settings should be saved back as a string:\n%s", got)
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// checkItemKey catches the key mistakes PutItem would answer with an opaque
+// ValidationException: a missing or empty partition or sort key, or one of
+// the wrong type. A table whose schema hasn't loaded passes.
+func checkItemKey(info *dynamo.TableInfo, item map[string]types.AttributeValue) error {
+	if info == nil {
+		return nil
+	}
+	for _, k := range []struct{ role, name, typ string }{
+		{"partition key", info.PartitionKey, info.PartitionType},
+		{"sort key", info.SortKey, info.SortKeyType},
+	} {
+		if k.name == "" {
+			continue
+		}
+		v, ok := item[k.name]
+		if !ok {
+			return fmt.Errorf("the item needs the %s %s (%s)", k.role, k.name, typeName(k.typ))
+		}
+		if got := models.GetAttributeType(v); got != k.typ {
+			return fmt.Errorf("the %s %s must be %s, not %s", k.role, k.name, typeName(k.typ), typeName(got))
+		}
+		if s, ok := v.(*types.AttributeValueMemberS); ok && s.Value == "" {
+			return fmt.Errorf("the %s %s can't be an empty string", k.role, k.name)
+		}
+	}
+	return nil
+}
+
+func typeName(t string) string {
+	switch t {
+	case "S":
+		return "a string"
+	case "N":
+		return "a number"
+	case "B":
+		return "binary"
+	case "NULL":
+		return "null"
+	case "BOOL":
+		return "a boolean"
+	case "L":
+		return "a list"
+	case "M":
+		return "a map"
+	}
+	return t
+}
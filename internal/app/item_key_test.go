@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+func TestCheckItemKey(t *testing.T) {
+	info := &dynamo.TableInfo{PartitionKey: "pk", PartitionType: "S", SortKey: "sk", SortKeyType: "N"}
+	for text, want := range map[string]string{
+		`{"pk": "a", "sk": 1}`:   "",
+		`{"sk": 1}`:              "the item needs the partition key pk (a string)",
+		`{"pk": "", "sk": 1}`:    "the partition key pk can't be an empty string",
+		`{"pk": "a"}`:            "the item needs the sort key sk (a number)",
+		`{"pk": "a", "sk": "1"}`: "the sort key sk must be a number, not a string",
+	} {
+		item, err := models.JSONToItem(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if err := checkItemKey(info, item); err != nil {
+			got = err.Error()
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestSaveWithoutTheKeyStaysInTheEditor(t *testing.T) {
+	m := populatedModel()
+	m.view = viewCreateItem
+	m.itemEditor.SetValue(`{"email": "a@b.c"}`)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.view != viewCreateItem || m.statusMsg != "✗ the item needs the partition key id (a string)" {
+		t.Errorf("view %d, status %q", m.view, m.statusMsg)
+	}
+}
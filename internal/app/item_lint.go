@@ -0,0 +1,32 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// lintItem warns about the editor's item before it is saved (see
+// models.LintItem). The warnings show once: saving the same text again
+// goes ahead, so they never stand in the way of a deliberate choice.
+func (m *Model) lintItem() bool {
+	text := m.editorText()
+	if m.lintWarnings != nil && text == m.lintedText {
+		m.lintWarnings, m.lintedText = nil, ""
+		return true
+	}
+	var original map[string]types.AttributeValue
+	if m.view == viewEditItem {
+		original = m.selectedItem
+	}
+	warnings, err := models.LintItem(text, original)
+	if err != nil || len(warnings) == 0 {
+		m.lintWarnings, m.lintedText = nil, ""
+		return true
+	}
+	m.lintWarnings, m.lintedText = warnings, text
+	m.statusMsg = fmt.Sprintf("⚠ %d warning(s) about this item: save again to write it anyway", len(warnings))
+	return false
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLintWarningsShowOnceBeforeSaving(t *testing.T) {
+	m := populatedModel()
+	m.view = viewEditItem
+	m.itemEditor.SetValue(`{"id": "1", "name": "alice", "status": "open"}`)
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.view != viewEditItem || !strings.Contains(m.View(), "status is a reserved word") {
+		t.Fatalf("view %d: the first save should stop on the warning", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.view != viewConfirmSave {
+		t.Errorf("view %d: saving the same text again should go ahead", m.view)
+	}
+}
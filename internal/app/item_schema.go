@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// checkItemSchema validates the editor's item against the JSON Schema the
+// config associates with the open table, keeping the violations for the
+// editor to list. It reports whether the item may be saved; a schema that
+// can't be read blocks the save too, rather than letting anything through.
+func (m *Model) checkItemSchema() bool {
+	m.schemaViolations = nil
+	path, ok := m.config.Schemas[m.currentTable]
+	if !ok {
+		return true
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("✗ Schema for %s: %v", m.currentTable, err)
+		return false
+	}
+	schema, err := models.ParseSchema(data)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("✗ Schema for %s (%s): %v", m.currentTable, path, err)
+		return false
+	}
+	violations, err := schema.ValidateJSON(m.editorText())
+	if err != nil {
+		m.statusMsg = "Invalid JSON: " + err.Error()
+		return false
+	}
+	if len(violations) == 0 {
+		return true
+	}
+	for _, v := range violations {
+		m.schemaViolations = append(m.schemaViolations, v.String())
+	}
+	m.statusMsg = fmt.Sprintf("✗ %d schema violation(s): fix them to save", len(violations))
+	return false
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSaveValidatesAgainstTheTableSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	schema := `{"type":"object","required":["id","email"],"properties":{"email":{"type":"string","pattern":"@"}}}`
+	if err := os.WriteFile(path, []byte(schema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := populatedModel()
+	m.config.Schemas = map[string]string{"Users": path}
+	m.view = viewEditItem
+	m.itemEditor.SetValue(`{"id": "1", "email": "nobody"}`)
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.view != viewEditItem {
+		t.Fatalf("view %d: an item breaking the schema should stay in the editor", m.view)
+	}
+	if out := m.View(); !strings.Contains(out, "email: does not match @") {
+		t.Errorf("the editor should list the violation:\n%s", out)
+	}
+
+	m.itemEditor.SetValue(`{"id": "1", "email": "a@b.c"}`)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.view != viewConfirmSave || len(m.schemaViolations) != 0 {
+		t.Errorf("view %d, violations %v: a valid item should go on to be saved", m.view, m.schemaViolations)
+	}
+}
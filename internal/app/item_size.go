@@ -0,0 +1,35 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// sizeMeterWidth is the bar's width in cells
+const sizeMeterWidth = 20
+
+// sizeMeter shows the edited item's size against DynamoDB's 400 KB limit,
+// turning to a warning from 75% and an error from 90%
+func (m Model) sizeMeter() string {
+	item, err := models.JSONToItem(m.editorText())
+	if err != nil {
+		return ui.HelpStyle.Render("Size: - (not valid JSON yet)")
+	}
+	size := models.ItemSize(item)
+	ratio := float64(size) / models.MaxItemSize
+	filled := min(int(ratio*sizeMeterWidth+0.5), sizeMeterWidth)
+	text := fmt.Sprintf("Size: %s %s of 400 KB (%.1f%%)",
+		strings.Repeat("█", filled)+strings.Repeat("░", sizeMeterWidth-filled), formatBytes(int64(size)), ratio*100)
+	switch {
+	case ratio > 1:
+		return ui.ErrorStyle.Render(text + " - too large to save")
+	case ratio >= 0.9:
+		return ui.ErrorStyle.Render(text + " - close to the limit")
+	case ratio >= 0.75:
+		return ui.WarningStyle.Render(text)
+	}
+	return ui.HelpStyle.Render(text)
+}
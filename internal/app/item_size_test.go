@@ -0,0 +1,26 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEditorShowsTheItemSize(t *testing.T) {
+	m := populatedModel()
+	m.view = viewCreateItem
+	m.itemEditor.SetValue(`{"id": "1"}`)
+	if out := m.View(); !strings.Contains(out, "3 bytes of 400 KB (0.0%)") {
+		t.Errorf("the meter should count id plus its value:\n%s", out)
+	}
+
+	m.itemEditor.SetValue(`{"id": "1", "blob": "` + strings.Repeat("x", 410*1024) + `"}`)
+	if meter := m.sizeMeter(); !strings.Contains(meter, "too large to save") {
+		t.Errorf("meter = %q", meter)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.view != viewCreateItem || !strings.Contains(m.statusMsg, "over DynamoDB's 400 KB limit") {
+		t.Errorf("view %d, status %q: an oversized item shouldn't be sent", m.view, m.statusMsg)
+	}
+}
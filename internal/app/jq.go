@@ -0,0 +1,215 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/itchyny/gojq"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// jqMaxResults caps the output kept from one run, so `.[]` over large lists
+// can't grow without bound
+const jqMaxResults = 100_000
+
+// openJQ post-processes the loaded items with a jq expression. The last
+// expression and its output are kept while browsing the same table.
+func (m *Model) openJQ() tea.Cmd {
+	if m.jqInput.Placeholder == "" {
+		in := textinput.New()
+		in.Placeholder = `select(.age > 30) | {id, name}`
+		in.CharLimit = 1024
+		in.Width = 60
+		m.jqInput = in
+	}
+	if m.jqFor != m.currentTable {
+		m.jqFor = m.currentTable
+		m.jqInput.SetValue("")
+		m.jqLines, m.jqOffset = nil, 0
+	}
+	m.view = viewJQ
+	return m.jqInput.Focus()
+}
+
+func (m *Model) updateJQ(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := max(m.jqHeight()-1, 1)
+	switch msg.String() {
+	case "esc":
+		m.jqInput.Blur()
+		m.view = viewTableData
+		return m, nil
+	case "enter":
+		m.runJQ()
+		return m, nil
+	case "tab":
+		m.jqSlurp = !m.jqSlurp
+		m.runJQ()
+		return m, nil
+	case "ctrl+s":
+		m.saveJQ()
+		return m, nil
+	case "up":
+		m.jqOffset = max(m.jqOffset-1, 0)
+		return m, nil
+	case "down":
+		m.jqOffset = max(min(m.jqOffset+1, len(m.jqLines)-page), 0)
+		return m, nil
+	case "pgup":
+		m.jqOffset = max(m.jqOffset-page, 0)
+		return m, nil
+	case "pgdown":
+		m.jqOffset = max(min(m.jqOffset+page, len(m.jqLines)-page), 0)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.jqInput, cmd = m.jqInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) runJQ() {
+	expr := strings.TrimSpace(m.jqInput.Value())
+	if expr == "" {
+		expr = "."
+	}
+	lines, err := evalJQ(expr, m.items, m.jqSlurp)
+	m.jqLines, m.jqOffset = lines, 0
+	if err != nil {
+		m.statusMsg = "✗ jq: " + err.Error()
+		return
+	}
+	m.statusMsg = fmt.Sprintf("%d results from %d loaded items", len(lines), len(m.items))
+}
+
+// evalJQ runs expr over each item, as `jq -c` does over a stream of JSON
+// lines, or once over the array of all of them with slurp, as `jq -s`.
+// Every output becomes one line of compact JSON; output produced before an
+// error is returned with it.
+func evalJQ(expr string, items []map[string]types.AttributeValue, slurp bool) ([]string, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]any, len(items))
+	for i, item := range items {
+		// Through JSON so numbers stay exact (json.Number) and sets become
+		// plain arrays, the types gojq works with
+		text, err := models.ItemToJSON(item, false)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.UseNumber()
+		if err := dec.Decode(&inputs[i]); err != nil {
+			return nil, err
+		}
+	}
+	if slurp {
+		inputs = []any{inputs}
+	}
+
+	var lines []string
+	for i, input := range inputs {
+		iter := code.Run(input)
+		for {
+			v, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, ok := v.(error); ok {
+				var halt *gojq.HaltError
+				if errors.As(err, &halt) && halt.Value() == nil {
+					return lines, nil // halt stops without an error
+				}
+				if slurp {
+					return lines, err
+				}
+				return lines, fmt.Errorf("item %d: %w", i+1, err)
+			}
+			out, err := gojq.Marshal(v)
+			if err != nil {
+				return lines, err
+			}
+			lines = append(lines, string(out))
+			if len(lines) == jqMaxResults {
+				return lines, fmt.Errorf("stopped at %d results", jqMaxResults)
+			}
+		}
+	}
+	return lines, nil
+}
+
+// saveJQ writes the output as JSON lines next to the table's exports
+func (m *Model) saveJQ() {
+	if len(m.jqLines) == 0 {
+		m.statusMsg = "✗ Nothing to save; Enter runs the expression"
+		return
+	}
+	path := filepath.Join(m.exportDir(), m.currentTable+".jq.jsonl")
+	var buf bytes.Buffer
+	for _, line := range m.jqLines {
+		buf.WriteString(line + "\n")
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		m.statusMsg = "✗ Failed to save: " + err.Error()
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✓ Saved %d results to %s", len(m.jqLines), path)
+}
+
+func (m Model) jqHeight() int {
+	return max(m.height-10, 3)
+}
+
+func (m Model) viewJQ() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("jq: " + m.currentTable))
+	b.WriteString("\n\n")
+	mode := "each item"
+	if m.jqSlurp {
+		mode = "all items as one array (-s)"
+	}
+	b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("Runs over %s of the %d loaded items", mode, len(m.items))))
+	b.WriteString("\n")
+	b.WriteString(ui.InputFocusedStyle.Render(m.jqInput.View()))
+	b.WriteString("\n\n")
+
+	height := m.jqHeight()
+	end := min(m.jqOffset+height, len(m.jqLines))
+	for _, line := range m.jqLines[m.jqOffset:end] {
+		b.WriteString(ui.ItemStyle.Render(ui.Truncate(line, max(m.width-4, 20))))
+		b.WriteString("\n")
+	}
+	for i := end - m.jqOffset; i < height; i++ {
+		b.WriteString("\n")
+	}
+
+	status := m.statusMsg
+	if len(m.jqLines) > height {
+		status += fmt.Sprintf(" | lines %d-%d of %d", m.jqOffset+1, end, len(m.jqLines))
+	}
+	b.WriteString(ui.StatusBarStyle.Render(status))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "Enter", Desc: "Run"},
+		{Key: "Tab", Desc: "Each/All"},
+		{Key: "↑↓/PgUp/PgDn", Desc: "Scroll"},
+		{Key: "Ctrl+S", Desc: "Save"},
+		{Key: "Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEvalJQ(t *testing.T) {
+	items := []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "1"}, "n": &types.AttributeValueMemberN{Value: "9007199254740993"}},
+		{"id": &types.AttributeValueMemberS{Value: "2"}, "n": &types.AttributeValueMemberN{Value: "1"}, "tags": &types.AttributeValueMemberSS{Value: []string{"a"}}},
+	}
+	lines, err := evalJQ(`select(.n > 1) | {id, n}`, items, false)
+	if err != nil || len(lines) != 1 || lines[0] != `{"id":"1","n":9007199254740993}` {
+		t.Errorf("each item: %q, %v", lines, err)
+	}
+	lines, err = evalJQ(`map(.tags // [] | length) | add`, items, true)
+	if err != nil || len(lines) != 1 || lines[0] != "1" {
+		t.Errorf("slurp: %q, %v", lines, err)
+	}
+	if _, err := evalJQ(`.id |`, items, false); err == nil {
+		t.Error("a syntax error should be reported")
+	}
+	if lines, err := evalJQ(`.id | tonumber | if . == 2 then error("bad") else . end`, items, false); err == nil || !strings.Contains(err.Error(), "item 2") || len(lines) != 1 {
+		t.Errorf("runtime error: %q, %v", lines, err)
+	}
+}
+
+func TestJQViewRunsAndSaves(t *testing.T) {
+	m := populatedModel()
+	m.config.ExportDir = t.TempDir()
+	m.view = viewTableData
+	m = drive(m, keyRunes("J"))
+	if m.view != viewJQ {
+		t.Fatalf("view = %d", m.view)
+	}
+	m.jqInput.SetValue(".name")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.jqLines) != 2 || m.statusMsg != "2 results from 2 loaded items" {
+		t.Fatalf("lines %q, status %q", m.jqLines, m.statusMsg)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	data, err := os.ReadFile(filepath.Join(m.config.ExportDir, "Users.jq.jsonl"))
+	if err != nil || string(data) != "\"alice\"\n\"bob\"\n" {
+		t.Fatalf("saved %q, %v", data, err)
+	}
+	if out := m.View(); !strings.Contains(out, `"alice"`) {
+		t.Error("the output should be shown")
+	}
+}
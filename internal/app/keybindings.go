@@ -0,0 +1,61 @@
+package app
+
+// Values of the keybindings config, layering another editor's keys over
+// the built-in ones
+const (
+	keybindingsVim   = "vim"
+	keybindingsEmacs = "emacs"
+)
+
+// jumpTo selects the first or last row of a list view, or scrolls an item
+// to its top or bottom
+func (m *Model) jumpTo(top bool) {
+	switch m.view {
+	case viewTables:
+		l := &m.tableList
+		if top || len(l.Items) == 0 {
+			l.Selected, l.Offset = 0, 0
+			return
+		}
+		l.Selected = len(l.Items) - 1
+		l.Offset = max(0, l.Selected-(l.Height-2)+1)
+	case viewTableData:
+		if top {
+			m.dataTable.GoToRow(0)
+		} else {
+			m.dataTable.GoToRow(len(m.dataTable.Rows) - 1)
+		}
+	case viewItemDetail:
+		if top {
+			m.itemViewport.GotoTop()
+		} else {
+			m.itemViewport.GotoBottom()
+		}
+	}
+}
+
+// moveHalfPage moves half a screen down (dir 1) or up (dir -1)
+func (m *Model) moveHalfPage(dir int) {
+	switch m.view {
+	case viewTables:
+		for range max((m.tableList.Height-2)/2, 1) {
+			if dir > 0 {
+				m.tableList.MoveDown()
+			} else {
+				m.tableList.MoveUp()
+			}
+		}
+	case viewTableData:
+		if len(m.dataTable.Rows) == 0 {
+			return
+		}
+		n := max((m.dataTable.Height-4)/2, 1)
+		m.dataTable.GoToRow(min(max(m.dataTable.SelectedRow+dir*n, 0), len(m.dataTable.Rows)-1))
+	case viewItemDetail:
+		if dir > 0 {
+			m.itemViewport.HalfViewDown()
+		} else {
+			m.itemViewport.HalfViewUp()
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// latencyInterval is how often the endpoint is pinged; a variable so tests
+// need not wait
+var latencyInterval = 30 * time.Second
+
+// slowLatency is the round trip past which the status bar warns; a region
+// on another continent is usually well above it
+const slowLatency = 250 * time.Millisecond
+
+type (
+	latencyMsg struct {
+		seq     int
+		latency time.Duration
+		err     error
+	}
+	latencyTickMsg struct{ seq int }
+)
+
+// startPinging measures the new connection's latency now and every
+// latencyInterval. Each connection bumps pingSeq, which retires the pings
+// of the one before.
+func (m *Model) startPinging() tea.Cmd {
+	m.pingSeq++
+	m.latency, m.latencyErr = 0, nil
+	return m.ping()
+}
+
+func (m *Model) ping() tea.Cmd {
+	client, seq := m.client, m.pingSeq
+	if client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), latencyInterval)
+		defer cancel()
+		d, err := client.Ping(ctx)
+		return latencyMsg{seq, d, err}
+	}
+}
+
+func (m *Model) handleLatency(msg latencyMsg) tea.Cmd {
+	if msg.seq != m.pingSeq {
+		return nil
+	}
+	m.latency, m.latencyErr = msg.latency, msg.err
+	return tea.Tick(latencyInterval, func(time.Time) tea.Msg { return latencyTickMsg{msg.seq} })
+}
+
+// latencyStatus is the status bar's connection and round trip, "" before
+// the first ping
+func (m Model) latencyStatus() string {
+	where := m.selectedRegion
+	if m.config.Endpoint != "" {
+		where = m.config.Endpoint
+	}
+	switch {
+	case m.latencyErr != nil:
+		return ui.ErrorStyle.Render(" | 📶 " + where + " unreachable")
+	case m.latency == 0:
+		return ""
+	}
+	s := fmt.Sprintf(" | 📶 %s %dms", where, m.latency.Milliseconds())
+	if m.latency > slowLatency {
+		return ui.WarningStyle.Render(s + " (slow)")
+	}
+	return ui.HelpStyle.Render(s)
+}
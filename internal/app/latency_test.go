@@ -0,0 +1,41 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyShowsInStatusBar(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.selectedRegion = "ap-southeast-2"
+	m.pingSeq = 1
+
+	if _, cmd := m.Update(latencyMsg{seq: 1, latency: 42 * time.Millisecond}); cmd == nil {
+		t.Fatal("a ping result should schedule the next ping")
+	}
+	m = drive(m, latencyMsg{seq: 1, latency: 42 * time.Millisecond})
+	if !strings.Contains(m.View(), "📶 ap-southeast-2 42ms") {
+		t.Fatal("status bar should show region and round trip")
+	}
+
+	m = drive(m, latencyMsg{seq: 1, latency: 320 * time.Millisecond})
+	if !strings.Contains(m.View(), "320ms (slow)") {
+		t.Fatal("a slow round trip should be flagged")
+	}
+
+	m = drive(m, latencyMsg{seq: 1, err: errors.New("timeout")})
+	if !strings.Contains(m.View(), "unreachable") {
+		t.Fatal("a failed ping should say the endpoint is unreachable")
+	}
+
+	// Pings of an earlier connection are dropped
+	if _, cmd := m.Update(latencyMsg{seq: 0, latency: time.Millisecond}); cmd != nil {
+		t.Fatal("a stale ping must not keep pinging")
+	}
+	if _, cmd := m.Update(latencyTickMsg{seq: 0}); cmd != nil {
+		t.Fatal("a stale tick must not ping")
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// maxMacroDepth bounds macros replaying macros, so one replaying itself
+// stops
+const maxMacroDepth = 10
+
+// macroStepMsg presses a replay's next key
+type macroStepMsg struct{}
+
+// isMacroRegister reports whether key names a register, a to z as in vim
+func isMacroRegister(key string) bool {
+	return len(key) == 1 && key[0] >= 'a' && key[0] <= 'z'
+}
+
+// recordMacroKey keeps a key pressed while recording; the keys a replay
+// presses are the replay's, not the user's
+func (m *Model) recordMacroKey(key tea.KeyMsg) {
+	if m.macroRecording != "" {
+		m.macroKeys = append(m.macroKeys, key)
+	}
+}
+
+// updateMacroKey handles vim's q{register}, q, @{register} and @@ in the
+// list views, given the key pressed before. It reports whether it used key.
+func (m *Model) updateMacroKey(key, pending string) (bool, tea.Model, tea.Cmd) {
+	switch {
+	case pending == "q" && isMacroRegister(key):
+		m.macroRecording, m.macroKeys = key, nil
+		m.statusMsg = "recording @" + key
+		return true, m, nil
+	case pending == "@" && (isMacroRegister(key) || key == "@"):
+		if key == "@" {
+			key = m.lastMacro
+		}
+		m.replayMacro(key)
+		return true, m, nil
+	case key == "q" && m.macroRecording != "":
+		keys := m.macroKeys[:max(len(m.macroKeys)-1, 0)] // all but this q
+		if m.macros == nil {
+			m.macros = map[string][]tea.KeyMsg{}
+		}
+		m.macros[m.macroRecording] = keys
+		m.statusMsg = fmt.Sprintf("✓ Recorded %d keys into @%s", len(keys), m.macroRecording)
+		m.macroRecording, m.macroKeys = "", nil
+		return true, m, nil
+	case key == "q" || key == "@":
+		m.vimPending = key
+		return true, m, nil
+	}
+	return false, m, nil
+}
+
+// replayMacro queues a register's keys to be pressed as if typed, ahead of
+// what is left of a replay running
+func (m *Model) replayMacro(reg string) {
+	keys := m.macros[reg]
+	switch {
+	case reg == "":
+		m.statusMsg = "✗ No macro replayed yet"
+		return
+	case len(keys) == 0:
+		m.statusMsg = "✗ Nothing recorded in @" + reg
+		return
+	case m.macroReplays >= maxMacroDepth:
+		m.statusMsg = "✗ Macros replay each other too deep"
+		m.stopReplay()
+		return
+	}
+	m.lastMacro = reg
+	m.macroReplays++
+	m.macroQueue = append(slices.Clone(keys), m.macroQueue...)
+}
+
+// stepMacro presses the next key of a replay once nothing is loading, so a
+// key following a save lands on the table the save reloaded
+func (m *Model) stepMacro() tea.Cmd {
+	if m.macroStepping || m.loading {
+		return nil
+	}
+	if len(m.macroQueue) == 0 {
+		m.macroReplays = 0
+		return nil
+	}
+	m.macroStepping = true
+	return func() tea.Msg { return macroStepMsg{} }
+}
+
+// nextMacroKey is the key a macroStepMsg presses
+func (m *Model) nextMacroKey() (tea.KeyMsg, bool) {
+	m.macroStepping = false
+	if len(m.macroQueue) == 0 {
+		return tea.KeyMsg{}, false
+	}
+	key := m.macroQueue[0]
+	m.macroQueue = m.macroQueue[1:]
+	return key, true
+}
+
+// stopReplay drops what is left of a replay
+func (m *Model) stopReplay() {
+	m.macroQueue = nil
+}
+
+// stopReplayOnError stops a replay at the first key that fails, or whose
+// command does, so a replay across rows doesn't run on after an error
+func (m *Model) stopReplayOnError(prev string) {
+	if len(m.macroQueue) == 0 || m.statusMsg == prev || kindOf(m.statusMsg) != statusError {
+		return
+	}
+	m.statusMsg += fmt.Sprintf(" (@%s stopped, %d keys left)", m.lastMacro, len(m.macroQueue))
+	m.stopReplay()
+}
+
+// macroStatus is the status bar's note of a recording
+func (m Model) macroStatus() string {
+	if m.macroRecording == "" {
+		return ""
+	}
+	return ui.WarningStyle.Render(" | ⏺ recording @" + m.macroRecording)
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// toastTTL is how long a toast stays up. Toasts leave with the first redraw
+// after it, so returning no command keeps Update's commands the operations'
+// own; a variable so tests need not wait.
+var toastTTL = 5 * time.Second
+
+const (
+	maxToasts        = 3   // older toasts make way for new ones
+	maxNotifications = 200 // the history forgets the oldest past this
+	toastWidth       = 48
+)
+
+// notification is a status message a background operation left behind
+type notification struct {
+	at   time.Time
+	text string
+}
+
+// notify keeps a status message that a background operation set, since the
+// next one would overwrite it, and shows it as a toast. Progress messages,
+// the ones ending in an ellipsis, are superseded by their outcome and skipped.
+func (m *Model) notify(prev string) {
+	text := m.statusMsg
+	if text == prev || text == "" || strings.HasSuffix(text, "...") || strings.HasSuffix(text, "…") {
+		return
+	}
+	if n := len(m.notifications); n > 0 && m.notifications[n-1].text == text {
+		return
+	}
+	m.notifications = append(m.notifications, notification{at: time.Now(), text: text})
+	if over := len(m.notifications) - maxNotifications; over > 0 {
+		m.notifications = m.notifications[over:]
+		m.toastsFrom = max(m.toastsFrom-over, 0)
+	}
+}
+
+// toasts are the latest notifications younger than toastTTL, oldest first
+func (m Model) toasts() []notification {
+	now := time.Now()
+	from := max(m.toastsFrom, len(m.notifications)-maxToasts)
+	for from < len(m.notifications) && now.Sub(m.notifications[from].at) >= toastTTL {
+		from++
+	}
+	return m.notifications[from:]
+}
+
+type statusKind int
+
+const (
+	statusInfo statusKind = iota
+	statusSuccess
+	statusWarning
+	statusError
+)
+
+// kindOf classifies a status message by the mark it starts with
+func kindOf(text string) statusKind {
+	switch {
+	case strings.HasPrefix(text, "✗"), strings.HasPrefix(text, "Error"), strings.HasPrefix(text, "Connection failed"):
+		return statusError
+	case strings.HasPrefix(text, "✓"):
+		return statusSuccess
+	case strings.HasPrefix(text, "⚠"):
+		return statusWarning
+	}
+	return statusInfo
+}
+
+// notificationStyle colors a notification by its kind
+func notificationStyle(text string) lipgloss.Style {
+	switch kindOf(text) {
+	case statusError:
+		return ui.ErrorStyle
+	case statusSuccess:
+		return ui.SuccessStyle
+	case statusWarning:
+		return ui.WarningStyle
+	}
+	return ui.ItemStyle
+}
+
+// withToasts draws the toasts over the top right corner of a view, newest
+// first
+func (m Model) withToasts(view string) string {
+	toasts := m.toasts()
+	if len(toasts) == 0 || m.view == viewNotifications {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	for i := range min(len(toasts), len(lines)) {
+		t := toasts[len(toasts)-1-i] // newest on top
+		toast := ui.StatusBarStyle.Foreground(notificationStyle(t.text).GetForeground()).
+			Render(ansi.Truncate(t.text, toastWidth, "…"))
+		keep := max(m.width-lipgloss.Width(toast), 0)
+		line := ansi.Truncate(lines[i], keep, "")
+		lines[i] = line + strings.Repeat(" ", keep-ansi.StringWidth(line)) + toast
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) openNotifications() {
+	m.notifyReturn = m.view
+	m.notifyCursor = 0 // newest first
+	m.toastsFrom = len(m.notifications)
+	m.view = viewNotifications
+}
+
+func (m *Model) updateNotifications(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.notifications)
+	switch msg.String() {
+	case "esc", "q", "ctrl+y":
+		m.view = m.notifyReturn
+	case "up", "k":
+		m.notifyCursor = max(m.notifyCursor-1, 0)
+	case "down", "j":
+		m.notifyCursor = max(min(m.notifyCursor+1, n-1), 0)
+	case "home", "g":
+		m.notifyCursor = 0
+	case "end", "G":
+		m.notifyCursor = max(n-1, 0)
+	case "c":
+		m.notifications = nil
+		m.notifyCursor, m.toastsFrom = 0, 0
+	}
+	return m, nil
+}
+
+func (m Model) viewNotifications() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render(fmt.Sprintf("🔔 Notifications (%d)", len(m.notifications))))
+	b.WriteString("\n")
+	b.WriteString(ui.HelpStyle.Render("What background operations reported this session, newest first."))
+	b.WriteString("\n\n")
+
+	n := len(m.notifications)
+	if n == 0 {
+		b.WriteString(ui.HelpStyle.Render("Nothing yet."))
+		b.WriteString("\n")
+	}
+	visible := max(m.height-8, 3)
+	start := max(0, min(m.notifyCursor-visible/2, n-visible))
+	for i := start; i < n && i < start+visible; i++ {
+		note := m.notifications[n-1-i]
+		line := note.at.Format("15:04:05") + "  " + ansi.Truncate(note.text, max(m.width-16, 20), "…")
+		if i == m.notifyCursor {
+			b.WriteString(ui.SelectedStyle.Render("▸ " + line))
+		} else {
+			b.WriteString(notificationStyle(note.text).Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑↓", Desc: "Select"},
+		{Key: "c", Desc: "Clear"},
+		{Key: "Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
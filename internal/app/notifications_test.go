@@ -0,0 +1,53 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestBackgroundMessagesAreKept(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, errMsg{errors.New("throttled")})
+	m.statusMsg = "Loading next page..."
+	m = drive(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = drive(m, scanResultMsg{result: &dynamo.ScanResult{Count: 1}})
+	m = drive(m, keyRunes(">")) // "Page size: ..." answers a key, not kept
+
+	if len(m.notifications) != 2 || m.notifications[0].text != "Error: throttled" ||
+		!strings.HasPrefix(m.notifications[1].text, "Loaded 1 items") {
+		t.Fatalf("notifications = %+v", m.notifications)
+	}
+	if top := strings.Split(m.View(), "\n")[0]; !strings.Contains(top, "Loaded 1 items") {
+		t.Errorf("the latest should show as a toast: %q", top)
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlY})
+	if m.view != viewNotifications || !strings.Contains(m.View(), "Error: throttled") {
+		t.Fatalf("Ctrl+Y should list the history, view %d", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTableData || strings.Contains(strings.Split(m.View(), "\n")[0], "Loaded 1 items") {
+		t.Error("Esc should go back, with the toasts already seen gone")
+	}
+}
+
+func TestToastsExpire(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, errMsg{errors.New("throttled")})
+	if !strings.Contains(m.View(), "Error: throttled") {
+		t.Fatal("a new notification should toast")
+	}
+	old := toastTTL
+	toastTTL = 0
+	t.Cleanup(func() { toastTTL = old })
+	if len(m.toasts()) != 0 {
+		t.Error("an expired toast should be gone at the next redraw")
+	}
+}
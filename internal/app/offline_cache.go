@@ -0,0 +1,250 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// offlineDir holds one snapshot per connection and table: the last page
+// loaded, so the table can still be browsed without connectivity
+const (
+	offlineDir      = "offline"
+	offlineMaxItems = 1000
+)
+
+type offlineSnapshot struct {
+	Connection string            `json:"connection"`
+	Table      string            `json:"table"`
+	SavedAt    time.Time         `json:"saved_at"`
+	Info       *dynamo.TableInfo `json:"info,omitempty"`
+	Items      []json.RawMessage `json:"items"` // DynamoDB JSON
+	path       string
+}
+
+// onlineKeys are the keys of each view that need DynamoDB, which offline
+// mode refuses. Ctrl+R in the tables list reconnects instead.
+var onlineKeys = map[viewMode][]string{
+	viewTables:     {"ctrl+n", "ctrl+o", "ctrl+e", "tab"},
+	viewTableData:  {"ctrl+e", "n", "e", "d", "X", "f", "S", "R", "t", "g", "s", "x", "r", "ctrl+r", "M", "ctrl+g", "ctrl+t", "ctrl+w"},
+	viewItemDetail: {"e", "d"},
+}
+
+func offlinePath(name string) (string, error) {
+	dir, err := appDataPath(offlineDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// saveOfflineSnapshot keeps the page on screen for offline browsing. Scans
+// with a filter aren't the table's contents, so they are not kept.
+func (m *Model) saveOfflineSnapshot() {
+	if m.offline || m.filterExpr != "" || m.currentTable == "" {
+		return
+	}
+	err := func() error {
+		snap := offlineSnapshot{
+			Connection: m.connectionKey(),
+			Table:      m.currentTable,
+			SavedAt:    time.Now(),
+			Info:       m.tableInfo,
+		}
+		for _, item := range m.items[:min(len(m.items), offlineMaxItems)] {
+			line, err := models.ItemToDynamoJSON(item, false)
+			if err != nil {
+				return err
+			}
+			snap.Items = append(snap.Items, json.RawMessage(line))
+		}
+		data, err := json.Marshal(snap)
+		if err != nil {
+			return err
+		}
+		path, err := offlinePath(url.QueryEscape(snap.Connection+"/"+snap.Table) + ".json")
+		if err != nil {
+			return err
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, path)
+	}()
+	if err != nil {
+		m.statusMsg += " - ✗ Failed to save offline snapshot: " + err.Error()
+	}
+}
+
+// readOfflineSnapshots lists the saved snapshots of every connection
+// without their items
+func readOfflineSnapshots() ([]offlineSnapshot, error) {
+	dir, err := offlinePath("")
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline snapshots: %w", err)
+	}
+	var snaps []offlineSnapshot
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		snap, err := readOfflineSnapshot(path)
+		if err != nil {
+			continue // a damaged snapshot shouldn't hide the others
+		}
+		snap.Items = nil
+		snaps = append(snaps, snap)
+	}
+	return snaps, nil
+}
+
+// offlineConnection picks whose snapshots to browse: the connection that
+// just failed when it has any, otherwise the one saved most recently. The
+// same table name under another profile or region is a different table.
+func offlineConnection(snaps []offlineSnapshot, current string) string {
+	latest := snaps[0]
+	for _, snap := range snaps {
+		if snap.Connection == current {
+			return current
+		}
+		if snap.SavedAt.After(latest.SavedAt) {
+			latest = snap
+		}
+	}
+	return latest.Connection
+}
+
+func readOfflineSnapshot(path string) (offlineSnapshot, error) {
+	var snap offlineSnapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snap, fmt.Errorf("failed to read offline snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	snap.path = path
+	return snap, nil
+}
+
+// goOffline lists the cached tables after a failed connection
+func (m *Model) goOffline() {
+	snaps, err := readOfflineSnapshots()
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	if len(snaps) == 0 {
+		m.statusMsg = "✗ No offline snapshots yet; tables are cached as you browse them"
+		return
+	}
+	conn := offlineConnection(snaps, m.connectionKey())
+	byTable := make(map[string]offlineSnapshot)
+	var tables []string
+	for _, snap := range snaps {
+		if snap.Connection == conn {
+			byTable[snap.Table] = snap
+			tables = append(tables, snap.Table)
+		}
+	}
+	sort.Strings(tables)
+
+	m.offline = true
+	m.offlineSnapshots = byTable
+	m.tables = tables
+	m.tableIndex = ui.NewFuzzyIndex(tables)
+	m.filteredTables = tables
+	m.tableFilter, m.filteredFor = "", ""
+	m.tableList.SetItems(tables)
+	m.view = viewTables
+	m.statusMsg = fmt.Sprintf("⚠ Offline: %d cached tables from %s; Ctrl+R reconnects", len(tables), conn)
+}
+
+// openOfflineTable shows the current table's snapshot
+func (m *Model) openOfflineTable() {
+	m.view = viewTableData
+	m.resetSpill()
+	m.lastKey = nil
+	snap, err := readOfflineSnapshot(m.offlineSnapshots[m.currentTable].path)
+	if err != nil {
+		m.items = nil
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	items := make([]map[string]types.AttributeValue, 0, len(snap.Items))
+	for _, raw := range snap.Items {
+		item, err := models.DynamoJSONToItem(raw)
+		if err != nil {
+			m.statusMsg = "✗ Failed to read offline snapshot: " + err.Error()
+			return
+		}
+		items = append(items, item)
+	}
+	m.tableInfo = snap.Info
+	m.items = items
+	m.showItems(items)
+	m.statusMsg = fmt.Sprintf("Showing %d cached items", len(items))
+}
+
+// goOnline leaves offline mode and reconnects
+func (m *Model) goOnline() tea.Cmd {
+	m.offline = false
+	m.offlineSnapshots = nil
+	m.tables, m.filteredTables = nil, nil
+	m.tableList.SetItems(nil)
+	m.view = viewConnect
+	m.loading = true
+	m.err = nil
+	m.statusMsg = "Reconnecting..."
+	return m.connect()
+}
+
+// refusesOnlineKey stops keys that need DynamoDB while offline, unless they
+// are typed into a search or filter
+func (m *Model) refusesOnlineKey(msg tea.KeyMsg) bool {
+	if !m.offline || (m.view != viewTables && !m.acceptsKeymap()) {
+		return false
+	}
+	if !slices.Contains(onlineKeys[m.view], msg.String()) {
+		return false
+	}
+	m.statusMsg = "✗ Offline: this needs a connection (Ctrl+R in the tables list reconnects)"
+	return true
+}
+
+// offlineStatus labels a snapshot as stale in the status bar
+func (m Model) offlineStatus() string {
+	if !m.offline {
+		return ""
+	}
+	snap, ok := m.offlineSnapshots[m.currentTable]
+	if !ok {
+		return ui.WarningStyle.Render(" | ⚠ Offline")
+	}
+	return ui.WarningStyle.Render(fmt.Sprintf(" | ⚠ Offline snapshot of %s from %s (stale)", snap.Connection, snap.SavedAt.Format("2006-01-02 15:04")))
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOfflineSnapshotBrowsesLastPage(t *testing.T) {
+	stubConfigDir(t)
+	populatedModel() // loading a page saves its snapshot
+
+	m := New()
+	m.width, m.height = 120, 40
+	m = drive(m, connectionTestMsg{err: errors.New("no route to host")})
+	m = drive(m, keyRunes("o"))
+	if !m.offline || m.view != viewTables || len(m.tables) != 1 || m.tables[0] != "Users" {
+		t.Fatalf("offline tables = %v (offline %t)", m.tables, m.offline)
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewTableData || len(m.items) != 2 || m.tableInfo == nil || m.tableInfo.PartitionKey != "id" {
+		t.Fatalf("snapshot not shown: %d items, info %+v", len(m.items), m.tableInfo)
+	}
+	if !strings.Contains(m.View(), "(stale)") {
+		t.Fatal("a snapshot should be labeled stale")
+	}
+
+	// Nothing that needs DynamoDB runs offline
+	if _, cmd := m.Update(keyRunes("r")); cmd != nil {
+		t.Fatal("refresh must not run offline")
+	}
+	m = drive(m, keyRunes("r"))
+	if !strings.HasPrefix(m.statusMsg, "✗ Offline") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m = drive(m, keyRunes("q"))
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR}); cmd == nil {
+		t.Fatal("Ctrl+R should reconnect")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if m.offline || m.view != viewConnect {
+		t.Fatal("reconnecting should leave offline mode")
+	}
+}
+
+func TestOfflineWithoutSnapshots(t *testing.T) {
+	stubConfigDir(t)
+	m := New()
+	m = drive(m, connectionTestMsg{err: errors.New("no route to host")})
+	m = drive(m, keyRunes("o"))
+	if m.offline || m.view != viewConnect || !strings.Contains(m.statusMsg, "No offline snapshots") {
+		t.Fatalf("offline %t, status %q", m.offline, m.statusMsg)
+	}
+}
+
+func TestOfflineKeepsConnectionsApart(t *testing.T) {
+	stubConfigDir(t)
+	m := populatedModel()
+	m.config.Profile = "prod"
+	m.items = m.items[:1]
+	m.saveOfflineSnapshot()
+
+	for profile, want := range map[string]int{"": 2, "prod": 1, "staging": 1} {
+		m := New()
+		m.width, m.height = 120, 40
+		m.config.Profile = profile
+		m = drive(m, connectionTestMsg{err: errors.New("no route to host"), profile: profile})
+		m = drive(m, keyRunes("o"))
+		m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if len(m.items) != want {
+			t.Errorf("profile %q: %d cached items, want %d", profile, len(m.items), want)
+		}
+		if want == 1 && !strings.Contains(m.View(), "prod@default") {
+			t.Errorf("profile %q: the status bar should name the snapshot's connection", profile)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// slowestTables is how many tables the stats view ranks
+const slowestTables = 10
+
+func (m *Model) openOpStats() {
+	m.opStatsReturn = m.view
+	m.view = viewOpStats
+}
+
+func (m *Model) updateOpStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "ctrl+f":
+		m.view = m.opStatsReturn
+	case "c":
+		m.opStats.Reset()
+		m.statusMsg = "✓ Statistics cleared"
+	}
+	return m, nil
+}
+
+// ms renders a latency in whole milliseconds, as the status bar does
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+// opStatsRow is a summary's columns after its name; throttled calls show
+// next to the errors they are part of
+func opStatsRow(s dynamo.OpSummary) string {
+	errs := fmt.Sprint(s.Errors)
+	if s.Throttled > 0 {
+		errs += fmt.Sprintf(" (%d throttled)", s.Throttled)
+	}
+	return fmt.Sprintf("%6d %8s %8s %8s %8d %9.1f  %s", s.Calls, ms(s.P50), ms(s.P95), ms(s.Max), s.Items, s.Capacity, errs)
+}
+
+func (m Model) viewOpStats() string {
+	stats := m.opStats
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("📈 AWS Call Statistics"))
+	b.WriteString("\n")
+	b.WriteString(ui.HelpStyle.Render("Latency, items and consumed capacity of every AWS call this session, retries included."))
+	b.WriteString("\n\n")
+
+	ops := stats.ByOperation()
+	if len(ops) == 0 {
+		b.WriteString(ui.HelpStyle.Render("No calls yet."))
+		b.WriteString("\n")
+	} else {
+		header := fmt.Sprintf("%6s %8s %8s %8s %8s %9s  %s", "Calls", "p50", "p95", "Max", "Items", "Capacity", "Errors")
+		b.WriteString(ui.TableHeaderStyle.Render(fmt.Sprintf("%-22s %s", "Operation", header)))
+		b.WriteString("\n")
+		for _, s := range ops {
+			b.WriteString(fmt.Sprintf("%-22s %s\n", s.Name, opStatsRow(s)))
+		}
+
+		tables := stats.ByTable()
+		if len(tables) > slowestTables {
+			tables = tables[:slowestTables]
+		}
+		if len(tables) > 0 {
+			b.WriteString("\n")
+			b.WriteString(ui.TableHeaderStyle.Render(fmt.Sprintf("%-32s %s", "Slowest tables", header)))
+			b.WriteString("\n")
+			for _, s := range tables {
+				line := fmt.Sprintf("%-32s %s", s.Name, opStatsRow(s))
+				if s.Throttled > 0 {
+					line = ui.WarningStyle.Render(line)
+				}
+				b.WriteString(line)
+				b.WriteString("\n")
+			}
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "c", Desc: "Clear"},
+		{Key: "Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestOpStatsViewShowsSessionCalls(t *testing.T) {
+	m := populatedModel()
+	stats := m.opStats
+	stats.Record(dynamo.OpRecord{Op: "Scan", Region: "us-east-1", Table: "Users", Duration: 40 * time.Millisecond, Items: 2, Capacity: 0.5})
+	stats.Record(dynamo.OpRecord{Op: "Query", Region: "eu-west-1", Table: "Orders", Duration: 900 * time.Millisecond, Err: true, Throttled: true})
+
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlF})
+	if m.view != viewOpStats {
+		t.Fatalf("Ctrl+F should open the stats, view %d", m.view)
+	}
+	out := m.View()
+	for _, want := range []string{"Scan", "40ms", "eu-west-1 Orders", "900ms", "1 throttled"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stats view lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "eu-west-1 Orders") > strings.Index(out, "us-east-1 Users") {
+		t.Error("the slowest table should come first")
+	}
+
+	m = drive(m, keyRunes("c"))
+	if len(stats.Records()) != 0 || !strings.Contains(m.View(), "No calls yet") {
+		t.Error("c should clear the statistics")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTableData {
+		t.Errorf("Esc should go back, view %d", m.view)
+	}
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// ExternalTool is a command o in the item view pipes the open item to, such
+// as a script that decodes a company-specific format. It reads the item as
+// JSON on stdin; what it prints is shown in a pane.
+type ExternalTool struct {
+	Name       string   `yaml:"name"`
+	Command    []string `yaml:"command"`       // program and arguments, run without a shell
+	DynamoJSON bool     `yaml:"dynamodb_json"` // send DynamoDB JSON instead of plain JSON
+}
+
+// toolTimeout bounds one run of an external tool
+const toolTimeout = 30 * time.Second
+
+// Run pipes item, as JSON, to the tool and returns what it wrote to stdout
+func (t ExternalTool) Run(ctx context.Context, item string) (string, error) {
+	cmd := exec.CommandContext(ctx, t.Command[0], t.Command[1:]...)
+	cmd.Stdin = strings.NewReader(item)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", t.Command[0], err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", t.Command[0], err)
+	}
+	return string(out), nil
+}
+
+// toolOutputMsg is what a tool printed for the item whose history key is key
+type toolOutputMsg struct {
+	tool string
+	key  string
+	out  string
+	err  error
+}
+
+// openWith runs the only configured tool on the open item, or lists them
+// to pick one from
+func (m *Model) openWith() tea.Cmd {
+	switch len(m.config.OpenWith) {
+	case 0:
+		m.statusMsg = "✗ No tools to open items with: add open_with to config.yaml"
+		return nil
+	case 1:
+		return m.runTool(m.config.OpenWith[0])
+	}
+	m.toolCursor, m.toolOutput = 0, nil
+	m.view = viewOpenWith
+	return nil
+}
+
+func (m *Model) runTool(tool ExternalTool) tea.Cmd {
+	var text string
+	var err error
+	if tool.DynamoJSON {
+		text, err = models.ItemToDynamoJSON(m.selectedItem, true)
+	} else {
+		text, err = models.ItemToJSON(m.selectedItem, true)
+	}
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+	key := m.historyKey(m.selectedItem)
+	m.statusMsg = "Running " + tool.Name + "..."
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), toolTimeout)
+		defer cancel()
+		out, err := tool.Run(ctx, text)
+		return toolOutputMsg{tool: tool.Name, key: key, out: out, err: err}
+	}
+}
+
+func (m *Model) showToolOutput(msg toolOutputMsg) {
+	if msg.err != nil {
+		m.statusMsg = "✗ " + msg.tool + " failed: " + msg.err.Error()
+		return
+	}
+	if (m.view != viewItemDetail && m.view != viewOpenWith) || msg.key != m.historyKey(m.selectedItem) {
+		return // moved on to another item
+	}
+	m.toolName = msg.tool
+	m.toolOutput = strings.Split(strings.TrimRight(msg.out, "\n"), "\n")
+	m.toolOutputOff = 0
+	m.view = viewOpenWith
+	m.statusMsg = fmt.Sprintf("%s printed %d line(s)", msg.tool, len(m.toolOutput))
+}
+
+func (m *Model) updateOpenWith(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.toolOutput != nil {
+		page := m.toolOutputHeight()
+		last := max(len(m.toolOutput)-page, 0)
+		switch msg.String() {
+		case "esc", "q":
+			m.toolOutput = nil
+			m.view = viewItemDetail
+		case "y":
+			if err := copyToClipboard(strings.Join(m.toolOutput, "\n")); err != nil {
+				m.statusMsg = "✗ Failed to copy: " + err.Error()
+			} else {
+				m.statusMsg = "✓ Copied " + m.toolName + " output to clipboard"
+			}
+		case "up", "k":
+			m.toolOutputOff = max(m.toolOutputOff-1, 0)
+		case "down", "j":
+			m.toolOutputOff = min(m.toolOutputOff+1, last)
+		case "pgup":
+			m.toolOutputOff = max(m.toolOutputOff-page, 0)
+		case "pgdown":
+			m.toolOutputOff = min(m.toolOutputOff+page, last)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.view = viewItemDetail
+	case "up", "k":
+		m.toolCursor = max(m.toolCursor-1, 0)
+	case "down", "j":
+		m.toolCursor = min(m.toolCursor+1, len(m.config.OpenWith)-1)
+	case "enter":
+		return m, m.runTool(m.config.OpenWith[m.toolCursor])
+	}
+	return m, nil
+}
+
+func (m Model) toolOutputHeight() int {
+	return max(m.height-8, 3)
+}
+
+func (m Model) viewOpenWith() string {
+	var b strings.Builder
+	if m.toolOutput == nil {
+		b.WriteString(ui.TitleStyle.Render("🧰 Open with"))
+		b.WriteString("\n\n")
+		for i, tool := range m.config.OpenWith {
+			label := fmt.Sprintf("%-20s %s", ui.Truncate(tool.Name, 20), strings.Join(tool.Command, " "))
+			if i == m.toolCursor {
+				b.WriteString(ui.SelectedStyle.Render("▸ " + label))
+			} else {
+				b.WriteString(ui.ItemStyle.Render("  " + label))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+		b.WriteString("\n")
+		b.WriteString(ui.RenderHelp([]ui.KeyBinding{{Key: "Enter", Desc: "Run"}, {Key: "q/Esc", Desc: "Back"}}))
+		return b.String()
+	}
+
+	b.WriteString(ui.TitleStyle.Render("🧰 " + m.toolName))
+	b.WriteString("\n\n")
+	height := m.toolOutputHeight()
+	end := min(m.toolOutputOff+height, len(m.toolOutput))
+	for _, line := range m.toolOutput[m.toolOutputOff:end] {
+		b.WriteString(ui.Truncate(line, max(m.width-2, 10)))
+		b.WriteString("\n")
+	}
+	for i := end - m.toolOutputOff; i < height; i++ {
+		b.WriteString("\n")
+	}
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑/↓", Desc: "Scroll"},
+		{Key: "PgUp/PgDn", Desc: "Page"},
+		{Key: "y", Desc: "Copy output"},
+		{Key: "q/Esc", Desc: "Item"},
+	}))
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOpenWithShowsTheToolsOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	m := populatedModel()
+	m.config.OpenWith = []ExternalTool{
+		{Name: "names", Command: []string{"sh", "-c", `grep -o '"name": *"[a-z]*"'; echo done`}},
+		{Name: "broken", Command: []string{"sh", "-c", "echo bad input >&2; exit 2"}},
+	}
+	m.selectedItem = m.items[0]
+	m.prepareItemView()
+	m.view = viewItemDetail
+
+	m = drive(m, keyRunes("o"))
+	if m.view != viewOpenWith || !strings.Contains(m.View(), "broken") {
+		t.Fatalf("several tools should be listed to pick from:\n%s", m.View())
+	}
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *next.(*Model)
+	if cmd == nil {
+		t.Fatal("enter should run the tool")
+	}
+	m = drive(m, cmd())
+	out := m.View()
+	if !strings.Contains(out, `"alice"`) || !strings.Contains(out, "done") {
+		t.Errorf("the tool's output should be shown:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("q"))
+	if m.view != viewItemDetail {
+		t.Errorf("q should go back to the item, view %d", m.view)
+	}
+
+	m = drive(m, keyRunes("o"))
+	m = drive(m, keyRunes("j"))
+	next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *next.(*Model)
+	m = drive(m, cmd())
+	if !strings.Contains(m.statusMsg, "bad input") {
+		t.Errorf("a failing tool's stderr should be reported, status %q", m.statusMsg)
+	}
+}
+
+func TestOpenWithNeedsConfiguredTools(t *testing.T) {
+	m := populatedModel()
+	m.selectedItem = m.items[0]
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("o"))
+	if m.view != viewItemDetail || !strings.Contains(m.statusMsg, "open_with") {
+		t.Errorf("view %d, status %q", m.view, m.statusMsg)
+	}
+
+	cfg := DefaultConfig()
+	cfg.OpenWith = []ExternalTool{{Name: "x"}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("a tool without a command should be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// overwriteCheckMsg is what is stored under a new item's key, nil when
+// nothing is
+type overwriteCheckMsg struct {
+	existing map[string]types.AttributeValue
+	err      error
+}
+
+// checkOverwrite looks up a new item's key before creating it, since
+// PutItem silently replaces whatever is stored there
+func (m *Model) checkOverwrite(item map[string]types.AttributeValue) tea.Cmd {
+	key := map[string]types.AttributeValue{m.tableInfo.PartitionKey: item[m.tableInfo.PartitionKey]}
+	if sk := m.tableInfo.SortKey; sk != "" {
+		key[sk] = item[sk]
+	}
+	client, table := m.client, m.currentTable
+	m.statusMsg = "Checking for an existing item..."
+	return func() tea.Msg {
+		existing, err := client.GetItem(context.Background(), table, key)
+		return overwriteCheckMsg{existing: existing, err: err}
+	}
+}
+
+func (m *Model) handleOverwriteCheck(msg overwriteCheckMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = "✗ Couldn't check for an existing item: " + msg.err.Error()
+		return nil
+	}
+	if msg.existing == nil {
+		m.statusMsg = ""
+		return m.confirmSave()
+	}
+	m.overwriting = msg.existing
+	m.view = viewConfirmOverwrite
+	return nil
+}
+
+func (m *Model) updateConfirmOverwrite(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.overwriting = nil
+		return m, m.saveItem()
+	case "v", "V":
+		m.selectedItem = m.overwriting
+		m.overwriting = nil
+		m.prepareItemView()
+		m.view = viewItemDetail
+	case "n", "N", "esc":
+		m.overwriting = nil
+		m.view = viewCreateItem
+	}
+	return m, nil
+}
+
+func (m Model) viewConfirmOverwrite() string {
+	key := strings.TrimPrefix(m.historyKey(m.overwriting), m.currentTable+"|")
+	content := ui.ModalStyle.Render(
+		ui.TitleStyle.Render("⚠ Item Already Exists") + "\n\n" +
+			ui.WarningStyle.Render("This will overwrite an existing item — view it?") + "\n\n" +
+			ui.ItemStyle.Render(strings.ReplaceAll(key, "|", ", ")) + "\n\n" +
+			ui.HelpStyle.Render("Press V to view it, Y to overwrite it, N to go back to the editor"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestCreatingOverAnExistingItemAsksFirst(t *testing.T) {
+	m := populatedModel()
+	m.view = viewCreateItem
+	m.itemEditor.SetValue(`{"id": "1", "email": "a@b.c"}`)
+	existing := m.items[0]
+
+	m = drive(m, overwriteCheckMsg{existing: existing})
+	if m.view != viewConfirmOverwrite || !strings.Contains(m.View(), "id=1") {
+		t.Fatalf("view %d: an existing item should be reported before it is replaced", m.view)
+	}
+	m = drive(m, keyRunes("n"))
+	if m.view != viewCreateItem || m.itemEditor.Value() == "" {
+		t.Fatalf("view %d: N should go back to the draft", m.view)
+	}
+
+	m = drive(m, overwriteCheckMsg{existing: existing})
+	m = drive(m, keyRunes("v"))
+	if m.view != viewItemDetail || m.selectedItem["name"].(*types.AttributeValueMemberS).Value != "alice" {
+		t.Errorf("view %d: V should open the stored item", m.view)
+	}
+
+	m.view = viewCreateItem
+	m = drive(m, overwriteCheckMsg{})
+	if m.view != viewConfirmSave {
+		t.Errorf("view %d: a free key should go on to the usual save", m.view)
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Panes are the widths, in columns, of the panes that share the screen with
+// a detail pane, which takes the rest
+type Panes struct {
+	Sidebar int `yaml:"sidebar"` // the version list beside an item's history
+}
+
+const (
+	minPaneWidth    = 16
+	maxPaneWidth    = 80
+	paneStep        = 4
+	minDetailWidth  = 30 // a sidebar never squeezes the detail pane below this
+	paneBorderWidth = 10 // borders and padding around the two panes
+)
+
+func defaultPanes() Panes {
+	return Panes{Sidebar: 30}
+}
+
+func (p Panes) validate() error {
+	if p.Sidebar < minPaneWidth || p.Sidebar > maxPaneWidth {
+		return fmt.Errorf("panes.sidebar must be between %d and %d, got %d", minPaneWidth, maxPaneWidth, p.Sidebar)
+	}
+	return nil
+}
+
+// detailWidth is what the screen leaves the detail pane beside the sidebar
+func (m Model) detailWidth() int {
+	return max(m.width-m.config.Panes.Sidebar-paneBorderWidth, minDetailWidth)
+}
+
+// resizeSidebar grows the sidebar by delta columns, shrinking the detail
+// pane by as much, and saves the new width to config.yaml
+func (m *Model) resizeSidebar(delta int) {
+	limit := maxPaneWidth
+	if m.width > 0 {
+		limit = min(limit, m.width-minDetailWidth-paneBorderWidth)
+	}
+	w := min(max(m.config.Panes.Sidebar+delta, minPaneWidth), max(limit, minPaneWidth))
+	if w == m.config.Panes.Sidebar {
+		return
+	}
+	m.config.Panes.Sidebar = w
+	if err := saveConfigKey("panes", m.config.Panes); err != nil {
+		m.statusMsg = "✗ Failed to save pane sizes: " + err.Error()
+		return
+	}
+	m.statusMsg = fmt.Sprintf("Sidebar is %d wide", w)
+}
+
+// saveConfigKey sets one top-level key of config.yaml, leaving the other
+// keys and their comments as they are
+func saveConfigKey(key string, value any) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a mapping of settings", path)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return err
+	}
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1], found = &node, true
+		}
+	}
+	if !found {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
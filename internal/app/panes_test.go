@@ -0,0 +1,45 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestResizingTheSidebarSavesIt(t *testing.T) {
+	writeConfig(t, "# my settings\ntheme: nord # dark terminal\n")
+	m := populatedModel()
+	m.recordSave(userItem("1", "alice"), userItem("1", "alicia"))
+	m.view = viewItemDetail
+	m = drive(m, keyRunes("h"))
+
+	m = drive(m, keyRunes("]"))
+	if m.config.Panes.Sidebar != 34 || m.detailWidth() != 120-34-paneBorderWidth {
+		t.Fatalf("sidebar %d, detail %d", m.config.Panes.Sidebar, m.detailWidth())
+	}
+	path, _ := ConfigPath()
+	data, _ := os.ReadFile(path)
+	if s := string(data); !strings.Contains(s, "# my settings") || !strings.Contains(s, "theme: nord # dark terminal") {
+		t.Errorf("saving should keep the rest of the file:\n%s", s)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file was left behind: %v", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil || cfg.Panes.Sidebar != 34 {
+		t.Fatalf("reloaded sidebar %d, err %v", cfg.Panes.Sidebar, err)
+	}
+
+	for range 30 {
+		m = drive(m, keyRunes("["))
+	}
+	if m.config.Panes.Sidebar != minPaneWidth {
+		t.Errorf("the sidebar should stop at %d, got %d", minPaneWidth, m.config.Panes.Sidebar)
+	}
+	for range 30 {
+		m = drive(m, keyRunes("]"))
+	}
+	if m.detailWidth() < minDetailWidth || m.config.Panes.Sidebar > m.width-minDetailWidth-paneBorderWidth {
+		t.Errorf("the detail pane was squeezed to %d", m.detailWidth())
+	}
+}
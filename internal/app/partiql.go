@@ -0,0 +1,351 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/internal/ui/textarea"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+	"github.com/godynamo/pkg/query"
+)
+
+// partiqlPreviewLimit caps how many items are read to show what an UPDATE
+// or DELETE will touch.
+const partiqlPreviewLimit = 25
+
+type (
+	partiqlResultMsg struct {
+		stmt   string
+		result *dynamo.StatementResult
+		more   bool // a further page of stmt, appended to the results
+	}
+	partiqlPreviewMsg struct{ write *partiqlWrite }
+	partiqlWriteMsg   struct {
+		write    *partiqlWrite
+		returned []map[string]types.AttributeValue
+	}
+)
+
+// partiqlWrite is an INSERT, UPDATE or DELETE waiting for confirmation,
+// with the items its WHERE clause currently matches.
+type partiqlWrite struct {
+	stmt     string
+	parsed   query.Statement
+	affected []map[string]types.AttributeValue
+	more     bool // the preview stopped at partiqlPreviewLimit
+}
+
+func (m *Model) openPartiQL(table string) tea.Cmd {
+	if m.partiqlEditor.Placeholder == "" {
+		ta := textarea.New()
+		ta.Placeholder = `SELECT * FROM "Table" WHERE pk = 'value'`
+		ta.ShowLineNumbers = false
+		ta.CharLimit = 0
+		ta.SetPromptFunc(0, func(int) string { return "" })
+		ta.SetHeight(6)
+		m.partiqlEditor = ta
+		m.partiqlTable = ui.NewDataTable()
+	}
+	m.resizePartiQL()
+	m.tableHighlighter()
+	if strings.TrimSpace(m.partiqlEditor.Value()) == "" && table != "" {
+		m.partiqlEditor.SetValue(fmt.Sprintf("SELECT * FROM %q", table))
+	}
+	m.partiqlReturn = m.view
+	m.partiqlFocusResults = false
+	m.view = viewPartiQL
+	return m.partiqlEditor.Focus()
+}
+
+// resizePartiQL fits the editor and results to the window once the editor exists
+func (m *Model) resizePartiQL() {
+	if m.partiqlEditor.Placeholder == "" {
+		return
+	}
+	m.partiqlEditor.SetWidth(max(m.width-20, 40))
+	m.partiqlTable.SetSize(max(m.width-35, 40), max(m.height-22, 5))
+}
+
+func (m *Model) updatePartiQL(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if w := m.partiqlPending; w != nil {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			m.partiqlPending = nil
+			m.loading = true
+			m.statusMsg = "Running " + w.parsed.Verb() + "..."
+			m.recordPartiQL(w.stmt)
+			return m, m.runPartiQLWrite(w)
+		case "n", "N", "esc":
+			m.partiqlPending = nil
+			m.statusMsg = w.parsed.Verb() + " cancelled"
+		}
+		return m, nil
+	}
+	if m.partiqlHistoryOpen {
+		return m.updatePartiQLHistory(msg)
+	}
+
+	if msg.String() != "tab" {
+		m.partiqlComplete = nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.partiqlEditor.Blur()
+		m.view = m.partiqlReturn
+		return m, nil
+	case "tab":
+		if !m.partiqlFocusResults && m.completePartiQL() {
+			return m, nil
+		}
+		fallthrough
+	case "shift+tab":
+		m.partiqlFocusResults = !m.partiqlFocusResults && len(m.partiqlItems) > 0
+		if m.partiqlFocusResults {
+			m.partiqlEditor.Blur()
+			return m, nil
+		}
+		return m, m.partiqlEditor.Focus()
+	case "ctrl+s":
+		return m, m.runPartiQL()
+	case "ctrl+r":
+		m.openPartiQLHistory()
+		return m, nil
+	}
+
+	if m.partiqlFocusResults {
+		switch msg.String() {
+		case "up", "k":
+			m.partiqlTable.MoveUp()
+		case "down", "j":
+			m.partiqlTable.MoveDown()
+		case "left", "h":
+			m.partiqlTable.MoveLeft()
+		case "right", "l":
+			m.partiqlTable.MoveRight()
+		case "n", "pgdown":
+			if m.partiqlNext != nil && !m.loading {
+				m.loading = true
+				m.statusMsg = "Loading next page..."
+				return m, m.executePartiQL(m.partiqlStmt, m.partiqlNext)
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.partiqlEditor, cmd = m.partiqlEditor.Update(msg)
+	return m, cmd
+}
+
+// runPartiQL runs a SELECT straight away; writes are previewed first and
+// wait in partiqlPending for confirmation.
+func (m *Model) runPartiQL() tea.Cmd {
+	stmt := strings.TrimSpace(m.partiqlEditor.Value())
+	if stmt == "" {
+		m.statusMsg = "✗ Enter a PartiQL statement"
+		return nil
+	}
+	parsed := query.ParseStatement(stmt)
+	if parsed.IsWrite() && m.refuseWrite(parsed.Verb()+" statements") {
+		return nil
+	}
+	m.err = nil
+	m.loading = true
+
+	if !parsed.IsWrite() {
+		m.statusMsg = "Running statement..."
+		m.recordPartiQL(stmt)
+		return m.executePartiQL(stmt, nil)
+	}
+	w := &partiqlWrite{stmt: stmt, parsed: parsed}
+	preview := parsed.PreviewSelect()
+	if preview == "" {
+		m.loading = false
+		m.partiqlPending = w
+		return nil
+	}
+	m.statusMsg = "Checking which items the " + parsed.Verb() + " affects..."
+	return func() tea.Msg {
+		res, err := m.client.ExecuteStatement(context.Background(), preview, nil, partiqlPreviewLimit)
+		if err != nil {
+			return errMsg{err}
+		}
+		w.affected = res.Items
+		w.more = res.NextToken != nil
+		return partiqlPreviewMsg{w}
+	}
+}
+
+func (m *Model) executePartiQL(stmt string, next *string) tea.Cmd {
+	return func() tea.Msg {
+		res, err := m.client.ExecuteStatement(context.Background(), stmt, next, m.pageSize)
+		if err != nil {
+			return errMsg{err}
+		}
+		return partiqlResultMsg{stmt: stmt, result: res, more: next != nil}
+	}
+}
+
+func (m *Model) runPartiQLWrite(w *partiqlWrite) tea.Cmd {
+	return func() tea.Msg {
+		res, err := m.client.ExecuteStatement(context.Background(), w.stmt, nil, 0)
+		if err != nil {
+			return errMsg{err}
+		}
+		return partiqlWriteMsg{write: w, returned: res.Items}
+	}
+}
+
+func (m *Model) handlePartiQLResult(msg partiqlResultMsg) {
+	m.loading = false
+	if msg.more {
+		m.partiqlItems = append(m.partiqlItems, msg.result.Items...)
+	} else {
+		m.partiqlItems = msg.result.Items
+	}
+	m.partiqlStmt = msg.stmt
+	m.partiqlNext = msg.result.NextToken
+	m.partiqlTable.SetData(m.partiqlRows(m.partiqlItems))
+	m.statusMsg = fmt.Sprintf("✓ %d items", len(m.partiqlItems))
+	if m.partiqlNext != nil {
+		m.statusMsg += " (more available)"
+	}
+}
+
+func (m *Model) handlePartiQLWrite(msg partiqlWriteMsg) tea.Cmd {
+	m.loading = false
+	w := msg.write
+	n := len(w.affected)
+	if w.parsed.Kind == "INSERT" {
+		n = 1
+	}
+	m.statusMsg = fmt.Sprintf("✓ %s applied to %d item(s)", w.parsed.Kind, n)
+	if w.parsed.Kind == "" {
+		m.statusMsg = "✓ Statement ran"
+	}
+	if len(msg.returned) > 0 {
+		m.partiqlItems, m.partiqlStmt, m.partiqlNext = msg.returned, "", nil
+		m.partiqlTable.SetData(m.partiqlRows(msg.returned))
+	}
+	// Keep the table view behind the editor in step with the write
+	if m.client != nil && w.parsed.TableName() == m.currentTable && m.partiqlReturn == viewTableData {
+		return m.scanTable()
+	}
+	return nil
+}
+
+// partiqlRows lays results out like the table view, keys first when the
+// statement reads the open table.
+func (m *Model) partiqlRows(items []map[string]types.AttributeValue) ([]string, [][]string) {
+	keySet := make(map[string]bool)
+	for _, item := range items {
+		for k := range item {
+			keySet[k] = true
+		}
+	}
+	var headers []string
+	if query.ParseStatement(m.partiqlStmt).TableName() == m.currentTable && m.tableInfo != nil {
+		headers = m.orderHeaders(keySet)
+	} else {
+		for k := range keySet {
+			headers = append(headers, k)
+		}
+		sort.Strings(headers)
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = make([]string, len(headers))
+		for j, h := range headers {
+			if v, ok := item[h]; ok {
+				rows[i][j] = m.cellText(v)
+			}
+		}
+	}
+	return headers, rows
+}
+
+func (m Model) viewPartiQL() string {
+	var b strings.Builder
+
+	b.WriteString(ui.TitleStyle.Render("⚡ PartiQL"))
+	b.WriteString("\n\n")
+
+	editorStyle := ui.InputFocusedStyle
+	if m.partiqlFocusResults {
+		editorStyle = ui.InputStyle
+	}
+	b.WriteString(editorStyle.Width(max(m.width-10, 40)).Render(m.partiqlEditor.View()))
+	b.WriteString("\n")
+	b.WriteString(m.viewPartiQLCompletions())
+	b.WriteString("\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(ui.ContentStyle.Render("Running..."))
+	case len(m.partiqlItems) == 0:
+		b.WriteString(ui.HelpStyle.Render("No results yet. Ctrl+S runs the statement."))
+	default:
+		b.WriteString(m.partiqlTable.View())
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	keys := []ui.KeyBinding{
+		{Key: "Ctrl+S", Desc: "Run"},
+		{Key: "Tab", Desc: "Complete"},
+		{Key: "Shift+Tab", Desc: "Editor/Results"},
+		{Key: "Ctrl+R", Desc: "History"},
+	}
+	if m.partiqlNext != nil {
+		keys = append(keys, ui.KeyBinding{Key: "n", Desc: "Next page"})
+	}
+	keys = append(keys, ui.KeyBinding{Key: "Esc", Desc: "Back"})
+	b.WriteString(ui.RenderHelp(keys))
+
+	if m.partiqlHistoryOpen {
+		return m.viewPartiQLHistory()
+	}
+	if w := m.partiqlPending; w != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.viewPartiQLConfirm(w))
+	}
+	return b.String()
+}
+
+func (m Model) viewPartiQLConfirm(w *partiqlWrite) string {
+	var body strings.Builder
+	table := w.parsed.TableName()
+	switch {
+	case w.parsed.Kind == "":
+		body.WriteString(ui.WarningStyle.Render("This statement wasn't recognised, so it may change data."))
+	case w.parsed.Kind == "INSERT":
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("Insert 1 item into %s?", table)))
+	case w.parsed.Where == "":
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("%s on %s has no WHERE clause; DynamoDB will reject it unless it names a full key.", w.parsed.Kind, table)))
+	case len(w.affected) == 0 && w.more:
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("The first %d items checked in %s don't match, but there are more; how many the %s affects is unknown.", partiqlPreviewLimit, table, w.parsed.Kind)))
+	case len(w.affected) == 0:
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("No items in %s currently match; the %s may fail its condition.", table, w.parsed.Kind)))
+	default:
+		count := fmt.Sprint(len(w.affected))
+		if w.more {
+			count += "+"
+		}
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("%s will affect %s item(s) in %s:", w.parsed.Kind, count, table)))
+		for _, item := range w.affected[:min(len(w.affected), 5)] {
+			line, _ := models.ItemToJSON(item, false)
+			body.WriteString("\n" + ui.HelpStyle.Render(ui.Truncate(line, 70)))
+		}
+	}
+	body.WriteString("\n\n" + ui.HelpStyle.Render("Press Y to confirm, N to cancel"))
+	return ui.ModalStyle.Render(ui.TitleStyle.Render("⚠️ Confirm "+w.parsed.Verb()) + "\n\n" + body.String())
+}
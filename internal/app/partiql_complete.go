@@ -0,0 +1,170 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/query"
+)
+
+// partiqlCompletion is the state of a run of Tab presses: the word being
+// completed and the candidate currently standing in for it.
+type partiqlCompletion struct {
+	inserted string // text the last Tab put in the editor
+	options  []string
+	idx      int
+}
+
+// tableHighlighter colours the names in m.tables in the PartiQL editor
+func (m *Model) tableHighlighter() {
+	known := make(map[string]bool, len(m.tables))
+	for _, t := range m.tables {
+		known[t] = true
+	}
+	m.partiqlEditor.Highlighter = ui.PartiQLHighlighter(func(name string) bool { return known[name] })
+}
+
+// completePartiQL replaces the word before the cursor with the next
+// candidate. It reports false when there is nothing to complete.
+func (m *Model) completePartiQL() bool {
+	if c := m.partiqlComplete; c != nil {
+		c.idx = (c.idx + 1) % len(c.options)
+		m.replaceBeforeCursor(c.inserted, c.options[c.idx])
+		c.inserted = c.options[c.idx]
+		return true
+	}
+
+	row, col := m.partiqlEditor.LogicalCursor()
+	line := []rune(strings.Split(m.partiqlEditor.Value(), "\n")[row])
+	start := col
+	for start > 0 && (isIdentRune(line[start-1]) || line[start-1] == '"') {
+		start--
+	}
+	prefix := string(line[start:col])
+
+	var prev string
+	for _, tok := range query.Tokenize(string(line[:start])) {
+		if tok.Kind != query.TokenSpace {
+			prev = strings.ToUpper(tok.Text)
+		}
+	}
+	options := m.partiqlCandidates(prefix, prev == "FROM" || prev == "INTO" || prev == "UPDATE")
+	if len(options) == 0 {
+		return false
+	}
+	m.replaceBeforeCursor(prefix, options[0])
+	m.partiqlComplete = &partiqlCompletion{inserted: options[0], options: options}
+	return true
+}
+
+// partiqlCandidates lists completions for prefix: table names after FROM,
+// INTO or UPDATE, otherwise the open table's attributes and then keywords.
+func (m *Model) partiqlCandidates(prefix string, wantTable bool) []string {
+	bare := strings.ToLower(strings.TrimPrefix(prefix, `"`))
+	matches := func(name string) bool {
+		return strings.HasPrefix(strings.ToLower(name), bare) && !strings.EqualFold(name, bare)
+	}
+
+	var out []string
+	if wantTable {
+		for _, t := range m.tables {
+			if matches(t) {
+				out = append(out, `"`+t+`"`)
+			}
+		}
+		return out
+	}
+	if bare == "" {
+		return nil
+	}
+
+	for _, a := range m.partiqlAttributes() {
+		if !matches(a) {
+			continue
+		}
+		if isPlainIdent(a) && !strings.HasPrefix(prefix, `"`) {
+			out = append(out, a)
+		} else {
+			out = append(out, `"`+a+`"`)
+		}
+	}
+	lower := strings.ToLower(prefix) == prefix
+	for _, kw := range query.Keywords() {
+		if matches(kw) && !strings.HasPrefix(prefix, `"`) {
+			if lower {
+				kw = strings.ToLower(kw)
+			}
+			out = append(out, kw)
+		}
+	}
+	return out
+}
+
+// partiqlAttributes returns the open table's key attributes followed by the
+// attribute names inferred from the items loaded so far.
+func (m *Model) partiqlAttributes() []string {
+	seen := map[string]bool{}
+	var attrs []string
+	add := func(names ...string) {
+		for _, n := range names {
+			if n != "" && !seen[n] {
+				seen[n] = true
+				attrs = append(attrs, n)
+			}
+		}
+	}
+	if info := m.tableInfo; info != nil {
+		add(info.PartitionKey, info.SortKey)
+		for _, idx := range append(info.GSIs, info.LSIs...) {
+			add(idx.PartitionKey, idx.SortKey)
+		}
+	}
+	if c := m.headerCache; c != nil && c.table == m.currentTable {
+		add(c.headers...)
+	}
+	add(m.partiqlTable.Headers...)
+	return attrs
+}
+
+func (m *Model) replaceBeforeCursor(old, replacement string) {
+	for range []rune(old) {
+		m.partiqlEditor, _ = m.partiqlEditor.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m.partiqlEditor.InsertString(replacement)
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// isPlainIdent reports whether name can be written without double quotes
+func isPlainIdent(name string) bool {
+	for i, r := range name {
+		if !isIdentRune(r) || (i == 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != "" && !query.IsKeyword(name)
+}
+
+// viewPartiQLCompletions lists the Tab candidates with the current one marked
+func (m Model) viewPartiQLCompletions() string {
+	c := m.partiqlComplete
+	if c == nil {
+		return ""
+	}
+	from := max(0, min(c.idx-3, len(c.options)-8))
+	shown := c.options[from:min(len(c.options), from+8)]
+	parts := make([]string, len(shown))
+	for i, o := range shown {
+		if from+i == c.idx {
+			parts[i] = ui.SelectedStyle.Render(o)
+		} else {
+			parts[i] = ui.HelpStyle.Render(o)
+		}
+	}
+	return strings.Join(parts, " ")
+}
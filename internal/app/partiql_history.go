@@ -0,0 +1,203 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// userConfigDir is where godynamo keeps files between sessions; a variable
+// so tests can point it at a temp dir.
+var userConfigDir = os.UserConfigDir
+
+// appDataPath returns the path of name in godynamo's config directory,
+// creating the directory if needed.
+func appDataPath(name string) (string, error) {
+	dir, err := userConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("no config directory: %w", err)
+	}
+	dir = filepath.Join(dir, "godynamo")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// partiqlHistoryFile holds one JSON entry per line, oldest first. Once it
+// passes partiqlHistoryMax lines the oldest are dropped.
+const (
+	partiqlHistoryFile = "partiql_history.jsonl"
+	partiqlHistoryMax  = 2000
+)
+
+type partiqlHistoryEntry struct {
+	Connection string    `json:"connection"`
+	Statement  string    `json:"statement"`
+	RanAt      time.Time `json:"ran_at"`
+}
+
+// connectionKey names the connection statements are filed under. Profiles
+// other than the default get their own, as their accounts differ.
+func (m *Model) connectionKey() string {
+	key := m.selectedRegion
+	if m.config.Endpoint != "" {
+		key = m.config.Endpoint
+	}
+	if key == "" {
+		key = "default"
+	}
+	if m.config.Profile != "" {
+		key = m.config.Profile + "@" + key
+	}
+	return key
+}
+
+// recordPartiQL appends stmt to the history file. Failing to save history
+// never stops the statement itself, so errors only reach the status bar.
+func (m *Model) recordPartiQL(stmt string) {
+	entry := partiqlHistoryEntry{Connection: m.connectionKey(), Statement: stmt, RanAt: time.Now()}
+	if err := appendPartiQLHistory(entry); err != nil {
+		m.statusMsg = "✗ " + err.Error()
+	}
+}
+
+func appendPartiQLHistory(entry partiqlHistoryEntry) error {
+	path, err := appDataPath(partiqlHistoryFile)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read PartiQL history: %w", err)
+	}
+	if n := bytes.Count(data, []byte("\n")); n >= partiqlHistoryMax {
+		// Rewrite without the oldest lines rather than growing forever
+		for ; n >= partiqlHistoryMax; n-- {
+			data = data[bytes.IndexByte(data, '\n')+1:]
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write PartiQL history: %w", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("failed to write PartiQL history: %w", err)
+		}
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write PartiQL history: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write PartiQL history: %w", err)
+	}
+	return nil
+}
+
+// loadPartiQLHistory returns conn's statements, newest first. Lines that do
+// not parse are skipped so one bad write cannot hide the rest.
+func loadPartiQLHistory(conn string) ([]partiqlHistoryEntry, error) {
+	path, err := appDataPath(partiqlHistoryFile)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PartiQL history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []partiqlHistoryEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var e partiqlHistoryEntry
+		if json.Unmarshal(sc.Bytes(), &e) == nil && e.Connection == conn {
+			entries = append(entries, e)
+		}
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, sc.Err()
+}
+
+func (m *Model) openPartiQLHistory() {
+	entries, err := loadPartiQLHistory(m.connectionKey())
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	if len(entries) == 0 {
+		m.statusMsg = "No PartiQL history for " + m.connectionKey() + " yet"
+		return
+	}
+	m.partiqlHistory = entries
+	m.partiqlHistoryIdx = 0
+	m.partiqlHistoryOpen = true
+}
+
+func (m *Model) updatePartiQLHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k", "ctrl+r":
+		if m.partiqlHistoryIdx > 0 {
+			m.partiqlHistoryIdx--
+		}
+	case "down", "j":
+		if m.partiqlHistoryIdx < len(m.partiqlHistory)-1 {
+			m.partiqlHistoryIdx++
+		}
+	case "enter", "ctrl+s":
+		m.partiqlHistoryOpen = false
+		m.partiqlEditor.SetValue(m.partiqlHistory[m.partiqlHistoryIdx].Statement)
+		if msg.String() == "ctrl+s" {
+			return m, m.runPartiQL()
+		}
+	case "esc", "q":
+		m.partiqlHistoryOpen = false
+	}
+	return m, nil
+}
+
+func (m Model) viewPartiQLHistory() string {
+	var body strings.Builder
+	height := max(m.height-12, 5)
+	from := max(0, min(m.partiqlHistoryIdx-height/2, len(m.partiqlHistory)-height))
+	width := max(m.width-40, 30)
+	for i := from; i < min(len(m.partiqlHistory), from+height); i++ {
+		e := m.partiqlHistory[i]
+		stmt := ui.Truncate(strings.Join(strings.Fields(e.Statement), " "), width)
+		line := ui.HelpStyle.Render(e.RanAt.Local().Format("2006-01-02 15:04")) + "  "
+		if i == m.partiqlHistoryIdx {
+			line += ui.SelectedStyle.Render(stmt)
+		} else {
+			line += stmt
+		}
+		body.WriteString(line + "\n")
+	}
+	body.WriteString("\n" + ui.HelpStyle.Render("↑↓: browse • Enter: edit • Ctrl+S: run • Esc: close"))
+	title := ui.TitleStyle.Render(fmt.Sprintf("PartiQL history (%s)", m.connectionKey()))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, ui.ModalStyle.Render(title+"\n\n"+body.String()))
+}
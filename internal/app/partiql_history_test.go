@@ -0,0 +1,93 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func stubConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	old := userConfigDir
+	userConfigDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { userConfigDir = old })
+	return dir
+}
+
+func TestPartiQLHistoryIsPerConnectionNewestFirst(t *testing.T) {
+	stubConfigDir(t)
+	base := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	for i, e := range []partiqlHistoryEntry{
+		{Connection: "us-east-1", Statement: "SELECT 1", RanAt: base},
+		{Connection: "eu-west-1", Statement: "SELECT 2", RanAt: base.Add(time.Minute)},
+		{Connection: "us-east-1", Statement: "SELECT 3", RanAt: base.Add(2 * time.Minute)},
+	} {
+		if err := appendPartiQLHistory(e); err != nil {
+			t.Fatalf("append %d: %v", i, err)
+		}
+	}
+	got, err := loadPartiQLHistory("us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Statement != "SELECT 3" || !got[1].RanAt.Equal(base) {
+		t.Fatalf("history = %+v", got)
+	}
+}
+
+func TestPartiQLHistoryDropsOldestPastMax(t *testing.T) {
+	dir := stubConfigDir(t)
+	path := filepath.Join(dir, "godynamo", partiqlHistoryFile)
+	os.MkdirAll(filepath.Dir(path), 0o700)
+	var buf bytes.Buffer
+	for i := 0; i < partiqlHistoryMax; i++ {
+		fmt.Fprintf(&buf, `{"connection":"c","statement":"S%d"}`+"\n", i)
+	}
+	os.WriteFile(path, buf.Bytes(), 0o600)
+
+	if err := appendPartiQLHistory(partiqlHistoryEntry{Connection: "c", Statement: "newest"}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := loadPartiQLHistory("c")
+	if len(got) != partiqlHistoryMax || got[0].Statement != "newest" || got[len(got)-1].Statement != "S1" {
+		t.Fatalf("len=%d newest=%q oldest=%q", len(got), got[0].Statement, got[len(got)-1].Statement)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("the rewrite should replace the history, not leave a temp file")
+	}
+}
+
+func TestPartiQLHistoryBrowseAndRestore(t *testing.T) {
+	stubConfigDir(t)
+	m := populatedModel()
+	m.view = viewTableData
+	m.selectedRegion = "us-east-1"
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+
+	for _, stmt := range []string{`SELECT * FROM "Users"`, `SELECT id FROM "Users"`} {
+		m.partiqlEditor.SetValue(stmt)
+		m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS}) // records; the command itself is not run
+		m.loading = false
+	}
+
+	m.partiqlEditor.SetValue("")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !m.partiqlHistoryOpen || len(m.partiqlHistory) != 2 {
+		t.Fatalf("history open=%v entries=%d", m.partiqlHistoryOpen, len(m.partiqlHistory))
+	}
+	if !strings.Contains(m.View(), "PartiQL history (us-east-1)") {
+		t.Fatal("history view not rendered")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.partiqlHistoryOpen || m.partiqlEditor.Value() != `SELECT * FROM "Users"` {
+		t.Fatalf("Enter should load the older statement, editor=%q", m.partiqlEditor.Value())
+	}
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/query"
+)
+
+func TestPartiQLOpensWithTableQuery(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+	if m.view != viewPartiQL || m.partiqlEditor.Value() != `SELECT * FROM "Users"` {
+		t.Fatalf("view=%v editor=%q", m.view, m.partiqlEditor.Value())
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewTableData {
+		t.Fatalf("esc returned to %v", m.view)
+	}
+}
+
+func TestPartiQLPagesAppend(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+
+	stmt := `SELECT * FROM "Users"`
+	m = drive(m, partiqlResultMsg{stmt: stmt, result: &dynamo.StatementResult{Items: m.items[:1], NextToken: aws.String("t")}})
+	m = drive(m, partiqlResultMsg{stmt: stmt, result: &dynamo.StatementResult{Items: m.items[1:2]}, more: true})
+	if len(m.partiqlItems) != 2 || m.partiqlNext != nil || len(m.partiqlTable.Rows) != 2 {
+		t.Fatalf("items=%d next=%v rows=%d", len(m.partiqlItems), m.partiqlNext, len(m.partiqlTable.Rows))
+	}
+	if m.partiqlTable.Headers[0] != "id" {
+		t.Fatalf("key column should lead for the open table: %v", m.partiqlTable.Headers)
+	}
+}
+
+func TestPartiQLWriteWaitsForConfirmation(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+
+	stmt := `DELETE FROM "Users" WHERE id = '1'`
+	w := &partiqlWrite{stmt: stmt, parsed: query.ParseStatement(stmt), affected: m.items[:1]}
+	m = drive(m, partiqlPreviewMsg{w})
+	if m.partiqlPending != w {
+		t.Fatal("preview should leave the write pending")
+	}
+	if !strings.Contains(m.View(), "DELETE will affect 1 item(s) in Users") {
+		t.Fatal("confirmation should show the affected item count")
+	}
+	m = drive(m, keyRunes("n"))
+	if m.partiqlPending != nil || m.view != viewPartiQL {
+		t.Fatal("n should cancel the write and stay in the editor")
+	}
+
+	m.partiqlEditor.SetValue(`INSERT INTO "Users" VALUE {'id': '3'}`)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.partiqlPending == nil || m.partiqlPending.parsed.Kind != "INSERT" {
+		t.Fatal("an INSERT needs confirmation before it runs")
+	}
+}
+
+func TestPartiQLConfirmsWhatItCannotCount(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+
+	stmt := `DELETE FROM "Users" WHERE age > 3`
+	m = drive(m, partiqlPreviewMsg{&partiqlWrite{stmt: stmt, parsed: query.ParseStatement(stmt), more: true}})
+	if out := m.View(); strings.Contains(out, "No items") || !strings.Contains(out, "unknown") {
+		t.Fatal("an empty first page with more to read shouldn't claim nothing matches")
+	}
+	m = drive(m, keyRunes("n"))
+
+	m.config.ReadOnly = true
+	m.partiqlEditor.SetValue(`EXISTS(SELECT * FROM "Users")`)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.loading || !strings.Contains(m.statusMsg, "Read-only") {
+		t.Fatalf("read-only mode should refuse an unrecognised statement: %q", m.statusMsg)
+	}
+	m.config.ReadOnly = false
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.partiqlPending == nil || !strings.Contains(m.View(), "wasn't recognised") {
+		t.Fatal("an unrecognised statement needs confirmation before it runs")
+	}
+}
+
+func TestPartiQLWriteReportsAffectedItems(t *testing.T) {
+	m := populatedModel()
+	stmt := `UPDATE "Users" SET age = 3 WHERE id = '1' RETURNING ALL NEW *`
+	w := &partiqlWrite{stmt: stmt, parsed: query.ParseStatement(stmt), affected: m.items[:1]}
+	returned := []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "1"}, "age": &types.AttributeValueMemberN{Value: "3"}}}
+	m.handlePartiQLWrite(partiqlWriteMsg{write: w, returned: returned})
+	if m.statusMsg != "✓ UPDATE applied to 1 item(s)" || len(m.partiqlItems) != 1 {
+		t.Fatalf("status=%q items=%d", m.statusMsg, len(m.partiqlItems))
+	}
+}
+
+func TestPartiQLCompletesTablesAttributesAndKeywords(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.tables = []string{"Users", "UserEvents", "Orders"}
+	m.itemsToTable(m.items) // what the table view infers from loaded items
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlE})
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	m.partiqlEditor.SetValue("SELECT * FROM Us")
+	m = drive(m, tab)
+	if got := m.partiqlEditor.Value(); got != `SELECT * FROM "Users"` {
+		t.Fatalf("table completion = %q", got)
+	}
+	m = drive(m, tab)
+	if got := m.partiqlEditor.Value(); got != `SELECT * FROM "UserEvents"` {
+		t.Fatalf("second Tab should cycle: %q", got)
+	}
+
+	m.partiqlEditor.SetValue(`SELECT * FROM "Users" WHERE na`)
+	m = drive(m, keyRunes("x")) // any other key ends the Tab cycle
+	m = drive(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m = drive(m, tab)
+	if got := m.partiqlEditor.Value(); got != `SELECT * FROM "Users" WHERE name` {
+		t.Fatalf("attribute completion = %q", got)
+	}
+
+	m.partiqlEditor.SetValue("sel")
+	m = drive(m, keyRunes("e"))
+	m = drive(m, tab)
+	if got := m.partiqlEditor.Value(); got != "select" {
+		t.Fatalf("keyword completion should keep the typed case: %q", got)
+	}
+	if m.partiqlFocusResults {
+		t.Fatal("a completing Tab must not move focus")
+	}
+}
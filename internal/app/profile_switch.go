@@ -0,0 +1,102 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// openProfileDropdown lists the AWS profiles to switch to, re-reading them
+// so profiles added while the program runs show up
+func (m *Model) openProfileDropdown() {
+	m.profiles = listProfiles()
+	if len(m.profiles) == 0 {
+		m.statusMsg = "✗ No profiles in ~/.aws/credentials"
+		return
+	}
+	m.regionDropdownOpen = false
+	m.profileDropdownOpen = true
+	m.profileIdx = max(0, slices.Index(m.profiles, m.config.Profile))
+}
+
+func (m *Model) updateProfileDropdown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.profileIdx > 0 {
+			m.profileIdx--
+		}
+	case "down", "j":
+		if m.profileIdx < len(m.profiles)-1 {
+			m.profileIdx++
+		}
+	case "enter":
+		m.profileDropdownOpen = false
+		if profile := m.profiles[m.profileIdx]; profile != m.config.Profile {
+			return m, m.switchProfile(profile)
+		}
+	case "esc", "ctrl+p":
+		m.profileDropdownOpen = false
+	}
+	return m, nil
+}
+
+// switchProfile reconnects with another profile's credentials. Its account
+// has other tables and regions, so discovery starts over.
+func (m *Model) switchProfile(profile string) tea.Cmd {
+	m.config.Profile = profile
+	m.client = nil
+	m.tables = nil
+	m.filteredTables = nil
+	m.tableList.SetItems(nil)
+	m.discoveredRegions = nil
+	m.selectedRegionIdx = 0
+	m.selectedRegion = m.config.Region
+	m.err = nil
+	m.loading = true
+	m.view = viewConnect
+	m.statusMsg = "Switching to profile " + profile + "..."
+	return m.connect()
+}
+
+// profileLabel names the profile in use
+func (m Model) profileLabel() string {
+	if m.config.Profile == "" {
+		return "default chain"
+	}
+	return m.config.Profile
+}
+
+// viewProfileDropdown renders the profile button and, when open, its list
+func (m Model) viewProfileDropdown() string {
+	var b strings.Builder
+	b.WriteString(ui.HelpStyle.Render("Profile: "))
+	label := " 👤 " + m.profileLabel() + " ▼ "
+	if !m.profileDropdownOpen {
+		b.WriteString(ui.ButtonStyle.Render(label))
+		return b.String()
+	}
+	b.WriteString(ui.ButtonFocusedStyle.Render(label))
+	b.WriteString("\n")
+
+	var list strings.Builder
+	for i, p := range m.profiles {
+		if i > 0 {
+			list.WriteString("\n")
+		}
+		if i == m.profileIdx {
+			list.WriteString(ui.SelectedStyle.Render("▸ " + p))
+		} else {
+			list.WriteString(ui.ItemStyle.Render("  " + p))
+		}
+	}
+	b.WriteString(lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorPrimary).
+		Padding(0, 1).
+		Render(list.String()))
+	return b.String()
+}
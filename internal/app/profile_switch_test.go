@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo/dynamotest"
+)
+
+func TestProfileDropdownSwitchesProfile(t *testing.T) {
+	old := listProfiles
+	listProfiles = func() []string { return []string{"default", "prod", "staging"} }
+	t.Cleanup(func() { listProfiles = old })
+
+	m := populatedModel()
+	m.view = viewTables
+	m.selectedRegion = "us-east-1"
+	m.tables = []string{"Users"}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if !m.profileDropdownOpen || !strings.Contains(m.View(), "staging") {
+		t.Fatalf("ctrl+p should list the profiles:\n%s", m.View())
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.config.Profile != "prod" || m.view != viewConnect || len(m.tables) != 0 || cmd == nil {
+		t.Fatalf("profile %q, view %d, tables %v", m.config.Profile, m.view, m.tables)
+	}
+	if got := m.connectionKey(); got != "prod@default" {
+		t.Errorf("connection key = %q, want the profile in it", got)
+	}
+	m.config.Endpoint = "http://localhost:8000"
+	if conn := m.connection("eu-west-1"); conn.Profile != "prod" || conn.Endpoint != "http://localhost:8000" || conn.Stats != m.opStats {
+		t.Errorf("region switches should connect as the session does: %+v", conn)
+	}
+}
+
+func TestConnectionsSwitchedAwayFromAreDropped(t *testing.T) {
+	m := populatedModel()
+	m.config.Profile = "prod"
+	m.selectedRegion = "eu-west-1"
+	m.client = nil
+
+	fake := dynamotest.New()
+	m = drive(m, connectionTestMsg{success: true, client: fake, profile: "staging", region: "eu-west-1"})
+	m = drive(m, connectionTestMsg{success: true, client: fake, profile: "prod", region: "us-east-1"})
+	m = drive(m, connectionTestMsg{err: errors.New("expired token"), profile: "staging"})
+	if m.client != nil || m.err != nil {
+		t.Fatalf("a stale connection was used: client %v, err %v", m.client, m.err)
+	}
+
+	m = drive(m, connectionTestMsg{success: true, client: fake, profile: "prod", region: "eu-west-1"})
+	if m.client == nil {
+		t.Fatal("the current connection should be used")
+	}
+}
+
+func TestProfileDropdownWithoutProfiles(t *testing.T) {
+	old := listProfiles
+	listProfiles = func() []string { return nil }
+	t.Cleanup(func() { listProfiles = old })
+
+	m := populatedModel()
+	m.view = viewTables
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.profileDropdownOpen || !strings.Contains(m.statusMsg, "No profiles") {
+		t.Fatalf("open = %v, status %q", m.profileDropdownOpen, m.statusMsg)
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// writeKeys are the keys of each view that start a change to a table or
+// item, which read-only mode refuses
+var writeKeys = map[viewMode]map[string]string{
+	viewTables:      {"ctrl+n": "creating tables", "ctrl+o": "importing from S3"},
+	viewTableData:   {"n": "creating items", "e": "editing items", "d": "deleting items", "X": "deleting items", "M": "renaming attributes", "ctrl+g": "generating items", "ctrl+t": "copying tables"},
+	viewItemDetail:  {"e": "editing items", "d": "deleting items"},
+	viewInsights:    {"e": "changing Contributor Insights"},
+	viewAutoScaling: {"enter": "changing auto scaling", "e": "changing auto scaling"},
+}
+
+// refusesWriteKey reports whether read-only mode stopped msg, telling the
+// user why. Keys typed into a search or filter are never refused.
+func (m *Model) refusesWriteKey(msg tea.KeyMsg) bool {
+	what, ok := writeKeys[m.view][msg.String()]
+	if !ok || !m.config.ReadOnly {
+		return false
+	}
+	if m.view == viewAutoScaling && m.scalingEditing {
+		return false
+	}
+	if m.view != viewTables && m.view != viewAutoScaling && !m.acceptsKeymap() {
+		return false
+	}
+	return m.refuseWrite(what)
+}
+
+// refuseWrite stops a change in read-only mode
+func (m *Model) refuseWrite(what string) bool {
+	if !m.config.ReadOnly {
+		return false
+	}
+	m.statusMsg = "✗ Read-only mode: " + what + " is disabled"
+	return true
+}
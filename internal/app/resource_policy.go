@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// resourcePolicyMsg carries a table's policy document, "" when it has none
+type resourcePolicyMsg struct{ key, policy string }
+
+// policyKey names the current table apart from same-named tables of other
+// profiles, regions and endpoints
+func (m *Model) policyKey() string {
+	return m.connectionKey() + "/" + m.currentTable
+}
+
+// toggleResourcePolicy switches the schema view between the table
+// description and its resource-based policy, fetching the policy once.
+func (m *Model) toggleResourcePolicy() tea.Cmd {
+	if m.showPolicy {
+		m.showPolicy = false
+		m.prepareSchemaView()
+		return nil
+	}
+	if m.tableInfo == nil || m.tableInfo.Arn == "" {
+		m.statusMsg = "✗ Table ARN unknown"
+		return nil
+	}
+	m.showPolicy = true
+	if m.policyFor == m.policyKey() {
+		m.preparePolicyView()
+		return nil
+	}
+	m.loading = true
+	m.statusMsg = "Reading resource policy..."
+	key, arn := m.policyKey(), m.tableInfo.Arn
+	return func() tea.Msg {
+		policy, err := m.client.ResourcePolicy(context.Background(), arn)
+		if err != nil {
+			return errMsg{err}
+		}
+		return resourcePolicyMsg{key, policy}
+	}
+}
+
+func (m *Model) handleResourcePolicy(msg resourcePolicyMsg) {
+	m.loading = false
+	m.policyFor, m.policy = msg.key, msg.policy
+	m.statusMsg = ""
+	if m.showPolicy && msg.key == m.policyKey() {
+		m.preparePolicyView()
+	}
+}
+
+func (m *Model) preparePolicyView() {
+	if m.policy == "" {
+		m.itemViewport.SetContent(ui.HelpStyle.Render("No resource-based policy. Access is granted only by IAM identity policies."))
+		return
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(m.policy), &doc); err != nil {
+		m.itemViewport.SetContent(m.policy)
+		return
+	}
+	m.itemViewport.SetContent(ui.NewJSONViewer(doc).Render())
+	m.itemViewport.GotoTop()
+}
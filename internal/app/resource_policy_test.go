@@ -0,0 +1,47 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaViewTogglesResourcePolicy(t *testing.T) {
+	m := populatedModel()
+	m.tableInfo.Arn = "arn:aws:dynamodb:us-east-1:111122223333:table/Users"
+	m.view = viewSchema
+
+	if _, cmd := m.Update(keyRunes("p")); cmd == nil {
+		t.Fatal("the first toggle should fetch the policy")
+	}
+	m = drive(m, keyRunes("p"))
+	m = drive(m, resourcePolicyMsg{m.policyKey(), `{"Statement":[{"Principal":{"AWS":"arn:aws:iam::444455556666:root"}}]}`})
+	out := m.View()
+	if !strings.Contains(out, "Resource Policy: Users") || !strings.Contains(out, "444455556666") {
+		t.Fatalf("policy not shown:\n%s", out)
+	}
+
+	// Back to the schema, then to the policy again without refetching
+	m = drive(m, keyRunes("p"))
+	if m.showPolicy || !strings.Contains(m.View(), "Table Schema") {
+		t.Fatal("p should switch back to the schema")
+	}
+	if _, cmd := m.Update(keyRunes("p")); cmd != nil {
+		t.Fatal("the policy should be kept for the open table")
+	}
+
+	// The same table name in another region is another table
+	m.selectedRegion = "eu-west-1"
+	if _, cmd := m.Update(keyRunes("p")); cmd == nil {
+		t.Fatal("another region's table should have its policy fetched")
+	}
+}
+
+func TestSchemaViewWithoutResourcePolicy(t *testing.T) {
+	m := populatedModel()
+	m.tableInfo.Arn = "arn:aws:dynamodb:us-east-1:111122223333:table/Users"
+	m.view = viewSchema
+	m = drive(drive(m, keyRunes("p")), resourcePolicyMsg{m.policyKey(), ""})
+	if !strings.Contains(m.View(), "No resource-based policy") {
+		t.Fatal("a table without a policy should say so")
+	}
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rowIndex is a trigram index over the loaded rows' cell text, so searching
+// tens of thousands of rows only verifies the few that share the query's
+// trigrams instead of scanning every cell.
+type rowIndex struct {
+	rows  [][]string
+	text  []string         // lowercased cells of each row, joined by \x00
+	grams map[string][]int // trigram → ascending row numbers
+}
+
+func newRowIndex(rows [][]string) *rowIndex {
+	ix := &rowIndex{rows: rows, text: make([]string, len(rows)), grams: map[string][]int{}}
+	for r, row := range rows {
+		text := strings.ToLower(strings.Join(row, "\x00"))
+		ix.text[r] = text
+		for i := 0; i+3 <= len(text); i++ {
+			g := text[i : i+3]
+			if strings.IndexByte(g, 0) >= 0 {
+				continue
+			}
+			if p := ix.grams[g]; len(p) == 0 || p[len(p)-1] != r {
+				ix.grams[g] = append(p, r)
+			}
+		}
+	}
+	return ix
+}
+
+// covers reports whether the index was built from rows
+func (ix *rowIndex) covers(rows [][]string) bool {
+	return ix != nil && len(ix.rows) == len(rows) && (len(rows) == 0 || &ix.rows[0] == &rows[0])
+}
+
+// search returns the rows with a cell containing query, case-insensitively
+func (ix *rowIndex) search(query string) []int {
+	q := strings.ToLower(query)
+	if q == "" || strings.IndexByte(q, 0) >= 0 {
+		return nil
+	}
+
+	var candidates []int
+	if len(q) < 3 {
+		candidates = make([]int, len(ix.text))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	} else {
+		// Start from the rarest trigram; every match must contain all of them
+		var lists [][]int
+		for i := 0; i+3 <= len(q); i++ {
+			p, ok := ix.grams[q[i:i+3]]
+			if !ok {
+				return nil
+			}
+			lists = append(lists, p)
+		}
+		sort.Slice(lists, func(a, b int) bool { return len(lists[a]) < len(lists[b]) })
+		candidates = lists[0]
+		for _, l := range lists[1:] {
+			candidates = intersectSorted(candidates, l)
+		}
+	}
+
+	var hits []int
+	for _, r := range candidates {
+		if strings.Contains(ix.text[r], q) {
+			hits = append(hits, r)
+		}
+	}
+	return hits
+}
+
+func intersectSorted(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+func (m *Model) openRowSearch() tea.Cmd {
+	if m.rowSearchInput.Placeholder == "" {
+		m.rowSearchInput = textinput.New()
+		m.rowSearchInput.Placeholder = "Search loaded rows..."
+		m.rowSearchInput.CharLimit = 156
+		m.rowSearchInput.Width = 30
+	}
+	m.rowSearchMode = true
+	m.rowSearchInput.SetValue(m.dataTable.Highlight)
+	return m.rowSearchInput.Focus()
+}
+
+func (m *Model) updateRowSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.rowSearchMode = false
+		m.rowSearchInput.Blur()
+		return m, nil
+	case "enter", "ctrl+n":
+		m.nextRowMatch(1)
+		return m, nil
+	case "ctrl+p":
+		m.nextRowMatch(-1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.rowSearchInput, cmd = m.rowSearchInput.Update(msg)
+	if q := m.rowSearchInput.Value(); q != m.dataTable.Highlight {
+		m.runRowSearch(q)
+	}
+	return m, cmd
+}
+
+// runRowSearch finds the rows matching q and jumps to the first match at or
+// after the selected row.
+func (m *Model) runRowSearch(q string) {
+	if !m.rowIdx.covers(m.dataTable.Rows) {
+		m.rowIdx = newRowIndex(m.dataTable.Rows)
+	}
+	m.dataTable.Highlight = q
+	m.rowMatches = m.rowIdx.search(q)
+	m.rowMatchIdx = sort.SearchInts(m.rowMatches, m.dataTable.SelectedRow)
+	if m.rowMatchIdx == len(m.rowMatches) {
+		m.rowMatchIdx = 0
+	}
+	if len(m.rowMatches) > 0 {
+		m.dataTable.GoToRow(m.rowMatches[m.rowMatchIdx])
+	}
+}
+
+func (m *Model) nextRowMatch(step int) {
+	if len(m.rowMatches) == 0 {
+		return
+	}
+	n := len(m.rowMatches)
+	m.rowMatchIdx = ((m.rowMatchIdx+step)%n + n) % n
+	m.dataTable.GoToRow(m.rowMatches[m.rowMatchIdx])
+}
+
+func (m *Model) clearRowSearch() {
+	m.rowSearchMode = false
+	m.rowSearchInput.Blur()
+	m.dataTable.Highlight = ""
+	m.rowMatches = nil
+	m.rowMatchIdx = 0
+}
+
+// rowSearchStatus is the status-bar fragment for an active row search
+func (m Model) rowSearchStatus() string {
+	if m.dataTable.Highlight == "" {
+		return ""
+	}
+	if len(m.rowMatches) == 0 {
+		return fmt.Sprintf(" | Search %q: no matches", m.dataTable.Highlight)
+	}
+	return fmt.Sprintf(" | Search %q: %d/%d rows (^N/^P)", m.dataTable.Highlight, m.rowMatchIdx+1, len(m.rowMatches))
+}
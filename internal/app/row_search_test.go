@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRowIndexSearchMatchesLinearScan(t *testing.T) {
+	var rows [][]string
+	for i := 0; i < 500; i++ {
+		rows = append(rows, []string{fmt.Sprint(i), fmt.Sprintf("user-%d@Example.com", i), "active"})
+	}
+	ix := newRowIndex(rows)
+	for _, q := range []string{"42", "EXAMPLE", "user-49", "ive", "a", "missing", "0@ex"} {
+		var want []int
+		for r, row := range rows {
+			for _, cell := range row {
+				if strings.Contains(strings.ToLower(cell), strings.ToLower(q)) {
+					want = append(want, r)
+					break
+				}
+			}
+		}
+		if got := ix.search(q); !reflect.DeepEqual(got, want) {
+			t.Fatalf("search(%q) = %d rows, linear scan = %d", q, len(got), len(want))
+		}
+	}
+}
+
+func TestRowIndexDoesNotMatchAcrossCells(t *testing.T) {
+	ix := newRowIndex([][]string{{"ab", "cd"}})
+	if got := ix.search("bc"); got != nil {
+		t.Fatalf("matched across a cell boundary: %v", got)
+	}
+}
+
+func TestRowSearchJumpsBetweenMatches(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, keyRunes("/"))
+	if !m.rowSearchMode {
+		t.Fatal("/ did not open row search")
+	}
+	m = drive(m, keyRunes("bob"))
+	if m.dataTable.SelectedRow != 1 || len(m.rowMatches) != 1 {
+		t.Fatalf("search for bob: row=%d matches=%v", m.dataTable.SelectedRow, m.rowMatches)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.rowSearchMode || m.dataTable.Highlight != "bob" {
+		t.Fatal("esc should close the input and keep the highlight")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.dataTable.Highlight != "" || m.view != viewTableData {
+		t.Fatal("second esc should clear the search, not leave the table")
+	}
+}
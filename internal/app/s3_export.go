@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// s3PollInterval is how often a running S3 export is re-described. Exports
+// take minutes at the very least, so there is no point polling faster.
+var s3PollInterval = 5 * time.Second
+
+// maxS3PollBackoff caps the wait between polls after describes fail
+const maxS3PollBackoff = 2 * time.Minute
+
+type (
+	s3ExportMsg     struct{ export *dynamo.S3Export }
+	s3ExportPollMsg struct{ arn string }
+
+	// s3ExportPollFailedMsg is a describe of a running export that failed;
+	// the export itself may well still be running
+	s3ExportPollFailedMsg struct {
+		arn string
+		err error
+	}
+)
+
+// s3PollBackoff is the wait before polling again after fails describes in a
+// row failed: the poll interval, doubled per failure
+func s3PollBackoff(fails int) time.Duration {
+	return min(s3PollInterval<<min(fails, 6), maxS3PollBackoff)
+}
+
+// Focus positions in the S3 export form
+const (
+	s3FieldBucket = iota
+	s3FieldPrefix
+	s3FieldFormat
+	s3FieldCount
+)
+
+func (m *Model) openS3Export() tea.Cmd {
+	if m.s3ExportInputs == nil {
+		bucket := textinput.New()
+		bucket.Placeholder = "S3 bucket name"
+		prefix := textinput.New()
+		prefix.Placeholder = "Key prefix (optional)"
+		m.s3ExportInputs = []textinput.Model{bucket, prefix}
+	}
+	if m.s3Export != nil && m.s3Export.Done() {
+		m.s3Export = nil
+	}
+	m.view = viewS3Export
+	return m.focusS3ExportField(s3FieldBucket)
+}
+
+func (m *Model) focusS3ExportField(field int) tea.Cmd {
+	m.s3ExportFocus = field
+	var cmd tea.Cmd
+	for i := range m.s3ExportInputs {
+		if i == field {
+			cmd = m.s3ExportInputs[i].Focus()
+		} else {
+			m.s3ExportInputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *Model) updateS3Export(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// An export is running or finished: the view is a status panel. Leaving it
+	// doesn't cancel anything; polling carries on and reports via statusMsg.
+	if m.s3Export != nil {
+		switch msg.String() {
+		case "q", "esc":
+			m.view = viewTableData
+		case "y":
+			if m.s3Export.Done() {
+				if err := copyToClipboard(m.s3Export.Location()); err == nil {
+					m.statusMsg = "✓ Copied S3 location to clipboard"
+				} else {
+					m.statusMsg = "✗ Failed to copy: " + err.Error()
+				}
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.view = viewTableData
+		return m, nil
+	case "tab", "down":
+		return m, m.focusS3ExportField((m.s3ExportFocus + 1) % s3FieldCount)
+	case "shift+tab", "up":
+		return m, m.focusS3ExportField((m.s3ExportFocus + s3FieldCount - 1) % s3FieldCount)
+	case "enter":
+		bucket := strings.TrimSpace(m.s3ExportInputs[s3FieldBucket].Value())
+		if bucket == "" {
+			m.statusMsg = "✗ S3 bucket is required"
+			return m, nil
+		}
+		format := "DYNAMODB_JSON"
+		if m.s3ExportIon {
+			format = "ION"
+		}
+		m.loading = true
+		m.statusMsg = "Starting S3 export..."
+		return m, m.startS3Export(dynamo.S3ExportInput{
+			TableName: m.currentTable,
+			Bucket:    bucket,
+			Prefix:    strings.TrimSpace(m.s3ExportInputs[s3FieldPrefix].Value()),
+			Format:    format,
+		})
+	}
+
+	if m.s3ExportFocus == s3FieldFormat {
+		switch msg.String() {
+		case " ", "left", "right", "h", "l":
+			m.s3ExportIon = !m.s3ExportIon
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.s3ExportInputs[m.s3ExportFocus], cmd = m.s3ExportInputs[m.s3ExportFocus].Update(msg)
+	return m, cmd
+}
+
+// handleS3Export records the latest export state and schedules the next poll
+// while the export is still running.
+func (m *Model) handleS3Export(exp *dynamo.S3Export) tea.Cmd {
+	m.s3Export = exp
+	m.s3ExportFails, m.s3ExportPollErr = 0, ""
+	m.loading = false
+	switch {
+	case !exp.Done():
+		m.statusMsg = "S3 export in progress..."
+		arn := exp.Arn
+		return tea.Tick(s3PollInterval, func(time.Time) tea.Msg { return s3ExportPollMsg{arn: arn} })
+	case exp.FailureMessage != "" || exp.Status == "FAILED":
+		m.statusMsg = "✗ S3 export failed: " + exp.FailureMessage
+	default:
+		m.statusMsg = "✓ S3 export completed: " + exp.Location()
+	}
+	return nil
+}
+
+func (m *Model) startS3Export(input dynamo.S3ExportInput) tea.Cmd {
+	return func() tea.Msg {
+		exp, err := m.client.StartS3Export(context.Background(), input)
+		if err != nil {
+			return errMsg{err}
+		}
+		return s3ExportMsg{exp}
+	}
+}
+
+// handleS3ExportPollFailed keeps polling an export whose describe failed,
+// backing off, so one transient error doesn't leave it shown as running
+func (m *Model) handleS3ExportPollFailed(msg s3ExportPollFailedMsg) tea.Cmd {
+	m.s3ExportFails++
+	m.s3ExportPollErr = msg.err.Error()
+	wait := s3PollBackoff(m.s3ExportFails)
+	m.statusMsg = fmt.Sprintf("⚠ Checking the S3 export failed, retrying in %s: %v", shortDuration(wait), msg.err)
+	arn := msg.arn
+	return tea.Tick(wait, func(time.Time) tea.Msg { return s3ExportPollMsg{arn: arn} })
+}
+
+func (m *Model) describeS3Export(arn string) tea.Cmd {
+	return func() tea.Msg {
+		exp, err := m.client.DescribeS3Export(context.Background(), arn)
+		if err != nil {
+			return s3ExportPollFailedMsg{arn: arn, err: err}
+		}
+		return s3ExportMsg{exp}
+	}
+}
+
+func (m Model) viewS3Export() string {
+	var body strings.Builder
+
+	if exp := m.s3Export; exp != nil {
+		body.WriteString(ui.ItemStyle.Render("Status:   ") + exp.Status + "\n")
+		body.WriteString(ui.ItemStyle.Render("Location: ") + exp.Location() + "\n")
+		if exp.Done() {
+			body.WriteString(ui.ItemStyle.Render("Items:    ") + fmt.Sprintf("%d", exp.ItemCount) + "\n")
+		}
+		if exp.FailureMessage != "" {
+			body.WriteString(ui.ErrorStyle.Render(exp.FailureMessage) + "\n")
+		}
+		if m.s3ExportPollErr != "" {
+			body.WriteString(ui.WarningStyle.Render("Last check failed, retrying: "+m.s3ExportPollErr) + "\n")
+		}
+		body.WriteString("\n")
+		help := "Esc: close (the export keeps running)"
+		if exp.Done() {
+			help = "y: copy location • Esc: close"
+		}
+		body.WriteString(ui.HelpStyle.Render(help))
+	} else {
+		body.WriteString(ui.HelpStyle.Render("Uses DynamoDB's native export; the table needs point-in-time recovery.") + "\n\n")
+		labels := []string{"Bucket", "Prefix"}
+		for i, in := range m.s3ExportInputs {
+			style := ui.InputStyle
+			if i == m.s3ExportFocus {
+				style = ui.InputFocusedStyle
+			}
+			body.WriteString(ui.ItemStyle.Render(labels[i]+":") + "\n" + style.Render(in.View()) + "\n")
+		}
+		format := "(•) DynamoDB JSON  ( ) ION"
+		if m.s3ExportIon {
+			format = "( ) DynamoDB JSON  (•) ION"
+		}
+		if m.s3ExportFocus == s3FieldFormat {
+			format = ui.SelectedStyle.Render(format)
+		}
+		body.WriteString(ui.ItemStyle.Render("Format:") + "\n" + format + "\n\n")
+		body.WriteString(ui.HelpStyle.Render("Tab: next field • Space: switch format • Enter: start • Esc: cancel"))
+	}
+
+	content := ui.ModalStyle.Render(
+		ui.TitleStyle.Render("☁ Export "+m.currentTable+" to S3") + "\n\n" + body.String(),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
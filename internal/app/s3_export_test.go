@@ -0,0 +1,92 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestS3ExportFormRequiresBucket(t *testing.T) {
+	m := populatedModel()
+	m.view = viewExport
+	m = drive(m, keyRunes("s"))
+	if m.view != viewS3Export {
+		t.Fatalf("view = %v, want viewS3Export", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.loading || !strings.Contains(m.statusMsg, "bucket is required") {
+		t.Fatalf("loading = %v status = %q", m.loading, m.statusMsg)
+	}
+}
+
+func TestS3ExportFormFieldsAndFormat(t *testing.T) {
+	m := populatedModel()
+	m.view = viewExport
+	m = drive(m, keyRunes("s"))
+	m = drive(m, keyRunes("bkt"))
+	if got := m.s3ExportInputs[s3FieldBucket].Value(); got != "bkt" {
+		t.Fatalf("bucket = %q", got)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.s3ExportFocus != s3FieldFormat {
+		t.Fatalf("focus = %d, want format", m.s3ExportFocus)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if !m.s3ExportIon {
+		t.Fatal("space on the format field should select ION")
+	}
+}
+
+func TestHandleS3ExportPollsUntilDone(t *testing.T) {
+	m := populatedModel()
+	if cmd := m.handleS3Export(&dynamo.S3Export{Arn: "arn", Status: "IN_PROGRESS"}); cmd == nil {
+		t.Fatal("an in-progress export should schedule a poll")
+	}
+	done := &dynamo.S3Export{Arn: "arn", Status: "COMPLETED", Bucket: "bkt", Manifest: "p/AWSDynamoDB/01/manifest-summary.json"}
+	if cmd := m.handleS3Export(done); cmd != nil {
+		t.Fatal("a finished export should stop polling")
+	}
+	if !strings.Contains(m.statusMsg, "s3://bkt/p/AWSDynamoDB/01/") {
+		t.Fatalf("statusMsg = %q", m.statusMsg)
+	}
+	m.view = viewS3Export
+	if out := m.View(); !strings.Contains(out, "COMPLETED") {
+		t.Fatal("status panel should show the export status")
+	}
+}
+
+func TestS3ExportKeepsPollingAfterAFailedDescribe(t *testing.T) {
+	m := populatedModel()
+	m.handleS3Export(&dynamo.S3Export{Arn: "arn", Status: "IN_PROGRESS"})
+	for fails := 1; fails <= 3; fails++ {
+		if cmd := m.handleS3ExportPollFailed(s3ExportPollFailedMsg{arn: "arn", err: errors.New("throttled")}); cmd == nil {
+			t.Fatal("a failed describe should schedule another poll")
+		}
+		if m.s3ExportFails != fails || !strings.Contains(m.statusMsg, "throttled") {
+			t.Fatalf("fails = %d, status %q", m.s3ExportFails, m.statusMsg)
+		}
+	}
+	m.view = viewS3Export
+	if out := m.View(); !strings.Contains(out, "IN_PROGRESS") || !strings.Contains(out, "throttled") {
+		t.Fatalf("the panel should show the export still running and the error:\n%s", out)
+	}
+
+	m.handleS3Export(&dynamo.S3Export{Arn: "arn", Status: "IN_PROGRESS"})
+	if m.s3ExportFails != 0 || m.s3ExportPollErr != "" {
+		t.Fatal("a describe that works should clear the failures")
+	}
+}
+
+func TestS3PollBackoffGrowsToACap(t *testing.T) {
+	if s3PollBackoff(1) != 2*s3PollInterval || s3PollBackoff(2) != 4*s3PollInterval {
+		t.Fatalf("backoff = %s, %s", s3PollBackoff(1), s3PollBackoff(2))
+	}
+	if got := s3PollBackoff(50); got != maxS3PollBackoff {
+		t.Fatalf("backoff after many failures = %s", got)
+	}
+}
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+type (
+	s3ImportMsg     struct{ imp *dynamo.S3Import }
+	s3ImportPollMsg struct{ arn string }
+
+	// s3ImportPollFailedMsg is a describe of a running import that failed
+	s3ImportPollFailedMsg struct {
+		arn string
+		err error
+	}
+)
+
+// Text fields of the S3 import form, followed by the two choice fields
+const (
+	importFieldBucket = iota
+	importFieldPrefix
+	importFieldTable
+	importFieldPK
+	importFieldPKType
+	importFieldSK
+	importFieldSKType
+	importFieldFormat
+	importFieldCompression
+	importFieldCount
+)
+
+var (
+	importFormats      = []string{"DYNAMODB_JSON", "ION", "CSV"}
+	importCompressions = []string{"NONE", "GZIP", "ZSTD"}
+)
+
+func (m *Model) openS3Import() tea.Cmd {
+	if m.s3ImportInputs == nil {
+		placeholders := []string{
+			"S3 bucket name",
+			"Key prefix (optional)",
+			"New table name",
+			"Partition key name (e.g., id)",
+			"Partition key type: S, N, or B",
+			"Sort key name (optional)",
+			"Sort key type: S, N, or B",
+		}
+		m.s3ImportInputs = make([]textinput.Model, len(placeholders))
+		for i, p := range placeholders {
+			m.s3ImportInputs[i] = textinput.New()
+			m.s3ImportInputs[i].Placeholder = p
+		}
+		m.s3ImportInputs[importFieldPKType].SetValue("S")
+		m.s3ImportInputs[importFieldSKType].SetValue("S")
+	}
+	if m.s3Import != nil && m.s3Import.Done() {
+		m.s3Import = nil
+	}
+	m.view = viewS3Import
+	return m.focusS3ImportField(importFieldBucket)
+}
+
+func (m *Model) focusS3ImportField(field int) tea.Cmd {
+	m.s3ImportFocus = field
+	var cmd tea.Cmd
+	for i := range m.s3ImportInputs {
+		if i == field {
+			cmd = m.s3ImportInputs[i].Focus()
+		} else {
+			m.s3ImportInputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *Model) updateS3Import(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.s3Import != nil {
+		switch msg.String() {
+		case "q", "esc":
+			m.view = viewTables
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.view = viewTables
+		return m, nil
+	case "tab", "down":
+		return m, m.focusS3ImportField((m.s3ImportFocus + 1) % importFieldCount)
+	case "shift+tab", "up":
+		return m, m.focusS3ImportField((m.s3ImportFocus + importFieldCount - 1) % importFieldCount)
+	case "enter":
+		input, err := m.s3ImportInput()
+		if err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return m, nil
+		}
+		m.loading = true
+		m.statusMsg = "Starting S3 import..."
+		return m, m.startS3Import(input)
+	}
+
+	switch m.s3ImportFocus {
+	case importFieldFormat, importFieldCompression:
+		step := 0
+		switch msg.String() {
+		case " ", "right", "l":
+			step = 1
+		case "left", "h":
+			step = -1
+		}
+		if m.s3ImportFocus == importFieldFormat {
+			m.s3ImportFormat = (m.s3ImportFormat + step + len(importFormats)) % len(importFormats)
+		} else {
+			m.s3ImportCompression = (m.s3ImportCompression + step + len(importCompressions)) % len(importCompressions)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.s3ImportInputs[m.s3ImportFocus], cmd = m.s3ImportInputs[m.s3ImportFocus].Update(msg)
+	return m, cmd
+}
+
+// s3ImportInput validates the form. The new table is created on-demand;
+// capacity can be changed once the import has finished.
+func (m *Model) s3ImportInput() (dynamo.S3ImportInput, error) {
+	value := func(field int) string { return strings.TrimSpace(m.s3ImportInputs[field].Value()) }
+
+	input := dynamo.S3ImportInput{
+		Bucket:      value(importFieldBucket),
+		Prefix:      value(importFieldPrefix),
+		Format:      importFormats[m.s3ImportFormat],
+		Compression: importCompressions[m.s3ImportCompression],
+		Table: dynamo.CreateTableInput{
+			TableName:     value(importFieldTable),
+			PartitionKey:  value(importFieldPK),
+			PartitionType: strings.ToUpper(value(importFieldPKType)),
+			SortKey:       value(importFieldSK),
+			SortKeyType:   strings.ToUpper(value(importFieldSKType)),
+			BillingMode:   "PAY_PER_REQUEST",
+		},
+	}
+
+	switch {
+	case input.Bucket == "":
+		return input, fmt.Errorf("S3 bucket is required")
+	case input.Table.TableName == "":
+		return input, fmt.Errorf("table name is required")
+	case input.Table.PartitionKey == "":
+		return input, fmt.Errorf("partition key is required")
+	case !validKeyType(input.Table.PartitionType):
+		return input, fmt.Errorf("partition key type must be S, N or B")
+	case input.Table.SortKey != "" && !validKeyType(input.Table.SortKeyType):
+		return input, fmt.Errorf("sort key type must be S, N or B")
+	}
+	return input, nil
+}
+
+func validKeyType(t string) bool {
+	return t == "S" || t == "N" || t == "B"
+}
+
+// handleS3Import records the latest import state and keeps polling until the
+// import reaches a final state.
+func (m *Model) handleS3Import(imp *dynamo.S3Import) tea.Cmd {
+	m.s3Import = imp
+	m.s3ImportFails, m.s3ImportPollErr = 0, ""
+	m.loading = false
+	switch {
+	case !imp.Done():
+		m.statusMsg = fmt.Sprintf("S3 import in progress (%d items processed)...", imp.ProcessedItems)
+		arn := imp.Arn
+		return tea.Tick(s3PollInterval, func(time.Time) tea.Msg { return s3ImportPollMsg{arn: arn} })
+	case imp.Failed():
+		m.statusMsg = fmt.Sprintf("✗ S3 import %s: %s %s", strings.ToLower(imp.Status), imp.FailureCode, imp.FailureMessage)
+		return nil
+	default:
+		m.statusMsg = fmt.Sprintf("✓ Imported %d items into %s", imp.ImportedItems, imp.TableName)
+		return m.loadTables()
+	}
+}
+
+func (m *Model) startS3Import(input dynamo.S3ImportInput) tea.Cmd {
+	return func() tea.Msg {
+		imp, err := m.client.StartS3Import(context.Background(), input)
+		if err != nil {
+			return errMsg{err}
+		}
+		return s3ImportMsg{imp}
+	}
+}
+
+// handleS3ImportPollFailed keeps polling an import whose describe failed,
+// backing off like an export's polls do
+func (m *Model) handleS3ImportPollFailed(msg s3ImportPollFailedMsg) tea.Cmd {
+	m.s3ImportFails++
+	m.s3ImportPollErr = msg.err.Error()
+	wait := s3PollBackoff(m.s3ImportFails)
+	m.statusMsg = fmt.Sprintf("⚠ Checking the S3 import failed, retrying in %s: %v", shortDuration(wait), msg.err)
+	arn := msg.arn
+	return tea.Tick(wait, func(time.Time) tea.Msg { return s3ImportPollMsg{arn: arn} })
+}
+
+func (m *Model) describeS3Import(arn string) tea.Cmd {
+	return func() tea.Msg {
+		imp, err := m.client.DescribeS3Import(context.Background(), arn)
+		if err != nil {
+			return s3ImportPollFailedMsg{arn: arn, err: err}
+		}
+		return s3ImportMsg{imp}
+	}
+}
+
+func (m Model) viewS3Import() string {
+	var body strings.Builder
+
+	if imp := m.s3Import; imp != nil {
+		body.WriteString(ui.ItemStyle.Render("Table:     ") + imp.TableName + "\n")
+		body.WriteString(ui.ItemStyle.Render("Status:    ") + imp.Status + "\n")
+		body.WriteString(ui.ItemStyle.Render("Processed: ") + fmt.Sprintf("%d", imp.ProcessedItems) + "\n")
+		body.WriteString(ui.ItemStyle.Render("Imported:  ") + fmt.Sprintf("%d", imp.ImportedItems) + "\n")
+		if imp.ErrorCount > 0 {
+			body.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("%d items rejected", imp.ErrorCount)) + "\n")
+		}
+		if imp.FailureCode != "" || imp.FailureMessage != "" {
+			body.WriteString(ui.ErrorStyle.Render(strings.TrimSpace(imp.FailureCode+": "+imp.FailureMessage)) + "\n")
+		}
+		if imp.LogGroupArn != "" && (imp.ErrorCount > 0 || imp.Failed()) {
+			body.WriteString(ui.HelpStyle.Render("Details: "+imp.LogGroupArn) + "\n")
+		}
+		if m.s3ImportPollErr != "" {
+			body.WriteString(ui.WarningStyle.Render("Last check failed, retrying: "+m.s3ImportPollErr) + "\n")
+		}
+		body.WriteString("\n")
+		help := "Esc: close (the import keeps running)"
+		if imp.Done() {
+			help = "Esc: close"
+		}
+		body.WriteString(ui.HelpStyle.Render(help))
+	} else {
+		body.WriteString(ui.HelpStyle.Render("ImportTable creates a new on-demand table from S3 data.") + "\n\n")
+		labels := []string{"Bucket", "Prefix", "Table name", "Partition key", "PK type", "Sort key", "SK type"}
+		for i, in := range m.s3ImportInputs {
+			style := ui.InputStyle
+			if i == m.s3ImportFocus {
+				style = ui.InputFocusedStyle
+			}
+			body.WriteString(ui.ItemStyle.Render(labels[i]+":") + "\n" + style.Render(in.View()) + "\n")
+		}
+		body.WriteString(m.renderImportChoice("Format", importFormats, m.s3ImportFormat, importFieldFormat))
+		body.WriteString(m.renderImportChoice("Compression", importCompressions, m.s3ImportCompression, importFieldCompression))
+		body.WriteString("\n" + ui.HelpStyle.Render("Tab: next field • Space/←→: change option • Enter: start • Esc: cancel"))
+	}
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("☁ Import table from S3") + "\n\n" + body.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+func (m Model) renderImportChoice(label string, options []string, selected, field int) string {
+	parts := make([]string, len(options))
+	for i, o := range options {
+		if i == selected {
+			parts[i] = "(•) " + o
+		} else {
+			parts[i] = "( ) " + o
+		}
+	}
+	line := strings.Join(parts, "  ")
+	if m.s3ImportFocus == field {
+		line = ui.SelectedStyle.Render(line)
+	}
+	return ui.ItemStyle.Render(label+":") + "\n" + line + "\n"
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestS3ImportFormValidation(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTables
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.view != viewS3Import {
+		t.Fatalf("view = %v, want viewS3Import", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.loading || !strings.Contains(m.statusMsg, "bucket is required") {
+		t.Fatalf("loading = %v status = %q", m.loading, m.statusMsg)
+	}
+
+	m.s3ImportInputs[importFieldBucket].SetValue("bkt")
+	m.s3ImportInputs[importFieldTable].SetValue("Users")
+	m.s3ImportInputs[importFieldPK].SetValue("id")
+	m.s3ImportInputs[importFieldPKType].SetValue("x")
+	if _, err := m.s3ImportInput(); err == nil || !strings.Contains(err.Error(), "partition key type") {
+		t.Fatalf("err = %v", err)
+	}
+	m.s3ImportInputs[importFieldPKType].SetValue("n")
+	input, err := m.s3ImportInput()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.Table.PartitionType != "N" || input.Format != "DYNAMODB_JSON" || input.Compression != "NONE" {
+		t.Fatalf("input = %+v", input)
+	}
+}
+
+func TestS3ImportChoiceFieldsCycle(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTables
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.s3ImportFocus != importFieldCompression {
+		t.Fatalf("focus = %d, want compression", m.s3ImportFocus)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyLeft})
+	if importCompressions[m.s3ImportCompression] != "ZSTD" {
+		t.Fatalf("compression = %s", importCompressions[m.s3ImportCompression])
+	}
+}
+
+func TestHandleS3ImportReportsFailure(t *testing.T) {
+	m := populatedModel()
+	if cmd := m.handleS3Import(&dynamo.S3Import{Arn: "arn", Status: "IN_PROGRESS"}); cmd == nil {
+		t.Fatal("an in-progress import should schedule a poll")
+	}
+	failed := &dynamo.S3Import{Status: "FAILED", FailureCode: "S3NoSuchKey", FailureMessage: "missing", LogGroupArn: "arn:logs"}
+	if cmd := m.handleS3Import(failed); cmd != nil {
+		t.Fatal("a failed import should stop polling")
+	}
+	if !strings.Contains(m.statusMsg, "S3NoSuchKey") {
+		t.Fatalf("statusMsg = %q", m.statusMsg)
+	}
+	m.view = viewS3Import
+	if out := m.View(); !strings.Contains(out, "arn:logs") {
+		t.Fatal("failed import should point at its CloudWatch log group")
+	}
+}
+
+func TestS3ImportKeepsPollingAfterAFailedDescribe(t *testing.T) {
+	m := populatedModel()
+	m.handleS3Import(&dynamo.S3Import{Arn: "arn", Status: "IN_PROGRESS", TableName: "Copy"})
+	if cmd := m.handleS3ImportPollFailed(s3ImportPollFailedMsg{arn: "arn", err: errors.New("expired token")}); cmd == nil {
+		t.Fatal("a failed describe should schedule another poll")
+	}
+	if m.s3ImportFails != 1 || !strings.Contains(m.statusMsg, "expired token") {
+		t.Fatalf("fails = %d, status %q", m.s3ImportFails, m.statusMsg)
+	}
+	m.view = viewS3Import
+	if out := m.View(); !strings.Contains(out, "expired token") {
+		t.Fatalf("the panel should show the error:\n%s", out)
+	}
+	m.handleS3Import(&dynamo.S3Import{Arn: "arn", Status: "IN_PROGRESS", TableName: "Copy"})
+	if m.s3ImportFails != 0 || m.s3ImportPollErr != "" {
+		t.Fatal("a describe that works should clear the failures")
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// sampleSegments is how many random segments a sample reads a page from
+const sampleSegments = 8
+
+type sampleResultMsg struct{ result *dynamo.SampleResult }
+
+// sampleTable reads a page-sized sample spread over the table. The table is
+// split so that each segment holds about one sample page, using the item
+// count from DescribeTable; with no count the sample is just the first page.
+func (m *Model) sampleTable() tea.Cmd {
+	perSegment := max(m.pageSize/sampleSegments, 1)
+	total := 1
+	if m.tableInfo != nil && m.tableInfo.ItemCount > 0 {
+		total = int(min(m.tableInfo.ItemCount/int64(perSegment), dynamo.MaxSampleSegments))
+	}
+	input := dynamo.SampleInput{
+		TableName:                m.currentTable,
+		Segments:                 sampleSegments,
+		TotalSegments:            max(total, 1),
+		Limit:                    perSegment,
+		FilterExpression:         m.filterExpr,
+		ExpressionAttributeNames: m.filterNames,
+		ExpressionValues:         m.filterValues,
+	}
+	m.loading = true
+	m.statusMsg = "Sampling..."
+	return func() tea.Msg {
+		res, err := m.client.SampleScan(context.Background(), input)
+		if err != nil {
+			return errMsg{err}
+		}
+		return sampleResultMsg{res}
+	}
+}
+
+// handleSampleResult shows the sample in place of the scanned pages. A
+// sample has no next page; r goes back to reading the table in order.
+func (m *Model) handleSampleResult(res *dynamo.SampleResult) {
+	m.resetSpill()
+	m.items = res.Items
+	m.lastKey = nil
+	m.loading = false
+	m.statusMsg = fmt.Sprintf("Sampled %d items from %d of %d segments (scanned %d records) - r reloads in order",
+		len(res.Items), len(res.Segments), res.TotalSegments, res.ScannedCount)
+	m.showItems(m.items)
+}
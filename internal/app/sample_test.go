@@ -0,0 +1,32 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestSampleKeyStartsLoading(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	if _, cmd := m.Update(keyRunes("S")); cmd == nil {
+		t.Fatal("S should start a sample")
+	}
+}
+
+func TestSampleResultReplacesPages(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.lastKey = numberedItems(0, 1)[0]
+
+	m = drive(m, sampleResultMsg{&dynamo.SampleResult{
+		Items: numberedItems(10, 13), Segments: []int{3, 9, 40}, TotalSegments: 64, ScannedCount: 3,
+	}})
+	if len(m.items) != 3 || m.lastKey != nil || m.loading {
+		t.Fatalf("items = %d, lastKey = %v, loading = %v", len(m.items), m.lastKey, m.loading)
+	}
+	if !strings.Contains(m.statusMsg, "3 of 64 segments") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// defaultScanTimeout is how long a filtered scan runs before asking whether
+// to carry on, when no other budget is configured.
+const defaultScanTimeout = 3 * time.Minute
+
+// scanBudget bounds one run of a continuous scan. Whichever limit is hit
+// first stops it; zero pages or records means no limit on that count.
+type scanBudget struct {
+	timeout time.Duration
+	pages   int
+	scanned int64
+}
+
+// ScanSettings bound scans. Each run of a continuous scan stops at whichever
+// of Timeout, MaxPages and MaxScanned is hit first and asks whether to carry
+// on; zero pages or records means no limit on that count.
+type ScanSettings struct {
+	Timeout    time.Duration `yaml:"timeout"`
+	MaxPages   int           `yaml:"max_pages"`
+	MaxScanned int           `yaml:"max_scanned"`
+
+	// ItemCap is how many items a continuous scan keeps in memory before
+	// moving older ones to disk
+	ItemCap int `yaml:"item_cap"`
+
+	// WarnMB is the table size above which a full-table scan asks first; 0
+	// never asks
+	WarnMB int `yaml:"warn_mb"`
+}
+
+func defaultScanSettings() ScanSettings {
+	return ScanSettings{Timeout: defaultScanTimeout, ItemCap: defaultScanItemCap, WarnMB: defaultScanWarnMB}
+}
+
+func (s ScanSettings) validate() error {
+	if s.Timeout <= 0 {
+		return fmt.Errorf("scan.timeout must be positive, got %s", s.Timeout)
+	}
+	if s.ItemCap < 1 {
+		return fmt.Errorf("scan.item_cap must be at least 1, got %d", s.ItemCap)
+	}
+	for name, n := range map[string]int{"max_pages": s.MaxPages, "max_scanned": s.MaxScanned, "warn_mb": s.WarnMB} {
+		if n < 0 {
+			return fmt.Errorf("scan.%s must not be negative, got %d", name, n)
+		}
+	}
+	return nil
+}
+
+// budget is the limits of one run
+func (s ScanSettings) budget() scanBudget {
+	return scanBudget{timeout: s.Timeout, pages: s.MaxPages, scanned: int64(s.MaxScanned)}
+}
+
+// limits is the budget's counts, which the scan itself checks; the timeout
+// is its context's deadline
+func (b scanBudget) limits() dynamo.ScanBudget {
+	return dynamo.ScanBudget{Pages: b.pages, Scanned: b.scanned}
+}
+
+// String lists the limits, e.g. "3m, 50 pages or 100000 records"
+func (b scanBudget) String() string {
+	parts := []string{shortDuration(b.timeout)}
+	if b.pages > 0 {
+		parts = append(parts, fmt.Sprintf("%d pages", b.pages))
+	}
+	if b.scanned > 0 {
+		parts = append(parts, fmt.Sprintf("%d records", b.scanned))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " or " + parts[len(parts)-1]
+}
+
+// shortDuration drops the zero units time.Duration prints, so 3m0s reads 3m
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanSettingsBudget(t *testing.T) {
+	if b := defaultScanSettings().budget(); b != (scanBudget{timeout: defaultScanTimeout}) || b.String() != "3m" {
+		t.Fatalf("default budget = %+v (%s)", b, b)
+	}
+	b := ScanSettings{Timeout: 90 * time.Second, MaxPages: 50, MaxScanned: 100000}.budget()
+	if b.timeout != 90*time.Second || b.pages != 50 || b.scanned != 100000 {
+		t.Fatalf("budget = %+v", b)
+	}
+	if b.String() != "1m30s, 50 pages or 100000 records" {
+		t.Fatalf("String() = %q", b.String())
+	}
+}
+
+func TestLoadConfigReadsScanBudget(t *testing.T) {
+	writeConfig(t, "scan:\n  timeout: 90s\n  max_pages: 50\n")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := defaultScanSettings()
+	want.Timeout, want.MaxPages = 90*time.Second, 50
+	if cfg.Scan != want {
+		t.Errorf("scan = %+v, want %+v", cfg.Scan, want)
+	}
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.scanBudget.String() != "1m30s or 50 pages" {
+		t.Errorf("budget = %s", m.scanBudget)
+	}
+
+	for _, body := range []string{"scan:\n  timeout: 0s\n", "scan:\n  max_scanned: -1\n"} {
+		writeConfig(t, body)
+		if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "scan.") {
+			t.Errorf("%q: err = %v, want one naming the setting", body, err)
+		}
+	}
+}
+
+func TestApplyEnvOverridesScanBudget(t *testing.T) {
+	env := map[string]string{
+		"GODYNAMO_SCAN_TIMEOUT":     "90s",
+		"GODYNAMO_SCAN_MAX_PAGES":   "50",
+		"GODYNAMO_SCAN_MAX_SCANNED": "100000",
+	}
+	old := getenv
+	getenv = func(k string) string { return env[k] }
+	t.Cleanup(func() { getenv = old })
+
+	cfg := DefaultConfig()
+	cfg.Scan.MaxPages = 10
+	if err := cfg.ApplyEnv(); err != nil {
+		t.Fatal(err)
+	}
+	want := defaultScanSettings()
+	want.Timeout, want.MaxPages, want.MaxScanned = 90*time.Second, 50, 100000
+	if cfg.Scan != want {
+		t.Fatalf("scan = %+v, want %+v", cfg.Scan, want)
+	}
+
+	env["GODYNAMO_SCAN_TIMEOUT"] = "soon"
+	if err := cfg.ApplyEnv(); err == nil || !strings.Contains(err.Error(), "GODYNAMO_SCAN_TIMEOUT") {
+		t.Errorf("err = %v, want one naming the variable", err)
+	}
+	env["GODYNAMO_SCAN_TIMEOUT"] = ""
+	env["GODYNAMO_SCAN_MAX_PAGES"] = "-1"
+	if err := cfg.ApplyEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "scan.max_pages") {
+		t.Errorf("err = %v, want the negative page count refused", err)
+	}
+}
+
+func TestContinueScanPromptShowsBudget(t *testing.T) {
+	m := populatedModel()
+	m.scanBudget = scanBudget{timeout: time.Hour, pages: 20}
+	m.view = viewConfirmContinueScan
+	if out := m.View(); !strings.Contains(out, "budget of 1h or 20 pages") {
+		t.Fatalf("prompt does not name the budget:\n%s", out)
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/query"
+)
+
+// defaultScanWarnMB is the table size, in MB, above which a full-table Scan
+// asks for confirmation first; scan.warn_mb overrides it and 0 turns the
+// warning off.
+const defaultScanWarnMB = 1024
+
+// costlyScan is a filter held back until the user accepts its scan cost
+type costlyScan struct {
+	expr   string
+	names  map[string]string
+	values map[string]interface{}
+	advice query.Advice
+}
+
+// applyFilter makes the filter current and reads the first page with it
+func (m *Model) applyFilter(expr string, names map[string]string, values map[string]interface{}) tea.Cmd {
+	m.filterExpr = expr
+	m.filterNames = names
+	m.filterValues = values
+	m.view = viewTableData
+	m.lastKey = nil
+	m.loading = true
+	m.statusMsg = "Running " + m.filterAdvice().Summary + "..."
+	return m.scanTable()
+}
+
+// checkScanCost returns the pending scan when the filter would read the whole
+// of a table larger than scanWarnBytes, or nil when it can run straight away.
+func (m *Model) checkScanCost(expr string, names map[string]string, values map[string]interface{}) *costlyScan {
+	if m.scanWarnBytes <= 0 || m.tableInfo == nil || m.tableInfo.SizeBytes <= m.scanWarnBytes {
+		return nil
+	}
+	advice := query.Advise(m.tableInfo, query.BuildPlan(m.tableInfo, expr, names, values), names)
+	if !advice.FullScan {
+		return nil
+	}
+	return &costlyScan{expr: expr, names: names, values: values, advice: advice}
+}
+
+func (m *Model) updateConfirmCostlyScan(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.costlyScan
+	switch msg.String() {
+	case "y", "Y":
+		m.costlyScan = nil
+		return m, m.applyFilter(s.expr, s.names, s.values)
+	case "n", "N", "esc":
+		m.costlyScan = nil
+		m.view = viewQuery
+		m.statusMsg = "Scan cancelled"
+	}
+	return m, nil
+}
+
+func (m Model) viewConfirmCostlyScan() string {
+	s := m.costlyScan
+	body := ui.WarningStyle.Render(fmt.Sprintf("This filter will scan all of %s.", m.currentTable)) + "\n\n" +
+		ui.ItemStyle.Render(fmt.Sprintf("Table size: %s", formatBytes(m.tableInfo.SizeBytes))) + "\n" +
+		ui.ItemStyle.Render(fmt.Sprintf("Items scanned: ~%d", m.tableInfo.ItemCount)) + "\n" +
+		ui.ItemStyle.Render(fmt.Sprintf("Read cost: ~%.0f RCUs", math.Ceil(s.advice.EstimatedRCU))) + "\n\n"
+	if s.advice.Hint != "" {
+		body += ui.HelpStyle.Render("Tip: "+s.advice.Hint) + "\n\n"
+	}
+	body += ui.HelpStyle.Render("Press Y to scan anyway, N to edit the filter")
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("💸 Expensive Scan") + "\n\n" + body)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filteredModel returns the query view with one `attr = value` condition
+func filteredModel(attr, value string) Model {
+	m := populatedModel()
+	m.view = viewQuery
+	m.filterBuilder.Conditions[0].AttributeName.SetValue(attr)
+	m.filterBuilder.Conditions[0].AttributeValue.SetValue(value)
+	return m
+}
+
+func TestCostlyScanAsksFirst(t *testing.T) {
+	m := filteredModel("name", "alice")
+	m.scanWarnBytes = 1024
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewConfirmCostlyScan || m.filterExpr != "" {
+		t.Fatalf("view = %d, filterExpr = %q; want confirmation before applying", m.view, m.filterExpr)
+	}
+	if out := m.View(); !strings.Contains(out, "Items scanned: ~2") || !strings.Contains(out, "~1 RCUs") {
+		t.Fatalf("confirmation is missing the estimate:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("n"))
+	if m.view != viewQuery || m.filterExpr != "" || m.costlyScan != nil {
+		t.Fatalf("cancel: view = %d, filterExpr = %q", m.view, m.filterExpr)
+	}
+
+	m = drive(drive(m, tea.KeyMsg{Type: tea.KeyEnter}), keyRunes("y"))
+	if m.view != viewTableData || m.filterExpr == "" || !m.loading {
+		t.Fatalf("confirm: view = %d, filterExpr = %q, loading = %v", m.view, m.filterExpr, m.loading)
+	}
+}
+
+func TestCheapReadsSkipConfirmation(t *testing.T) {
+	query := filteredModel("id", "1")
+	query.scanWarnBytes = 1024
+	cases := map[string]Model{
+		"query on the partition key": query,
+		"table under the threshold":  filteredModel("name", "alice"),
+	}
+	for name, m := range cases {
+		m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.view != viewTableData || m.filterExpr == "" {
+			t.Errorf("%s: view = %d, filterExpr = %q; want the filter applied", name, m.view, m.filterExpr)
+		}
+	}
+}
+
+func TestScanWarnBytesFromConfig(t *testing.T) {
+	stubConfigDir(t)
+	m, err := NewWithConfig(DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.scanWarnBytes != 1<<30 {
+		t.Fatalf("default = %d", m.scanWarnBytes)
+	}
+	cfg := DefaultConfig()
+	cfg.Scan.WarnMB = 5
+	if m, _ = NewWithConfig(cfg); m.scanWarnBytes != 5<<20 {
+		t.Fatalf("5 MB = %d", m.scanWarnBytes)
+	}
+	cfg.Scan.WarnMB = 0
+	if m, _ = NewWithConfig(cfg); m.scanWarnBytes != 0 {
+		t.Fatalf("0 should disable the warning, got %d", m.scanWarnBytes)
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// waitForScan delivers the next message from a running continuous scan:
+// progress while it runs, then its result or error.
+func waitForScan(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// startContinuousScan returns a command that runs scan in the background,
+// with options for the chosen segment, the scan budget and progress reports.
+// Progress counts start from the items and records already found, so a
+// continued scan carries on from where the last stopped.
+func (m *Model) startContinuousScan(found int, scanned int64, scan func(ctx context.Context, opts dynamo.ScanOptions) tea.Msg) tea.Cmd {
+	ch := make(chan tea.Msg, 1)
+	m.scanCh = ch
+	m.scanStarted = time.Now()
+	m.scanItemsFound, m.scanTotalScanned = found, scanned
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.scanBudget.timeout)
+		m.scanCancel = cancel
+		opts := m.scanOptions()
+		opts.Budget = m.scanBudget.limits()
+		opts.Progress = func(f int, s int64) {
+			ch <- scanProgressMsg{itemsFound: found + f, totalScanned: scanned + s, ch: ch}
+		}
+		go func() {
+			defer cancel()
+			ch <- scan(ctx, opts)
+		}()
+		return <-ch
+	}
+}
+
+// handleScanProgress records the counts of the current scan. Messages from a
+// scan that has since been replaced are still drained so it can finish.
+func (m *Model) handleScanProgress(msg scanProgressMsg) tea.Cmd {
+	if msg.ch == m.scanCh {
+		m.scanItemsFound, m.scanTotalScanned = msg.itemsFound, msg.totalScanned
+	}
+	return waitForScan(msg.ch)
+}
+
+// scanFraction estimates how much of the table (or scan segment) has been
+// read from DescribeTable's item count; ok is false when it has none.
+func (m *Model) scanFraction() (float64, bool) {
+	if m.tableInfo == nil || m.tableInfo.ItemCount <= 0 {
+		return 0, false
+	}
+	total := float64(m.tableInfo.ItemCount)
+	if m.scanSegment != nil {
+		total /= float64(m.scanSegment.Total)
+	}
+	// The count is refreshed every few hours, so never claim to be done
+	return min(float64(m.scanTotalScanned)/total, 0.99), true
+}
+
+func (m Model) viewScanProgress() string {
+	parts := []string{
+		fmt.Sprintf("%d items found", m.scanItemsFound),
+		fmt.Sprintf("%d records scanned", m.scanTotalScanned),
+		fmt.Sprintf("%s elapsed", time.Since(m.scanStarted).Truncate(time.Second)),
+	}
+	line := m.spinner.View() + " Scanning... " + strings.Join(parts, " • ")
+	frac, ok := m.scanFraction()
+	if !ok {
+		return ui.ContentStyle.Render(line)
+	}
+	const width = 30
+	filled := int(frac * width)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return ui.ContentStyle.Render(line) + "\n" +
+		ui.SuccessStyle.Render(bar) + ui.HelpStyle.Render(fmt.Sprintf(" ~%d%% of the table", int(frac*100)))
+}
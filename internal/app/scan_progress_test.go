@@ -0,0 +1,56 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestScanProgressRendersWhileScanning(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.tableInfo.ItemCount = 1000
+	m.filterExpr = "#n0 = :v0"
+	m.filterNames = map[string]string{"#n0": "name"}
+	m.filterValues = map[string]interface{}{":v0": "alice"}
+	m.loading = true
+	if m.scanTable() == nil || m.scanCh == nil {
+		t.Fatal("a filtered scan should run in the background")
+	}
+
+	m = drive(m, scanProgressMsg{itemsFound: 3, totalScanned: 250, ch: m.scanCh})
+	out := m.View()
+	if !strings.Contains(out, "3 items found • 250 records scanned") || !strings.Contains(out, "~25% of the table") {
+		t.Fatalf("progress line missing:\n%s", out)
+	}
+
+	// A message from an earlier scan does not overwrite the counts
+	m = drive(m, scanProgressMsg{itemsFound: 99, totalScanned: 9999, ch: make(chan tea.Msg)})
+	if m.scanItemsFound != 3 || m.scanTotalScanned != 250 {
+		t.Fatalf("stale progress applied: %d/%d", m.scanItemsFound, m.scanTotalScanned)
+	}
+}
+
+func TestScanFractionPerSegment(t *testing.T) {
+	m := populatedModel()
+	m.tableInfo.ItemCount = 1000
+	m.scanTotalScanned = 100
+	if f, _ := m.scanFraction(); f != 0.1 {
+		t.Fatalf("fraction = %v", f)
+	}
+	m.scanSegment = &dynamo.ScanSegment{Segment: 0, Total: 4}
+	if f, _ := m.scanFraction(); f != 0.4 {
+		t.Fatalf("fraction of one of 4 segments = %v", f)
+	}
+	m.scanTotalScanned = 5000
+	if f, _ := m.scanFraction(); f != 0.99 {
+		t.Fatalf("fraction should stop short of done, got %v", f)
+	}
+	m.tableInfo.ItemCount = 0
+	if _, ok := m.scanFraction(); ok {
+		t.Fatal("no item count, no estimate")
+	}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// scanPositionsFile keeps where each unfinished filtered scan stopped, one
+// entry per connection and table, so a scan can be resumed after a restart.
+const scanPositionsFile = "scan_positions.json"
+
+type savedScan struct {
+	Connection string              `json:"connection"`
+	Table      string              `json:"table"`
+	Filter     string              `json:"filter"` // as the filter builder summarised it
+	Expr       string              `json:"expr"`
+	Names      map[string]string   `json:"names"`
+	Values     json.RawMessage     `json:"values"`   // DynamoDB JSON, so numbers keep their precision
+	LastKey    string              `json:"last_key"` // DynamoDB JSON
+	Segment    *dynamo.ScanSegment `json:"segment,omitempty"`
+	Scanned    int64               `json:"scanned"`
+	SavedAt    time.Time           `json:"saved_at"`
+}
+
+// filterValuesJSON is a filter's values as DynamoDB JSON
+func filterValuesJSON(values map[string]interface{}) (json.RawMessage, error) {
+	item := make(map[string]types.AttributeValue, len(values))
+	for k, v := range values {
+		item[k] = models.InterfaceToAttributeValue(v)
+	}
+	text, err := models.ItemToDynamoJSON(item, false)
+	return json.RawMessage(text), err
+}
+
+// filterValues reads the values filterValuesJSON saved back
+func (s *savedScan) filterValues() (map[string]interface{}, error) {
+	if len(s.Values) == 0 {
+		return nil, nil
+	}
+	item, err := models.DynamoJSONToItem(s.Values)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		values[k] = models.AttributeValueToInterface(v)
+	}
+	return values, nil
+}
+
+func readSavedScans() ([]savedScan, string, error) {
+	path, err := appDataPath(scanPositionsFile)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, path, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read saved scans: %w", err)
+	}
+	var scans []savedScan
+	if err := json.Unmarshal(data, &scans); err != nil {
+		return nil, "", fmt.Errorf("failed to read saved scans: %w", err)
+	}
+	return scans, path, nil
+}
+
+// storeSavedScan replaces conn/table's entry with s, or removes it when s is nil
+func storeSavedScan(conn, table string, s *savedScan) error {
+	scans, path, err := readSavedScans()
+	if err != nil {
+		return err
+	}
+	kept := scans[:0]
+	for _, e := range scans {
+		if e.Connection != conn || e.Table != table {
+			kept = append(kept, e)
+		}
+	}
+	if s != nil {
+		kept = append(kept, *s)
+	} else if len(kept) == len(scans) {
+		return nil
+	}
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save scan position: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save scan position: %w", err)
+	}
+	return nil
+}
+
+func loadSavedScan(conn, table string) *savedScan {
+	scans, _, err := readSavedScans()
+	if err != nil {
+		return nil
+	}
+	for i := range scans {
+		if scans[i].Connection == conn && scans[i].Table == table {
+			return &scans[i]
+		}
+	}
+	return nil
+}
+
+// recordScanPosition saves where a filtered scan stopped, or forgets the
+// saved position once a scan reaches the end of the table.
+func (m *Model) recordScanPosition(result *dynamo.ContinuousScanResult) {
+	if !result.HasMore && m.savedScan == nil {
+		return
+	}
+	var s *savedScan
+	if result.HasMore && result.LastEvaluatedKey != nil {
+		key, err := models.ItemToDynamoJSON(result.LastEvaluatedKey, false)
+		if err != nil {
+			return
+		}
+		values, err := filterValuesJSON(m.filterValues)
+		if err != nil {
+			return
+		}
+		s = &savedScan{
+			Connection: m.connectionKey(),
+			Table:      m.currentTable,
+			Filter:     m.filterBuilder.GetFilterSummary(),
+			Expr:       m.filterExpr,
+			Names:      m.filterNames,
+			Values:     values,
+			LastKey:    key,
+			Segment:    m.scanSegment,
+			Scanned:    result.TotalScanned,
+			SavedAt:    time.Now(),
+		}
+	}
+	if err := storeSavedScan(m.connectionKey(), m.currentTable, s); err != nil {
+		m.statusMsg += " - ✗ " + err.Error()
+		return
+	}
+	m.savedScan = s
+}
+
+// resumeScan restores a saved scan's filter and carries on reading from its
+// last key. Items found before the restart are not kept, only the position.
+func (m *Model) resumeScan() tea.Cmd {
+	s := m.savedScan
+	if s == nil {
+		m.statusMsg = "No saved scan for " + m.currentTable
+		return nil
+	}
+	startKey, err := models.DynamoJSONToItem([]byte(s.LastKey))
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+	values, err := s.filterValues()
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+	m.filterExpr, m.filterNames, m.filterValues = s.Expr, s.Names, values
+	m.scanSegment = s.Segment
+	m.lastKey = nil
+	m.loading = true
+	m.statusMsg = fmt.Sprintf("Resuming scan after %d records...", s.Scanned)
+	return m.startContinuousScan(0, s.Scanned, func(ctx context.Context, opts dynamo.ScanOptions) tea.Msg {
+		result, err := m.client.ScanTableContinuous(ctx, m.scanInput(m.pageSize, startKey), opts)
+		if err != nil {
+			return errMsg{err}
+		}
+		result.TotalScanned += s.Scanned
+		return continuousScanMsg{result: result, totalScanned: result.TotalScanned}
+	})
+}
+
+// savedScanStatus is the status-bar note for a resumable scan of the table
+func (m *Model) savedScanStatus() string {
+	s := m.savedScan
+	if s == nil || m.loading {
+		return ""
+	}
+	filter := s.Filter
+	if filter == "" {
+		filter = s.Expr
+	}
+	return fmt.Sprintf(" | Saved scan (%s) stopped after %d records on %s - R resumes",
+		filter, s.Scanned, s.SavedAt.Local().Format("Jan 2 15:04"))
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestScanPositionSurvivesRestart(t *testing.T) {
+	stubConfigDir(t)
+	m := populatedModel()
+	m.view = viewTableData
+	m.selectedRegion = "eu-west-1"
+	m.filterExpr = "#n0 = :v0"
+	m.filterNames = map[string]string{"#n0": "name"}
+	m.filterValues = map[string]interface{}{":v0": "carol", ":v1": float64(3), ":v2": int64(9007199254740993)}
+	m.scanSegment = &dynamo.ScanSegment{Segment: 1, Total: 4}
+
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "42"}}
+	m = drive(m, continuousScanMsg{result: &dynamo.ContinuousScanResult{
+		LastEvaluatedKey: lastKey, TotalScanned: 5000, HasMore: true, TimedOut: true,
+	}})
+
+	// A new session opening the same table finds the position
+	m2 := populatedModel()
+	m2.view = viewTableData
+	m2.selectedRegion = "eu-west-1"
+	m2 = drive(m2, tableInfoMsg{m2.tableInfo})
+	s := m2.savedScan
+	if s == nil || s.Scanned != 5000 || s.Expr != "#n0 = :v0" || s.Segment.Total != 4 {
+		t.Fatalf("saved scan = %+v", s)
+	}
+	if !strings.Contains(m2.View(), "R resumes") {
+		t.Fatal("the status bar should offer to resume")
+	}
+
+	// Resuming restores the filter and segment
+	m2 = drive(m2, keyRunes("R"))
+	if m2.filterExpr != s.Expr || m2.scanSegment == nil || m2.scanSegment.Segment != 1 || m2.scanCh == nil || m2.scanTotalScanned != 5000 {
+		t.Fatalf("resume did not restore the scan: expr=%q segment=%+v scanned=%d", m2.filterExpr, m2.scanSegment, m2.scanTotalScanned)
+	}
+	if v := m2.filterValues; v[":v0"] != "carol" || v[":v1"] != int64(3) || v[":v2"] != int64(9007199254740993) {
+		t.Fatalf("filter values should come back exact, got %#v", v)
+	}
+
+	// Reaching the end forgets the position
+	m2 = drive(m2, continuousScanMsg{result: &dynamo.ContinuousScanResult{TotalScanned: 9000}})
+	if m2.savedScan != nil || loadSavedScan("eu-west-1", "Users") != nil {
+		t.Fatal("finished scan should clear the saved position")
+	}
+}
+
+func TestSavedScansAreKeptPerTable(t *testing.T) {
+	stubConfigDir(t)
+	a := &savedScan{Connection: "c", Table: "A", LastKey: `{"id":{"S":"1"}}`}
+	b := &savedScan{Connection: "c", Table: "B", LastKey: `{"id":{"S":"2"}}`}
+	for _, s := range []*savedScan{a, b} {
+		if err := storeSavedScan(s.Connection, s.Table, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storeSavedScan("c", "A", nil); err != nil {
+		t.Fatal(err)
+	}
+	if loadSavedScan("c", "A") != nil || loadSavedScan("c", "B") == nil || loadSavedScan("other", "B") != nil {
+		t.Fatal("removing A's position should leave B's")
+	}
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// Focus positions in the scan segment panel
+const (
+	segFieldSegment = iota
+	segFieldTotal
+	segFieldCount
+)
+
+// scanOptions limits a scan to the chosen segment, if any
+func (m *Model) scanOptions() dynamo.ScanOptions {
+	return dynamo.ScanOptions{Segment: m.scanSegment}
+}
+
+func (m *Model) openScanSegment() tea.Cmd {
+	if m.segmentInputs == nil {
+		seg := textinput.New()
+		seg.Placeholder = "0"
+		total := textinput.New()
+		total.Placeholder = "e.g. 16"
+		for _, in := range []*textinput.Model{&seg, &total} {
+			in.CharLimit = 7
+			in.Width = 12
+		}
+		m.segmentInputs = []textinput.Model{seg, total}
+	}
+	if s := m.scanSegment; s != nil {
+		m.segmentInputs[segFieldSegment].SetValue(strconv.Itoa(s.Segment))
+		m.segmentInputs[segFieldTotal].SetValue(strconv.Itoa(s.Total))
+	}
+	m.view = viewScanSegment
+	return m.focusSegmentField(segFieldSegment)
+}
+
+func (m *Model) focusSegmentField(field int) tea.Cmd {
+	m.segmentFocus = field
+	var cmd tea.Cmd
+	for i := range m.segmentInputs {
+		if i == field {
+			cmd = m.segmentInputs[i].Focus()
+		} else {
+			m.segmentInputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *Model) updateScanSegment(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.view = viewTableData
+		return m, nil
+	case "tab", "down", "shift+tab", "up":
+		return m, m.focusSegmentField((m.segmentFocus + 1) % segFieldCount)
+	case "ctrl+d":
+		// Back to scanning the whole table
+		for i := range m.segmentInputs {
+			m.segmentInputs[i].SetValue("")
+		}
+		return m, nil
+	case "enter":
+		seg, err := m.parseScanSegment()
+		if err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return m, nil
+		}
+		m.scanSegment = seg
+		m.view = viewTableData
+		m.lastKey = nil
+		m.loading = true
+		m.statusMsg = "Scanning " + m.segmentLabel() + "..."
+		return m, m.scanTable()
+	}
+
+	var cmd tea.Cmd
+	m.segmentInputs[m.segmentFocus], cmd = m.segmentInputs[m.segmentFocus].Update(msg)
+	return m, cmd
+}
+
+// parseScanSegment reads the panel; both fields empty means the whole table
+func (m *Model) parseScanSegment() (*dynamo.ScanSegment, error) {
+	segText := strings.TrimSpace(m.segmentInputs[segFieldSegment].Value())
+	totalText := strings.TrimSpace(m.segmentInputs[segFieldTotal].Value())
+	if segText == "" && totalText == "" {
+		return nil, nil
+	}
+	seg, err := strconv.Atoi(segText)
+	if err != nil {
+		return nil, fmt.Errorf("segment must be a number")
+	}
+	total, err := strconv.Atoi(totalText)
+	if err != nil {
+		return nil, fmt.Errorf("total segments must be a number")
+	}
+	s := &dynamo.ScanSegment{Segment: seg, Total: total}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// segmentLabel names what the table view scans
+func (m *Model) segmentLabel() string {
+	if m.scanSegment == nil {
+		return "the whole table"
+	}
+	return fmt.Sprintf("segment %d of %d", m.scanSegment.Segment, m.scanSegment.Total)
+}
+
+func (m Model) viewScanSegment() string {
+	var body strings.Builder
+	body.WriteString(ui.HelpStyle.Render("Scan one slice of the table. Segments are numbered from 0;") + "\n")
+	body.WriteString(ui.HelpStyle.Render("leave both fields empty to scan everything.") + "\n\n")
+	labels := []string{"Segment", "Total segments"}
+	for i, in := range m.segmentInputs {
+		style := ui.InputStyle
+		if i == m.segmentFocus {
+			style = ui.InputFocusedStyle
+		}
+		body.WriteString(ui.ItemStyle.Render(labels[i]+":") + "\n" + style.Render(in.View()) + "\n")
+	}
+	body.WriteString("\n" + ui.HelpStyle.Render("Currently scanning "+m.segmentLabel()) + "\n\n")
+	body.WriteString(ui.HelpStyle.Render("Tab: next field • Ctrl+D: clear • Enter: scan • Esc: cancel"))
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("🧩 Scan Segment") + "\n\n" + body.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestScanSegmentPanel(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+
+	m = drive(m, keyRunes("g"))
+	if m.view != viewScanSegment {
+		t.Fatalf("view = %d, want the segment panel", m.view)
+	}
+	m = drive(m, keyRunes("3"))
+	m = drive(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = drive(m, keyRunes("3"))
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewScanSegment || !strings.Contains(m.statusMsg, "segment must be between 0 and 2") {
+		t.Fatalf("segment 3 of 3 accepted: view = %d, status = %q", m.view, m.statusMsg)
+	}
+
+	m = drive(m, keyRunes("2"))
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.scanSegment == nil || m.scanSegment.Segment != 3 || m.scanSegment.Total != 32 || m.view != viewTableData {
+		t.Fatalf("scanSegment = %+v, view = %d", m.scanSegment, m.view)
+	}
+	if !strings.Contains(m.View(), "Scanning segment 3 of 32") {
+		t.Fatal("status bar should name the segment")
+	}
+
+	m = drive(drive(drive(m, keyRunes("g")), tea.KeyMsg{Type: tea.KeyCtrlD}), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.scanSegment != nil {
+		t.Fatalf("cleared panel should scan the whole table, got %+v", m.scanSegment)
+	}
+}
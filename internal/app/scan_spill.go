@@ -0,0 +1,158 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// defaultScanItemCap is how many items a continuous scan keeps in memory
+// before older results are moved to disk; scan.item_cap overrides it.
+const defaultScanItemCap = 50000
+
+// itemSpill holds the pages a continuous scan pushed out of memory, as
+// DynamoDB JSON lines in a temp file. Only the offsets stay in memory.
+type itemSpill struct {
+	f     *os.File
+	pages []spillPage
+	items int
+}
+
+type spillPage struct {
+	offset, length int64
+	count          int
+}
+
+func newItemSpill() (*itemSpill, error) {
+	f, err := os.CreateTemp("", "godynamo-scan-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan spill file: %w", err)
+	}
+	return &itemSpill{f: f}, nil
+}
+
+// add appends items as a new page at the end of the file
+func (s *itemSpill) add(items []map[string]types.AttributeValue) error {
+	offset, err := s.f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to write scan spill file: %w", err)
+	}
+	w := bufio.NewWriter(s.f)
+	for _, item := range items {
+		line, err := models.ItemToDynamoJSON(item, false)
+		if err != nil {
+			return err
+		}
+		w.WriteString(line)
+		w.WriteByte('\n')
+	}
+	length := int64(w.Buffered())
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write scan spill file: %w", err)
+	}
+	s.pages = append(s.pages, spillPage{offset: offset, length: length, count: len(items)})
+	s.items += len(items)
+	return nil
+}
+
+// page reads page i back into memory
+func (s *itemSpill) page(i int) ([]map[string]types.AttributeValue, error) {
+	p := s.pages[i]
+	data := make([]byte, p.length)
+	if _, err := s.f.ReadAt(data, p.offset); err != nil {
+		return nil, fmt.Errorf("failed to read scan spill file: %w", err)
+	}
+	items := make([]map[string]types.AttributeValue, 0, p.count)
+	for _, line := range bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) {
+		item, err := models.DynamoJSONToItem(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read scan spill file: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (s *itemSpill) close() {
+	s.f.Close()
+	os.Remove(s.f.Name())
+}
+
+// resetSpill drops the spill file of the previous scan
+func (m *Model) resetSpill() {
+	if m.spill != nil {
+		m.spill.close()
+	}
+	m.spill = nil
+	m.spillView = 0
+	m.spillLive = nil
+}
+
+// liveItems returns the in-memory tail of the scan, even while a spilled
+// page is on screen.
+func (m *Model) liveItems() []map[string]types.AttributeValue {
+	if m.spillView > 0 {
+		return m.spillLive
+	}
+	return m.items
+}
+
+// spillOverflow moves the oldest items past the cap to disk
+func (m *Model) spillOverflow() error {
+	limit := m.scanItemCap
+	if limit <= 0 {
+		limit = defaultScanItemCap
+	}
+	over := len(m.items) - limit
+	if over <= 0 {
+		return nil
+	}
+	if m.spill == nil {
+		s, err := newItemSpill()
+		if err != nil {
+			return err
+		}
+		m.spill = s
+	}
+	if err := m.spill.add(m.items[:over]); err != nil {
+		return err
+	}
+	m.items = append([]map[string]types.AttributeValue(nil), m.items[over:]...)
+	return nil
+}
+
+// showSpillPage puts spilled page n (1-based) on screen; n past the last
+// page goes back to the live items.
+func (m *Model) showSpillPage(n int) {
+	if m.spill == nil || n < 1 {
+		return
+	}
+	if n > len(m.spill.pages) {
+		if m.spillView > 0 {
+			m.items = m.spillLive
+			m.spillLive = nil
+			m.spillView = 0
+			m.showItems(m.items)
+			m.statusMsg = fmt.Sprintf("Showing the latest %d items", len(m.items))
+		}
+		return
+	}
+	items, err := m.spill.page(n - 1)
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	if m.spillView == 0 {
+		m.spillLive = m.items
+	}
+	m.spillView = n
+	m.items = items
+	m.showItems(items)
+	m.statusMsg = fmt.Sprintf("Spilled page %d/%d (%d items)", n, len(m.spill.pages), len(items))
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func numberedItems(from, to int) []map[string]types.AttributeValue {
+	var items []map[string]types.AttributeValue
+	for i := from; i < to; i++ {
+		items = append(items, map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: fmt.Sprint(i)}})
+	}
+	return items
+}
+
+func itemID(item map[string]types.AttributeValue) string {
+	return item["id"].(*types.AttributeValueMemberS).Value
+}
+
+func TestContinuousScanSpillsPastCap(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.scanItemCap = 3
+	t.Cleanup(m.resetSpill)
+
+	m.handleContinuousScanResult(&dynamo.ContinuousScanResult{Items: numberedItems(0, 5)})
+	if len(m.items) != 3 || itemID(m.items[0]) != "2" {
+		t.Fatalf("live items = %d starting at %s, want the newest 3", len(m.items), itemID(m.items[0]))
+	}
+	if m.spill == nil || m.spill.items != 2 {
+		t.Fatalf("expected 2 spilled items, got %+v", m.spill)
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.spillView != 1 || len(m.items) != 2 || itemID(m.items[1]) != "1" {
+		t.Fatalf("PgUp did not page the spilled items back in: view=%d items=%d", m.spillView, len(m.items))
+	}
+	if live := m.liveItems(); len(live) != 3 {
+		t.Fatalf("live items lost while browsing spill: %d", len(live))
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.spillView != 0 || len(m.items) != 3 || itemID(m.items[0]) != "2" {
+		t.Fatalf("PgDown did not return to the live items: view=%d items=%d", m.spillView, len(m.items))
+	}
+}
+
+func TestFreshScanDropsSpill(t *testing.T) {
+	m := populatedModel()
+	m.scanItemCap = 1
+	m.handleContinuousScanResult(&dynamo.ContinuousScanResult{Items: numberedItems(0, 3)})
+	if m.spill == nil {
+		t.Fatal("expected a spill file")
+	}
+	m = drive(m, continuousScanMsg{result: &dynamo.ContinuousScanResult{Items: numberedItems(0, 1)}})
+	if m.spill != nil {
+		t.Fatal("a new scan should discard the previous spill")
+	}
+}
@@ -0,0 +1,239 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/internal/ui/textarea"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// Seeding writes generated items in chunks of seedChunk, reporting after
+// each, and refuses counts above seedMax
+const (
+	seedChunk = 100
+	seedMax   = 1_000_000
+)
+
+type (
+	seedProgressMsg struct{ written int }
+	seedDoneMsg     struct {
+		written int
+		failed  []dynamo.FailedWrite // given up on, to queue for a retry
+		err     error
+	}
+)
+
+// openSeed fills the table with synthetic items from a template, starting
+// from one that matches the table's key schema
+func (m *Model) openSeed() tea.Cmd {
+	if m.tableInfo == nil {
+		return nil
+	}
+	if m.seedEditor.Placeholder == "" {
+		count := textinput.New()
+		count.CharLimit = 7
+		count.Width = 10
+		count.SetValue("100")
+		m.seedCount = count
+		ta := textarea.New()
+		ta.Placeholder = `{"id": "{{uuid}}"}`
+		ta.ShowLineNumbers = false
+		ta.CharLimit = 0
+		ta.SetPromptFunc(0, func(int) string { return "" })
+		ta.SetHeight(12)
+		ta.SetWidth(60)
+		m.seedEditor = ta
+	}
+	if m.seedFor != m.currentTable {
+		m.seedFor = m.currentTable
+		m.seedEditor.SetValue(defaultSeedTemplate(m.tableInfo.PartitionKey, m.tableInfo.PartitionType, m.tableInfo.SortKey, m.tableInfo.SortKeyType))
+	}
+	m.seedWritten, m.seedTotal = 0, 0
+	m.view = viewSeed
+	return m.focusSeedField(0)
+}
+
+// defaultSeedTemplate fills the key attributes with values of their type
+// and adds a few ordinary attributes to edit from
+func defaultSeedTemplate(pk, pkType, sk, skType string) string {
+	tmpl := map[string]string{
+		"name":      "user-{{seq}}",
+		"score":     "{{int 1 100}}",
+		"active":    "{{bool}}",
+		"createdAt": "{{timestamp}}",
+	}
+	tmpl[pk] = "{{uuid}}"
+	if pkType == "N" {
+		tmpl[pk] = "{{seq}}"
+	}
+	if sk != "" {
+		tmpl[sk] = "{{timestamp}}"
+		if skType == "N" {
+			tmpl[sk] = "{{int 1 1000000}}"
+		}
+	}
+	data, _ := json.MarshalIndent(tmpl, "", "  ")
+	return string(data)
+}
+
+func (m *Model) focusSeedField(field int) tea.Cmd {
+	m.seedFocus = field
+	if field == 0 {
+		m.seedEditor.Blur()
+		return m.seedCount.Focus()
+	}
+	m.seedCount.Blur()
+	return m.seedEditor.Focus()
+}
+
+func (m *Model) updateSeed(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.seedCh != nil {
+		if msg.String() == "esc" {
+			m.seedCancel()
+			m.statusMsg = "Stopping..."
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc":
+		m.view = viewTableData
+		return m, nil
+	case "tab", "shift+tab":
+		return m, m.focusSeedField(1 - m.seedFocus)
+	case "ctrl+s":
+		return m, m.startSeed()
+	case "enter":
+		if m.seedFocus == 0 {
+			return m, m.startSeed()
+		}
+	}
+	var cmd tea.Cmd
+	if m.seedFocus == 0 {
+		m.seedCount, cmd = m.seedCount.Update(msg)
+	} else {
+		m.seedEditor, cmd = m.seedEditor.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *Model) startSeed() tea.Cmd {
+	n, err := strconv.Atoi(strings.TrimSpace(m.seedCount.Value()))
+	if err != nil || n < 1 || n > seedMax {
+		m.statusMsg = fmt.Sprintf("✗ Item count must be between 1 and %d", seedMax)
+		return nil
+	}
+	tmpl, err := models.ParseSeedTemplate(m.seedEditor.Value())
+	if err != nil {
+		m.statusMsg = "✗ Template: " + err.Error()
+		return nil
+	}
+	sample := tmpl.Item(rand.New(rand.NewPCG(0, 0)), 1)
+	for _, key := range []string{m.tableInfo.PartitionKey, m.tableInfo.SortKey} {
+		if _, ok := sample[key]; key != "" && !ok {
+			m.statusMsg = "✗ Template: every item needs the key attribute " + key
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 1)
+	m.seedCh, m.seedCancel = ch, cancel
+	m.seedWritten, m.seedTotal = 0, n
+	m.statusMsg = fmt.Sprintf("Writing %d items to %s...", n, m.currentTable)
+
+	client, table := m.client, m.currentTable
+	go func() {
+		defer cancel()
+		r := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0))
+		done, written := 0, 0
+		var failed []dynamo.FailedWrite
+		for done < n {
+			chunk := make([]map[string]types.AttributeValue, 0, min(seedChunk, n-done))
+			for i := range cap(chunk) {
+				chunk = append(chunk, tmpl.Item(r, done+i+1))
+			}
+			done += len(chunk)
+			written += len(chunk)
+			if err := client.WriteItems(ctx, table, chunk); err != nil {
+				var wf *dynamo.WriteFailures
+				if !errors.As(err, &wf) {
+					ch <- seedDoneMsg{written: written - len(chunk), failed: failed, err: err}
+					return
+				}
+				failed = append(failed, wf.Failed...)
+				written -= len(wf.Failed)
+			}
+			if done < n {
+				ch <- seedProgressMsg{written}
+			}
+		}
+		ch <- seedDoneMsg{written: written, failed: failed}
+	}()
+	return waitForSeed(ch)
+}
+
+func waitForSeed(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func (m *Model) handleSeedDone(msg seedDoneMsg) tea.Cmd {
+	m.seedCh, m.seedCancel = nil, nil
+	m.seedWritten = msg.written
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("✗ Stopped after %d items: %s", msg.written, msg.err)
+		if len(msg.failed) > 0 {
+			m.statusMsg += m.queueFailedWrites(m.currentTable, msg.failed)
+		}
+		return nil
+	}
+	m.view = viewTableData
+	m.statusMsg = fmt.Sprintf("✓ Wrote %d generated items to %s", msg.written, m.currentTable)
+	if len(msg.failed) > 0 {
+		m.statusMsg += m.queueFailedWrites(m.currentTable, msg.failed)
+	}
+	m.lastKey = nil
+	m.loading = true
+	return m.scanTable()
+}
+
+func (m Model) viewSeed() string {
+	var body strings.Builder
+	body.WriteString(ui.HelpStyle.Render("Placeholders: {{uuid}} {{int 1 100}} {{float 0 5}} {{bool}} {{timestamp}} {{seq}} {{pick a b}}") + "\n")
+	body.WriteString(ui.HelpStyle.Render("Items with an existing key are replaced.") + "\n\n")
+
+	countStyle, editorStyle := ui.InputFocusedStyle, ui.InputStyle
+	if m.seedFocus == 1 {
+		countStyle, editorStyle = ui.InputStyle, ui.InputFocusedStyle
+	}
+	body.WriteString(ui.ItemStyle.Render("Items:") + "\n" + countStyle.Render(m.seedCount.View()) + "\n")
+	body.WriteString(ui.ItemStyle.Render("Template:") + "\n" + editorStyle.Render(m.seedEditor.View()) + "\n\n")
+
+	if m.seedCh != nil {
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("Written %d of %d...", m.seedWritten, m.seedTotal)) + "\n")
+	}
+	body.WriteString(ui.StatusBarStyle.Render(m.statusMsg) + "\n\n")
+	help := "Tab: count/template • Ctrl+S: write • Esc: cancel"
+	if m.seedCh != nil {
+		help = "Esc: stop"
+	}
+	body.WriteString(ui.HelpStyle.Render(help))
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("🌱 Generate Items: "+m.currentTable) + "\n\n" + body.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
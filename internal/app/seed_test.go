@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/models"
+)
+
+func TestSeedStartsFromTheKeySchema(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.view != viewSeed || !strings.Contains(m.seedEditor.Value(), `"id": "{{uuid}}"`) {
+		t.Fatalf("view %d, template %s", m.view, m.seedEditor.Value())
+	}
+	if _, err := models.ParseSeedTemplate(defaultSeedTemplate("pk", "N", "sk", "S")); err != nil {
+		t.Fatalf("default template should parse: %v", err)
+	}
+
+	m.seedCount.SetValue("0")
+	if cmd := m.startSeed(); cmd != nil || !strings.Contains(m.statusMsg, "between 1 and") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	m.seedCount.SetValue("10")
+	m.seedEditor.SetValue(`{"name": "{{uuid}}"}`)
+	if cmd := m.startSeed(); cmd != nil || !strings.Contains(m.statusMsg, "key attribute id") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m = drive(m, seedDoneMsg{written: 10})
+	if m.view != viewTableData || m.statusMsg != "✓ Wrote 10 generated items to Users" {
+		t.Fatalf("view %d, status %q", m.view, m.statusMsg)
+	}
+}
@@ -0,0 +1,275 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// Steps of the first-run wizard
+const (
+	setupStepTarget = iota
+	setupStepProfile
+	setupStepRegion
+	setupStepEndpoint
+	setupStepTheme
+)
+
+// setupDiscover is the region choice that keeps region discovery
+const setupDiscover = "Discover regions with tables"
+
+// listProfiles is a variable so tests don't read ~/.aws
+var listProfiles = func() []string {
+	names, _, _ := dynamo.ListProfiles()
+	return names
+}
+
+// FirstRun reports whether there is no config file yet, which is when the
+// setup wizard runs
+func FirstRun() bool {
+	path, err := ConfigPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// setupChoices are the settings the wizard writes to config.yaml
+type setupChoices struct {
+	Profile  string `yaml:"profile,omitempty"`
+	Region   string `yaml:"region,omitempty"`
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Theme    string `yaml:"theme"`
+}
+
+// startSetup shows the wizard instead of connecting
+func (m *Model) startSetup() {
+	m.view = viewSetup
+	m.loading = false
+	m.setupStep = setupStepTarget
+	m.setupCursor = 0
+	m.setupChoices = setupChoices{Theme: m.config.Theme}
+	m.setupEndpoint = textinput.New()
+	m.setupEndpoint.Placeholder = LocalEndpoint
+	m.setupEndpoint.SetValue(LocalEndpoint)
+	m.statusMsg = "Welcome! A few questions and GoDynamo is set up."
+}
+
+// setupOptions are the choices of the current step
+func (m *Model) setupOptions() []string {
+	switch m.setupStep {
+	case setupStepTarget:
+		return []string{"AWS", "DynamoDB Local or another endpoint"}
+	case setupStepProfile:
+		return append([]string{"Default credential chain"}, m.setupProfiles...)
+	case setupStepRegion:
+		return append([]string{setupDiscover}, dynamo.AWSRegions...)
+	case setupStepTheme:
+		themes := make([]string, 0, len(ui.Themes))
+		for name := range ui.Themes {
+			themes = append(themes, name)
+		}
+		sort.Strings(themes)
+		return themes
+	}
+	return nil
+}
+
+func (m *Model) updateSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.setupStep == setupStepEndpoint {
+		switch msg.String() {
+		case "esc":
+			m.setupStepTo(setupStepTarget)
+		case "enter":
+			endpoint := strings.TrimSpace(m.setupEndpoint.Value())
+			if endpoint == "" {
+				m.statusMsg = "✗ Enter the endpoint URL"
+				return m, nil
+			}
+			m.setupChoices.Endpoint = endpoint
+			m.setupStepTo(setupStepTheme)
+		default:
+			var cmd tea.Cmd
+			m.setupEndpoint, cmd = m.setupEndpoint.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	options := m.setupOptions()
+	switch msg.String() {
+	case "up", "k":
+		if m.setupCursor > 0 {
+			m.setupCursor--
+		}
+	case "down", "j":
+		if m.setupCursor < len(options)-1 {
+			m.setupCursor++
+		}
+	case "esc":
+		switch m.setupStep {
+		case setupStepTarget:
+			// Skip the wizard this time; it comes back until a config exists
+			m.view = viewConnect
+			m.loading = true
+			m.statusMsg = "Connecting to AWS DynamoDB..."
+			return m, m.connect()
+		case setupStepProfile:
+			m.setupStepTo(setupStepTarget)
+		case setupStepRegion:
+			m.setupStepTo(setupStepProfile)
+		case setupStepTheme:
+			_ = ui.SetTheme(m.config.theme()) // undo the preview
+			if m.setupChoices.Endpoint != "" {
+				m.setupStepTo(setupStepEndpoint)
+			} else {
+				m.setupStepTo(setupStepRegion)
+			}
+		}
+		return m, nil
+	case "enter":
+		return m, m.chooseSetupOption(options[m.setupCursor])
+	}
+	if m.setupStep == setupStepTheme {
+		_ = ui.SetTheme(options[m.setupCursor]) // preview while choosing
+	}
+	return m, nil
+}
+
+func (m *Model) setupStepTo(step int) {
+	m.setupStep = step
+	m.setupCursor = 0
+	if step == setupStepEndpoint {
+		m.setupEndpoint.Focus()
+	} else {
+		m.setupEndpoint.Blur()
+	}
+	if step == setupStepTheme {
+		m.setupCursor = max(0, slices.Index(m.setupOptions(), m.setupChoices.Theme))
+	}
+}
+
+func (m *Model) chooseSetupOption(choice string) tea.Cmd {
+	switch m.setupStep {
+	case setupStepTarget:
+		if m.setupCursor == 1 {
+			m.setupChoices.Profile, m.setupChoices.Region = "", ""
+			m.setupStepTo(setupStepEndpoint)
+			return nil
+		}
+		m.setupChoices.Endpoint = ""
+		m.setupProfiles = listProfiles()
+		m.setupStepTo(setupStepProfile)
+	case setupStepProfile:
+		m.setupChoices.Profile = ""
+		if m.setupCursor > 0 {
+			m.setupChoices.Profile = choice
+		}
+		m.setupStepTo(setupStepRegion)
+	case setupStepRegion:
+		m.setupChoices.Region = ""
+		if choice != setupDiscover {
+			m.setupChoices.Region = choice
+		}
+		m.setupStepTo(setupStepTheme)
+	case setupStepTheme:
+		m.setupChoices.Theme = choice
+		return m.finishSetup()
+	}
+	return nil
+}
+
+// finishSetup writes the choices to config.yaml and connects with them
+func (m *Model) finishSetup() tea.Cmd {
+	c := m.setupChoices
+	m.config.Profile, m.config.Region, m.config.Endpoint, m.config.Theme = c.Profile, c.Region, c.Endpoint, c.Theme
+	_ = ui.SetTheme(m.config.theme())
+	m.selectedRegion = c.Region
+
+	m.view = viewConnect
+	m.loading = true
+	m.statusMsg = "Connecting to AWS DynamoDB..."
+	if err := writeSetupConfig(c); err != nil {
+		m.statusMsg = "✗ " + err.Error()
+	}
+	return m.connect()
+}
+
+func writeSetupConfig(c setupChoices) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	header := "# Written by the GoDynamo setup wizard; the README lists every setting.\n"
+	if err := os.WriteFile(path, append([]byte(header), data...), 0o600); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
+
+func (m Model) viewSetup() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("⚡ GoDynamo Setup"))
+	b.WriteString("\n\n")
+
+	questions := map[int]string{
+		setupStepTarget:   "Where are your tables?",
+		setupStepProfile:  "Which AWS profile should GoDynamo use?",
+		setupStepRegion:   "Default region",
+		setupStepEndpoint: "Endpoint URL",
+		setupStepTheme:    "Theme",
+	}
+	b.WriteString(ui.KeyStyle.Render(questions[m.setupStep]))
+	b.WriteString("\n\n")
+
+	if m.setupStep == setupStepEndpoint {
+		b.WriteString(ui.InputFocusedStyle.Render(m.setupEndpoint.View()))
+		b.WriteString("\n")
+	} else {
+		options := m.setupOptions()
+		// Keep the cursor on screen in the long region list
+		visible := max(m.height-12, 5)
+		start := max(0, min(m.setupCursor-visible/2, len(options)-visible))
+		for i := start; i < len(options) && i < start+visible; i++ {
+			if i == m.setupCursor {
+				b.WriteString(ui.SelectedStyle.Render("▸ " + options[i]))
+			} else {
+				b.WriteString(ui.ItemStyle.Render("  " + options[i]))
+			}
+			b.WriteString("\n")
+		}
+		if m.setupStep == setupStepProfile && len(m.setupProfiles) == 0 {
+			b.WriteString(ui.HelpStyle.Render("No profiles in ~/.aws; environment variables or an instance role will be used."))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	back := "Back"
+	if m.setupStep == setupStepTarget {
+		back = "Skip"
+	}
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑/↓", Desc: "Select"},
+		{Key: "Enter", Desc: "Choose"},
+		{Key: "Esc", Desc: back},
+	}))
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/godynamo/internal/ui"
+)
+
+func setupModel(t *testing.T) Model {
+	t.Helper()
+	stubConfigDir(t)
+	old := listProfiles
+	listProfiles = func() []string { return []string{"default", "staging"} }
+	t.Cleanup(func() { listProfiles = old; ui.SetTheme("neon") })
+
+	if !FirstRun() {
+		t.Fatal("an empty config directory is a first run")
+	}
+	cfg := DefaultConfig()
+	cfg.Setup = true
+	m, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.width, m.height = 120, 40
+	if m.view != viewSetup || m.Init() != nil {
+		t.Fatal("the wizard should show before connecting")
+	}
+	return m
+}
+
+func TestSetupWizardWritesChoices(t *testing.T) {
+	m := setupModel(t)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	m = drive(m, enter) // AWS
+	if !strings.Contains(m.View(), "staging") {
+		t.Fatalf("profile step should list profiles:\n%s", m.View())
+	}
+	m = drive(drive(drive(m, down), down), enter) // staging
+	m = drive(drive(m, down), enter)              // first listed region
+	m = drive(m, keyRunes("j"))                   // previews the next theme
+	if ui.ColorPrimary == ui.Themes["neon"].Primary {
+		t.Error("moving over a theme should preview it")
+	}
+	_, cmd := m.Update(enter)
+	m = drive(m, enter)
+	if m.view != viewConnect || cmd == nil {
+		t.Fatalf("finishing should connect, view = %d", m.view)
+	}
+
+	path, _ := ConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got setupChoices
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Profile != "staging" || got.Region != "us-east-1" || got.Endpoint != "" || got.Theme == "neon" {
+		t.Errorf("config written = %+v", got)
+	}
+	if cfg, err := LoadConfig(); err != nil || cfg.Profile != "staging" {
+		t.Errorf("the written config should load back: %+v, %v", cfg, err)
+	}
+}
+
+func TestSetupWizardLocalEndpoint(t *testing.T) {
+	m := setupModel(t)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.setupStep != setupStepEndpoint {
+		t.Fatalf("step = %d, want the endpoint", m.setupStep)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter}) // keep the suggested URL
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.setupStep != setupStepEndpoint {
+		t.Fatalf("esc from the theme should go back to the endpoint, step %d", m.setupStep)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.config.Endpoint != "http://localhost:8000" || m.config.Profile != "" {
+		t.Errorf("config = %+v", m.config)
+	}
+}
+
+func TestSetupWizardSkip(t *testing.T) {
+	m := setupModel(t)
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.view != viewConnect {
+		t.Fatalf("esc on the first question should skip, view = %d", m.view)
+	}
+	if !FirstRun() {
+		t.Error("skipping must not write a config, so the wizard comes back")
+	}
+}
@@ -0,0 +1,361 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// snapshotDir holds named snapshots of result sets, one file each, to diff
+// later runs against
+const snapshotDir = "snapshots"
+
+type resultSnapshot struct {
+	Name       string            `json:"name"`
+	Connection string            `json:"connection"`
+	Table      string            `json:"table"`
+	Filter     string            `json:"filter,omitempty"` // the filter it was taken with, as the status bar puts it
+	TakenAt    time.Time         `json:"taken_at"`
+	Items      []json.RawMessage `json:"items"` // DynamoDB JSON
+	path       string
+}
+
+func snapshotPath(name string) (string, error) {
+	dir, err := appDataPath(snapshotDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// readSnapshots lists the current table's snapshots, newest first
+func (m *Model) readSnapshots() ([]resultSnapshot, error) {
+	dir, err := snapshotPath("")
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	var snaps []resultSnapshot
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var snap resultSnapshot
+		if json.Unmarshal(data, &snap) != nil || snap.Connection != m.connectionKey() || snap.Table != m.currentTable {
+			continue
+		}
+		snap.path = path
+		snaps = append(snaps, snap)
+	}
+	slices.SortFunc(snaps, func(a, b resultSnapshot) int { return b.TakenAt.Compare(a.TakenAt) })
+	return snaps, nil
+}
+
+// openSnapshots lists the table's snapshots (D)
+func (m *Model) openSnapshots() {
+	snaps, err := m.readSnapshots()
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	m.snapshots, m.snapshotCursor = snaps, 0
+	m.snapshotNaming = false
+	m.view = viewSnapshots
+}
+
+// startSnapshot asks for a name for a snapshot of the loaded items
+func (m *Model) startSnapshot() tea.Cmd {
+	in := textinput.New()
+	in.Placeholder = "before-fix"
+	in.CharLimit = 64
+	in.Width = 40
+	in.SetValue(time.Now().Format("2006-01-02-150405"))
+	in.CursorEnd()
+	m.snapshotName = in
+	m.snapshotNaming = true
+	return m.snapshotName.Focus()
+}
+
+// takeSnapshot saves the loaded items under name, replacing a snapshot of
+// the same name
+func (m *Model) takeSnapshot(name string) error {
+	snap := resultSnapshot{
+		Name:       name,
+		Connection: m.connectionKey(),
+		Table:      m.currentTable,
+		TakenAt:    time.Now(),
+	}
+	if m.filterExpr != "" {
+		snap.Filter = m.filterBuilder.GetFilterSummary()
+	}
+	for _, item := range m.items {
+		line, err := models.ItemToDynamoJSON(item, false)
+		if err != nil {
+			return err
+		}
+		snap.Items = append(snap.Items, json.RawMessage(line))
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	path, err := snapshotPath(url.QueryEscape(snap.Connection+"/"+snap.Table+"/"+name) + ".json")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (snap resultSnapshot) items() ([]map[string]types.AttributeValue, error) {
+	items := make([]map[string]types.AttributeValue, 0, len(snap.Items))
+	for _, raw := range snap.Items {
+		item, err := models.DynamoJSONToItem(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %s: %w", snap.Name, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (m *Model) updateSnapshots(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.snapshotNaming {
+		switch msg.String() {
+		case "esc":
+			m.snapshotNaming = false
+		case "enter":
+			name := strings.TrimSpace(m.snapshotName.Value())
+			if name == "" {
+				return m, nil
+			}
+			if err := m.takeSnapshot(name); err != nil {
+				m.statusMsg = "✗ Failed to save snapshot: " + err.Error()
+				return m, nil
+			}
+			m.openSnapshots()
+			m.statusMsg = fmt.Sprintf("📸 Saved %d items as %q", len(m.items), name)
+		default:
+			var cmd tea.Cmd
+			m.snapshotName, cmd = m.snapshotName.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.pendingDiff = nil
+		m.view = viewTableData
+	case "up", "k":
+		m.snapshotCursor = max(m.snapshotCursor-1, 0)
+	case "down", "j":
+		m.snapshotCursor = min(m.snapshotCursor+1, max(len(m.snapshots)-1, 0))
+	case "n":
+		if len(m.items) == 0 {
+			m.statusMsg = "✗ No items loaded to snapshot"
+			return m, nil
+		}
+		return m, m.startSnapshot()
+	case "d":
+		if m.snapshotCursor < len(m.snapshots) {
+			snap := m.snapshots[m.snapshotCursor]
+			if err := os.Remove(snap.path); err != nil {
+				m.statusMsg = "✗ " + err.Error()
+				return m, nil
+			}
+			m.openSnapshots()
+			m.statusMsg = fmt.Sprintf("Deleted snapshot %q", snap.Name)
+		}
+	case "enter":
+		if m.snapshotCursor < len(m.snapshots) {
+			return m, m.diffSnapshot(m.snapshots[m.snapshotCursor])
+		}
+	}
+	return m, nil
+}
+
+// diffSnapshot runs the table view's read again and compares what it
+// returns with snap. Offline, the loaded items are compared instead.
+func (m *Model) diffSnapshot(snap resultSnapshot) tea.Cmd {
+	m.pendingDiff = &snap
+	if m.offline || m.client == nil {
+		m.finishSnapshotDiff()
+		return nil
+	}
+	m.loading = true
+	m.lastKey = nil
+	m.statusMsg = "Running the read again to compare with " + snap.Name + "..."
+	return m.scanTable()
+}
+
+// finishSnapshotDiff compares the items just read with the snapshot
+// waiting for them
+func (m *Model) finishSnapshotDiff() {
+	snap := m.pendingDiff
+	if snap == nil {
+		return
+	}
+	m.pendingDiff = nil
+	before, err := snap.items()
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return
+	}
+	d := models.DiffResults(before, m.items, func(item map[string]types.AttributeValue) string {
+		return strings.TrimPrefix(m.historyKey(item), m.currentTable+"|")
+	})
+
+	title := fmt.Sprintf("vs %q: %d items taken %s", snap.Name, len(before), snap.TakenAt.Format("2006-01-02 15:04"))
+	if snap.Filter != "" {
+		title += " with " + snap.Filter
+	}
+	lines := []string{
+		ui.HelpStyle.Render(title),
+		ui.SuccessStyle.Render(fmt.Sprintf("+%d added", len(d.Added))) + "  " +
+			ui.ErrorStyle.Render(fmt.Sprintf("−%d removed", len(d.Removed))) + "  " +
+			ui.WarningStyle.Render(fmt.Sprintf("~%d modified", len(d.Modified))) + "  " +
+			ui.HelpStyle.Render(fmt.Sprintf("%d unchanged", d.Unchanged)),
+		"",
+	}
+	for _, a := range d.Added {
+		lines = append(lines, ui.SuccessStyle.Render("+ "+a.Key))
+	}
+	for _, r := range d.Removed {
+		lines = append(lines, ui.ErrorStyle.Render("− "+r.Key))
+	}
+	for _, mod := range d.Modified {
+		lines = append(lines, ui.WarningStyle.Render("~ "+mod.Key))
+		for _, c := range mod.Changes {
+			lines = append(lines, ui.ItemStyle.Render(fmt.Sprintf("    %s: %s → %s", c.Name, diffValue(c.Before), diffValue(c.After))))
+		}
+	}
+	m.snapshotDiff, m.snapshotDiffOff = lines, 0
+	m.view = viewSnapshotDiff
+	m.statusMsg = fmt.Sprintf("Compared %d items with %q", len(m.items), snap.Name)
+}
+
+// diffValue shows one side of a changed attribute
+func diffValue(av types.AttributeValue) string {
+	if av == nil {
+		return "(absent)"
+	}
+	if s, ok := av.(*types.AttributeValueMemberS); ok {
+		return fmt.Sprintf("%q", models.FormatValue(s, 60))
+	}
+	return models.FormatValue(av, 60)
+}
+
+func (m *Model) updateSnapshotDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := m.snapshotDiffHeight()
+	last := max(len(m.snapshotDiff)-page, 0)
+	switch msg.String() {
+	case "esc", "q":
+		m.view = viewSnapshots
+	case "up", "k":
+		m.snapshotDiffOff = max(m.snapshotDiffOff-1, 0)
+	case "down", "j":
+		m.snapshotDiffOff = min(m.snapshotDiffOff+1, last)
+	case "pgup":
+		m.snapshotDiffOff = max(m.snapshotDiffOff-page, 0)
+	case "pgdown":
+		m.snapshotDiffOff = min(m.snapshotDiffOff+page, last)
+	}
+	return m, nil
+}
+
+func (m Model) snapshotDiffHeight() int {
+	return max(m.height-8, 3)
+}
+
+func (m Model) viewSnapshots() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("📸 Snapshots: " + m.currentTable))
+	b.WriteString("\n\n")
+
+	if m.snapshotNaming {
+		b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("Name for a snapshot of the %d loaded items:", len(m.items))))
+		b.WriteString("\n")
+		b.WriteString(ui.InputFocusedStyle.Render(m.snapshotName.View()))
+		b.WriteString("\n\n")
+		b.WriteString(ui.RenderHelp([]ui.KeyBinding{{Key: "Enter", Desc: "Save"}, {Key: "Esc", Desc: "Cancel"}}))
+		return b.String()
+	}
+
+	if len(m.snapshots) == 0 {
+		b.WriteString(ui.HelpStyle.Render("No snapshots of this table yet: n saves the loaded items to compare later runs with."))
+	}
+	for i, snap := range m.snapshots {
+		label := fmt.Sprintf("%-24s %s  %6d items", ui.Truncate(snap.Name, 24), snap.TakenAt.Format("2006-01-02 15:04"), len(snap.Items))
+		if snap.Filter != "" {
+			label += "  " + snap.Filter
+		}
+		if i == m.snapshotCursor {
+			b.WriteString(ui.SelectedStyle.Render("▸ " + label))
+		} else {
+			b.WriteString(ui.ItemStyle.Render("  " + label))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "n", Desc: "Snapshot loaded items"},
+		{Key: "Enter", Desc: "Diff a fresh run"},
+		{Key: "d", Desc: "Delete"},
+		{Key: "q/Esc", Desc: "Back"},
+	}))
+	return b.String()
+}
+
+func (m Model) viewSnapshotDiff() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("📸 Diff: " + m.currentTable))
+	b.WriteString("\n\n")
+	height := m.snapshotDiffHeight()
+	end := min(m.snapshotDiffOff+height, len(m.snapshotDiff))
+	for _, line := range m.snapshotDiff[m.snapshotDiffOff:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	for i := end - m.snapshotDiffOff; i < height; i++ {
+		b.WriteString("\n")
+	}
+	b.WriteString(ui.RenderHelp([]ui.KeyBinding{
+		{Key: "↑/↓", Desc: "Scroll"},
+		{Key: "PgUp/PgDn", Desc: "Page"},
+		{Key: "q/Esc", Desc: "Snapshots"},
+	}))
+	return b.String()
+}
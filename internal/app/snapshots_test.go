@@ -0,0 +1,64 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestSnapshotDiffShowsAddedRemovedAndModified(t *testing.T) {
+	stubConfigDir(t)
+	m := populatedModel()
+	m.view = viewTableData
+
+	m = drive(m, keyRunes("D"))
+	m = drive(m, keyRunes("n"))
+	m.snapshotName.SetValue("before-fix")
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.snapshots) != 1 || m.snapshots[0].Name != "before-fix" || len(m.snapshots[0].Items) != 2 {
+		t.Fatalf("snapshots %+v (status %q)", m.snapshots, m.statusMsg)
+	}
+
+	m.items = []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "1"}, "name": &types.AttributeValueMemberS{Value: "alicia"}},
+		{"id": &types.AttributeValueMemberS{Value: "3"}, "name": &types.AttributeValueMemberS{Value: "carol"}},
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter}) // no client: compares the loaded items
+	if m.view != viewSnapshotDiff {
+		t.Fatalf("view %d (status %q)", m.view, m.statusMsg)
+	}
+	out := m.View()
+	for _, want := range []string{"+1 added", "−1 removed", "~1 modified", "+ id=3", "− id=2", `name: "alice" → "alicia"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff lacks %q:\n%s", want, out)
+		}
+	}
+
+	m = drive(m, keyRunes("q"))
+	m = drive(m, keyRunes("d"))
+	if m.view != viewSnapshots || len(m.snapshots) != 0 {
+		t.Errorf("d should delete the snapshot, %d left", len(m.snapshots))
+	}
+}
+
+func TestSnapshotDiffWaitsForTheFreshRun(t *testing.T) {
+	stubConfigDir(t)
+	m := populatedModel()
+	if err := m.takeSnapshot("base"); err != nil {
+		t.Fatal(err)
+	}
+	snaps, err := m.readSnapshots()
+	if err != nil || len(snaps) != 1 {
+		t.Fatalf("snapshots %v, %v", snaps, err)
+	}
+	m.pendingDiff = &snaps[0]
+	m.view = viewSnapshots
+	m.handleScanResult(&dynamo.ScanResult{Items: m.items[:1], Count: 1})
+	if m.view != viewSnapshotDiff || !strings.Contains(m.View(), "− id=2") {
+		t.Errorf("the read's result should open the diff:\n%s", m.View())
+	}
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keepSpinning starts the spinner's ticks when something starts loading
+func (m *Model) keepSpinning() tea.Cmd {
+	if !m.loading || m.spinning {
+		return nil
+	}
+	m.spinning = true
+	return m.spinner.Tick
+}
+
+// handleSpinnerTick turns the spinner, and lets its ticks stop once nothing
+// is loading so an idle screen isn't redrawn ten times a second
+func (m *Model) handleSpinnerTick(msg spinner.TickMsg) tea.Cmd {
+	if !m.loading {
+		m.spinning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSpinnerTurnsWhileLoading(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.loading = true
+	v, cmd := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = *v.(*Model)
+	if cmd == nil || !m.spinning {
+		t.Fatal("loading should start the spinner")
+	}
+	tick, ok := m.spinner.Tick().(spinner.TickMsg)
+	if !ok {
+		t.Fatal("the spinner should tick")
+	}
+	if !strings.Contains(m.View(), m.spinner.View()+" Loading...") {
+		t.Error("the loading view should show the spinner")
+	}
+
+	m.loading = false
+	m = drive(m, tick)
+	if m.spinning {
+		t.Error("ticks should stop once nothing is loading")
+	}
+}
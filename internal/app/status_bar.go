@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// StatusBarLayout is which segments the table view's status bar shows on
+// its left, in its center and on its right, by name
+type StatusBarLayout struct {
+	Left   []string `yaml:"left"`
+	Center []string `yaml:"center"`
+	Right  []string `yaml:"right"`
+}
+
+// defaultStatusBar is the status bar godynamo always had; region, table,
+// capacity and clock are there to be added
+func defaultStatusBar() StatusBarLayout {
+	return StatusBarLayout{
+		Left: []string{"message", "readonly", "latency", "offline", "column", "filter", "scan", "page", "search", "failed", "dryrun", "watch", "macro"},
+	}
+}
+
+func (l StatusBarLayout) validate() error {
+	for _, name := range slices.Concat(l.Left, l.Center, l.Right) {
+		if _, ok := statusSegments[name]; !ok {
+			return fmt.Errorf("status_bar: unknown segment %q", name)
+		}
+	}
+	return nil
+}
+
+func (l StatusBarLayout) has(name string) bool {
+	return slices.Contains(slices.Concat(l.Left, l.Center, l.Right), name)
+}
+
+// statusSegments render each segment, "" when it has nothing to say. All but
+// the message start with their own separator.
+var statusSegments = map[string]func(m Model) string{
+	"message": func(m Model) string { return m.statusMsg },
+	"readonly": func(m Model) string {
+		if !m.config.ReadOnly {
+			return ""
+		}
+		return ui.WarningStyle.Render(" | 🔒 Read-only")
+	},
+	"latency": Model.latencyStatus,
+	"offline": Model.offlineStatus,
+	"column": func(m Model) string {
+		if len(m.dataTable.Headers) == 0 {
+			return ""
+		}
+		return ui.HelpStyle.Render(fmt.Sprintf(" | Col %d/%d", m.dataTable.SelectedCol+1, len(m.dataTable.Headers)))
+	},
+	"filter": func(m Model) string {
+		var s string
+		if summary := m.filterBuilder.GetFilterSummary(); summary != "" {
+			s += ui.WarningStyle.Render(" | Filter: " + summary)
+		}
+		if m.filterExpr != "" {
+			s += ui.HelpStyle.Render(" | " + m.filterAdvice().Summary)
+		}
+		return s
+	},
+	"scan": func(m Model) string {
+		var s string
+		if m.scanSegment != nil {
+			s += ui.WarningStyle.Render(" | Scanning " + m.segmentLabel())
+		}
+		if m.tableInfo != nil {
+			if t := m.tableInfo.Transition(); t != "" {
+				s += ui.WarningStyle.Render(" | ⏳ " + t)
+			}
+		}
+		return s + ui.HelpStyle.Render(m.savedScanStatus())
+	},
+	"page": func(m Model) string {
+		var s string
+		if m.lastKey != nil {
+			s += ui.HelpStyle.Render(" | More items available (PgDown)")
+		}
+		if m.spill != nil {
+			s += ui.HelpStyle.Render(fmt.Sprintf(" | %d older items on disk (PgUp)", m.spill.items))
+		}
+		return s
+	},
+	"search": func(m Model) string {
+		if s := m.rowSearchStatus(); s != "" {
+			return ui.WarningStyle.Render(s)
+		}
+		return ""
+	},
+	"failed": Model.failedWritesStatus,
+	"dryrun": Model.dryRunStatus,
+	"watch":  Model.watchStatus,
+	"macro":  Model.macroStatus,
+	"region": func(m Model) string {
+		where := m.selectedRegion
+		if m.config.Endpoint != "" {
+			where = m.config.Endpoint
+		}
+		if where == "" {
+			return ""
+		}
+		return ui.HelpStyle.Render(" | 🌍 " + where)
+	},
+	"table": func(m Model) string {
+		return ui.HelpStyle.Render(" | ⚡ " + m.currentTable)
+	},
+	"capacity": func(m Model) string {
+		if m.capacity == 0 {
+			return ""
+		}
+		return ui.HelpStyle.Render(fmt.Sprintf(" | %.1f RCU", m.capacity))
+	},
+	"clock": func(m Model) string {
+		return ui.HelpStyle.Render(" | " + time.Now().Format("15:04"))
+	},
+}
+
+// statusBar renders the table view's status bar from the configured layout
+func (m Model) statusBar() string {
+	render := func(names []string) string {
+		var s string
+		for _, name := range names {
+			s += statusSegments[name](m)
+		}
+		return s
+	}
+	layout := m.config.StatusBar
+	return ui.StatusBar{
+		Left:   render(layout.Left),
+		Center: render(layout.Center),
+		Right:  render(layout.Right),
+		Width:  m.width,
+	}.View()
+}
+
+type clockTickMsg struct{}
+
+// tickClock redraws the status bar on the minute while it shows the clock
+func (m Model) tickClock() tea.Cmd {
+	if !m.config.StatusBar.has("clock") {
+		return nil
+	}
+	return tea.Every(time.Minute, func(time.Time) tea.Msg { return clockTickMsg{} })
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestStatusBarLayoutFromConfig(t *testing.T) {
+	writeConfig(t, "status_bar:\n  right: [region, capacity, clock]\n")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.StatusBar.Left) == 0 {
+		t.Fatal("setting the right side should keep the default left side")
+	}
+
+	m := populatedModel()
+	m.config.StatusBar = cfg.StatusBar
+	m.view = viewTableData
+	m.selectedRegion = "eu-west-1"
+	m = drive(m, scanResultMsg{result: &dynamo.ScanResult{Count: 2, ConsumedCapacity: 1.5}})
+
+	var bar string
+	for _, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, "Loaded") {
+			bar = line
+		}
+	}
+	for _, want := range []string{"eu-west-1", "1.5 RCU"} {
+		if !strings.Contains(bar, want) {
+			t.Errorf("status bar lacks %q: %q", want, bar)
+		}
+	}
+	if !regexp.MustCompile(`\| \d\d:\d\d *$`).MatchString(bar) {
+		t.Errorf("the clock should end the bar: %q", bar)
+	}
+	if m.Init() == nil {
+		t.Error("a status bar with the clock should tick")
+	}
+}
+
+func TestDefaultStatusBarHasNoClock(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	if m.tickClock() != nil || strings.Contains(m.View(), "RCU") {
+		t.Error("the default status bar should be the one without the new segments")
+	}
+}
@@ -0,0 +1,351 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// streamPollInterval is the pause between reads once the tail has caught up
+var streamPollInterval = time.Second
+
+// streamRecordLimit is how many records the tail keeps on screen
+const streamRecordLimit = 500
+
+// streamStartCount is the number of dynamo.StreamStart choices
+const streamStartCount = dynamo.StartAtTimestamp + 1
+
+type (
+	streamShardsMsg  struct{ shards []dynamo.StreamShard }
+	streamOpenedMsg  struct{ reader *dynamo.StreamReader }
+	streamPollMsg    struct{ reader *dynamo.StreamReader }
+	streamRecordsMsg struct {
+		reader  *dynamo.StreamReader
+		records []dynamo.StreamRecord
+	}
+)
+
+// openStreams lists the table's stream shards for the user to pick from
+func (m *Model) openStreams() tea.Cmd {
+	if m.tableInfo == nil || m.tableInfo.StreamArn == "" {
+		m.statusMsg = "✗ Streams are not enabled on " + m.currentTable
+		return nil
+	}
+	if m.streamAtInput.Placeholder == "" {
+		ti := textinput.New()
+		ti.Placeholder = "30m, 15:04 or 2006-01-02T15:04:05Z"
+		ti.Width = 36
+		m.streamAtInput = ti
+	}
+	m.streamReader = nil
+	m.streamRecords = nil
+	m.loading = true
+	m.statusMsg = "Reading stream shards..."
+	arn := m.tableInfo.StreamArn
+	return func() tea.Msg {
+		shards, err := m.client.ListStreamShards(context.Background(), arn)
+		if err != nil {
+			return errMsg{err}
+		}
+		return streamShardsMsg{shards}
+	}
+}
+
+func (m *Model) handleStreamShards(shards []dynamo.StreamShard) {
+	m.loading = false
+	m.streamShards = shards
+	m.streamPicked = make(map[string]bool, len(shards))
+	for _, s := range shards {
+		m.streamPicked[s.ID] = true
+	}
+	m.streamCursor = 0
+	m.view = viewStreams
+	m.statusMsg = fmt.Sprintf("%d shards", len(shards))
+}
+
+func (m *Model) updateStreams(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.streamReader != nil {
+		switch msg.String() {
+		case "esc", "q":
+			// The first batch may still be on its way; it is dropped
+			m.streamReader = nil
+			m.loading = false
+			m.statusMsg = "Stopped tailing"
+		case "c":
+			m.streamRecords = nil
+		}
+		return m, nil
+	}
+
+	if m.streamAtInput.Focused() {
+		switch msg.String() {
+		case "tab", "esc":
+			m.streamAtInput.Blur()
+			return m, nil
+		case "enter":
+			m.streamAtInput.Blur()
+			return m, m.startStreamTail()
+		}
+		var cmd tea.Cmd
+		m.streamAtInput, cmd = m.streamAtInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		// Shards still being opened are dropped when they arrive
+		m.loading = false
+		m.view = viewTableData
+	case "up", "k":
+		if m.streamCursor > 0 {
+			m.streamCursor--
+		}
+	case "down", "j":
+		if m.streamCursor < len(m.streamShards)-1 {
+			m.streamCursor++
+		}
+	case " ":
+		if m.streamCursor < len(m.streamShards) {
+			id := m.streamShards[m.streamCursor].ID
+			m.streamPicked[id] = !m.streamPicked[id]
+		}
+	case "a":
+		// Select all, or none if all are already selected
+		all := len(m.pickedShards()) == len(m.streamShards)
+		for _, s := range m.streamShards {
+			m.streamPicked[s.ID] = !all
+		}
+	case "o":
+		for _, s := range m.streamShards {
+			m.streamPicked[s.ID] = s.Open
+		}
+	case "p", "right":
+		m.streamStart = (m.streamStart + 1) % streamStartCount
+	case "left":
+		m.streamStart = (m.streamStart + streamStartCount - 1) % streamStartCount
+	case "tab":
+		if m.streamStart == dynamo.StartAtTimestamp {
+			return m, m.streamAtInput.Focus()
+		}
+	case "enter":
+		return m, m.startStreamTail()
+	}
+	return m, nil
+}
+
+func (m *Model) pickedShards() []string {
+	var ids []string
+	for _, s := range m.streamShards {
+		if m.streamPicked[s.ID] {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
+func (m *Model) startStreamTail() tea.Cmd {
+	shards := m.pickedShards()
+	if len(shards) == 0 {
+		m.statusMsg = "✗ Select at least one shard"
+		return nil
+	}
+	var at time.Time
+	if m.streamStart == dynamo.StartAtTimestamp {
+		t, err := parseStreamTime(m.streamAtInput.Value(), time.Now())
+		if err != nil {
+			m.statusMsg = "✗ " + err.Error()
+			return nil
+		}
+		at = t
+	}
+	m.streamRecords = nil
+	m.loading = true
+	m.statusMsg = fmt.Sprintf("Opening %d shard(s) at %s...", len(shards), m.streamStart)
+	arn, start := m.tableInfo.StreamArn, m.streamStart
+	return func() tea.Msg {
+		r, err := m.client.OpenStream(context.Background(), arn, shards, start, at)
+		if err != nil {
+			return errMsg{err}
+		}
+		return streamOpenedMsg{r}
+	}
+}
+
+// parseStreamTime reads a replay start: a duration ago ("30m"), a time of
+// day today ("15:04"), a local date and time, or RFC 3339.
+func parseStreamTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		y, mo, d := now.Date()
+		return time.Date(y, mo, d, t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("start time %q: use 30m, 15:04, 2006-01-02 15:04 or RFC 3339", s)
+}
+
+func pollStream(r *dynamo.StreamReader) tea.Cmd {
+	return func() tea.Msg {
+		records, err := r.Poll(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return streamRecordsMsg{reader: r, records: records}
+	}
+}
+
+// handleStreamRecords appends a batch and schedules the next read: straight
+// away while replaying a backlog, after streamPollInterval once caught up.
+// Batches from a reader that has been stopped are dropped.
+func (m *Model) handleStreamRecords(msg streamRecordsMsg) tea.Cmd {
+	if msg.reader != m.streamReader {
+		return nil
+	}
+	m.loading = false
+	m.streamRecords = append(m.streamRecords, msg.records...)
+	if over := len(m.streamRecords) - streamRecordLimit; over > 0 {
+		m.streamRecords = m.streamRecords[over:]
+	}
+	r := msg.reader
+	if r.OpenShards() == 0 {
+		m.statusMsg = fmt.Sprintf("✓ Read every selected shard to its end (%d records)", len(m.streamRecords))
+		return nil
+	}
+	m.statusMsg = fmt.Sprintf("Tailing %d shard(s) from %s", r.OpenShards(), m.streamStart)
+	if r.Behind() {
+		return pollStream(r)
+	}
+	return tea.Tick(streamPollInterval, func(time.Time) tea.Msg { return streamPollMsg{r} })
+}
+
+func (m Model) viewStreams() string {
+	var b strings.Builder
+	b.WriteString(ui.TitleStyle.Render("🌊 Stream: " + m.currentTable))
+	b.WriteString("\n")
+	b.WriteString(ui.HelpStyle.Render(m.tableInfo.StreamArn + " (" + m.tableInfo.StreamViewType + ")"))
+	b.WriteString("\n\n")
+
+	if m.streamReader != nil {
+		b.WriteString(m.viewStreamRecords())
+		b.WriteString("\n\n")
+		b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+		b.WriteString("\n")
+		b.WriteString(ui.RenderHelp([]ui.KeyBinding{{Key: "c", Desc: "Clear"}, {Key: "Esc", Desc: "Stop"}}))
+		return b.String()
+	}
+
+	start := ""
+	for s := dynamo.StartTrimHorizon; s <= dynamo.StartAtTimestamp; s++ {
+		label := "( ) " + s.String()
+		if s == m.streamStart {
+			label = ui.SelectedStyle.Render("(•) " + s.String())
+		}
+		start += label + "  "
+	}
+	b.WriteString(ui.ItemStyle.Render("Start: ") + start + "\n")
+	if m.streamStart == dynamo.StartAtTimestamp {
+		style := ui.InputStyle
+		if m.streamAtInput.Focused() {
+			style = ui.InputFocusedStyle
+		}
+		b.WriteString(ui.ItemStyle.Render("From:  ") + style.Render(m.streamAtInput.View()) + "\n")
+	}
+	b.WriteString("\n")
+
+	height := max(m.height-16, 5)
+	from := max(0, min(m.streamCursor-height/2, len(m.streamShards)-height))
+	for i := from; i < min(len(m.streamShards), from+height); i++ {
+		s := m.streamShards[i]
+		box := "[ ]"
+		if m.streamPicked[s.ID] {
+			box = "[x]"
+		}
+		state := ui.HelpStyle.Render("closed")
+		if s.Open {
+			state = ui.SuccessStyle.Render("open")
+		}
+		line := fmt.Sprintf("%s %s", box, s.ID)
+		if i == m.streamCursor {
+			line = ui.SelectedStyle.Render(line)
+		}
+		b.WriteString(line + "  " + state + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(ui.StatusBarStyle.Render(m.statusMsg))
+	b.WriteString("\n")
+	keys := []ui.KeyBinding{
+		{Key: "Space", Desc: "Toggle"},
+		{Key: "a", Desc: "All/none"},
+		{Key: "o", Desc: "Open only"},
+		{Key: "p", Desc: "Start position"},
+	}
+	if m.streamStart == dynamo.StartAtTimestamp {
+		keys = append(keys, ui.KeyBinding{Key: "Tab", Desc: "Edit time"})
+	}
+	keys = append(keys, ui.KeyBinding{Key: "Enter", Desc: "Tail"}, ui.KeyBinding{Key: "Esc", Desc: "Back"})
+	b.WriteString(ui.RenderHelp(keys))
+	return b.String()
+}
+
+// viewStreamRecords shows the newest records that fit, oldest at the top
+func (m Model) viewStreamRecords() string {
+	if len(m.streamRecords) == 0 {
+		return ui.HelpStyle.Render("Waiting for records...")
+	}
+	height := max(m.height-12, 5)
+	width := max(m.width-50, 30)
+	var lines []string
+	for _, r := range m.streamRecords[max(0, len(m.streamRecords)-height):] {
+		image := r.NewImage
+		if r.EventName == "REMOVE" {
+			image = r.OldImage
+		}
+		detail := ""
+		if image != nil {
+			detail, _ = models.ItemToJSON(image, false)
+		}
+		event := fmt.Sprintf("%-6s", r.EventName)
+		switch r.EventName {
+		case "INSERT":
+			event = ui.SuccessStyle.Render(event)
+		case "REMOVE":
+			event = ui.ErrorStyle.Render(event)
+		default:
+			event = ui.WarningStyle.Render(event)
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s  %s  %s",
+			ui.HelpStyle.Render(r.Time.Local().Format("15:04:05")), event, formatKeys(r.Keys), ui.HelpStyle.Render(ui.Truncate(detail, width))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatKeys renders a key as name=value pairs in name order
+func formatKeys(keys map[string]types.AttributeValue) string {
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, k := range names {
+		parts[i] = k + "=" + models.FormatValue(keys[k], 40)
+	}
+	return strings.Join(parts, " ")
+}
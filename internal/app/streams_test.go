@@ -0,0 +1,132 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func streamModel() Model {
+	m := populatedModel()
+	m.view = viewTableData
+	m.tableInfo.StreamArn = "arn:aws:dynamodb:eu-west-1:1:table/Users/stream/x"
+	m.tableInfo.StreamViewType = "NEW_AND_OLD_IMAGES"
+	m = drive(m, keyRunes("t"))
+	return drive(m, streamShardsMsg{[]dynamo.StreamShard{{ID: "shard-a"}, {ID: "shard-b", ParentID: "shard-a", Open: true}}})
+}
+
+func TestStreamsNeedAStream(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, keyRunes("t"))
+	if m.view != viewTableData || !strings.Contains(m.statusMsg, "not enabled") {
+		t.Fatalf("view = %d, status = %q", m.view, m.statusMsg)
+	}
+}
+
+func TestStreamShardAndStartSelection(t *testing.T) {
+	m := streamModel()
+	if m.view != viewStreams || len(m.pickedShards()) != 2 {
+		t.Fatalf("view = %d, picked = %v; every shard starts selected", m.view, m.pickedShards())
+	}
+
+	m = drive(m, keyRunes("o"))
+	if got := m.pickedShards(); len(got) != 1 || got[0] != "shard-b" {
+		t.Fatalf("open only picked %v", got)
+	}
+	m = drive(m, keyRunes(" "))
+	if got := m.pickedShards(); len(got) != 2 {
+		t.Fatalf("space should toggle the shard under the cursor, picked %v", got)
+	}
+	m = drive(m, keyRunes("a"))
+	if len(m.pickedShards()) != 0 {
+		t.Fatal("a with everything picked should clear the selection")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.statusMsg, "at least one shard") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m = drive(drive(m, keyRunes("a")), keyRunes("p"))
+	if m.streamStart != dynamo.StartLatest {
+		t.Fatalf("start = %s", m.streamStart)
+	}
+	m = drive(drive(m, keyRunes("p")), tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range "soon" {
+		m = drive(m, keyRunes(string(r)))
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.streamStart != dynamo.StartAtTimestamp || !strings.Contains(m.statusMsg, `start time "soon"`) {
+		t.Fatalf("start = %s, status = %q", m.streamStart, m.statusMsg)
+	}
+	if !strings.Contains(m.View(), "(•) AT_TIMESTAMP") {
+		t.Fatal("the chosen start position should be marked")
+	}
+}
+
+func TestStreamRecordsFromStoppedReaderAreDropped(t *testing.T) {
+	m := streamModel()
+	r := &dynamo.StreamReader{}
+	m = drive(m, streamOpenedMsg{r})
+	rec := dynamo.StreamRecord{
+		EventName: "MODIFY", Time: time.Now(),
+		Keys:     map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "7"}},
+		NewImage: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "7"}, "name": &types.AttributeValueMemberS{Value: "eve"}},
+	}
+	m = drive(m, streamRecordsMsg{reader: r, records: []dynamo.StreamRecord{rec}})
+	if out := m.View(); !strings.Contains(out, "id=7") || !strings.Contains(out, `"name":"eve"`) {
+		t.Fatalf("record not shown:\n%s", out)
+	}
+
+	m = drive(m, keyRunes("q"))
+	m = drive(m, streamRecordsMsg{reader: r, records: []dynamo.StreamRecord{rec}})
+	if m.streamReader != nil || len(m.streamRecords) != 1 {
+		t.Fatalf("stopped reader still delivering: %d records", len(m.streamRecords))
+	}
+}
+
+func TestParseStreamTime(t *testing.T) {
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"30m":                  now.Add(-30 * time.Minute),
+		"09:15":                time.Date(2026, 10, 15, 9, 15, 0, 0, time.UTC),
+		"2026-10-14 23:00":     time.Date(2026, 10, 14, 23, 0, 0, 0, time.UTC),
+		"2026-10-15T10:00:00Z": time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+	}
+	for in, want := range cases {
+		if got, err := parseStreamTime(in, now); err != nil || !got.Equal(want) {
+			t.Errorf("%q = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseStreamTime("yesterday", now); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestLeavingStreamsBeforeTheFirstBatchStopsLoading(t *testing.T) {
+	m := streamModel()
+	r := &dynamo.StreamReader{}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.loading {
+		t.Fatal("starting the tail should be loading")
+	}
+	m = drive(m, streamOpenedMsg{r})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m = drive(m, streamRecordsMsg{reader: r})
+	if m.loading || m.streamReader != nil {
+		t.Fatalf("loading = %v after stopping before the first batch", m.loading)
+	}
+
+	// Leaving while the shards are still being opened
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m = drive(m, streamOpenedMsg{r})
+	if m.loading || m.streamReader != nil || m.view != viewTableData {
+		t.Fatalf("loading = %v, reader %v, view %d after leaving while opening", m.loading, m.streamReader, m.view)
+	}
+}
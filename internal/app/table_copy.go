@@ -0,0 +1,335 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/internal/ui/textarea"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// Fields of the copy panel
+const (
+	copyFieldDest = iota
+	copyFieldTransform
+	copyFieldSamples
+	copyFieldCount
+)
+
+// defaultVerifySamples is how many items a verification compares by default
+const defaultVerifySamples = 100
+
+type (
+	copyProgressMsg struct{ progress dynamo.CopyProgress }
+	copyDoneMsg     struct {
+		progress dynamo.CopyProgress
+		dest     string
+		err      error
+	}
+	copyVerifiedMsg struct {
+		report dynamo.VerifyReport
+		err    error
+	}
+)
+
+// openTableCopy copies the current table into another existing table,
+// optionally reshaping items with a transform spec on the way
+func (m *Model) openTableCopy() tea.Cmd {
+	if m.tableInfo == nil {
+		return nil
+	}
+	if m.copyEditor.Placeholder == "" {
+		dest := textinput.New()
+		dest.Placeholder = "destination table"
+		dest.CharLimit = 255
+		dest.Width = 40
+		m.copyDest = dest
+		ta := textarea.New()
+		ta.Placeholder = "rename id pk\ndrop legacyField\nset migrated true"
+		ta.ShowLineNumbers = false
+		ta.CharLimit = 0
+		ta.SetPromptFunc(0, func(int) string { return "" })
+		ta.SetHeight(8)
+		ta.SetWidth(60)
+		m.copyEditor = ta
+		samples := textinput.New()
+		samples.CharLimit = 5
+		samples.Width = 8
+		samples.SetValue(strconv.Itoa(defaultVerifySamples))
+		m.copySamples = samples
+	}
+	m.copyProgress = dynamo.CopyProgress{}
+	m.copyReport = nil
+	m.view = viewTableCopy
+	return m.focusCopyField(copyFieldDest)
+}
+
+func (m *Model) focusCopyField(field int) tea.Cmd {
+	m.copyFocus = field
+	m.copyDest.Blur()
+	m.copyEditor.Blur()
+	m.copySamples.Blur()
+	switch field {
+	case copyFieldTransform:
+		return m.copyEditor.Focus()
+	case copyFieldSamples:
+		return m.copySamples.Focus()
+	}
+	return m.copyDest.Focus()
+}
+
+func (m *Model) updateTableCopy(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.copyCh != nil {
+		if msg.String() == "esc" {
+			m.copyCancel()
+			m.statusMsg = "Stopping..."
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc":
+		m.view = viewTableData
+		return m, nil
+	case "tab":
+		return m, m.focusCopyField((m.copyFocus + 1) % copyFieldCount)
+	case "shift+tab":
+		return m, m.focusCopyField((m.copyFocus + copyFieldCount - 1) % copyFieldCount)
+	case "ctrl+s":
+		return m, m.startTableCopy()
+	case "ctrl+r":
+		return m, m.startCopyVerify()
+	case "enter":
+		if m.copyFocus == copyFieldDest {
+			return m, m.startTableCopy()
+		}
+		if m.copyFocus == copyFieldSamples {
+			return m, m.startCopyVerify()
+		}
+	}
+	var cmd tea.Cmd
+	switch m.copyFocus {
+	case copyFieldDest:
+		m.copyDest, cmd = m.copyDest.Update(msg)
+	case copyFieldTransform:
+		m.copyEditor, cmd = m.copyEditor.Update(msg)
+	default:
+		m.copySamples, cmd = m.copySamples.Update(msg)
+	}
+	return m, cmd
+}
+
+// copyInput reads the panel; the destination key is filled in by the job
+func (m *Model) copyInput() (dynamo.CopyInput, error) {
+	in := dynamo.CopyInput{Source: m.currentTable, Dest: strings.TrimSpace(m.copyDest.Value()), Segments: m.workers()}
+	if in.Dest == "" {
+		return in, fmt.Errorf("Enter the destination table")
+	}
+	transform, err := models.ParseTransform(m.copyEditor.Value())
+	if err != nil {
+		return in, fmt.Errorf("Transform: %w", err)
+	}
+	if !transform.Empty() {
+		in.Transform = transform.Apply
+	}
+	return in, nil
+}
+
+// withDestKey describes the destination for the attributes every item needs
+func withDestKey(ctx context.Context, client dynamo.API, in dynamo.CopyInput) (dynamo.CopyInput, error) {
+	info, err := client.DescribeTable(ctx, in.Dest)
+	if err != nil {
+		return in, err
+	}
+	in.DestKey = []string{info.PartitionKey}
+	if info.SortKey != "" {
+		in.DestKey = append(in.DestKey, info.SortKey)
+	}
+	return in, nil
+}
+
+func (m *Model) startTableCopy() tea.Cmd {
+	in, err := m.copyInput()
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 1)
+	m.copyCh, m.copyCancel = ch, cancel
+	m.copyProgress = dynamo.CopyProgress{}
+	m.copyReport = nil
+	m.statusMsg = fmt.Sprintf("Copying %s to %s...", in.Source, in.Dest)
+
+	client := m.client
+	go func() {
+		defer cancel()
+		in, err := withDestKey(ctx, client, in)
+		if err != nil {
+			ch <- copyDoneMsg{dest: in.Dest, err: err}
+			return
+		}
+		p, err := client.CopyTable(ctx, in, func(p dynamo.CopyProgress) {
+			ch <- copyProgressMsg{p}
+		})
+		ch <- copyDoneMsg{progress: p, dest: in.Dest, err: err}
+	}()
+	return waitForCopy(ch)
+}
+
+// startCopyVerify compares the destination with the source, as the
+// transform in the panel would have written it
+func (m *Model) startCopyVerify() tea.Cmd {
+	in, err := m.copyInput()
+	if err != nil {
+		m.statusMsg = "✗ " + err.Error()
+		return nil
+	}
+	samples, err := strconv.Atoi(strings.TrimSpace(m.copySamples.Value()))
+	if err != nil || samples < 0 {
+		m.statusMsg = "✗ Samples must be a number, 0 to only compare counts"
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 1)
+	m.copyCh, m.copyCancel = ch, cancel
+	m.copyReport = nil
+	m.statusMsg = fmt.Sprintf("Verifying %s against %s...", in.Dest, in.Source)
+
+	client := m.client
+	go func() {
+		defer cancel()
+		in, err := withDestKey(ctx, client, in)
+		if err != nil {
+			ch <- copyVerifiedMsg{err: err}
+			return
+		}
+		r, err := client.VerifyCopy(ctx, dynamo.VerifyInput{CopyInput: in, Samples: samples})
+		ch <- copyVerifiedMsg{report: r, err: err}
+	}()
+	return waitForCopy(ch)
+}
+
+func waitForCopy(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func (m *Model) handleCopyDone(msg copyDoneMsg) {
+	m.copyCh, m.copyCancel = nil, nil
+	m.copyProgress = msg.progress
+	var wf *dynamo.WriteFailures
+	if msg.err != nil && !errors.As(msg.err, &wf) {
+		// Items written before the error stay in the destination
+		m.statusMsg = fmt.Sprintf("✗ Stopped after copying %d items: %s", msg.progress.Copied, msg.err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✓ Copied %d items from %s to %s; Ctrl+R verifies", msg.progress.Copied, m.currentTable, msg.dest)
+	if wf != nil {
+		m.statusMsg += m.queueFailedWrites(msg.dest, wf.Failed)
+	}
+}
+
+func (m *Model) handleCopyVerified(msg copyVerifiedMsg) {
+	m.copyCh, m.copyCancel = nil, nil
+	if msg.err != nil {
+		m.statusMsg = "✗ Verification failed: " + msg.err.Error()
+		return
+	}
+	r := msg.report
+	m.copyReport = &r
+	if r.OK() {
+		m.statusMsg = fmt.Sprintf("✓ Verified: %d items in both tables, %d sampled items match", r.SourceCount, r.Sampled)
+	} else {
+		m.statusMsg = "✗ Verification found differences"
+	}
+}
+
+// copyReportView lists what a verification found, a few keys of each kind
+func (m Model) copyReportView() string {
+	r := m.copyReport
+	if r == nil {
+		return ""
+	}
+	var b strings.Builder
+	counts := fmt.Sprintf("Items: %d in %s, %d in the destination", r.SourceCount, m.currentTable, r.DestCount)
+	if r.SourceCount == r.DestCount {
+		b.WriteString(ui.SuccessStyle.Render("✓ "+counts) + "\n")
+	} else {
+		b.WriteString(ui.ErrorStyle.Render("✗ "+counts) + "\n")
+	}
+	b.WriteString(ui.ItemStyle.Render(fmt.Sprintf("Sampled %d: %d missing, %d different", r.Sampled, len(r.Missing), len(r.Different))) + "\n")
+	for _, kind := range []struct {
+		label string
+		keys  []string
+	}{{"missing", r.Missing}, {"different", r.Different}} {
+		label, keys := kind.label, kind.keys
+		for i, key := range keys {
+			if i == 5 {
+				b.WriteString(ui.HelpStyle.Render(fmt.Sprintf("  … %d more %s", len(keys)-i, label)) + "\n")
+				break
+			}
+			b.WriteString(ui.WarningStyle.Render("  "+label+": "+key) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// copyPreview shows the selected item as the transform would write it
+func (m Model) copyPreview() string {
+	if m.dataTable.SelectedRow >= len(m.items) {
+		return ""
+	}
+	transform, err := models.ParseTransform(m.copyEditor.Value())
+	if err != nil {
+		return ui.ErrorStyle.Render("✗ " + err.Error())
+	}
+	preview, err := models.ItemToJSON(transform.Apply(m.items[m.dataTable.SelectedRow]), false)
+	if err != nil {
+		return ""
+	}
+	return ui.HelpStyle.Render("Selected item becomes: " + ui.Truncate(preview, 70))
+}
+
+func (m Model) viewTableCopy() string {
+	var body strings.Builder
+	body.WriteString(ui.HelpStyle.Render("Copies every item into an existing table; items with the same key are replaced.") + "\n")
+	body.WriteString(ui.HelpStyle.Render("Transform rules, one per line: rename a b • copy a b • drop a • set a <JSON>") + "\n\n")
+
+	style := func(field int) lipgloss.Style {
+		if m.copyFocus == field {
+			return ui.InputFocusedStyle
+		}
+		return ui.InputStyle
+	}
+	body.WriteString(ui.ItemStyle.Render("Destination:") + "\n" + style(copyFieldDest).Render(m.copyDest.View()) + "\n")
+	body.WriteString(ui.ItemStyle.Render("Transform (optional):") + "\n" + style(copyFieldTransform).Render(m.copyEditor.View()) + "\n")
+	body.WriteString(m.copyPreview() + "\n")
+	body.WriteString(ui.ItemStyle.Render("Items to compare when verifying:") + "\n" + style(copyFieldSamples).Render(m.copySamples.View()) + "\n\n")
+
+	if m.copyCh != nil && m.copyProgress.Scanned > 0 {
+		p := m.copyProgress
+		body.WriteString(ui.WarningStyle.Render(fmt.Sprintf("Copied %d items (scanned %d)...", p.Copied, p.Scanned)) + "\n")
+	}
+	body.WriteString(m.copyReportView())
+	body.WriteString(ui.StatusBarStyle.Render(m.statusMsg) + "\n\n")
+	help := "Tab: next field • Ctrl+S: copy • Ctrl+R: verify • Esc: cancel"
+	if m.copyCh != nil {
+		help = "Esc: stop"
+	}
+	body.WriteString(ui.HelpStyle.Render(help))
+
+	content := ui.ModalStyle.Render(ui.TitleStyle.Render("📋 Copy Table: "+m.currentTable) + "\n\n" + body.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestTableCopyChecksInputAndPreviews(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlT})
+	if m.view != viewTableCopy {
+		t.Fatalf("view = %d", m.view)
+	}
+
+	if cmd := m.startTableCopy(); cmd != nil || m.statusMsg != "✗ Enter the destination table" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	m.copyDest.SetValue("UsersV2")
+	m.copyEditor.SetValue("rename id")
+	if cmd := m.startTableCopy(); cmd != nil || !strings.Contains(m.statusMsg, "Transform: line 1") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m.copyEditor.SetValue("rename id pk\nset v 2")
+	if p := m.copyPreview(); !strings.Contains(p, `"pk":`) || strings.Contains(p, `"id":`) || !strings.Contains(p, `"v":2`) {
+		t.Errorf("preview = %q", p)
+	}
+
+	m = drive(m, copyDoneMsg{progress: dynamo.CopyProgress{Copied: 2}, dest: "UsersV2"})
+	if m.view != viewTableCopy || m.statusMsg != "✓ Copied 2 items from Users to UsersV2; Ctrl+R verifies" {
+		t.Fatalf("view %d, status %q", m.view, m.statusMsg)
+	}
+}
+
+func TestCopyVerificationReport(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlT})
+	m.copyDest.SetValue("UsersV2")
+	m.copySamples.SetValue("lots")
+	if cmd := m.startCopyVerify(); cmd != nil || !strings.Contains(m.statusMsg, "Samples must be a number") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m = drive(m, copyVerifiedMsg{report: dynamo.VerifyReport{SourceCount: 2, DestCount: 2, Sampled: 2}})
+	if m.statusMsg != "✓ Verified: 2 items in both tables, 2 sampled items match" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	m = drive(m, copyVerifiedMsg{report: dynamo.VerifyReport{SourceCount: 2, DestCount: 1, Sampled: 2, Missing: []string{"pk=2"}}})
+	out := m.View()
+	if m.statusMsg != "✗ Verification found differences" || !strings.Contains(out, "missing: pk=2") || !strings.Contains(out, "1 in the destination") {
+		t.Fatalf("status %q, view:\n%s", m.statusMsg, out)
+	}
+}
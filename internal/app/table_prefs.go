@@ -0,0 +1,386 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// tablePrefsFile keeps each table's column layout, sort and page size,
+// keyed by connection and table name
+const tablePrefsFile = "table_prefs.json"
+
+// Column widths "," and "." step between
+const (
+	minColWidth = 4
+	maxColWidth = 120
+)
+
+// Column width modes W cycles through
+const (
+	widthAuto  = "auto"  // as wide as the content, up to a cap
+	widthEqual = "equal" // the table's width shared out evenly
+	widthFixed = "fixed" // the widths set with "," and "."
+)
+
+var widthModes = []string{widthAuto, widthEqual, widthFixed}
+
+type tablePrefs struct {
+	Order    []string       `json:"order,omitempty"` // columns as last arranged; new ones go after
+	Hidden   []string       `json:"hidden,omitempty"`
+	Widths   map[string]int `json:"widths,omitempty"`
+	SortBy   string         `json:"sort_by,omitempty"`
+	SortDesc bool           `json:"sort_desc,omitempty"`
+	PageSize int32          `json:"page_size,omitempty"`
+	// WidthMode is one of widthModes; "" is fixed once a width was set
+	// and auto before
+	WidthMode string `json:"width_mode,omitempty"`
+}
+
+func (p tablePrefs) empty() bool {
+	return len(p.Order) == 0 && len(p.Hidden) == 0 && len(p.Widths) == 0 && p.SortBy == "" && p.PageSize == 0 && p.WidthMode == ""
+}
+
+func (p tablePrefs) widthMode() string {
+	switch {
+	case p.WidthMode != "":
+		return p.WidthMode
+	case len(p.Widths) > 0:
+		return widthFixed
+	}
+	return widthAuto
+}
+
+func (m *Model) tablePrefsKey() string {
+	return m.connectionKey() + "/" + m.currentTable
+}
+
+func readTablePrefs() (map[string]tablePrefs, error) {
+	path, err := appDataPath(tablePrefsFile)
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string]tablePrefs)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table preferences: %w", err)
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return all, nil
+}
+
+// loadTablePrefs restores the preferences of the table being opened. The
+// page size falls back to the configured one.
+func (m *Model) loadTablePrefs() {
+	m.prefs = tablePrefs{}
+	if all, err := readTablePrefs(); err != nil {
+		m.statusMsg = "✗ " + err.Error()
+	} else {
+		m.prefs = all[m.tablePrefsKey()]
+	}
+	m.pageSize = m.config.PageSize
+	if m.prefs.PageSize > 0 {
+		m.pageSize = m.prefs.PageSize
+	}
+}
+
+// saveTablePrefs writes the current table's preferences, replacing the file
+// so a crash mid-write can't leave it half written
+func (m *Model) saveTablePrefs() {
+	err := func() error {
+		all, err := readTablePrefs()
+		if err != nil {
+			return err
+		}
+		if m.prefs.empty() {
+			delete(all, m.tablePrefsKey())
+		} else {
+			all[m.tablePrefsKey()] = m.prefs
+		}
+		data, err := json.MarshalIndent(all, "", "  ")
+		if err != nil {
+			return err
+		}
+		path, err := appDataPath(tablePrefsFile)
+		if err != nil {
+			return err
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, path)
+	}()
+	if err != nil {
+		m.statusMsg = "✗ Failed to save table preferences: " + err.Error()
+	}
+}
+
+// showItems puts items on screen in the table's saved sort and column layout
+func (m *Model) showItems(items []map[string]types.AttributeValue) {
+	m.sortItems(items)
+	headers, rows := m.itemsToTable(items)
+	headers, rows = m.prefs.layout(headers, rows)
+	m.columns = headers
+	m.setTableData(m.decorateHeaders(headers), rows)
+	m.dataTable.Marks = m.watchRowMarks(items)
+	m.applyWidthMode()
+}
+
+// applyWidthMode sets the column widths the table's width mode asks for,
+// over the content widths the rows were laid out with
+func (m *Model) applyWidthMode() {
+	switch m.prefs.widthMode() {
+	case widthEqual:
+		m.dataTable.EqualColWidths()
+	case widthFixed:
+		for i, h := range m.columns {
+			if w, ok := m.prefs.Widths[h]; ok && i < len(m.dataTable.ColWidths) {
+				m.dataTable.ColWidths[i] = w
+			}
+		}
+	}
+}
+
+// cycleWidthMode switches between content, equal and fixed column widths
+func (m *Model) cycleWidthMode() {
+	i := slices.Index(widthModes, m.prefs.widthMode())
+	m.prefs.WidthMode = widthModes[(i+1)%len(widthModes)]
+	m.saveTablePrefs()
+	m.redrawItems()
+	switch m.prefs.WidthMode {
+	case widthAuto:
+		m.statusMsg = "Column widths: fit the content"
+	case widthEqual:
+		m.statusMsg = "Column widths: equal"
+	case widthFixed:
+		m.statusMsg = fmt.Sprintf("Column widths: fixed (%d set with , and .)", len(m.prefs.Widths))
+	}
+}
+
+// redrawItems applies a preference change to the rows already loaded,
+// keeping the cursor where it was
+func (m *Model) redrawItems() {
+	row, col, off := m.dataTable.SelectedRow, m.dataTable.SelectedCol, m.dataTable.HorizontalOff
+	m.showItems(m.items)
+	m.dataTable.GoToRow(min(row, len(m.items)-1))
+	m.dataTable.SelectedCol = min(col, max(len(m.columns)-1, 0))
+	m.dataTable.HorizontalOff = min(off, m.dataTable.SelectedCol)
+}
+
+// layout reorders and hides columns. Without an order or hidden columns the
+// rows are returned as built.
+func (p tablePrefs) layout(headers []string, rows [][]string) ([]string, [][]string) {
+	if len(p.Order) == 0 && len(p.Hidden) == 0 {
+		return headers, rows
+	}
+	rank := make(map[string]int, len(p.Order))
+	for i, h := range p.Order {
+		rank[h] = i
+	}
+	var keep []int // source column of each shown column
+	for i, h := range headers {
+		if !slices.Contains(p.Hidden, h) {
+			keep = append(keep, i)
+		}
+	}
+	sort.SliceStable(keep, func(a, b int) bool {
+		ra, oka := rank[headers[keep[a]]]
+		rb, okb := rank[headers[keep[b]]]
+		if oka && okb {
+			return ra < rb
+		}
+		return oka && !okb
+	})
+
+	shown := make([]string, len(keep))
+	for i, src := range keep {
+		shown[i] = headers[src]
+	}
+	n := len(keep)
+	cells := make([]string, len(rows)*n)
+	out := make([][]string, len(rows))
+	for r, row := range rows {
+		dst := cells[r*n : (r+1)*n : (r+1)*n]
+		for i, src := range keep {
+			dst[i] = row[src]
+		}
+		out[r] = dst
+	}
+	return shown, out
+}
+
+// decorateHeaders marks the sorted column
+func (m *Model) decorateHeaders(headers []string) []string {
+	i := slices.Index(headers, m.prefs.SortBy)
+	if i < 0 {
+		return headers
+	}
+	out := slices.Clone(headers)
+	if m.prefs.SortDesc {
+		out[i] += " ▼"
+	} else {
+		out[i] += " ▲"
+	}
+	return out
+}
+
+// sortItems orders items by the saved sort column: numbers by value,
+// everything else by its text. Items without the attribute go last.
+func (m *Model) sortItems(items []map[string]types.AttributeValue) {
+	by := m.prefs.SortBy
+	if by == "" {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, aok := items[i][by]
+		b, bok := items[j][by]
+		if !aok || !bok {
+			return aok && !bok
+		}
+		c := compareValues(a, b)
+		if m.prefs.SortDesc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func compareValues(a, b types.AttributeValue) int {
+	an, aok := a.(*types.AttributeValueMemberN)
+	bn, bok := b.(*types.AttributeValueMemberN)
+	if aok && bok {
+		x, errX := strconv.ParseFloat(an.Value, 64)
+		y, errY := strconv.ParseFloat(bn.Value, 64)
+		if errX == nil && errY == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	x, y := models.FormatValue(a, 0), models.FormatValue(b, 0)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// selectedColumn is the attribute under the cursor, "" without one
+func (m *Model) selectedColumn() string {
+	if m.dataTable.SelectedCol < len(m.columns) {
+		return m.columns[m.dataTable.SelectedCol]
+	}
+	return ""
+}
+
+// selectedValue is the selected cell's attribute; ok is false when the item
+// lacks it
+func (m *Model) selectedValue() (types.AttributeValue, bool) {
+	col := m.selectedColumn()
+	if col == "" || m.dataTable.SelectedRow >= len(m.items) {
+		return nil, false
+	}
+	v, ok := m.items[m.dataTable.SelectedRow][col]
+	return v, ok
+}
+
+// cycleSort sorts by the selected column, ascending then descending then
+// not at all
+func (m *Model) cycleSort() {
+	col := m.selectedColumn()
+	if col == "" {
+		return
+	}
+	switch {
+	case m.prefs.SortBy != col:
+		m.prefs.SortBy, m.prefs.SortDesc = col, false
+		m.statusMsg = "Sorted by " + col + " ascending"
+	case !m.prefs.SortDesc:
+		m.prefs.SortDesc = true
+		m.statusMsg = "Sorted by " + col + " descending"
+	default:
+		m.prefs.SortBy, m.prefs.SortDesc = "", false
+		m.statusMsg = "Sort cleared; new pages load in table order"
+	}
+	m.saveTablePrefs()
+	m.redrawItems()
+}
+
+// hideColumn hides the selected column until showAllColumns
+func (m *Model) hideColumn() {
+	col := m.selectedColumn()
+	if col == "" || len(m.columns) == 1 {
+		return
+	}
+	m.prefs.Hidden = append(m.prefs.Hidden, col)
+	m.prefs.Order = slices.DeleteFunc(m.prefs.Order, func(h string) bool { return h == col })
+	m.saveTablePrefs()
+	m.redrawItems()
+	m.statusMsg = fmt.Sprintf("Hid %s (%d hidden, C shows them)", col, len(m.prefs.Hidden))
+}
+
+func (m *Model) showAllColumns() {
+	if len(m.prefs.Hidden) == 0 {
+		return
+	}
+	n := len(m.prefs.Hidden)
+	m.prefs.Hidden = nil
+	m.saveTablePrefs()
+	m.redrawItems()
+	m.statusMsg = fmt.Sprintf("Showing %d hidden columns", n)
+}
+
+// moveColumn swaps the selected column with its neighbour and follows it
+func (m *Model) moveColumn(delta int) {
+	i := m.dataTable.SelectedCol
+	j := i + delta
+	if i >= len(m.columns) || j < 0 || j >= len(m.columns) {
+		return
+	}
+	order := slices.Clone(m.columns)
+	order[i], order[j] = order[j], order[i]
+	m.prefs.Order = order
+	m.saveTablePrefs()
+	m.redrawItems()
+	m.dataTable.SelectedCol = j
+	if j < m.dataTable.HorizontalOff {
+		m.dataTable.HorizontalOff = j
+	}
+}
+
+// resizeColumn widens or narrows the selected column
+func (m *Model) resizeColumn(delta int) {
+	col := m.selectedColumn()
+	if col == "" {
+		return
+	}
+	w := min(max(m.dataTable.ColWidths[m.dataTable.SelectedCol]+delta, minColWidth), maxColWidth)
+	m.dataTable.ColWidths[m.dataTable.SelectedCol] = w
+	if m.prefs.Widths == nil {
+		m.prefs.Widths = make(map[string]int)
+	}
+	m.prefs.Widths[col] = w
+	m.prefs.WidthMode = widthFixed
+	m.saveTablePrefs()
+	m.statusMsg = fmt.Sprintf("%s is %d wide", col, w)
+}
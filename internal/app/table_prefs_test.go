@@ -0,0 +1,153 @@
+package app
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// TestMain keeps tests that change a table's preferences, such as its page
+// size, from writing to the real config directory
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "godynamo-test")
+	if err != nil {
+		panic(err)
+	}
+	userConfigDir = func() (string, error) { return dir, nil }
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func prefsModel(t *testing.T) Model {
+	t.Helper()
+	stubConfigDir(t)
+	m := populatedModel()
+	m.view = viewTableData
+	m.tableInfo = &dynamo.TableInfo{Name: "Users", PartitionKey: "id"}
+	m.items = []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "a"}, "age": &types.AttributeValueMemberN{Value: "9"}, "name": &types.AttributeValueMemberS{Value: "Zoe"}},
+		{"id": &types.AttributeValueMemberS{Value: "b"}, "age": &types.AttributeValueMemberN{Value: "10"}, "name": &types.AttributeValueMemberS{Value: "Al"}},
+		{"id": &types.AttributeValueMemberS{Value: "c"}, "name": &types.AttributeValueMemberS{Value: "Cy"}},
+	}
+	m.showItems(m.items)
+	return m
+}
+
+func TestSortCyclesAndPutsMissingLast(t *testing.T) {
+	m := prefsModel(t)
+	m.dataTable.SelectedCol = 1 // age
+	m = drive(m, keyRunes("o"))
+	if got := m.dataTable.Headers[1]; got != "age ▲" {
+		t.Fatalf("header = %q, want the sort marked", got)
+	}
+	ids := func() string {
+		var s []string
+		for _, item := range m.items {
+			s = append(s, item["id"].(*types.AttributeValueMemberS).Value)
+		}
+		return strings.Join(s, "")
+	}
+	if got := ids(); got != "abc" {
+		t.Errorf("ascending by age = %s, want numbers compared as numbers", got)
+	}
+	m = drive(m, keyRunes("o"))
+	if got := ids(); got != "bac" {
+		t.Errorf("descending by age = %s", got)
+	}
+	if m.dataTable.Rows[0][0] != "b" {
+		t.Errorf("rows must follow the sorted items, first row %v", m.dataTable.Rows[0])
+	}
+	m = drive(m, keyRunes("o"))
+	if m.prefs.SortBy != "" || m.dataTable.Headers[1] != "age" {
+		t.Errorf("third press should clear the sort, prefs %+v", m.prefs)
+	}
+}
+
+func TestColumnLayoutIsRestoredWhenTheTableIsReopened(t *testing.T) {
+	m := prefsModel(t)
+	m.dataTable.SelectedCol = 2 // name
+	m = drive(m, keyRunes("<"))
+	if got := strings.Join(m.columns, ","); got != "id,name,age" {
+		t.Fatalf("columns = %s after moving name left", got)
+	}
+	if m.dataTable.SelectedCol != 1 {
+		t.Errorf("cursor should follow the moved column, at %d", m.dataTable.SelectedCol)
+	}
+	m = drive(m, keyRunes("."))
+	width := m.dataTable.ColWidths[1]
+	m.dataTable.SelectedCol = 2
+	m = drive(m, keyRunes("c"))
+	m = drive(m, keyRunes("+"))
+
+	reopened := populatedModel()
+	reopened.tableInfo = m.tableInfo
+	reopened.items = m.items
+	reopened.loadTablePrefs()
+	reopened.showItems(reopened.items)
+	if got := strings.Join(reopened.columns, ","); got != "id,name" {
+		t.Errorf("reopened columns = %s, want age hidden and name moved", got)
+	}
+	if reopened.dataTable.ColWidths[1] != width || reopened.pageSize != 600 {
+		t.Errorf("width %d (want %d), page size %d", reopened.dataTable.ColWidths[1], width, reopened.pageSize)
+	}
+	if row := reopened.dataTable.Rows[0]; len(row) != 2 || row[1] != "Zoe" {
+		t.Errorf("row = %v, want cells following the columns", row)
+	}
+
+	reopened.view = viewTableData
+	reopened = drive(reopened, keyRunes("C"))
+	if len(reopened.columns) != 3 {
+		t.Errorf("C should show hidden columns again, got %v", reopened.columns)
+	}
+}
+
+func TestWidthModesCycle(t *testing.T) {
+	m := prefsModel(t)
+	auto := slices.Clone(m.dataTable.ColWidths)
+
+	m = drive(m, keyRunes("W"))
+	w := slices.Clone(m.dataTable.ColWidths)
+	if m.prefs.WidthMode != widthEqual || w[0] != w[1] || w[1] != w[2] || w[0] <= auto[0] {
+		t.Fatalf("equal mode widths = %v (content %v)", w, auto)
+	}
+	m = drive(m, tea.WindowSizeMsg{Width: 200, Height: 40})
+	if m.dataTable.ColWidths[0] <= w[0] {
+		t.Error("equal widths should follow the terminal's width")
+	}
+
+	m = drive(m, keyRunes("W"))
+	if m.prefs.WidthMode != widthFixed || !slices.Equal(m.dataTable.ColWidths, auto) {
+		t.Errorf("fixed mode without set widths = %v, want the content widths", m.dataTable.ColWidths)
+	}
+	m = drive(m, keyRunes("W"))
+	if m.prefs.WidthMode != widthAuto {
+		t.Errorf("mode = %q, want back to auto", m.prefs.WidthMode)
+	}
+
+	m = drive(m, keyRunes("."))
+	if m.prefs.widthMode() != widthFixed {
+		t.Error("setting a width should switch to fixed widths")
+	}
+	reopened := populatedModel()
+	reopened.loadTablePrefs()
+	if reopened.prefs.WidthMode != widthFixed {
+		t.Errorf("reopened mode = %q", reopened.prefs.WidthMode)
+	}
+}
+
+func TestTablePrefsAreKeptPerTable(t *testing.T) {
+	m := prefsModel(t)
+	m = drive(m, keyRunes("+"))
+	m.currentTable = "Orders"
+	m.loadTablePrefs()
+	if m.pageSize != m.config.PageSize {
+		t.Errorf("another table should start from the configured page size, got %d", m.pageSize)
+	}
+}
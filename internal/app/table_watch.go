@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// tableWatchInterval is how often a table that is still changing is
+// described again; a variable so tests need not wait.
+var tableWatchInterval = 5 * time.Second
+
+type (
+	tableWatchMsg  struct{ table string }
+	tableStatusMsg struct{ info *dynamo.TableInfo }
+)
+
+// watchTable schedules another look at info's table while it or one of its
+// indexes is still being created, updated or backfilled.
+func watchTable(info *dynamo.TableInfo) tea.Cmd {
+	if info == nil || info.Transition() == "" {
+		return nil
+	}
+	name := info.Name
+	return tea.Tick(tableWatchInterval, func(time.Time) tea.Msg { return tableWatchMsg{name} })
+}
+
+// refreshTableStatus describes the table again unless the user has moved on
+func (m *Model) refreshTableStatus(table string) tea.Cmd {
+	if table != m.currentTable || m.client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		info, err := m.client.RefreshTable(context.Background(), table)
+		if err != nil {
+			return errMsg{err}
+		}
+		return tableStatusMsg{info}
+	}
+}
+
+func (m *Model) handleTableStatus(info *dynamo.TableInfo) tea.Cmd {
+	if info.Name != m.currentTable {
+		return nil
+	}
+	was := ""
+	if m.tableInfo != nil {
+		was = m.tableInfo.Transition()
+	}
+	m.tableInfo = info
+	if m.view == viewSchema && !m.showPolicy {
+		m.prepareSchemaView()
+	}
+	if was != "" && info.Transition() == "" {
+		m.statusMsg = "✓ " + info.Name + " is ACTIVE"
+	}
+	return watchTable(info)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestTableWatchFollowsStatusUntilActive(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	updating := *m.tableInfo
+	updating.Status = "UPDATING"
+	updating.GSIs = []dynamo.IndexInfo{{Name: "byEmail", Status: "CREATING", Backfilling: true}}
+
+	if _, cmd := m.Update(tableInfoMsg{&updating}); cmd == nil {
+		t.Fatal("a table that is UPDATING should be watched")
+	}
+	m = drive(m, tableInfoMsg{&updating})
+	if !strings.Contains(m.View(), "⏳ UPDATING, byEmail backfilling") {
+		t.Fatal("status bar should say what is still changing")
+	}
+
+	active := updating
+	active.Status = "ACTIVE"
+	active.GSIs = []dynamo.IndexInfo{{Name: "byEmail", Status: "ACTIVE"}}
+	if _, cmd := m.Update(tableStatusMsg{&active}); cmd != nil {
+		t.Fatal("an ACTIVE table needs no more watching")
+	}
+	m = drive(m, tableStatusMsg{&active})
+	if m.statusMsg != "✓ Users is ACTIVE" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	// Once the user leaves the table a pending tick does nothing
+	m.currentTable = "Orders"
+	if cmd := m.refreshTableStatus("Users"); cmd != nil {
+		t.Fatal("watch should stop for a table that is no longer open")
+	}
+}
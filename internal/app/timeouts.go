@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Timeouts bound single requests so a hung connection ends in an error
+// instead of "Loading..." forever. Zero means no deadline.
+type Timeouts struct {
+	ListTables time.Duration `yaml:"list_tables"`
+	ScanPage   time.Duration `yaml:"scan_page"`
+	Query      time.Duration `yaml:"query"`
+}
+
+func defaultTimeouts() Timeouts {
+	return Timeouts{
+		ListTables: 30 * time.Second,
+		ScanPage:   30 * time.Second,
+		Query:      30 * time.Second,
+	}
+}
+
+func (t Timeouts) validate() error {
+	for name, d := range map[string]time.Duration{
+		"list_tables": t.ListTables,
+		"scan_page":   t.ScanPage,
+		"query":       t.Query,
+	} {
+		if d < 0 {
+			return fmt.Errorf("timeouts.%s must not be negative, got %s", name, d)
+		}
+	}
+	return nil
+}
+
+// requestContext bounds one request by d
+func requestContext(d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), d)
+}
+
+// timedOut names the setting to raise when err came from ctx's deadline
+func timedOut(ctx context.Context, err error, op, setting string, d time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s timed out after %s (timeouts.%s in config.yaml)", op, d, setting)
+	}
+	return err
+}
@@ -1,9 +1,13 @@
 package app
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
 )
 
 // keyRunes builds a rune key message (e.g. "f", "+") for driving Update.
@@ -79,3 +83,80 @@ func TestUpdateTableDataPageSizeAdjust(t *testing.T) {
 		t.Fatalf("'-' should decrease page size back to %d, got %d", orig, m.pageSize)
 	}
 }
+
+func TestUpdateItemDetailCompactToggle(t *testing.T) {
+	m := populatedModel()
+	m.view = viewItemDetail
+	m.prepareItemView()
+	m = drive(m, keyRunes("c"))
+	if !m.compactJSON || !m.jsonViewer.Compact {
+		t.Fatal("c should switch the item view to compact JSON")
+	}
+	// The mode sticks when the next item is opened.
+	m.prepareItemView()
+	if !m.jsonViewer.Compact {
+		t.Fatal("compact mode lost on prepareItemView")
+	}
+	m = drive(m, keyRunes("c"))
+	if m.compactJSON {
+		t.Fatal("second c should restore pretty JSON")
+	}
+}
+
+func TestUpdateItemDetailCompactScrollsToTheEnd(t *testing.T) {
+	m := populatedModel()
+	m.layout()
+	attrs := map[string]interface{}{}
+	for i := 0; i < 200; i++ {
+		attrs[fmt.Sprintf("attr%03d", i)] = fmt.Sprintf("value %d", i)
+	}
+	m.view = viewItemDetail
+	m.jsonViewer = ui.NewJSONViewer(attrs)
+	m.updateItemViewContent()
+	m = drive(m, keyRunes("c"))
+	if strings.Contains(m.View(), "attr199") {
+		t.Fatal("the end of the item shows before scrolling")
+	}
+	for i := 0; i < 50; i++ {
+		m = drive(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	}
+	if out := m.View(); !strings.Contains(out, `"attr199"`) {
+		t.Fatalf("compact item not scrolled to its end:\n%s", out)
+	}
+}
+
+func TestUpdateItemDetailYAMLToggle(t *testing.T) {
+	m := populatedModel()
+	m.view = viewItemDetail
+	m.prepareItemView()
+	m = drive(m, keyRunes("v"))
+	if !m.yamlView || !m.jsonViewer.YAML {
+		t.Fatal("v should switch the item view to YAML")
+	}
+	if out := m.jsonViewer.Render(); !strings.Contains(out, "name: alice") {
+		t.Fatalf("YAML content not rendered:\n%s", out)
+	}
+}
+
+func TestTableFilterDebouncesLongLists(t *testing.T) {
+	tables := make([]string, tableFilterDebounceAt)
+	for i := range tables {
+		tables[i] = fmt.Sprintf("table_%04d", i)
+	}
+	tables[42] = "orders"
+	m := drive(New(), tablesLoadedMsg{tables: tables})
+
+	m = drive(m, keyRunes("o"))
+	m = drive(m, keyRunes("r"))
+	if m.tableFilter != "or" || m.filteredFor != "o" {
+		t.Fatalf("filter applied before the debounce fired: %q filtered for %q", m.tableFilter, m.filteredFor)
+	}
+	m = drive(m, tableFilterMsg{seq: m.tableFilterSeq - 1})
+	if m.filteredFor == "or" {
+		t.Fatal("a superseded debounce tick applied the filter")
+	}
+	m = drive(m, tableFilterMsg{seq: m.tableFilterSeq})
+	if len(m.filteredTables) != 1 || m.filteredTables[0] != "orders" {
+		t.Fatalf("filtered = %v", m.filteredTables)
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/godynamo/internal/dynamo"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 // drive feeds one message through Update and returns the updated Model.
@@ -129,3 +129,37 @@ var errTest = testError("test error")
 type testError string
 
 func (e testError) Error() string { return string(e) }
+
+func TestRegionDiscoveryStreamsRegions(t *testing.T) {
+	ch := make(chan dynamo.RegionInfo)
+	m := drive(New(), regionScanStartedMsg{ch: ch})
+
+	m = drive(m, regionFoundMsg{ch: ch, region: dynamo.RegionInfo{Region: "us-east-1", TableCount: 2}})
+	if m.selectedRegion != "us-east-1" || len(m.discoveredRegions) != 1 {
+		t.Fatalf("first region not selected: %q %v", m.selectedRegion, m.discoveredRegions)
+	}
+	m = drive(m, regionFoundMsg{ch: ch, region: dynamo.RegionInfo{Region: "eu-west-1", TableCount: 1}})
+	if m.selectedRegion != "us-east-1" || len(m.discoveredRegions) != 2 {
+		t.Fatalf("later region should only join the list: %q %v", m.selectedRegion, m.discoveredRegions)
+	}
+
+	stale := make(chan dynamo.RegionInfo)
+	m = drive(m, regionFoundMsg{ch: stale, region: dynamo.RegionInfo{Region: "sa-east-1"}})
+	if len(m.discoveredRegions) != 2 {
+		t.Fatal("region from a restarted discovery was not ignored")
+	}
+
+	m = drive(m, regionsDiscoveredMsg{ch: ch})
+	if m.regionCh != nil || m.err != nil {
+		t.Fatalf("discovery not finished cleanly: ch=%v err=%v", m.regionCh, m.err)
+	}
+}
+
+func TestRegionDiscoveryWithNoTables(t *testing.T) {
+	ch := make(chan dynamo.RegionInfo)
+	m := drive(New(), regionScanStartedMsg{ch: ch})
+	m = drive(m, regionsDiscoveredMsg{ch: ch})
+	if m.err == nil || m.loading {
+		t.Fatalf("expected an error when no region has tables, got err=%v loading=%v", m.err, m.loading)
+	}
+}
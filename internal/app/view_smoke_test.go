@@ -4,8 +4,8 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/godynamo/internal/dynamo"
 	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 // populatedModel builds a Model with enough in-memory state to exercise the
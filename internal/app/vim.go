@@ -0,0 +1,256 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+)
+
+// vimCommands maps : commands, per view, to the built-in key doing the same
+// there, so every command is an existing action
+var vimCommands = map[viewMode]map[string]string{
+	viewTables: {
+		"q": "esc", "new": "ctrl+n", "create": "ctrl+n", "import": "ctrl+o", "partiql": "ctrl+e",
+		"refresh": "ctrl+r", "profile": "ctrl+p", "region": "tab", "search": "/",
+	},
+	viewTableData: {
+		"q": "esc", "new": "n", "edit": "e", "delete": "d", "deletekey": "X", "yank": "y", "rename": "M",
+		"generate": "ctrl+g", "copy": "ctrl+t", "failed": "F", "filter": "f", "search": "/",
+		"jq": "J", "sort": "o", "sample": "S", "partitions": "P", "resume": "R", "segment": "g", "stream": "t", "watch": "ctrl+w", "snapshots": "D",
+		"export": "x", "schema": "s", "arn": "A", "console": "O", "partiql": "ctrl+e",
+		"refresh": "r", "reload": "ctrl+r",
+	},
+	viewItemDetail: {
+		"q": "esc", "edit": "e", "delete": "d", "history": "h", "export": "x", "yank": "y",
+		"compact": "c", "yaml": "v", "search": "/", "arn": "A", "console": "O", "open": "o",
+	},
+}
+
+// updateVim adds vim motions to the list views: gg and G jump to the first
+// and last row, ctrl+d and ctrl+u move half a screen, : opens the command
+// line, and q and @ record and replay macros. Every other key does what it
+// does without vim.
+func (m *Model) updateVim(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if _, ok := vimCommands[m.view]; ok && m.acceptsKeymap() {
+		pending := m.vimPending
+		m.vimPending = ""
+		if ok, model, cmd := m.updateMacroKey(msg.String(), pending); ok {
+			return model, cmd
+		}
+		switch key := msg.String(); {
+		case key == "g" && pending == "g":
+			m.jumpTo(true)
+			return m, nil
+		case key == "g":
+			m.vimPending = "g"
+			return m, nil
+		case key == "G":
+			m.jumpTo(false)
+			return m, nil
+		case key == "ctrl+d":
+			m.moveHalfPage(1)
+			return m, nil
+		case key == "ctrl+u":
+			m.moveHalfPage(-1)
+			return m, nil
+		case key == ":":
+			return m, m.openVimCommandLine()
+		}
+	}
+	prev := m.view
+	model, cmd := m.updateKey(msg)
+	m.vimEditorOpened(prev)
+	return model, cmd
+}
+
+// vimEditorOpened starts the item editor in normal mode, as vim does
+func (m *Model) vimEditorOpened(prev viewMode) {
+	editing := m.view == viewCreateItem || m.view == viewEditItem
+	if editing && prev != m.view {
+		m.visualMode, m.visualSelectMode = true, false
+		m.statusMsg = "-- NORMAL --"
+	}
+}
+
+func (m *Model) openVimCommandLine() tea.Cmd {
+	in := textinput.New()
+	in.Prompt = ":"
+	in.CharLimit = 64
+	m.vimCmd = in
+	m.vimCmdMode = true
+	m.vimPending = ""
+	return m.vimCmd.Focus()
+}
+
+func (m *Model) updateVimCommandLine(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.vimCmdMode = false
+		return nil
+	case "enter":
+		m.vimCmdMode = false
+		return m.runVimCommand(strings.TrimSpace(m.vimCmd.Value()))
+	case "backspace":
+		if m.vimCmd.Value() == "" {
+			m.vimCmdMode = false
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	m.vimCmd, cmd = m.vimCmd.Update(msg)
+	return cmd
+}
+
+// runVimCommand runs a : command: :qa quits, :w and :q save and leave the
+// item editor, :N goes to row N, and the rest press the key vimCommands
+// names, refused the same way the key would be
+func (m *Model) runVimCommand(name string) tea.Cmd {
+	switch name {
+	case "":
+		return nil
+	case "qa", "qa!", "quit":
+		m.resetSpill()
+		return tea.Quit
+	}
+	if m.view == viewCreateItem || m.view == viewEditItem {
+		switch name {
+		case "w", "wq", "x":
+			return m.submitItemEditor()
+		case "q", "q!":
+			m.visualMode, m.visualSelectMode = false, false
+			m.view = viewTableData
+			return nil
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil && m.view == viewTableData {
+		m.dataTable.GoToRow(min(max(n-1, 0), len(m.dataTable.Rows)-1))
+		return nil
+	}
+	key, ok := keyMsgFor(vimCommands[m.view][name])
+	if !ok {
+		m.statusMsg = "✗ Not a command here: " + name
+		return nil
+	}
+	if m.refusesWriteKey(key) || m.refusesOnlineKey(key) {
+		return nil
+	}
+	prev := m.view
+	_, cmd := m.updateKey(key)
+	m.vimEditorOpened(prev)
+	return cmd
+}
+
+// withCommandLine shows the : command line in place of a view's last line
+func withCommandLine(view, line string) string {
+	lines := strings.Split(view, "\n")
+	lines[len(lines)-1] = line
+	return strings.Join(lines, "\n")
+}
+
+// updateEditorVim is modal editing in the item editor. Normal mode is the
+// editor's navigation mode plus vim's motions and edits; i, a, o and their
+// capitals insert, and Esc returns to normal mode. It reports whether it
+// handled msg; h, j, k, l, v, y and p are left to the navigation mode.
+func (m *Model) updateEditorVim(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.vimCmdMode {
+		return true, m.updateVimCommandLine(msg)
+	}
+	key := msg.String()
+	if !m.visualMode {
+		if key == "esc" {
+			m.visualMode = true
+			m.statusMsg = "-- NORMAL --"
+			return true, nil
+		}
+		return false, nil
+	}
+
+	press := func(keys ...tea.KeyMsg) tea.Cmd {
+		var cmds []tea.Cmd
+		for _, k := range keys {
+			var cmd tea.Cmd
+			m.itemEditor, cmd = m.itemEditor.Update(k)
+			cmds = append(cmds, cmd)
+		}
+		return tea.Batch(cmds...)
+	}
+	move := func(keys ...tea.KeyMsg) (bool, tea.Cmd) {
+		cmd := press(keys...)
+		m.syncSelection()
+		return true, cmd
+	}
+	insert := func(keys ...tea.KeyMsg) (bool, tea.Cmd) {
+		cmd := press(keys...)
+		m.visualMode, m.visualSelectMode = false, false
+		m.itemEditor.ClearSelection()
+		m.statusMsg = "-- INSERT --"
+		return true, cmd
+	}
+	k := func(t tea.KeyType) tea.KeyMsg { return tea.KeyMsg{Type: t} }
+
+	pending := m.vimPending
+	m.vimPending = ""
+	switch {
+	case key == "esc" && !m.visualSelectMode:
+		return true, nil
+	case key == ":":
+		return true, m.openVimCommandLine()
+	case key == "i":
+		return insert()
+	case key == "a":
+		return insert(k(tea.KeyRight))
+	case key == "A":
+		return insert(k(tea.KeyEnd))
+	case key == "I":
+		return insert(k(tea.KeyHome))
+	case key == "o":
+		return insert(k(tea.KeyEnd), k(tea.KeyEnter))
+	case key == "O":
+		return insert(k(tea.KeyHome), k(tea.KeyEnter), k(tea.KeyUp))
+	case key == "x" && !m.visualSelectMode:
+		return true, press(k(tea.KeyDelete))
+	case key == "d" && pending == "d":
+		return true, press(k(tea.KeyHome), k(tea.KeyCtrlK), k(tea.KeyDelete))
+	case key == "d" && !m.visualSelectMode:
+		m.vimPending = "d"
+		return true, nil
+	case key == "g" && pending == "g":
+		return move(k(tea.KeyCtrlHome), k(tea.KeyHome))
+	case key == "g":
+		m.vimPending = "g"
+		return true, nil
+	case key == "G":
+		return move(k(tea.KeyCtrlEnd))
+	case key == "0":
+		return move(k(tea.KeyHome))
+	case key == "$":
+		return move(k(tea.KeyEnd))
+	case key == "w":
+		return move(tea.KeyMsg{Type: tea.KeyRight, Alt: true})
+	case key == "b":
+		return move(tea.KeyMsg{Type: tea.KeyLeft, Alt: true})
+	}
+	return false, nil
+}
+
+func (m Model) editorVimHelp() string {
+	if !m.visualMode {
+		return ui.RenderHelp([]ui.KeyBinding{
+			{Key: "Esc", Desc: "Normal mode"},
+			{Key: "Ctrl+S", Desc: "Save"},
+		})
+	}
+	return ui.RenderHelp([]ui.KeyBinding{
+		{Key: "h/j/k/l/w/b", Desc: "Move"},
+		{Key: "i/a/o", Desc: "Insert"},
+		{Key: "x/dd", Desc: "Delete"},
+		{Key: "v", Desc: "Select"},
+		{Key: "y/p", Desc: "Copy/Paste"},
+		{Key: ":w", Desc: "Save"},
+		{Key: ":q", Desc: "Cancel"},
+	})
+}
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/dynamo/dynamotest"
+)
+
+func vimModel() Model {
+	m := populatedModel()
+	m.config.Keybindings = keybindingsVim
+	m.view = viewTableData
+	return m
+}
+
+func typeVimCommand(m Model, cmd string) Model {
+	m = drive(m, keyRunes(":"))
+	for _, r := range cmd {
+		m = drive(m, keyRunes(string(r)))
+	}
+	return drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestVimMotionsInTableData(t *testing.T) {
+	m := vimModel()
+	m = drive(m, keyRunes("G"))
+	if m.dataTable.SelectedRow != 1 {
+		t.Fatalf("G: row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, keyRunes("g"))
+	m = drive(m, keyRunes("g"))
+	if m.dataTable.SelectedRow != 0 || m.view != viewTableData {
+		t.Fatalf("gg: row %d, view %d (a lone g would open segments)", m.dataTable.SelectedRow, m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlD})
+	if m.dataTable.SelectedRow != 1 {
+		t.Fatalf("ctrl+d: row %d", m.dataTable.SelectedRow)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	if m.dataTable.SelectedRow != 0 {
+		t.Fatalf("ctrl+u: row %d", m.dataTable.SelectedRow)
+	}
+}
+
+func TestVimCommandLine(t *testing.T) {
+	m := vimModel()
+	m = drive(m, keyRunes(":"))
+	if !m.vimCmdMode || !strings.Contains(m.View(), ":") {
+		t.Fatal(": should open the command line")
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.vimCmdMode || m.view != viewTableData {
+		t.Fatal("Esc should only close the command line")
+	}
+
+	m = typeVimCommand(m, "2")
+	if m.dataTable.SelectedRow != 1 {
+		t.Errorf(":2 went to row %d", m.dataTable.SelectedRow)
+	}
+	m = typeVimCommand(m, "schema")
+	if m.view != viewSchema {
+		t.Errorf(":schema opened view %d", m.view)
+	}
+
+	m = vimModel()
+	m = typeVimCommand(m, "bogus")
+	if !strings.Contains(m.statusMsg, "Not a command") {
+		t.Errorf("status %q", m.statusMsg)
+	}
+
+	m.config.ReadOnly = true
+	m = typeVimCommand(m, "delete")
+	if m.view != viewTableData || !strings.Contains(m.statusMsg, "Read-only") {
+		t.Errorf("read-only :delete: view %d, status %q", m.view, m.statusMsg)
+	}
+}
+
+func TestVimModalItemEditor(t *testing.T) {
+	m := vimModel()
+	m = drive(m, keyRunes("e"))
+	if m.view != viewEditItem || !m.visualMode {
+		t.Fatalf("the editor should open in normal mode: view %d, normal %v", m.view, m.visualMode)
+	}
+	before := m.itemEditor.Value()
+	for _, k := range []string{"g", "g", "j", "0", "x"} {
+		m = drive(m, keyRunes(k))
+	}
+	if m.itemEditor.Value() == before {
+		t.Error("x should delete a character")
+	}
+	m = drive(m, keyRunes("i"))
+	if m.visualMode {
+		t.Fatal("i should insert")
+	}
+	m = drive(m, keyRunes(" "))
+	if m.itemEditor.Value() != before {
+		t.Errorf("typing in insert mode: %q", m.itemEditor.Value())
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.visualMode || m.view != viewEditItem {
+		t.Fatal("Esc in insert mode should return to normal mode, not leave")
+	}
+	m = typeVimCommand(m, "q")
+	if m.view != viewTableData {
+		t.Errorf(":q left to view %d", m.view)
+	}
+}
+
+func TestVimOffKeepsKeys(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, keyRunes("g"))
+	if m.view == viewTableData {
+		t.Error("without vim, g should open scan segments")
+	}
+}
+
+func TestVimMacroRecordAndReplay(t *testing.T) {
+	m := vimModel()
+	m = drive(m, keyRunes("q"))
+	m = drive(m, keyRunes("a"))
+	if m.macroRecording != "a" || !strings.Contains(m.View(), "recording @a") {
+		t.Fatalf("qa should start recording, status %q", m.statusMsg)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.view != viewItemDetail {
+		t.Fatalf("keys still act while recording, view %d", m.view)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyEsc})
+	m = drive(m, keyRunes("j"))
+	m = drive(m, keyRunes("q"))
+	if m.macroRecording != "" || len(m.macros["a"]) != 3 || m.view != viewTableData {
+		t.Fatalf("q should stop recording: %d keys, view %d", len(m.macros["a"]), m.view)
+	}
+
+	m = drive(m, keyRunes("g"))
+	m = drive(m, keyRunes("g"))
+	m = drive(m, keyRunes("@"))
+	m = settle(m, keyRunes("a"))
+	if m.view != viewTableData || m.dataTable.SelectedRow != 1 || m.lastMacro != "a" {
+		t.Fatalf("@a: view %d, row %d", m.view, m.dataTable.SelectedRow)
+	}
+	if len(m.macros["a"]) != 3 {
+		t.Error("a replay shouldn't record its own keys")
+	}
+
+	m = drive(m, keyRunes("@"))
+	m = drive(m, keyRunes("b"))
+	if !strings.Contains(m.statusMsg, "Nothing recorded in @b") {
+		t.Errorf("@b: status %q", m.statusMsg)
+	}
+}
+
+func TestVimMacroStopsAtFirstFailure(t *testing.T) {
+	m := vimModel()
+	m.config.ReadOnly = true
+	m.macros = map[string][]tea.KeyMsg{"a": {keyRunes("d"), keyRunes("j")}}
+	m = drive(m, keyRunes("@"))
+	m = settle(m, keyRunes("a"))
+	if m.dataTable.SelectedRow != 0 || !strings.Contains(m.statusMsg, "@a stopped, 1 keys left") {
+		t.Errorf("row %d, status %q", m.dataTable.SelectedRow, m.statusMsg)
+	}
+}
+
+func TestVimMacroWaitsForSavesBetweenRows(t *testing.T) {
+	user := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+	fake := dynamotest.New()
+	fake.AddTable(dynamo.TableInfo{Name: "Users", PartitionKey: "id", PartitionType: "S"}, user("1"), user("2"), user("3"))
+	cfg := DefaultConfig()
+	cfg.Keybindings = keybindingsVim
+	m := openFakeTable(t, cfg, fake)
+
+	press := func(keys ...tea.Msg) {
+		for _, k := range keys {
+			m = settle(m, k)
+		}
+	}
+	enter, esc := tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyEsc}
+	// Open the row, add "seen" under the opening brace, save, next row
+	press(keyRunes("q"), keyRunes("a"), enter, keyRunes("e"), keyRunes("g"), keyRunes("g"), keyRunes("o"))
+	for _, r := range `"seen": true,` {
+		press(keyRunes(string(r)))
+	}
+	press(esc, keyRunes(":"), keyRunes("w"), enter, keyRunes("y"), keyRunes("j"), keyRunes("q"))
+	if m.view != viewTableData || m.dataTable.SelectedRow != 1 {
+		t.Fatalf("recording: view %d, row %d (status %q)", m.view, m.dataTable.SelectedRow, m.statusMsg)
+	}
+
+	press(keyRunes("@"), keyRunes("a"))
+	if m.view != viewTableData || m.dataTable.SelectedRow != 2 {
+		t.Fatalf("@a: view %d, row %d (status %q)", m.view, m.dataTable.SelectedRow, m.statusMsg)
+	}
+	press(keyRunes("@"), keyRunes("@"))
+	for _, item := range fake.Items("Users") {
+		if _, ok := item["seen"]; !ok {
+			t.Errorf("item %v wasn't edited", item["id"])
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/internal/ui"
+	"github.com/godynamo/pkg/models"
+)
+
+// defaultWatchInterval is the pause between runs of a watched read, unless
+// watch_interval says otherwise
+const defaultWatchInterval = 5 * time.Second
+
+// watchChange is how an item differs from the watch's previous run
+type watchChange int
+
+const (
+	watchNew watchChange = iota + 1
+	watchChanged
+)
+
+// watchTickMsg asks for the next run of the watch numbered seq
+type watchTickMsg struct{ seq int }
+
+// toggleWatch starts re-running the table view's read, filter and all, every
+// watchEvery, or stops it. The items on screen are the first run.
+func (m *Model) toggleWatch() tea.Cmd {
+	m.watchSeq++
+	if m.watching {
+		m.stopWatch()
+		m.statusMsg = "Watch stopped"
+		return nil
+	}
+	m.watching, m.watchTable = true, m.currentTable
+	m.watchSeen = m.watchFingerprints()
+	m.watchAwaiting = m.filterExpr != "" && len(m.items) == 0
+	m.statusMsg = fmt.Sprintf("👁 Watching %s every %s", m.currentTable, shortDuration(m.watchEvery))
+	if m.watchAwaiting {
+		m.statusMsg += "; nothing matches yet, you'll be alerted when something does"
+	}
+	return m.nextWatchTick()
+}
+
+func (m *Model) stopWatch() {
+	m.watching, m.watchSeen, m.watchMarks, m.watchAwaiting = false, nil, nil, false
+	m.dataTable.Marks = nil
+}
+
+func (m *Model) nextWatchTick() tea.Cmd {
+	seq := m.watchSeq
+	return tea.Tick(m.watchEvery, func(time.Time) tea.Msg { return watchTickMsg{seq} })
+}
+
+// handleWatchTick runs the read again. A run still going, or the user busy
+// in another view, skips a turn; leaving the table ends the watch.
+func (m *Model) handleWatchTick(msg watchTickMsg) tea.Cmd {
+	if !m.watching || msg.seq != m.watchSeq {
+		return nil
+	}
+	if m.currentTable != m.watchTable {
+		m.stopWatch()
+		return nil
+	}
+	if m.loading || m.view != viewTableData || m.client == nil {
+		return m.nextWatchTick()
+	}
+	m.loading = true
+	m.lastKey = nil
+	return tea.Batch(m.scanTable(), m.nextWatchTick())
+}
+
+// watchFingerprints is the loaded items' JSON by key
+func (m *Model) watchFingerprints() map[string]string {
+	seen := make(map[string]string, len(m.items))
+	for _, item := range m.items {
+		if key := m.historyKey(item); key != "" {
+			text, _ := models.ItemToDynamoJSON(item, false)
+			seen[key] = text
+		}
+	}
+	return seen
+}
+
+// afterRead follows up a read of the table view: the reload after a save
+// keeps the cursor on its row, a watch marks what changed and a snapshot
+// diff waiting for a fresh run gets it. It returns the watch's alert, if
+// the read raised one.
+func (m *Model) afterRead() tea.Cmd {
+	if m.keepRow {
+		m.dataTable.GoToRow(min(m.keptRow, len(m.dataTable.Rows)-1))
+		m.keepRow = false
+	}
+	alert := m.markWatchChanges()
+	m.finishSnapshotDiff()
+	return alert
+}
+
+// markWatchChanges compares a run's items with the run before, marking
+// those that are new or changed since
+func (m *Model) markWatchChanges() tea.Cmd {
+	if !m.watching {
+		return nil
+	}
+	seen := m.watchFingerprints()
+	m.watchMarks = map[string]watchChange{}
+	var added, changed int
+	for key, text := range seen {
+		switch before, ok := m.watchSeen[key]; {
+		case !ok:
+			m.watchMarks[key] = watchNew
+			added++
+		case before != text:
+			m.watchMarks[key] = watchChanged
+			changed++
+		}
+	}
+	m.watchSeen = seen
+	m.dataTable.Marks = m.watchRowMarks(m.items)
+	m.statusMsg = fmt.Sprintf("👁 %s: %d items, %d new, %d changed", time.Now().Format("15:04:05"), len(m.items), added, changed)
+
+	// A filter that matched nothing alerts once when it first matches, and
+	// again after it goes back to matching nothing
+	var alert tea.Cmd
+	if m.watchAwaiting && len(m.items) > 0 {
+		alert = m.alertWatchMatch()
+	}
+	m.watchAwaiting = m.filterExpr != "" && len(m.items) == 0
+	return alert
+}
+
+// watchRowMarks marks the rows of items the last watch run found new (+)
+// or changed (~)
+func (m *Model) watchRowMarks(items []map[string]types.AttributeValue) map[int]ui.RowMark {
+	if len(m.watchMarks) == 0 {
+		return nil
+	}
+	marks := map[int]ui.RowMark{}
+	for i, item := range items {
+		switch m.watchMarks[m.historyKey(item)] {
+		case watchNew:
+			marks[i] = ui.RowMark{Sign: "+", Color: ui.ColorSuccess}
+		case watchChanged:
+			marks[i] = ui.RowMark{Sign: "~", Color: ui.ColorWarning}
+		}
+	}
+	return marks
+}
+
+// watchStatus is the status bar's note of a running watch
+func (m Model) watchStatus() string {
+	if !m.watching {
+		return ""
+	}
+	return ui.WarningStyle.Render(" | 👁 Watch " + shortDuration(m.watchEvery))
+}
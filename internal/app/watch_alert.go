@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Seams for tests: where the bell rings and how a desktop notification is
+// shown
+var (
+	bellOut       io.Writer = os.Stderr
+	notifyDesktop           = desktopNotification
+)
+
+// desktopNotification shows a notification through the desktop's own tool:
+// notify-send on Linux and the BSDs, osascript on macOS
+func desktopNotification(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("desktop notifications are not supported on windows")
+	default:
+		cmd = exec.Command("notify-send", "--app-name=godynamo", title, body)
+	}
+	return cmd.Run()
+}
+
+// alertWatchMatch tells a user waiting on a filtered watch that had no
+// matches that items match now: the terminal bell rings and the command it
+// returns shows a desktop notification, which may not be there to show it
+func (m *Model) alertWatchMatch() tea.Cmd {
+	title := "godynamo: " + m.currentTable
+	filter := m.filterBuilder.GetFilterSummary()
+	if filter == "" {
+		filter = m.filterExpr
+	}
+	body := fmt.Sprintf("%d item(s) now match %s", len(m.items), filter)
+	fmt.Fprint(bellOut, "\a")
+	m.statusMsg = "🔔 " + body
+	return func() tea.Msg {
+		_ = notifyDesktop(title, body)
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestWatchMarksNewAndChangedItems(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlW})
+	if !m.watching || !strings.Contains(m.statusBar(), "Watch") {
+		t.Fatalf("Ctrl+W should start watching (status %q)", m.statusMsg)
+	}
+
+	items := []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "1"}, "name": &types.AttributeValueMemberS{Value: "alice"}},
+		{"id": &types.AttributeValueMemberS{Value: "2"}, "name": &types.AttributeValueMemberS{Value: "robert"}},
+		{"id": &types.AttributeValueMemberS{Value: "3"}, "name": &types.AttributeValueMemberS{Value: "carol"}},
+	}
+	m = drive(m, scanResultMsg{&dynamo.ScanResult{Items: items, Count: 3}})
+	marks := m.dataTable.Marks
+	if len(marks) != 2 || marks[1].Sign != "~" || marks[2].Sign != "+" {
+		t.Fatalf("marks %+v, want bob changed and carol new", marks)
+	}
+	if !strings.Contains(m.statusMsg, "1 new, 1 changed") {
+		t.Errorf("status %q", m.statusMsg)
+	}
+
+	m = drive(m, scanResultMsg{&dynamo.ScanResult{Items: items, Count: 3}})
+	if len(m.dataTable.Marks) != 0 {
+		t.Errorf("an unchanged run should clear the marks, got %+v", m.dataTable.Marks)
+	}
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlW})
+	if m.watching {
+		t.Error("Ctrl+W again should stop watching")
+	}
+}
+
+func TestWatchTickSkipsWhileBusyAndEndsOffTable(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m.toggleWatch()
+
+	m.loading = true
+	if cmd := m.handleWatchTick(watchTickMsg{m.watchSeq}); cmd == nil || !m.watching {
+		t.Error("a run still going should only put off the next")
+	}
+	if cmd := m.handleWatchTick(watchTickMsg{m.watchSeq - 1}); cmd != nil {
+		t.Error("a tick of an earlier watch should be dropped")
+	}
+
+	m.currentTable = "Orders"
+	if cmd := m.handleWatchTick(watchTickMsg{m.watchSeq}); cmd != nil || m.watching {
+		t.Error("another table should end the watch")
+	}
+}
+
+func TestWatchAlertsWhenFilterFirstMatches(t *testing.T) {
+	var bell strings.Builder
+	notified := make(chan string, 2)
+	bellOut = &bell
+	notifyDesktop = func(title, body string) error { notified <- body; return nil }
+	t.Cleanup(func() { bellOut, notifyDesktop = os.Stderr, desktopNotification })
+
+	m := populatedModel()
+	m.view = viewTableData
+	m.filterExpr = "#f0 = :v0"
+	m.items = nil
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlW})
+	if !m.watchAwaiting || !strings.Contains(m.statusMsg, "alerted") {
+		t.Fatalf("a filter matching nothing should wait for a match (status %q)", m.statusMsg)
+	}
+
+	m = drive(m, scanResultMsg{&dynamo.ScanResult{}})
+	if bell.Len() != 0 {
+		t.Fatal("no match yet should not alert")
+	}
+
+	items := []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "3"}}}
+	next, cmd := m.Update(scanResultMsg{&dynamo.ScanResult{Items: items, Count: 1}})
+	m = *next.(*Model)
+	if bell.String() != "\a" || !strings.Contains(m.statusMsg, "1 item(s) now match") {
+		t.Fatalf("bell %q, status %q", bell.String(), m.statusMsg)
+	}
+	if len(notified) != 0 {
+		t.Fatal("the notification should wait for its command to run")
+	}
+	runCmd(cmd)
+	select {
+	case body := <-notified:
+		if !strings.Contains(body, "now match") {
+			t.Errorf("notification %q", body)
+		}
+	default:
+		t.Error("the alert's command showed no notification")
+	}
+
+	m = drive(m, scanResultMsg{&dynamo.ScanResult{Items: items, Count: 1}})
+	if bell.String() != "\a" {
+		t.Error("matches that carry on should alert only once")
+	}
+}
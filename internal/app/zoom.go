@@ -0,0 +1,59 @@
+package app
+
+// zoomViews are the views whose main component Ctrl+Z can zoom
+var zoomViews = map[viewMode]bool{
+	viewTableData:  true,
+	viewItemDetail: true,
+	viewCreateItem: true,
+	viewEditItem:   true,
+}
+
+// toggleZoom gives the data table, item viewer and editor the whole
+// terminal, or gives the rest of the layout back
+func (m *Model) toggleZoom() {
+	m.zoomed = !m.zoomed
+	m.layout()
+}
+
+// layout sizes the components for the window, less the header, status bar
+// and help around them unless zoomed
+func (m *Model) layout() {
+	w, h := m.width, m.height
+	m.tableList.Height = h - 10
+	if m.zoomed {
+		m.dataTable.SetSize(w, h)
+		m.itemViewport.Width, m.itemViewport.Height = w, h
+		m.itemEditor.SetWidth(w)
+		m.itemEditor.SetHeight(h)
+	} else {
+		m.dataTable.SetSize(w-35, h-10)
+		m.itemViewport.Width, m.itemViewport.Height = w-40, h-15
+		m.itemEditor.SetWidth(w - 20)
+		m.itemEditor.SetHeight(h - 12)
+	}
+	if m.prefs.widthMode() == widthEqual {
+		m.dataTable.EqualColWidths()
+	}
+	m.resizePartiQL()
+	if m.view == viewItemDetail {
+		m.updateItemViewContent() // compact JSON wraps at the new width
+	}
+}
+
+// viewZoomed is the zoomed component alone; ok is false for views that
+// have nothing to zoom, or nothing loaded to zoom yet
+func (m Model) viewZoomed() (string, bool) {
+	if !m.zoomed || !zoomViews[m.view] {
+		return "", false
+	}
+	switch m.view {
+	case viewTableData:
+		if m.loading || len(m.items) == 0 {
+			return "", false
+		}
+		return m.dataTable.View(), true
+	case viewItemDetail:
+		return m.itemViewportContent(), true
+	}
+	return m.itemEditor.View(), true
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestZoomGivesTheComponentTheWindow(t *testing.T) {
+	m := populatedModel()
+	m.view = viewTableData
+	m = drive(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlZ})
+	out := m.View()
+	if !m.zoomed || m.dataTable.Height != 40 || strings.Contains(out, "⚡ Users") || !strings.Contains(out, "alice") {
+		t.Fatalf("zoomed table, height %d:\n%s", m.dataTable.Height, out)
+	}
+
+	m = drive(m, keyRunes("e"))
+	if out := m.View(); strings.Contains(out, "Enter JSON for the item") || !strings.Contains(out, "alice") {
+		t.Errorf("the editor should open zoomed:\n%s", out)
+	}
+	m = drive(m, tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if m.zoomed || !strings.Contains(m.View(), "Enter JSON for the item") {
+		t.Error("a second Ctrl+Z should restore the layout")
+	}
+	if m.dataTable.Height != 30 {
+		t.Errorf("table height %d after restoring", m.dataTable.Height)
+	}
+}
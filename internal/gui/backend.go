@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/godynamo/internal/dynamo"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 // Backend is the set of DynamoDB operations the bridge needs (reads + writes).
@@ -12,12 +12,11 @@ import (
 type Backend interface {
 	ListTables(ctx context.Context) ([]string, error)
 	DescribeTable(ctx context.Context, name string) (*dynamo.TableInfo, error)
-	ScanTable(ctx context.Context, name string, limit int32,
-		startKey map[string]types.AttributeValue,
-		filterExpr string, names map[string]string, values map[string]interface{}) (*dynamo.ScanResult, error)
+	ScanTable(ctx context.Context, input dynamo.ScanInput, opts dynamo.ScanOptions) (*dynamo.ScanResult, error)
 	QueryTable(ctx context.Context, input dynamo.QueryInput) (*dynamo.QueryResult, error)
+	GetItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (map[string]types.AttributeValue, error)
 	PutItem(ctx context.Context, tableName string, item map[string]types.AttributeValue) error
-	DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) error
+	DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (bool, error)
 	CreateTable(ctx context.Context, input dynamo.CreateTableInput) error
 }
 
@@ -0,0 +1,128 @@
+package gui
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/godynamo/internal/app"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// defaultServeAddr is where Serve listens without --addr: loopback only
+const defaultServeAddr = "127.0.0.1:8787"
+
+// serveOptions are Serve's flags, with the connection settings read from
+// config.yaml and GODYNAMO_* environment variables in cfg
+type serveOptions struct {
+	addr  string
+	token string
+	cfg   app.Config
+}
+
+// parseServeArgs applies Serve's flags over cfg, which flags win over as in
+// the terminal UI
+func parseServeArgs(args []string, cfg app.Config) (serveOptions, error) {
+	opts := serveOptions{token: os.Getenv("GODYNAMO_API_TOKEN"), cfg: cfg}
+	fs := flag.NewFlagSet("godynamo serve", flag.ContinueOnError)
+	fs.StringVar(&opts.addr, "addr", defaultServeAddr, "address to listen on")
+	fs.StringVar(&opts.cfg.Profile, "profile", cfg.Profile, "AWS profile (default: the standard credential chain)")
+	fs.StringVar(&opts.cfg.Endpoint, "endpoint", cfg.Endpoint, "DynamoDB-compatible endpoint to connect to, e.g. DynamoDB Local")
+	local := fs.Bool("local", false, "connect to DynamoDB Local at "+app.LocalEndpoint)
+	fs.StringVar(&opts.token, "token", opts.token, "bearer token clients must send (default: GODYNAMO_API_TOKEN, else a random one)")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if *local {
+		endpointSet := false
+		fs.Visit(func(f *flag.Flag) { endpointSet = endpointSet || f.Name == "endpoint" })
+		if endpointSet {
+			return opts, fmt.Errorf("--local and --endpoint both say where to connect; give one")
+		}
+		opts.cfg.Endpoint = app.LocalEndpoint
+	}
+	return opts, opts.cfg.Validate()
+}
+
+// Serve runs the bridge's HTTP/JSON API without the desktop app, so editors
+// and scripts can list tables, scan, query and read or write items through
+// GoDynamo's connection handling. It connects as config.yaml and the
+// environment say, like the terminal UI, and blocks until interrupted.
+func Serve(args []string) error {
+	cfg, err := app.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.ApplyEnv(); err != nil {
+		return err
+	}
+	opts, err := parseServeArgs(args, cfg)
+	if err != nil {
+		return err
+	}
+	if err := opts.cfg.Network().Validate(); err != nil {
+		return err
+	}
+	if opts.token == "" {
+		if opts.token, err = newToken(); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.addr, err)
+	}
+	s := newServer(opts.token)
+	s.activeProfile = opts.cfg.Profile
+	s.readOnly = opts.cfg.ReadOnly
+	s.network = opts.cfg.Network()
+	if opts.cfg.Endpoint != "" {
+		s.useEndpoint(opts.cfg.Endpoint, opts.cfg.Region)
+	}
+	srv := &http.Server{Handler: s.handler()}
+
+	fmt.Printf("GoDynamo API listening on http://%s\n", ln.Addr())
+	if opts.cfg.Endpoint != "" {
+		fmt.Printf("Connected to %s\n", opts.cfg.Endpoint)
+	}
+	if s.readOnly {
+		fmt.Println("Read-only: changes to tables and items are refused")
+	}
+	fmt.Printf("Send every request with: Authorization: Bearer %s\n", opts.token)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// useEndpoint connects s to a DynamoDB-compatible endpoint instead of AWS;
+// there are no regions to discover behind it, only the one requests are
+// signed for
+func (s *server) useEndpoint(endpoint, region string) {
+	if region == "" {
+		region = dynamo.DefaultEndpointRegion
+	}
+	s.connectFn = func(profile, region string) (Backend, error) {
+		return dynamo.EndpointClient(context.Background(), dynamo.ConnectionConfig{Profile: profile, Region: region, Endpoint: endpoint, Network: s.network})
+	}
+	s.discoverFn = func(ctx context.Context, profile string) ([]string, error) {
+		return []string{region}, nil
+	}
+}
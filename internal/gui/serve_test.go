@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/godynamo/internal/app"
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func TestParseServeArgs(t *testing.T) {
+	t.Setenv("GODYNAMO_API_TOKEN", "from-env")
+	opts, err := parseServeArgs(nil, app.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.addr != defaultServeAddr || opts.token != "from-env" || opts.cfg.Profile != "" {
+		t.Errorf("defaults = %+v", opts)
+	}
+
+	opts, err = parseServeArgs([]string{"--addr", "127.0.0.1:9000", "--profile", "prod", "--token", "secret"}, app.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.addr != "127.0.0.1:9000" || opts.cfg.Profile != "prod" || opts.token != "secret" {
+		t.Errorf("flags = %+v", opts)
+	}
+}
+
+func TestParseServeArgsStartsFromConfig(t *testing.T) {
+	cfg := app.DefaultConfig()
+	cfg.Profile, cfg.Endpoint, cfg.ReadOnly = "dev", "http://localhost:4566", true
+	opts, err := parseServeArgs(nil, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.cfg.Profile != "dev" || opts.cfg.Endpoint != "http://localhost:4566" || !opts.cfg.ReadOnly {
+		t.Errorf("config lost: %+v", opts.cfg)
+	}
+
+	opts, err = parseServeArgs([]string{"--profile", "prod", "--local"}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.cfg.Profile != "prod" || opts.cfg.Endpoint != app.LocalEndpoint {
+		t.Errorf("flags should win over the config: %+v", opts.cfg)
+	}
+
+	if _, err := parseServeArgs([]string{"--local", "--endpoint", "http://x:1"}, cfg); err == nil {
+		t.Error("--local with --endpoint should be refused")
+	}
+}
+
+func TestReadOnlyServerRefusesWrites(t *testing.T) {
+	f := &fakeBackend{}
+	s := newTestServer(f)
+	s.readOnly = true
+	for _, req := range []struct{ method, target, body string }{
+		{http.MethodPost, "/tables/t/item?region=us-east-1", `{"json":"{\"id\":\"1\"}"}`},
+		{http.MethodDelete, "/tables/t/item?region=us-east-1", `{"json":"{\"id\":\"1\"}"}`},
+		{http.MethodPost, "/tables?region=us-east-1", `{"name":"NewT","pk":"id","pkType":"S"}`},
+	} {
+		rec := do(s, req.method, req.target, req.body)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "read-only") {
+			t.Errorf("%s %s = %d %s, want 403", req.method, req.target, rec.Code, rec.Body.String())
+		}
+	}
+	if f.putItem != nil || f.deleteKey != nil || f.createIn.TableName != "" {
+		t.Fatal("a read-only server reached the backend for a write")
+	}
+	if rec := do(s, http.MethodGet, "/tables?region=us-east-1", ""); rec.Code != http.StatusOK {
+		t.Fatalf("reads should still work, got %d", rec.Code)
+	}
+}
+
+func TestEndpointServerDiscoversItsRegion(t *testing.T) {
+	s := newServer("test-token")
+	s.useEndpoint("http://localhost:8000", "")
+	regions, err := s.discoverFn(context.Background(), "")
+	if err != nil || len(regions) != 1 || regions[0] != "us-east-1" {
+		t.Fatalf("regions = %v, %v", regions, err)
+	}
+	b, err := s.connectFn("", regions[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.(*dynamo.Client); !ok {
+		t.Fatalf("backend = %#v, want a client of the endpoint", b)
+	}
+}
@@ -11,9 +11,9 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/godynamo/internal/dynamo"
-	"github.com/godynamo/internal/models"
-	"github.com/godynamo/internal/query"
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+	"github.com/godynamo/pkg/query"
 )
 
 type errorResponse struct {
@@ -30,6 +30,8 @@ type server struct {
 	token         string
 	mu            sync.RWMutex
 	activeProfile string
+	readOnly      bool               // refuse the routes changing tables and items
+	network       dynamo.Network     // transport for proxies and private CAs
 	clients       map[string]Backend // key: region (for the active profile)
 	connectFn     func(profile, region string) (Backend, error)
 	discoverFn    func(ctx context.Context, profile string) ([]string, error)
@@ -41,22 +43,22 @@ func newServer(token string) *server {
 	s := &server{
 		token:      token,
 		clients:    map[string]Backend{},
-		connectFn:  defaultConnectFn,
-		discoverFn: defaultDiscoverFn,
 		profilesFn: dynamo.ListProfiles,
 	}
+	s.connectFn = s.regionClient
+	s.discoverFn = s.regionsWithTables
 	s.h = s.buildHandler()
 	return s
 }
 
-// defaultConnectFn builds a real *dynamo.Client for one profile+region.
-func defaultConnectFn(profile, region string) (Backend, error) {
-	return dynamo.NewClient(dynamo.ConnectionConfig{Region: region, Profile: profile})
+// regionClient builds a real *dynamo.Client for one profile+region.
+func (s *server) regionClient(profile, region string) (Backend, error) {
+	return dynamo.RegionClient(dynamo.ConnectionConfig{Profile: profile, Region: region, Network: s.network})
 }
 
-// defaultDiscoverFn returns the region names that have tables for the profile.
-func defaultDiscoverFn(ctx context.Context, profile string) ([]string, error) {
-	infos, err := dynamo.DiscoverRegionsWithTables(ctx, profile, false, "")
+// regionsWithTables returns the region names that have tables for the profile.
+func (s *server) regionsWithTables(ctx context.Context, profile string) ([]string, error) {
+	infos, err := dynamo.DiscoverRegionsWithTables(ctx, dynamo.ConnectionConfig{Profile: profile, Network: s.network})
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +75,14 @@ func (s *server) buildHandler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /profiles", s.handleProfiles)
 	mux.HandleFunc("POST /discover", s.handleDiscover)
+	mux.HandleFunc("GET /tables", s.handleListTables)
 	mux.HandleFunc("GET /tables/{name}/schema", s.handleSchema)
 	mux.HandleFunc("GET /tables/{name}/scan", s.handleScan)
 	mux.HandleFunc("POST /tables/{name}/query", s.handleQuery)
-	mux.HandleFunc("POST /tables/{name}/item", s.handlePutItem)
-	mux.HandleFunc("DELETE /tables/{name}/item", s.handleDeleteItem)
-	mux.HandleFunc("POST /tables", s.handleCreateTable)
+	mux.HandleFunc("GET /tables/{name}/item", s.handleGetItem)
+	mux.HandleFunc("POST /tables/{name}/item", s.writing(s.handlePutItem))
+	mux.HandleFunc("DELETE /tables/{name}/item", s.writing(s.handleDeleteItem))
+	mux.HandleFunc("POST /tables", s.writing(s.handleCreateTable))
 	return s.withMiddleware(mux)
 }
 
@@ -99,6 +103,17 @@ func (s *server) withMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// writing refuses a route changing tables or items when s is read-only
+func (s *server) writing(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.readOnly {
+			writeError(w, http.StatusForbidden, "read-only mode: changes to tables and items are refused")
+			return
+		}
+		next(w, r)
+	}
+}
+
 func (s *server) authorized(r *http.Request) bool {
 	const prefix = "Bearer "
 	h := r.Header.Get("Authorization")
@@ -193,6 +208,21 @@ func (s *server) handleDiscover(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]interface{}{"profile": req.Profile, "regions": out})
 }
 
+func (s *server) handleListTables(w http.ResponseWriter, r *http.Request) {
+	backend, ok := s.resolveRegion(w, r)
+	if !ok {
+		return
+	}
+	tables, err := backend.ListTables(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	tables = append([]string{}, tables...)
+	sort.Strings(tables)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tables": tables})
+}
+
 func (s *server) handleSchema(w http.ResponseWriter, r *http.Request) {
 	backend, ok := s.resolveRegion(w, r)
 	if !ok {
@@ -229,7 +259,7 @@ func (s *server) handleScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := backend.ScanTable(r.Context(), name, limit, startKey, "", nil, nil)
+	result, err := backend.ScanTable(r.Context(), dynamo.ScanInput{TableName: name, Limit: limit, StartKey: startKey}, dynamo.ScanOptions{})
 	if err != nil {
 		writeError(w, http.StatusBadGateway, err.Error())
 		return
@@ -334,9 +364,8 @@ func (s *server) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// DescribeTable is called per query to plan Query-vs-Scan. The schema is
-	// stable for the session, so this small per-request cost is acceptable for a
-	// loopback dev tool (caching can come with the region-switch phase).
+	// DescribeTable is called per query to plan Query-vs-Scan; the client
+	// caches it, so only the first query on a table pays for the round trip.
 	info, err := backend.DescribeTable(r.Context(), name)
 	if err != nil {
 		writeError(w, http.StatusBadGateway, err.Error())
@@ -386,7 +415,14 @@ func (s *server) handleQuery(w http.ResponseWriter, r *http.Request) {
 		rawItems, lastKey, count, scannedCount = res.Items, res.LastEvaluatedKey, res.Count, res.ScannedCount
 	} else {
 		mode = "scan"
-		res, serr := backend.ScanTable(r.Context(), name, limit, startKey, plan.FilterExpression, plan.Names, plan.Values)
+		res, serr := backend.ScanTable(r.Context(), dynamo.ScanInput{
+			TableName:                name,
+			FilterExpression:         plan.FilterExpression,
+			ExpressionAttributeNames: plan.Names,
+			ExpressionValues:         plan.Values,
+			Limit:                    limit,
+			StartKey:                 startKey,
+		}, dynamo.ScanOptions{})
 		if serr != nil {
 			writeError(w, http.StatusBadGateway, serr.Error())
 			return
@@ -434,6 +470,39 @@ type createTableRequest struct {
 	WCU         int64  `json:"wcu"`
 }
 
+// handleGetItem fetches one item by the key in ?key=, plain JSON such as
+// {"pk":"123","sk":"2024"}
+func (s *server) handleGetItem(w http.ResponseWriter, r *http.Request) {
+	backend, ok := s.resolveRegion(w, r)
+	if !ok {
+		return
+	}
+	raw := r.URL.Query().Get("key")
+	if raw == "" {
+		writeError(w, http.StatusBadRequest, "key is required")
+		return
+	}
+	key, err := models.JSONToItem(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	item, err := backend.GetItem(r.Context(), r.PathValue("name"), key)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	if item == nil {
+		writeError(w, http.StatusNotFound, "item not found")
+		return
+	}
+	converted := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		converted[k] = models.AttributeValueToInterface(v)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"item": converted})
+}
+
 func (s *server) handlePutItem(w http.ResponseWriter, r *http.Request) {
 	backend, ok := s.resolveRegion(w, r)
 	if !ok {
@@ -504,10 +573,15 @@ func (s *server) handleDeleteItem(w http.ResponseWriter, r *http.Request) {
 		key[info.SortKey] = v
 	}
 
-	if err := backend.DeleteItem(r.Context(), name, key); err != nil {
+	found, err := backend.DeleteItem(r.Context(), name, key)
+	if err != nil {
 		writeError(w, http.StatusBadGateway, err.Error())
 		return
 	}
+	if !found {
+		writeError(w, http.StatusNotFound, "no item has that key")
+		return
+	}
 	writeJSON(w, http.StatusOK, map[string]interface{}{"ok": true})
 }
 
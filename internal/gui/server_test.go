@@ -10,7 +10,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/godynamo/internal/dynamo"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 type fakeBackend struct {
@@ -20,12 +20,15 @@ type fakeBackend struct {
 	scanErr  error
 	query    *dynamo.QueryResult
 	queryErr error
-	putItem   map[string]types.AttributeValue
-	putErr    error
-	deleteKey map[string]types.AttributeValue
-	deleteErr error
-	createIn  dynamo.CreateTableInput
-	createErr error
+	getKey        map[string]types.AttributeValue
+	getItem       map[string]types.AttributeValue
+	putItem       map[string]types.AttributeValue
+	putErr        error
+	deleteKey     map[string]types.AttributeValue
+	deleteErr     error
+	deleteMissing bool
+	createIn      dynamo.CreateTableInput
+	createErr     error
 }
 
 func (f *fakeBackend) ListTables(ctx context.Context) ([]string, error) { return f.tables, nil }
@@ -34,9 +37,7 @@ func (f *fakeBackend) DescribeTable(ctx context.Context, name string) (*dynamo.T
 	return f.info, nil
 }
 
-func (f *fakeBackend) ScanTable(ctx context.Context, name string, limit int32,
-	startKey map[string]types.AttributeValue, filterExpr string,
-	names map[string]string, values map[string]interface{}) (*dynamo.ScanResult, error) {
+func (f *fakeBackend) ScanTable(ctx context.Context, input dynamo.ScanInput, opts dynamo.ScanOptions) (*dynamo.ScanResult, error) {
 	return f.scan, f.scanErr
 }
 
@@ -44,14 +45,19 @@ func (f *fakeBackend) QueryTable(ctx context.Context, input dynamo.QueryInput) (
 	return f.query, f.queryErr
 }
 
+func (f *fakeBackend) GetItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	f.getKey = key
+	return f.getItem, nil
+}
+
 func (f *fakeBackend) PutItem(ctx context.Context, tableName string, item map[string]types.AttributeValue) error {
 	f.putItem = item
 	return f.putErr
 }
 
-func (f *fakeBackend) DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) error {
+func (f *fakeBackend) DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (bool, error) {
 	f.deleteKey = key
-	return f.deleteErr
+	return !f.deleteMissing, f.deleteErr
 }
 
 func (f *fakeBackend) CreateTable(ctx context.Context, input dynamo.CreateTableInput) error {
@@ -288,6 +294,15 @@ func TestDeleteItemDerivesKey(t *testing.T) {
 	}
 }
 
+func TestDeleteItemNotFound(t *testing.T) {
+	f := &fakeBackend{info: &dynamo.TableInfo{PartitionKey: "id"}, deleteMissing: true}
+	s := newTestServer(f)
+	rec := do(s, http.MethodDelete, "/tables/t/item?region=us-east-1", `{"json":"{\"id\":\"1\"}"}`)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("want 404, got %d (%s)", rec.Code, rec.Body.String())
+	}
+}
+
 func TestDeleteItemMissingKey(t *testing.T) {
 	f := &fakeBackend{info: &dynamo.TableInfo{PartitionKey: "id"}}
 	s := newTestServer(f)
@@ -516,3 +531,42 @@ func TestRegionRoutingCachesPerRegion(t *testing.T) {
 		t.Fatalf("want connectFn called once for sa-east-1, got %d", calls["sa-east-1"])
 	}
 }
+
+func TestListTablesSorted(t *testing.T) {
+	s := newTestServer(&fakeBackend{tables: []string{"users", "orders"}})
+	rec := do(s, http.MethodGet, "/tables?region=us-east-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	var body struct{ Tables []string }
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Tables) != 2 || body.Tables[0] != "orders" {
+		t.Fatalf("tables = %v", body.Tables)
+	}
+}
+
+func TestGetItemByKey(t *testing.T) {
+	f := &fakeBackend{getItem: map[string]types.AttributeValue{
+		"id":   &types.AttributeValueMemberS{Value: "1"},
+		"name": &types.AttributeValueMemberS{Value: "Alice"},
+	}}
+	s := newTestServer(f)
+	rec := do(s, http.MethodGet, `/tables/t/item?region=us-east-1&key=%7B%22id%22%3A%221%22%7D`, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	if v, ok := f.getKey["id"].(*types.AttributeValueMemberS); !ok || v.Value != "1" {
+		t.Fatalf("key passed to GetItem = %v", f.getKey)
+	}
+	if !strings.Contains(rec.Body.String(), `"name":"Alice"`) {
+		t.Fatalf("body = %s", rec.Body.String())
+	}
+
+	f.getItem = nil
+	if rec := do(s, http.MethodGet, `/tables/t/item?region=us-east-1&key=%7B%22id%22%3A%222%22%7D`, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("a missing item: want 404, got %d", rec.Code)
+	}
+	if rec := do(s, http.MethodGet, "/tables/t/item?region=us-east-1", ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("no key: want 400, got %d", rec.Code)
+	}
+}
@@ -22,8 +22,21 @@ type DataTable struct {
 	ColWidths     []int
 	ShowRowNums   bool
 	FocusEnabled  bool
+	Highlight     string          // case-insensitive text to highlight in visible cells
+	WrapSelected  bool            // wrap the selected row's cells onto as many lines as they need
+	Marks         map[int]RowMark // rows to call out, by index
 }
 
+// RowMark calls out a row: Sign goes before its number and its cells take
+// Color unless selected
+type RowMark struct {
+	Sign  string
+	Color lipgloss.Color
+}
+
+// NullCell is what a cell holding NULL shows, dimmed
+const NullCell = "∅"
+
 // NewDataTable creates a new DataTable
 func NewDataTable() DataTable {
 	return DataTable{
@@ -88,6 +101,22 @@ func (t *DataTable) calculateColWidths() {
 	}
 }
 
+// EqualColWidths gives every column the same width, sharing out the table's
+// width when that leaves each at least 12 columns
+func (t *DataTable) EqualColWidths() {
+	if len(t.ColWidths) == 0 {
+		return
+	}
+	avail := t.Width - 15
+	if t.ShowRowNums {
+		avail -= 6 // the row number column
+	}
+	w := max(avail/len(t.ColWidths)-3, 12)
+	for i := range t.ColWidths {
+		t.ColWidths[i] = w
+	}
+}
+
 // MoveUp moves selection up
 func (t *DataTable) MoveUp() {
 	if t.SelectedRow > 0 {
@@ -132,6 +161,21 @@ func (t *DataTable) MoveRight() {
 	}
 }
 
+// GoToRow selects row i and scrolls it into view
+func (t *DataTable) GoToRow(i int) {
+	if i < 0 || i >= len(t.Rows) {
+		return
+	}
+	t.SelectedRow = i
+	visibleRows := t.Height - 4
+	if visibleRows < 1 {
+		visibleRows = 10
+	}
+	if i < t.Offset || i >= t.Offset+visibleRows {
+		t.Offset = max(0, i-visibleRows/2)
+	}
+}
+
 // GetSelectedRow returns the currently selected row
 func (t *DataTable) GetSelectedRow() []string {
 	if t.SelectedRow >= 0 && t.SelectedRow < len(t.Rows) {
@@ -227,12 +271,25 @@ func (t *DataTable) View() string {
 		visibleRows = 10
 	}
 
+	startRow := t.Offset
 	endRow := t.Offset + visibleRows
+	wrapHeight := 1
+	if t.WrapSelected && t.SelectedRow >= 0 && t.SelectedRow < len(t.Rows) {
+		// the wrapped row takes the place of the rows below it, keeping
+		// itself in view
+		wrapHeight = t.wrappedHeight(t.Rows[t.SelectedRow], startCol, endCol, visibleRows)
+		endRow -= wrapHeight - 1
+		if t.SelectedRow >= endRow {
+			startRow += t.SelectedRow - endRow + 1
+			endRow = t.SelectedRow + 1
+		}
+	}
 	if endRow > len(t.Rows) {
 		endRow = len(t.Rows)
 	}
 
-	for rowIdx := t.Offset; rowIdx < endRow; rowIdx++ {
+	var rows []string
+	for rowIdx := startRow; rowIdx < endRow; rowIdx++ {
 		row := t.Rows[rowIdx]
 		var cells []string
 
@@ -241,7 +298,7 @@ func (t *DataTable) View() string {
 			if rowIdx == t.SelectedRow && t.FocusEnabled {
 				numStyle = TableCellSelectedStyle
 			}
-			cells = append(cells, numStyle.Width(rowNumWidth).Render(fmt.Sprintf("%d", rowIdx+1)))
+			cells = append(cells, numStyle.Width(rowNumWidth).Render(fmt.Sprintf("%s%d", t.Marks[rowIdx].Sign, rowIdx+1)))
 		}
 
 		// Show scroll indicator for left
@@ -270,7 +327,17 @@ func (t *DataTable) View() string {
 					style = TableCellSelectedStyle
 				}
 			}
-			cells = append(cells, style.Width(width+2).Render(Truncate(cell, width)))
+			if mark, ok := t.Marks[rowIdx]; ok && rowIdx != t.SelectedRow {
+				style = style.Foreground(mark.Color)
+			}
+			if cell == NullCell {
+				style = style.Foreground(ColorTextMuted).Faint(true)
+			}
+			if wrapHeight > 1 && rowIdx == t.SelectedRow {
+				cells = append(cells, style.Width(width+2).Render(highlightCell(wrapCell(cell, width, wrapHeight), t.Highlight)))
+				continue
+			}
+			cells = append(cells, style.Width(width+2).Render(highlightCell(Truncate(cell, width), t.Highlight)))
 		}
 
 		// Show scroll indicator for right
@@ -282,13 +349,31 @@ func (t *DataTable) View() string {
 			cells = append(cells, style.Width(2).Render("▶"))
 		}
 
-		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+
+	// The header stays above the rows as they scroll; a scrollbar on the
+	// right shows where they are
+	body := strings.Join(rows, "\n")
+	scrolled := len(t.Rows) > endRow-startRow
+	if scrolled && body != "" {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, body, " ", scrollbar(lipgloss.Height(body), startRow, endRow, len(t.Rows)))
+	}
+	b.WriteString(body)
+	if body != "" {
 		b.WriteString("\n")
 	}
 
 	// Footer with row count
-	footer := fmt.Sprintf("Showing %d-%d of %d rows", t.Offset+1, endRow, len(t.Rows))
+	footer := fmt.Sprintf("Showing %d-%d of %d rows", startRow+1, endRow, len(t.Rows))
+	if scrolled {
+		footer += fmt.Sprintf(" (%d%%)", endRow*100/len(t.Rows))
+	}
 	b.WriteString(HelpStyle.Render(footer))
+	if startCol > 0 || endCol < len(t.Headers) {
+		b.WriteString(HelpStyle.Render(fmt.Sprintf(" • Columns %d-%d of %d ", startCol+1, endCol, len(t.Headers))))
+		b.WriteString(t.columnMap(startCol, endCol))
+	}
 
 	return b.String()
 }
@@ -603,17 +688,118 @@ type StatusBar struct {
 	Width  int
 }
 
-// View renders the status bar
+// View renders the status bar. Center and Right keep their own width and
+// Left gets what remains, cut short rather than wrapped onto a second line.
+// A bar with only Left, or without a Width, is as wide as its text.
 func (s StatusBar) View() string {
-	leftStyle := StatusBarStyle.Width(s.Width / 3)
-	centerStyle := StatusBarStyle.Width(s.Width / 3).Align(lipgloss.Center)
-	rightStyle := StatusBarStyle.Width(s.Width / 3).Align(lipgloss.Right)
+	if s.Width <= 0 || s.Center == "" && s.Right == "" {
+		return StatusBarStyle.Render(s.Left + s.Center + s.Right)
+	}
+	inner := s.Width - StatusBarStyle.GetHorizontalFrameSize()
+	cw, rw := lipgloss.Width(s.Center), lipgloss.Width(s.Right)
+	left := lipgloss.NewStyle().MaxWidth(max(inner-cw-rw, 0)).Render(s.Left)
 
-	return lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftStyle.Render(s.Left),
-		centerStyle.Render(s.Center),
-		rightStyle.Render(s.Right),
-	)
+	lw := lipgloss.Width(left)
+	free := max(inner-lw-cw-rw, 0)
+	before := 0 // spaces between Left and Center, placing Center mid-bar
+	if s.Center != "" {
+		before = min(max((inner-cw)/2-lw, 0), free)
+	}
+	return StatusBarStyle.Render(left + strings.Repeat(" ", before) + s.Center +
+		strings.Repeat(" ", free-before) + s.Right)
+}
+
+// maxColumnMap is the most cells the column map takes; wider tables share
+// cells between columns
+const maxColumnMap = 40
+
+// columnMap draws a cell per column, filled for the ones on screen, so the
+// horizontal position shows at a glance
+func (t *DataTable) columnMap(startCol, endCol int) string {
+	n := len(t.Headers)
+	cells := min(n, maxColumnMap)
+	var b strings.Builder
+	for i := range cells {
+		from, to := i*n/cells, max((i+1)*n/cells, i*n/cells+1)
+		switch {
+		case from <= t.SelectedCol && t.SelectedCol < to:
+			b.WriteString(KeyStyle.Render("■"))
+		case from < endCol && to > startCol:
+			b.WriteString(lipgloss.NewStyle().Foreground(ColorPrimary).Render("■"))
+		default:
+			b.WriteString(DividerStyle.Render("·"))
+		}
+	}
+	return b.String()
 }
 
+// scrollbar is a track height lines tall with a thumb over the part from
+// start to end of total rows
+func scrollbar(height, start, end, total int) string {
+	thumb := max(height*(end-start)/total, 1)
+	top := min(height*start/total, height-thumb)
+	if end == total {
+		top = height - thumb // the last row is in view: the thumb sits at the bottom
+	}
+	lines := make([]string, height)
+	for i := range lines {
+		if i >= top && i < top+thumb {
+			lines[i] = lipgloss.NewStyle().Foreground(ColorPrimary).Render("█")
+		} else {
+			lines[i] = DividerStyle.Render("│")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrappedHeight is how many lines the row's visible cells need when wrapped,
+// at most limit
+func (t *DataTable) wrappedHeight(row []string, startCol, endCol, limit int) int {
+	h := 1
+	for i := startCol; i < endCol && i < len(row) && i < len(t.ColWidths); i++ {
+		if t.ColWidths[i] > 0 {
+			h = max(h, len(wrapLines(row[i], t.ColWidths[i])))
+		}
+	}
+	return max(min(h, limit), 1)
+}
+
+// wrapLines breaks text into lines of at most width columns, at spaces where
+// it can
+func wrapLines(text string, width int) []string {
+	return strings.Split(lipgloss.NewStyle().Width(width).Render(text), "\n")
+}
+
+// wrapCell wraps text to width, keeping the first height lines and ending the
+// last with ... when some are left out
+func wrapCell(text string, width, height int) string {
+	lines := wrapLines(text, width)
+	if len(lines) > height {
+		lines = lines[:height]
+		lines[height-1] = Truncate(strings.TrimRight(lines[height-1], " ")+"...", width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightCell marks every case-insensitive occurrence of query in text
+func highlightCell(text, query string) string {
+	if query == "" {
+		return text
+	}
+	lower, q := strings.ToLower(text), strings.ToLower(query)
+	if len(lower) != len(text) || !strings.Contains(lower, q) {
+		return text
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, q)
+		if i < 0 {
+			break
+		}
+		b.WriteString(text[:i])
+		b.WriteString(SearchHighlightStyle.Render(text[i : i+len(q)]))
+		text, lower = text[i+len(q):], lower[i+len(q):]
+	}
+	b.WriteString(text)
+	return b.String()
+}
@@ -1,6 +1,12 @@
 package ui
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 func TestDataTableSetDataResetsCursor(t *testing.T) {
 	dt := NewDataTable()
@@ -68,6 +74,83 @@ func TestDataTableHorizontalNavBounds(t *testing.T) {
 	}
 }
 
+func TestDataTableWrapsSelectedRow(t *testing.T) {
+	dt := NewDataTable()
+	long := strings.Repeat("word ", 30) + "END"
+	rows := [][]string{{"1", long}}
+	for i := 2; i <= 20; i++ {
+		rows = append(rows, []string{fmt.Sprint(i), "short"})
+	}
+	dt.SetData([]string{"id", "body"}, rows)
+	dt.SetSize(100, 14)
+	if strings.Contains(dt.View(), "END") {
+		t.Fatal("without wrap the long cell should be cut")
+	}
+	dt.WrapSelected = true
+	out := dt.View()
+	if !strings.Contains(out, "END") {
+		t.Errorf("the selected row should wrap to show all of its value:\n%s", out)
+	}
+	if lines := strings.Count(out, "\n"); lines > 14 {
+		t.Errorf("wrapping grew the table to %d lines", lines)
+	}
+
+	dt.GoToRow(19)
+	if out := dt.View(); !strings.Contains(out, "-20 of 20 rows") {
+		t.Errorf("the selected row should stay in view:\n%s", out)
+	}
+}
+
+func TestDataTableKeepsHeaderAndShowsScrollPosition(t *testing.T) {
+	dt := NewDataTable()
+	var rows [][]string
+	for i := 1; i <= 50; i++ {
+		rows = append(rows, []string{fmt.Sprint(i)})
+	}
+	dt.SetData([]string{"id"}, rows)
+	dt.SetSize(60, 14)
+	dt.GoToRow(49)
+	out := dt.View()
+	if first := strings.Split(out, "\n")[0]; !strings.Contains(first, "id") {
+		t.Errorf("the header should stay on top, got %q", first)
+	}
+	if !strings.Contains(out, "█") || !strings.Contains(out, "(100%)") {
+		t.Errorf("want a scrollbar and the position at the end:\n%s", out)
+	}
+
+	dt.SetData([]string{"id"}, rows[:3])
+	if out := dt.View(); strings.Contains(out, "█") || strings.Contains(out, "%") {
+		t.Errorf("rows that all fit need no scrollbar:\n%s", out)
+	}
+}
+
+func TestDataTableColumnMap(t *testing.T) {
+	dt := NewDataTable()
+	headers := make([]string, 24)
+	row := make([]string, 24)
+	for i := range headers {
+		headers[i] = fmt.Sprintf("attribute_%02d", i+1)
+		row[i] = strings.Repeat("x", 20)
+	}
+	dt.SetData(headers, [][]string{row})
+	dt.SetSize(120, 14)
+	for range 10 {
+		dt.MoveRight()
+	}
+	out := dt.View()
+	if !strings.Contains(out, "Columns 8-11 of 24") {
+		t.Errorf("want the visible columns in the footer:\n%s", out)
+	}
+	if strings.Count(out, "■") != 4 || strings.Count(out, "·") != 20 {
+		t.Errorf("want a cell per column, 4 of them filled:\n%s", out)
+	}
+
+	dt.SetData(headers[:2], [][]string{row[:2]})
+	if strings.Contains(dt.View(), "Columns") {
+		t.Error("columns that all fit need no map")
+	}
+}
+
 func TestDataTableGetSelectedRow(t *testing.T) {
 	dt := NewDataTable()
 	dt.SetData([]string{"a"}, [][]string{{"x"}, {"y"}})
@@ -120,3 +203,31 @@ func TestListGetSelectedEmpty(t *testing.T) {
 		t.Fatal("empty list should return empty string")
 	}
 }
+
+func TestStatusBarFitsWidth(t *testing.T) {
+	bar := StatusBar{Left: strings.Repeat("left ", 40), Right: "12:00", Width: 60}
+	out := bar.View()
+	if strings.Contains(out, "\n") || lipgloss.Width(out) != 60 {
+		t.Fatalf("want one line of 60 columns, got %d:\n%s", lipgloss.Width(out), out)
+	}
+	if !strings.HasSuffix(strings.TrimRight(out, " "), "12:00") {
+		t.Errorf("the right segment should stay whole at the end: %q", out)
+	}
+
+	bar = StatusBar{Left: "a", Center: "mid", Right: "z", Width: 40}
+	out = bar.View()
+	if i := strings.Index(out, "mid"); i < 15 || i > 20 {
+		t.Errorf("center at column %d of 40: %q", i, out)
+	}
+}
+
+func TestDataTableMarksRows(t *testing.T) {
+	dt := NewDataTable()
+	dt.SetData([]string{"id"}, [][]string{{"a"}, {"b"}, {"c"}})
+	dt.SetSize(80, 10)
+	dt.Marks = map[int]RowMark{1: {Sign: "+", Color: ColorSuccess}}
+	out := dt.View()
+	if !strings.Contains(out, "+2") || strings.Contains(out, "+1") || strings.Contains(out, "+3") {
+		t.Errorf("only row 2 should carry the mark:\n%s", out)
+	}
+}
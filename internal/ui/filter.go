@@ -8,7 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/godynamo/internal/query"
+	"github.com/godynamo/pkg/query"
 )
 
 // FilterOperator represents a filter comparison operator
@@ -253,6 +253,11 @@ func (f *FilterBuilder) Update(msg tea.Msg) tea.Cmd {
 // BuildExpression builds a DynamoDB filter expression by delegating to the
 // shared query package (single source of truth with the GUI bridge).
 func (f *FilterBuilder) BuildExpression() (string, map[string]string, map[string]interface{}) {
+	return query.BuildExpression(f.QueryConditions())
+}
+
+// QueryConditions returns the rows as the query package's conditions
+func (f *FilterBuilder) QueryConditions() []query.Condition {
 	conds := make([]query.Condition, len(f.Conditions))
 	for i, c := range f.Conditions {
 		conds[i] = query.Condition{
@@ -262,7 +267,7 @@ func (f *FilterBuilder) BuildExpression() (string, map[string]string, map[string
 			Value:    c.AttributeValue.Value(),
 		}
 	}
-	return query.BuildExpression(conds)
+	return conds
 }
 
 // View renders the filter builder
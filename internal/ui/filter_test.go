@@ -3,7 +3,7 @@ package ui
 import (
 	"testing"
 
-	"github.com/godynamo/internal/query"
+	"github.com/godynamo/pkg/query"
 )
 
 func TestFilterBuilderBuildExpressionDelegates(t *testing.T) {
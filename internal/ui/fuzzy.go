@@ -14,31 +14,76 @@ type FuzzyMatch struct {
 
 // FuzzyFind performs fuzzy matching on a list of strings
 func FuzzyFind(pattern string, items []string) []FuzzyMatch {
+	return NewFuzzyIndex(items).Find(pattern)
+}
+
+// FuzzyIndex keeps the lowercased candidates between searches so that
+// filtering a long list on every keystroke only pays for the scoring. When
+// a pattern extends the previous one, only the previous matches are scored
+// again, since anything that failed the shorter pattern fails the longer.
+type FuzzyIndex struct {
+	items []string
+	lower []string
+	runes [][]rune
+
+	lastPattern string
+	lastHits    []int // indexes into items that matched lastPattern, ascending
+}
+
+// NewFuzzyIndex prepares items for repeated FuzzyFind-style searches
+func NewFuzzyIndex(items []string) *FuzzyIndex {
+	ix := &FuzzyIndex{
+		items: items,
+		lower: make([]string, len(items)),
+		runes: make([][]rune, len(items)),
+	}
+	for i, item := range items {
+		ix.lower[i] = strings.ToLower(item)
+		ix.runes[i] = []rune(ix.lower[i])
+	}
+	return ix
+}
+
+// Find returns the items matching pattern, best first, like FuzzyFind
+func (ix *FuzzyIndex) Find(pattern string) []FuzzyMatch {
 	if pattern == "" {
 		// Return all items with score 0
-		results := make([]FuzzyMatch, len(items))
-		for i, item := range items {
+		results := make([]FuzzyMatch, len(ix.items))
+		for i, item := range ix.items {
 			results[i] = FuzzyMatch{Text: item, Score: 0}
 		}
+		ix.lastPattern, ix.lastHits = "", nil
 		return results
 	}
 
 	pattern = strings.ToLower(pattern)
-	var results []FuzzyMatch
+	patternRunes := []rune(pattern)
 
-	for _, item := range items {
-		score, matchedIdx := fuzzyScore(pattern, strings.ToLower(item))
+	candidates := ix.lastHits
+	if ix.lastPattern == "" || !strings.HasPrefix(pattern, ix.lastPattern) {
+		candidates = nil
+		for i := range ix.items {
+			candidates = append(candidates, i)
+		}
+	}
+
+	var results []FuzzyMatch
+	var hits []int
+	for _, i := range candidates {
+		score, matchedIdx := fuzzyScoreRunes(pattern, patternRunes, ix.lower[i], ix.runes[i])
 		if score > 0 {
+			hits = append(hits, i)
 			results = append(results, FuzzyMatch{
-				Text:       item,
+				Text:       ix.items[i],
 				Score:      score,
 				MatchedIdx: matchedIdx,
 			})
 		}
 	}
+	ix.lastPattern, ix.lastHits = pattern, hits
 
-	// Sort by score (higher is better)
-	sort.Slice(results, func(i, j int) bool {
+	// Sort by score (higher is better); ties keep the list order
+	sort.SliceStable(results, func(i, j int) bool {
 		return results[i].Score > results[j].Score
 	})
 
@@ -48,6 +93,11 @@ func FuzzyFind(pattern string, items []string) []FuzzyMatch {
 // fuzzyScore calculates a fuzzy match score
 // Returns score (0 if no match) and matched character indices
 func fuzzyScore(pattern, text string) (int, []int) {
+	return fuzzyScoreRunes(pattern, []rune(pattern), text, []rune(text))
+}
+
+// fuzzyScoreRunes is fuzzyScore with the rune slices already decoded
+func fuzzyScoreRunes(pattern string, patternRunes []rune, text string, textRunes []rune) (int, []int) {
 	if len(pattern) == 0 {
 		return 1, nil
 	}
@@ -55,10 +105,7 @@ func fuzzyScore(pattern, text string) (int, []int) {
 		return 0, nil
 	}
 
-	// Quick check: all pattern chars must exist in text
-	patternRunes := []rune(pattern)
-	textRunes := []rune(text)
-	
+
 	patternIdx := 0
 	var matchedIdx []int
 	score := 0
@@ -59,3 +59,19 @@ func TestHighlightMatchesNoMatches(t *testing.T) {
 		t.Fatalf("got %q", got)
 	}
 }
+
+func TestFuzzyIndexNarrowsLikeFuzzyFind(t *testing.T) {
+	items := []string{"users", "user_events", "orders", "Audit_Log", "sessions"}
+	ix := NewFuzzyIndex(items)
+	for _, pattern := range []string{"u", "us", "use", "user", "users", "s", "se", "", "AUD", "audx", "aud"} {
+		got, want := ix.Find(pattern), FuzzyFind(pattern, items)
+		if len(got) != len(want) {
+			t.Fatalf("%q: %d matches, FuzzyFind has %d", pattern, len(got), len(want))
+		}
+		for i := range got {
+			if got[i].Text != want[i].Text || got[i].Score != want[i].Score {
+				t.Fatalf("%q: match %d = %+v, want %+v", pattern, i, got[i], want[i])
+			}
+		}
+	}
+}
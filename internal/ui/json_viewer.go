@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 // JSONViewer renders JSON with syntax highlighting
@@ -12,6 +14,9 @@ type JSONViewer struct {
 	Data      interface{}
 	Collapsed map[string]bool
 	Indent    int
+	Compact   bool // render on a single line, like FormatJSONCompact
+	Width     int  // compact lines are wrapped at this width so they scroll; 0 keeps them whole
+	YAML      bool // render as block YAML instead of JSON
 
 	// Search state
 	SearchQuery  string
@@ -21,6 +26,34 @@ type JSONViewer struct {
 
 	// Internal render state
 	currentLine int
+
+	// Render cache: the highlighted document split into lines, rebuilt only
+	// when renderKey changes. Matches are cached with the inactive style and
+	// the current one is restyled per view, so n/N never re-renders.
+	lines       []string
+	cached      renderKey
+	matches     []renderedMatch
+	collapseGen int
+}
+
+// renderKey holds everything the rendered lines depend on apart from Data,
+// which callers replace by creating a new viewer.
+type renderKey struct {
+	valid       bool
+	query       string
+	compact     bool
+	width       int
+	yaml        bool
+	indent      int
+	collapseGen int
+	collapsed   int
+}
+
+type renderedMatch struct {
+	line     int
+	inactive string // as rendered into the cached line
+	active   string
+	nth      int // occurrence of inactive on its line
 }
 
 // NewJSONViewer creates a new JSONViewer
@@ -34,13 +67,149 @@ func NewJSONViewer(data interface{}) *JSONViewer {
 
 // Render returns a syntax-highlighted string representation
 func (j *JSONViewer) Render() string {
+	lines := j.Lines()
+	return j.View(0, len(lines))
+}
+
+// Lines returns the rendered document, one entry per line, with every match
+// in the inactive highlight style. It is cached until the search query,
+// display mode or collapsed state changes.
+func (j *JSONViewer) Lines() []string {
+	key := renderKey{
+		valid:       true,
+		query:       j.SearchQuery,
+		compact:     j.Compact,
+		width:       j.Width,
+		yaml:        j.YAML,
+		indent:      j.Indent,
+		collapseGen: j.collapseGen,
+		collapsed:   len(j.Collapsed),
+	}
+	if key == j.cached {
+		return j.lines
+	}
+
 	j.TotalMatches = 0
 	j.MatchLines = make([]int, 0)
+	j.matches = j.matches[:0]
 	j.currentLine = 0
 
 	var sb strings.Builder
-	j.renderNode(&sb, j.Data, 0, "root")
-	return sb.String()
+	out := ""
+	if j.YAML && yamlNested(j.Data) {
+		j.renderYAMLNode(&sb, j.Data, 0, "root", false)
+		out = strings.TrimSuffix(sb.String(), "\n")
+	} else {
+		j.renderNode(&sb, j.Data, 0, "root")
+		out = sb.String()
+	}
+
+	j.lines = strings.Split(out, "\n")
+	if j.Compact && j.Width > 0 {
+		j.wrapLines()
+	}
+	j.cached = key
+	j.numberMatches()
+	return j.lines
+}
+
+// wrapLines soft-wraps the rendered lines at Width, after commas where it
+// can, and moves each match to the wrapped line it landed on. A match cut in
+// two by the wrap stays on the line of the match before it.
+func (j *JSONViewer) wrapLines() {
+	var wrapped []string
+	first := make([]int, len(j.lines)) // the wrapped line each line starts on
+	for i, line := range j.lines {
+		first[i] = len(wrapped)
+		wrapped = append(wrapped, strings.Split(ansi.Wrap(line, j.Width, ","), "\n")...)
+	}
+	first = append(first, len(wrapped))
+
+	line, used := -1, map[string]int{} // occurrences taken on line
+	for i := range j.matches {
+		m := &j.matches[i]
+		orig := m.line
+		if line < first[orig] {
+			line = first[orig]
+			clear(used)
+		}
+		k := line
+		for ; k < first[orig+1]; k++ {
+			taken := 0
+			if k == line {
+				taken = used[m.inactive]
+			}
+			if strings.Count(wrapped[k], m.inactive) > taken {
+				break
+			}
+		}
+		if k < first[orig+1] {
+			if k != line {
+				line = k
+				clear(used)
+			}
+			used[m.inactive]++
+		}
+		m.line = line
+		j.MatchLines[i] = line
+	}
+	j.lines = wrapped
+}
+
+// View renders height lines starting at offset, with the current match in
+// the active highlight style. Only that window is touched, so scrolling a
+// large document costs the same as scrolling a small one.
+func (j *JSONViewer) View(offset, height int) string {
+	lines := j.Lines()
+	offset = max(0, min(offset, len(lines)))
+	end := max(offset, min(offset+height, len(lines)))
+	window := lines[offset:end]
+
+	if j.CurrentMatch >= 0 && j.CurrentMatch < len(j.matches) {
+		if m := j.matches[j.CurrentMatch]; m.line >= offset && m.line < end && m.active != m.inactive {
+			window = append([]string(nil), window...)
+			window[m.line-offset] = replaceNth(window[m.line-offset], m.inactive, m.active, m.nth)
+		}
+	}
+	return strings.Join(window, "\n")
+}
+
+// Invalidate drops the render cache. Toggle, ExpandAll and CollapseAll do
+// this themselves; call it after editing Collapsed directly.
+func (j *JSONViewer) Invalidate() {
+	j.cached = renderKey{}
+}
+
+// numberMatches records, for each match, which occurrence of its rendered
+// text it is on its line, so View can restyle exactly that one.
+func (j *JSONViewer) numberMatches() {
+	seen := map[string]int{}
+	line := -1
+	for i := range j.matches {
+		m := &j.matches[i]
+		if m.line != line {
+			line = m.line
+			clear(seen)
+		}
+		m.nth = seen[m.inactive]
+		seen[m.inactive]++
+	}
+}
+
+func replaceNth(s, old, new string, n int) string {
+	at := 0
+	for ; n >= 0; n-- {
+		i := strings.Index(s[at:], old)
+		if i < 0 {
+			return s
+		}
+		if n == 0 {
+			at += i
+			break
+		}
+		at += i + len(old)
+	}
+	return s[:at] + new + s[at+len(old):]
 }
 
 func (j *JSONViewer) write(sb *strings.Builder, s string) {
@@ -103,6 +272,18 @@ func (j *JSONViewer) renderNode(sb *strings.Builder, v interface{}, indent int,
 			return
 		}
 
+		if j.Compact {
+			j.write(sb, "[")
+			for i, item := range val {
+				if i > 0 {
+					j.write(sb, ",")
+				}
+				j.renderNode(sb, item, 0, fmt.Sprintf("%s[%d]", path, i))
+			}
+			j.write(sb, "]")
+			return
+		}
+
 		j.write(sb, "[\n")
 		for i, item := range val {
 			itemPath := fmt.Sprintf("%s[%d]", path, i)
@@ -135,11 +316,18 @@ func (j *JSONViewer) renderNode(sb *strings.Builder, v interface{}, indent int,
 		}
 		sort.Strings(keys)
 
-		j.write(sb, "{\n")
+		open, sep, colon, pad := "{\n", ",\n", ": ", indentStr+strings.Repeat(" ", j.Indent)
+		if j.Compact {
+			open, sep, colon, pad = "{", ",", ":", ""
+		}
+
+		j.write(sb, open)
 		for i, k := range keys {
 			keyPath := fmt.Sprintf("%s.%s", path, k)
-			j.write(sb, indentStr)
-			j.write(sb, strings.Repeat(" ", j.Indent))
+			if i > 0 {
+				j.write(sb, sep)
+			}
+			j.write(sb, pad)
 
 			// Highlight key if it matches
 			keyStr := fmt.Sprintf("\"%s\"", k)
@@ -149,14 +337,13 @@ func (j *JSONViewer) renderNode(sb *strings.Builder, v interface{}, indent int,
 				j.write(sb, JSONKeyStyle.Render(keyStr))
 			}
 
-			j.write(sb, ": ")
+			j.write(sb, colon)
 			j.renderNode(sb, val[k], indent+j.Indent, keyPath)
-			if i < len(keys)-1 {
-				j.write(sb, ",")
-			}
+		}
+		if !j.Compact {
 			j.write(sb, "\n")
+			j.write(sb, indentStr)
 		}
-		j.write(sb, indentStr)
 		j.write(sb, "}")
 
 	default:
@@ -192,16 +379,17 @@ func (j *JSONViewer) highlightText(text string) string {
 		// This is a match
 		matchContent := text[absoluteIdx : absoluteIdx+len(lowerQuery)]
 
-		// Determine style based on if this is the current active match
-		style := SearchHighlightStyle
-		if j.TotalMatches == j.CurrentMatch {
-			style = SearchActiveHighlightStyle
-		}
-
-		sb.WriteString(style.Render(matchContent))
+		// Matches are cached inactive; View restyles the current one
+		rendered := SearchHighlightStyle.Render(matchContent)
+		sb.WriteString(rendered)
 
 		// Record the line number for this match
 		j.MatchLines = append(j.MatchLines, j.currentLine)
+		j.matches = append(j.matches, renderedMatch{
+			line:     j.currentLine,
+			inactive: rendered,
+			active:   SearchActiveHighlightStyle.Render(matchContent),
+		})
 
 		j.TotalMatches++
 		currentIndex = absoluteIdx + len(lowerQuery)
@@ -213,16 +401,19 @@ func (j *JSONViewer) highlightText(text string) string {
 // Toggle collapses or expands a path
 func (j *JSONViewer) Toggle(path string) {
 	j.Collapsed[path] = !j.Collapsed[path]
+	j.collapseGen++
 }
 
 // ExpandAll expands all paths
 func (j *JSONViewer) ExpandAll() {
 	j.Collapsed = make(map[string]bool)
+	j.collapseGen++
 }
 
 // CollapseAll collapses all paths
 func (j *JSONViewer) CollapseAll() {
 	j.collapseRecursive(j.Data, "root")
+	j.collapseGen++
 }
 
 func (j *JSONViewer) collapseRecursive(v interface{}, path string) {
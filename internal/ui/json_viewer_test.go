@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestFormatJSONCompact(t *testing.T) {
@@ -76,3 +79,133 @@ func TestJSONViewerRenderDoesNotPanicOnNil(t *testing.T) {
 	jv := NewJSONViewer(nil)
 	_ = jv.Render()
 }
+
+func TestJSONViewerCompactRendersSingleLine(t *testing.T) {
+	jv := NewJSONViewer(map[string]interface{}{
+		"a": int64(1),
+		"b": []interface{}{"x", "y"},
+		"c": map[string]interface{}{"d": true},
+	})
+	jv.Compact = true
+	if got, want := jv.Render(), `{"a":1,"b":["x","y"],"c":{"d":true}}`; got != want {
+		t.Fatalf("compact render = %q, want %q", got, want)
+	}
+}
+
+func TestJSONViewerCompactWrapsAtWidth(t *testing.T) {
+	data := map[string]interface{}{}
+	for i := 0; i < 50; i++ {
+		data[fmt.Sprintf("attr%02d", i)] = "needle"
+	}
+	jv := NewJSONViewer(data)
+	jv.Compact = true
+	jv.Width = 40
+	jv.SearchQuery = "needle"
+	lines := jv.Lines()
+	if len(lines) < 10 {
+		t.Fatalf("compact JSON wrapped into %d lines, want many", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w > 40 {
+			t.Fatalf("line %d is %d wide: %q", i, w, ansi.Strip(line))
+		}
+	}
+	if !strings.Contains(ansi.Strip(lines[len(lines)-1]), `"attr49":"needle"}`) {
+		t.Fatalf("last line = %q", ansi.Strip(lines[len(lines)-1]))
+	}
+	// Every match points at a wrapped line that holds it.
+	if jv.TotalMatches != 50 {
+		t.Fatalf("TotalMatches = %d", jv.TotalMatches)
+	}
+	for i, line := range jv.MatchLines {
+		if !strings.Contains(ansi.Strip(lines[line]), "needle") {
+			t.Fatalf("match %d on line %d without it: %q", i, line, ansi.Strip(lines[line]))
+		}
+	}
+	jv.CurrentMatch = 49
+	if got := ansi.Strip(jv.View(jv.MatchLines[49], 1)); !strings.Contains(got, "attr49") {
+		t.Fatalf("last match's line = %q", got)
+	}
+}
+
+func TestJSONViewerPrettyLayout(t *testing.T) {
+	jv := NewJSONViewer(map[string]interface{}{"a": int64(1), "b": int64(2)})
+	if got, want := jv.Render(), "{\n  \"a\": 1,\n  \"b\": 2\n}"; got != want {
+		t.Fatalf("pretty render = %q, want %q", got, want)
+	}
+}
+
+func TestJSONViewerYAMLRender(t *testing.T) {
+	jv := NewJSONViewer(map[string]interface{}{
+		"name":  "alice",
+		"yes":   "yes",
+		"tags":  []interface{}{"a", map[string]interface{}{"k": int64(1), "v": true}},
+		"meta":  map[string]interface{}{"empty": []interface{}{}},
+		"count": int64(3),
+	})
+	jv.YAML = true
+	want := strings.Join([]string{
+		"count: 3",
+		"meta:",
+		"  empty: []",
+		"name: alice",
+		"tags:",
+		"  - a",
+		"  - k: 1",
+		"    v: true",
+		`"yes": "yes"`,
+	}, "\n")
+	if got := jv.Render(); got != want {
+		t.Fatalf("YAML render =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJSONViewerYAMLSearchCountsMatches(t *testing.T) {
+	jv := NewJSONViewer(map[string]interface{}{"a": "needle", "b": map[string]interface{}{"needle": int64(1)}})
+	jv.YAML = true
+	jv.SearchQuery = "needle"
+	jv.Render()
+	if jv.TotalMatches != 2 || len(jv.MatchLines) != 2 || jv.MatchLines[1] != 2 {
+		t.Fatalf("matches = %d lines = %v", jv.TotalMatches, jv.MatchLines)
+	}
+}
+
+func TestJSONViewerCachesLinesUntilKeyChanges(t *testing.T) {
+	jv := NewJSONViewer(map[string]interface{}{"a": "needle", "b": "needle"})
+	jv.SearchQuery = "needle"
+	first := jv.Lines()
+
+	// Moving between matches must not re-render the document.
+	jv.Data = map[string]interface{}{"changed": true}
+	jv.CurrentMatch = 1
+	if got := jv.Lines(); &got[0] != &first[0] {
+		t.Fatal("Lines re-rendered although only CurrentMatch changed")
+	}
+	if jv.TotalMatches != 2 {
+		t.Fatalf("TotalMatches = %d", jv.TotalMatches)
+	}
+
+	jv.ExpandAll()
+	if got := strings.Join(jv.Lines(), "\n"); !strings.Contains(got, "changed") {
+		t.Fatalf("ExpandAll did not invalidate the cache:\n%s", got)
+	}
+}
+
+func TestJSONViewerViewWindow(t *testing.T) {
+	jv := NewJSONViewer(map[string]interface{}{"a": int64(1), "b": int64(2), "c": int64(3)})
+	if got, want := jv.View(1, 2), "  \"a\": 1,\n  \"b\": 2,"; got != want {
+		t.Fatalf("View(1, 2) = %q, want %q", got, want)
+	}
+	if got := jv.View(10, 5); got != "" {
+		t.Fatalf("View past the end = %q", got)
+	}
+}
+
+func TestReplaceNth(t *testing.T) {
+	if got := replaceNth("x a x a x", "x", "Y", 1); got != "x a Y a x" {
+		t.Fatalf("got %q", got)
+	}
+	if got := replaceNth("x", "x", "Y", 3); got != "x" {
+		t.Fatalf("missing occurrence should leave s unchanged, got %q", got)
+	}
+}
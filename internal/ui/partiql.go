@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/godynamo/pkg/query"
+)
+
+// PartiQLHighlighter returns a textarea Highlighter that colours keywords,
+// string and number literals, and identifiers isTable recognises as tables.
+func PartiQLHighlighter(isTable func(name string) bool) func([]rune, lipgloss.Style) []lipgloss.Style {
+	return func(line []rune, base lipgloss.Style) []lipgloss.Style {
+		styles := make([]lipgloss.Style, 0, len(line))
+		for _, tok := range query.Tokenize(string(line)) {
+			st := base
+			switch tok.Kind {
+			case query.TokenKeyword:
+				st = base.Foreground(JSONBoolStyle.GetForeground()).Bold(true)
+			case query.TokenString:
+				st = base.Foreground(JSONStringStyle.GetForeground())
+			case query.TokenNumber:
+				st = base.Foreground(JSONNumberStyle.GetForeground())
+			case query.TokenIdent, query.TokenQuotedIdent:
+				if isTable != nil && isTable(strings.Trim(tok.Text, `"`)) {
+					st = base.Foreground(ColorWarning).Bold(true)
+				} else if tok.Kind == query.TokenQuotedIdent {
+					st = base.Foreground(JSONKeyStyle.GetForeground())
+				}
+			}
+			for range []rune(tok.Text) {
+				styles = append(styles, st)
+			}
+		}
+		return styles
+	}
+}
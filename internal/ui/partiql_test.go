@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPartiQLHighlighterStylesEveryRune(t *testing.T) {
+	line := []rune(`SELECT * FROM "Users" WHERE név = 'é' AND n > 5`)
+	hl := PartiQLHighlighter(func(name string) bool { return name == "Users" })
+	styles := hl(line, lipgloss.NewStyle())
+	if len(styles) != len(line) {
+		t.Fatalf("%d styles for %d runes", len(styles), len(line))
+	}
+	fg := func(i int) lipgloss.TerminalColor { return styles[i].GetForeground() }
+	if fg(0) != JSONBoolStyle.GetForeground() {
+		t.Error("keyword not highlighted")
+	}
+	if fg(15) != ColorWarning {
+		t.Error("known table not highlighted")
+	}
+	if fg(len(line)-1) != JSONNumberStyle.GetForeground() {
+		t.Error("number not highlighted")
+	}
+	if _, ok := fg(28).(lipgloss.NoColor); !ok {
+		t.Errorf("plain attribute should keep the base style, got %v", fg(28))
+	}
+}
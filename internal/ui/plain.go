@@ -0,0 +1,97 @@
+package ui
+
+import "strings"
+
+// marks are the glyphs that carry meaning, spelled out for a screen reader
+var marks = map[rune]string{
+	'✓': "OK:",
+	'✗': "Error:",
+	'❌': "Error:",
+	'⚠': "Warning:",
+}
+
+// PlainText readies a rendered view for screen readers and braille displays:
+// the check, cross and warning marks become words, other emoji are dropped and
+// box-drawing lines become blanks, so only the text is read out. Colors are
+// kept; their escape codes are plain ASCII and pass through.
+func PlainText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case marks[r] != "":
+			b.WriteString(marks[r])
+			i = skipDecoration(runes, i, false)
+		case isEmoji(r):
+			i = skipDecoration(runes, i, true)
+		case r >= 0x2500 && r <= 0x257F: // box drawing
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// skipDecoration skips the variation selector after the glyph at i and, when
+// the glyph was dropped, the space that separated it from its label
+func skipDecoration(runes []rune, i int, dropped bool) int {
+	if i+1 < len(runes) && runes[i+1] == 0xFE0F {
+		i++
+	}
+	if dropped && i+1 < len(runes) && runes[i+1] == ' ' {
+		i++
+	}
+	return i
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, symbols and flags
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x231A && r <= 0x23FF: // watches, hourglasses and media keys
+		return true
+	case r == 0xFE0F || r == 0x200D: // emoji presentation and joiners
+		return true
+	}
+	return false
+}
+
+// asciiGlyphs stand in for the glyphs godynamo draws, in terminals and fonts
+// that can't show them
+var asciiGlyphs = map[rune]string{
+	'▸': ">", '▶': ">", '◀': "<", '▲': "^", '▼': "v",
+	'↑': "^", '↓': "v", '←': "<", '→': "->",
+	'✓': "+", '✗': "x", '❌': "x", '⚠': "!",
+	'█': "#", '▌': "|", '░': ".", '■': "#", '·': ".",
+	'≠': "!=", '≤': "<=", '≥': ">=", '∅': "~",
+	'─': "-", '━': "-", '═': "=", '│': "|", '┃': "|", '║': "|",
+}
+
+// ASCIIText swaps the glyphs in a rendered view for ASCII: arrows and marks
+// get a look-alike, box drawing becomes +, - and |, and other emoji, which
+// have none, are dropped. Text from the table, accents included, is kept.
+func ASCIIText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case asciiGlyphs[r] != "":
+			b.WriteString(asciiGlyphs[r])
+			i = skipDecoration(runes, i, false)
+		case isEmoji(r):
+			i = skipDecoration(runes, i, true)
+		case r >= 0x2500 && r <= 0x257F: // corners and joints
+			b.WriteByte('+')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
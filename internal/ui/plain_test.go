@@ -0,0 +1,35 @@
+package ui
+
+import "testing"
+
+func TestPlainText(t *testing.T) {
+	for in, want := range map[string]string{
+		"⚡ Item Details":            "Item Details",
+		"🌍 Select AWS Region":       "Select AWS Region",
+		"✓ Saved":                   "OK: Saved",
+		"✗ Failed to copy: x":       "Error: Failed to copy: x",
+		"⚠️ Table busy":             "Warning: Table busy",
+		"╭──╮\n│ a│\n╰──╯":          "    \n  a \n    ",
+		" | 🔒 Read-only":            " | Read-only",
+		"\x1b[1mplain ascii\x1b[0m": "\x1b[1mplain ascii\x1b[0m",
+	} {
+		if got := PlainText(in); got != want {
+			t.Errorf("PlainText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestASCIIText(t *testing.T) {
+	for in, want := range map[string]string{
+		"▸ Users":                 "> Users",
+		"◀ id │ name ▶":           "< id | name >",
+		" 🌍 us-east-1 (3 tables)": " us-east-1 (3 tables)",
+		"╭──╮\n╰──╯":              "+--+\n+--+",
+		"✓ Saved":                 "+ Saved",
+		"José ↑↓":                 "José ^v",
+	} {
+		if got := ASCIIText(in); got != want {
+			t.Errorf("ASCIIText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
-// Theme colors - Cyberpunk/Neon aesthetic
+// Theme colors - Cyberpunk/Neon aesthetic by default, see SetTheme
 var (
 	// Primary colors
 	ColorPrimary   = lipgloss.Color("#00FFFF") // Cyan
@@ -25,223 +30,354 @@ var (
 	ColorTextBright = lipgloss.Color("#FFFFFF") // White
 )
 
-// Styles
+// Theme is a palette the styles are built from
+type Theme struct {
+	Primary, Secondary, Accent, Success, Error, Warning lipgloss.Color
+	Bg, BgLight, BgHighlight                            lipgloss.Color
+	Text, TextMuted, TextBright                         lipgloss.Color
+}
+
+// Themes are the palettes SetTheme accepts by name
+var Themes = map[string]Theme{
+	"neon": {
+		Primary: ColorPrimary, Secondary: ColorSecondary, Accent: ColorAccent,
+		Success: ColorSuccess, Error: ColorError, Warning: ColorWarning,
+		Bg: ColorBg, BgLight: ColorBgLight, BgHighlight: ColorBgHighlight,
+		Text: ColorText, TextMuted: ColorTextMuted, TextBright: ColorTextBright,
+	},
+	// For terminals with a light background
+	"light": {
+		Primary: "#005F87", Secondary: "#AF005F", Accent: "#875F00",
+		Success: "#007700", Error: "#D70000", Warning: "#AF5F00",
+		Bg: "#FFFFFF", BgLight: "#EEEEEE", BgHighlight: "#D0E4F5",
+		Text: "#1C1C1C", TextMuted: "#6C6C6C", TextBright: "#000000",
+	},
+	// Muted tones that are easier on the eyes than neon
+	"nord": {
+		Primary: "#88C0D0", Secondary: "#B48EAD", Accent: "#EBCB8B",
+		Success: "#A3BE8C", Error: "#BF616A", Warning: "#D08770",
+		Bg: "#2E3440", BgLight: "#3B4252", BgHighlight: "#434C5E",
+		Text: "#D8DEE9", TextMuted: "#7B88A1", TextBright: "#ECEFF4",
+	},
+	// Bright on black and nothing dim, for low vision; accessible mode uses it
+	"high-contrast": {
+		Primary: "#FFFF00", Secondary: "#00FFFF", Accent: "#FFFFFF",
+		Success: "#00FF00", Error: "#FF6060", Warning: "#FFB000",
+		Bg: "#000000", BgLight: "#000000", BgHighlight: "#0000C0",
+		Text: "#FFFFFF", TextMuted: "#E0E0E0", TextBright: "#FFFFFF",
+	},
+}
+
+// SetTheme switches every color and style to the named theme
+func SetTheme(name string) error {
+	t, ok := Themes[name]
+	if !ok {
+		names := make([]string, 0, len(Themes))
+		for n := range Themes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown theme %q (have %s)", name, strings.Join(names, ", "))
+	}
+	ColorPrimary, ColorSecondary, ColorAccent = t.Primary, t.Secondary, t.Accent
+	ColorSuccess, ColorError, ColorWarning = t.Success, t.Error, t.Warning
+	ColorBg, ColorBgLight, ColorBgHighlight = t.Bg, t.BgLight, t.BgHighlight
+	ColorText, ColorTextMuted, ColorTextBright = t.Text, t.TextMuted, t.TextBright
+	buildStyles()
+	return nil
+}
+
+var (
+	noColor      bool
+	colorProfile termenv.Profile
+)
+
+// SetNoColor drops every color, as NO_COLOR asks, or brings them back.
+// Selections and search matches are shown in reverse video instead.
+func SetNoColor(on bool) {
+	if on == noColor {
+		return
+	}
+	if on {
+		colorProfile = lipgloss.ColorProfile()
+		lipgloss.SetColorProfile(termenv.Ascii)
+	} else {
+		lipgloss.SetColorProfile(colorProfile)
+	}
+	noColor = on
+	buildStyles()
+}
+
+// Styles, rebuilt from the colors whenever the theme changes
 var (
+	AppStyle                   lipgloss.Style
+	TitleStyle                 lipgloss.Style
+	LogoStyle                  lipgloss.Style
+	SidebarStyle               lipgloss.Style
+	ContentStyle               lipgloss.Style
+	ContentNoBorderStyle       lipgloss.Style
+	SelectedStyle              lipgloss.Style
+	ItemStyle                  lipgloss.Style
+	TableHeaderStyle           lipgloss.Style
+	TableCellStyle             lipgloss.Style
+	TableCellSelectedStyle     lipgloss.Style
+	StatusBarStyle             lipgloss.Style
+	HelpStyle                  lipgloss.Style
+	KeyStyle                   lipgloss.Style
+	DescStyle                  lipgloss.Style
+	ErrorStyle                 lipgloss.Style
+	SuccessStyle               lipgloss.Style
+	WarningStyle               lipgloss.Style
+	InfoPanelStyle             lipgloss.Style
+	InputStyle                 lipgloss.Style
+	InputFocusedStyle          lipgloss.Style
+	ButtonStyle                lipgloss.Style
+	ButtonFocusedStyle         lipgloss.Style
+	BadgeStyle                 lipgloss.Style
+	TypeStyle                  lipgloss.Style
+	ModalStyle                 lipgloss.Style
+	DividerStyle               lipgloss.Style
+	TabStyle                   lipgloss.Style
+	TabActiveStyle             lipgloss.Style
+	JSONKeyStyle               lipgloss.Style
+	JSONStringStyle            lipgloss.Style
+	JSONNumberStyle            lipgloss.Style
+	JSONBoolStyle              lipgloss.Style
+	JSONNullStyle              lipgloss.Style
+	SearchHighlightStyle       lipgloss.Style
+	SearchActiveHighlightStyle lipgloss.Style
+)
+
+func init() { buildStyles() }
+
+// buildStyles derives every style from the current colors
+func buildStyles() {
 	// App container
 	AppStyle = lipgloss.NewStyle().
-			Background(ColorBg)
+		Background(ColorBg)
 
 	// Title bar
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			Background(ColorBgLight).
-			Padding(0, 2).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		Background(ColorBgLight).
+		Padding(0, 2).
+		MarginBottom(1)
 
 	// Logo/Brand
 	LogoStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorSecondary).
-			Background(ColorBgLight).
-			Padding(1, 4).
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(ColorPrimary)
+		Bold(true).
+		Foreground(ColorSecondary).
+		Background(ColorBgLight).
+		Padding(1, 4).
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorPrimary)
 
 	// Sidebar
 	SidebarStyle = lipgloss.NewStyle().
-			Width(30).
-			Padding(1, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Background(ColorBgLight)
+		Width(30).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Background(ColorBgLight)
 
 	// Main content area
 	ContentStyle = lipgloss.NewStyle().
-			Padding(1, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorSecondary)
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorSecondary)
 
 	// Content area without borders (for clean copy/paste with mouse)
 	ContentNoBorderStyle = lipgloss.NewStyle().
-				Padding(1, 2)
+		Padding(1, 2)
 
 	// Selected item
 	SelectedStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorBg).
-			Background(ColorPrimary).
-			Padding(0, 1)
+		Bold(true).
+		Foreground(ColorBg).
+		Background(ColorPrimary).
+		Padding(0, 1)
 
 	// Normal list item
 	ItemStyle = lipgloss.NewStyle().
-			Foreground(ColorText).
-			Padding(0, 1)
+		Foreground(ColorText).
+		Padding(0, 1)
 
 	// Table header
 	TableHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorSecondary).
-				Background(ColorBgLight).
-				Padding(0, 1).
-				Border(lipgloss.NormalBorder(), false, false, true, false).
-				BorderForeground(ColorPrimary)
+		Bold(true).
+		Foreground(ColorSecondary).
+		Background(ColorBgLight).
+		Padding(0, 1).
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(ColorPrimary)
 
 	// Table cell
 	TableCellStyle = lipgloss.NewStyle().
-			Foreground(ColorText).
-			Padding(0, 1)
+		Foreground(ColorText).
+		Padding(0, 1)
 
 	// Table cell selected
 	TableCellSelectedStyle = lipgloss.NewStyle().
-				Foreground(ColorBg).
-				Background(ColorPrimary).
-				Padding(0, 1)
+		Foreground(ColorBg).
+		Background(ColorPrimary).
+		Padding(0, 1)
 
 	// Status bar
 	StatusBarStyle = lipgloss.NewStyle().
-			Foreground(ColorText).
-			Background(ColorBgLight).
-			Padding(0, 2)
+		Foreground(ColorText).
+		Background(ColorBgLight).
+		Padding(0, 2)
 
 	// Help text
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(ColorTextMuted).
-			Italic(true)
+		Foreground(ColorTextMuted).
+		Italic(true)
 
 	// Key binding
 	KeyStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	// Description
 	DescStyle = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	// Error message
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorError).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorError).
+		Bold(true).
+		Padding(0, 1)
 
 	// Success message
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(ColorSuccess).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorSuccess).
+		Bold(true).
+		Padding(0, 1)
 
 	// Warning message
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(ColorWarning).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorWarning).
+		Bold(true).
+		Padding(0, 1)
 
 	// Info panel
 	InfoPanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorAccent).
-			Padding(1, 2).
-			MarginTop(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorAccent).
+		Padding(1, 2).
+		MarginTop(1)
 
 	// Input field
 	InputStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(0, 1)
 
 	// Focused input
 	InputFocusedStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorSecondary).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorSecondary).
+		Padding(0, 1)
 
 	// Button
 	ButtonStyle = lipgloss.NewStyle().
-			Foreground(ColorText).
-			Background(ColorBgLight).
-			Padding(0, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorTextMuted)
+		Foreground(ColorText).
+		Background(ColorBgLight).
+		Padding(0, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorTextMuted)
 
 	// Button focused
 	ButtonFocusedStyle = lipgloss.NewStyle().
-				Foreground(ColorBg).
-				Background(ColorPrimary).
-				Bold(true).
-				Padding(0, 2).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorPrimary)
+		Foreground(ColorBg).
+		Background(ColorPrimary).
+		Bold(true).
+		Padding(0, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary)
 
 	// Badge/Tag
 	BadgeStyle = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorSecondary).
-			Padding(0, 1).
-			Bold(true)
+		Foreground(ColorBg).
+		Background(ColorSecondary).
+		Padding(0, 1).
+		Bold(true)
 
 	// Type indicator
 	TypeStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	// Modal
 	ModalStyle = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(ColorPrimary).
-			Background(ColorBgLight).
-			Padding(2, 4)
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorPrimary).
+		Background(ColorBgLight).
+		Padding(2, 4)
 
 	// Divider
 	DividerStyle = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	// Tab inactive
 	TabStyle = lipgloss.NewStyle().
-			Foreground(ColorTextMuted).
-			Padding(0, 2).
-			Border(lipgloss.RoundedBorder(), true, true, false, true).
-			BorderForeground(ColorTextMuted)
+		Foreground(ColorTextMuted).
+		Padding(0, 2).
+		Border(lipgloss.RoundedBorder(), true, true, false, true).
+		BorderForeground(ColorTextMuted)
 
 	// Tab active
 	TabActiveStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true).
-			Padding(0, 2).
-			Border(lipgloss.RoundedBorder(), true, true, false, true).
-			BorderForeground(ColorPrimary)
+		Foreground(ColorPrimary).
+		Bold(true).
+		Padding(0, 2).
+		Border(lipgloss.RoundedBorder(), true, true, false, true).
+		BorderForeground(ColorPrimary)
 
 	// JSON Key
 	JSONKeyStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary)
+		Foreground(ColorSecondary)
 
 	// JSON String
 	JSONStringStyle = lipgloss.NewStyle().
-			Foreground(ColorSuccess)
+		Foreground(ColorSuccess)
 
 	// JSON Number
 	JSONNumberStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent)
+		Foreground(ColorAccent)
 
 	// JSON Boolean
 	JSONBoolStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary)
+		Foreground(ColorPrimary)
 
 	// JSON Null
 	JSONNullStyle = lipgloss.NewStyle().
-			Foreground(ColorTextMuted).
-			Italic(true)
+		Foreground(ColorTextMuted).
+		Italic(true)
 
 	// Search Highlight
 	SearchHighlightStyle = lipgloss.NewStyle().
-				Background(ColorBgHighlight).
-				Foreground(ColorWarning)
+		Background(ColorBgHighlight).
+		Foreground(ColorWarning)
 
 	// Active Search Highlight
 	SearchActiveHighlightStyle = lipgloss.NewStyle().
-					Background(ColorWarning).
-					Foreground(ColorBg).
-					Bold(true)
-)
+		Background(ColorWarning).
+		Foreground(ColorBg).
+		Bold(true)
+
+	if noColor {
+		// without colors, only reverse video and underlines set these apart
+		SelectedStyle = SelectedStyle.Reverse(true)
+		TableCellSelectedStyle = TableCellSelectedStyle.Reverse(true)
+		ButtonFocusedStyle = ButtonFocusedStyle.Reverse(true)
+		BadgeStyle = BadgeStyle.Reverse(true)
+		TabActiveStyle = TabActiveStyle.Underline(true)
+		SearchHighlightStyle = SearchHighlightStyle.Underline(true)
+		SearchActiveHighlightStyle = SearchActiveHighlightStyle.Reverse(true)
+	}
+}
 
 // RenderHelp renders a help line with key bindings
 func RenderHelp(bindings []KeyBinding) string {
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestSetThemeRebuildsStyles(t *testing.T) {
+	defer SetTheme("neon")
+	if err := SetTheme("light"); err != nil {
+		t.Fatal(err)
+	}
+	if ColorPrimary != Themes["light"].Primary || TitleStyle.GetForeground() != Themes["light"].Primary {
+		t.Errorf("title foreground = %v, want the light primary", TitleStyle.GetForeground())
+	}
+	if err := SetTheme("plaid"); err == nil {
+		t.Error("an unknown theme should be refused")
+	}
+	if ColorPrimary != Themes["light"].Primary {
+		t.Error("a refused theme must leave the colors alone")
+	}
+}
+
+func TestSetNoColor(t *testing.T) {
+	before := lipgloss.ColorProfile()
+	SetNoColor(true)
+	if lipgloss.ColorProfile() != termenv.Ascii || !SelectedStyle.GetReverse() {
+		t.Error("NO_COLOR should drop colors and show the selection in reverse video")
+	}
+	SetNoColor(false)
+	if lipgloss.ColorProfile() != before || SelectedStyle.GetReverse() {
+		t.Error("turning it off should restore the colors")
+	}
+}
@@ -246,6 +246,10 @@ type Model struct {
 	// promptWidth is the width of the prompt.
 	promptWidth int
 
+	// Highlighter, if set, returns a style for every rune of a logical line,
+	// derived from base (the line's normal style). Selection still wins.
+	Highlighter func(line []rune, base lipgloss.Style) []lipgloss.Style
+
 	// width is the maximum number of characters that can be displayed at once.
 	// If 0 or less this setting is ignored.
 	width int
@@ -1122,6 +1126,11 @@ func (m Model) View() string {
 			style = m.style.computedText()
 		}
 
+		var lineStyles []lipgloss.Style
+		if m.Highlighter != nil {
+			lineStyles = m.Highlighter(line, style)
+		}
+
 		runningCol := 0
 		for wl, wrappedLine := range wrappedLines {
 			prompt := m.getPromptString(displayLine)
@@ -1202,7 +1211,7 @@ func (m Model) View() string {
 				}
 			}
 
-			if !intersects {
+			if !intersects && lineStyles == nil {
 				// Fast path
 				if m.row == l && lineInfo.RowOffset == wl {
 					s.WriteString(style.Render(string(wrappedLine[:lineInfo.ColumnOffset])))
@@ -1218,7 +1227,7 @@ func (m Model) View() string {
 					s.WriteString(style.Render(string(wrappedLine)))
 				}
 			} else {
-				// Slow path: split by selection
+				// Slow path: style each rune for the selection or highlighter
 				// We iterate runes to apply styles
 				for i, r := range wrappedLine {
 					absCol := wlStart + i
@@ -1241,6 +1250,9 @@ func (m Model) View() string {
 					}
 
 					charStyle := style
+					if absCol < len(lineStyles) {
+						charStyle = lineStyles[absCol]
+					}
 					if selected {
 						charStyle = m.style.Selection
 					}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// renderYAMLNode writes a non-empty map or list as block YAML. When inline is
+// set the caller has already written the first line's prefix (a "- " list
+// marker), so the first entry must not be indented again.
+func (j *JSONViewer) renderYAMLNode(sb *strings.Builder, v interface{}, indent int, path string, inline bool) {
+	pad := strings.Repeat(" ", indent)
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for i, k := range keys {
+			if i > 0 || !inline {
+				j.write(sb, pad)
+			}
+			j.write(sb, JSONKeyStyle.Render(j.highlightText(yamlString(k))))
+			j.write(sb, ":")
+			j.renderYAMLChild(sb, val[k], indent+j.Indent, fmt.Sprintf("%s.%s", path, k), " ", "\n")
+		}
+
+	case []interface{}:
+		for i, item := range val {
+			if i > 0 || !inline {
+				j.write(sb, pad)
+			}
+			j.write(sb, "- ")
+			j.renderYAMLChild(sb, item, indent+2, fmt.Sprintf("%s[%d]", path, i), "", "")
+		}
+	}
+}
+
+// renderYAMLChild writes the value after a "key:" or "- " prefix. Nested
+// containers continue in block style (after nestSep, on a new line for maps or
+// inline for list items); everything else is written as a scalar after
+// scalarSep.
+func (j *JSONViewer) renderYAMLChild(sb *strings.Builder, v interface{}, indent int, path, scalarSep, nestSep string) {
+	if yamlNested(v) && !j.Collapsed[path] {
+		j.write(sb, nestSep)
+		j.renderYAMLNode(sb, v, indent, path, nestSep == "")
+		return
+	}
+	j.write(sb, scalarSep)
+	j.renderYAMLScalar(sb, v, path)
+	j.write(sb, "\n")
+}
+
+func (j *JSONViewer) renderYAMLScalar(sb *strings.Builder, v interface{}, path string) {
+	switch val := v.(type) {
+	case string:
+		j.write(sb, JSONStringStyle.Render(j.highlightText(yamlString(val))))
+	case map[string]interface{}:
+		if len(val) == 0 {
+			j.write(sb, "{}")
+		} else {
+			j.write(sb, fmt.Sprintf("{...} %s", HelpStyle.Render(fmt.Sprintf("(%d keys)", len(val)))))
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			j.write(sb, "[]")
+		} else {
+			j.write(sb, fmt.Sprintf("[...] %s", HelpStyle.Render(fmt.Sprintf("(%d items)", len(val)))))
+		}
+	default:
+		// Numbers, booleans and null render the same as in JSON
+		j.renderNode(sb, v, 0, path)
+	}
+}
+
+func yamlNested(v interface{}) bool {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return len(val) > 0
+	case []interface{}:
+		return len(val) > 0
+	}
+	return false
+}
+
+// yamlString returns s as a YAML scalar: plain when that reads back as the
+// same string, otherwise double-quoted (JSON quoting is valid YAML).
+func yamlString(s string) string {
+	if s != "" && !strings.Contains(s, "\n") {
+		if out, err := yaml.Marshal(s); err == nil {
+			plain := strings.TrimSuffix(string(out), "\n")
+			if plain == s {
+				return s
+			}
+		}
+	}
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
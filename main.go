@@ -1,8 +1,11 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/godynamo/internal/app"
@@ -14,25 +17,50 @@ type mode int
 const (
 	modeGUI mode = iota
 	modeTUI
+	modeServe
 )
 
+// startupFlags say where the terminal UI connects and what it opens; starting
+// with one of them selects the terminal UI
+var startupFlags = []string{"table", "endpoint", "local", "region", "profile"}
+
 // selectMode decides which interface to launch from the CLI args (os.Args[1:]).
-// Default is the GUI; `tui` selects the terminal UI; `gui` is an accepted alias
-// for the default and is stripped so trailing flags pass through to gui.Run.
+// Default is the GUI; `tui` selects the terminal UI, as does starting with
+// one of startupFlags; `serve` runs the HTTP/JSON API alone; `gui` is an
+// accepted alias for the default and is stripped so trailing flags pass
+// through to gui.Run.
 func selectMode(args []string) (mode, []string) {
 	if len(args) > 0 && args[0] == "tui" {
 		return modeTUI, args[1:]
 	}
+	if len(args) > 0 && args[0] == "serve" {
+		return modeServe, args[1:]
+	}
+	if len(args) > 0 && isStartupFlag(args[0]) {
+		return modeTUI, args // a link to a table opens in the terminal UI
+	}
 	if len(args) > 0 && args[0] == "gui" {
 		return modeGUI, args[1:]
 	}
 	return modeGUI, args
 }
 
+func isStartupFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return strings.HasPrefix(arg, "-") && slices.Contains(startupFlags, name)
+}
+
 func main() {
 	m, rest := selectMode(os.Args[1:])
-	if m == modeTUI {
-		runTUI()
+	switch m {
+	case modeTUI:
+		runTUI(rest)
+		return
+	case modeServe:
+		if err := gui.Serve(rest); err != nil && err != flag.ErrHelp {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		return
 	}
 	if err := gui.Run(rest); err != nil {
@@ -41,10 +69,70 @@ func main() {
 	}
 }
 
+// tuiConfig loads the config file, then applies GODYNAMO_* environment
+// variables and the command line flags over it
+func tuiConfig(args []string) (app.Config, error) {
+	cfg, err := app.LoadConfig()
+	if err != nil {
+		return cfg, err
+	}
+	if err := cfg.ApplyEnv(); err != nil {
+		return cfg, err
+	}
+	cfg.Setup = app.FirstRun()
+	fs := flag.NewFlagSet("godynamo tui", flag.ContinueOnError)
+	pageSize := fs.Int("page-size", int(cfg.PageSize), "items loaded per page")
+	fs.StringVar(&cfg.Region, "region", cfg.Region, "region to connect to instead of the first with tables")
+	fs.StringVar(&cfg.Theme, "theme", cfg.Theme, "color theme: neon, light, nord or high-contrast")
+	fs.StringVar(&cfg.ExportDir, "export-dir", cfg.ExportDir, "directory exports are written to")
+	fs.BoolVar(&cfg.ConfirmDelete, "confirm-delete", cfg.ConfirmDelete, "ask before deleting an item")
+	fs.BoolVar(&cfg.ConfirmSave, "confirm-save", cfg.ConfirmSave, "ask before saving an item")
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "plan changes in a panel instead of making them")
+	fs.BoolVar(&cfg.Accessible, "accessible", cfg.Accessible, "high contrast and plain text for screen readers")
+	fs.StringVar(&cfg.Endpoint, "endpoint", cfg.Endpoint, "DynamoDB-compatible endpoint to connect to, e.g. DynamoDB Local")
+	local := fs.Bool("local", false, "connect to DynamoDB Local at "+app.LocalEndpoint)
+	fs.StringVar(&cfg.Profile, "profile", cfg.Profile, "AWS profile to use instead of the default credential chain")
+	fs.StringVar(&cfg.Table, "table", "", "open this table once connected")
+	fs.StringVar(&cfg.Key, "key", "", "with --table, open the item with this key, e.g. pk=123,sk=2024")
+	fs.BoolVar(&cfg.NoColor, "no-color", cfg.NoColor, "draw without colors")
+	fs.BoolVar(&cfg.ASCII, "ascii", cfg.ASCII, "draw arrows, marks and borders in ASCII")
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	cfg.PageSize = int32(*pageSize)
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if *local {
+		if set["endpoint"] {
+			return cfg, fmt.Errorf("--local and --endpoint both say where to connect; give one")
+		}
+		cfg.Endpoint = app.LocalEndpoint
+	}
+	// Told where to connect, the first run goes there instead of the wizard
+	for _, name := range startupFlags {
+		if set[name] {
+			cfg.Setup = false
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
 // runTUI launches the Bubble Tea terminal UI (mouse capture stays off so text
 // selection works in the terminal).
-func runTUI() {
-	model := app.New()
+func runTUI(args []string) {
+	cfg, err := tuiConfig(args)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	model, err := app.NewWithConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
 	p := tea.NewProgram(
 		model,
 		tea.WithAltScreen(),
@@ -3,6 +3,8 @@ package main
 import (
 	"reflect"
 	"testing"
+
+	"github.com/godynamo/internal/app"
 )
 
 func TestSelectMode(t *testing.T) {
@@ -18,7 +20,12 @@ func TestSelectMode(t *testing.T) {
 		{"gui with flags", []string{"gui", "--port", "9"}, modeGUI, []string{"--port", "9"}},
 		{"tui", []string{"tui"}, modeTUI, []string{}},
 		{"tui with extra", []string{"tui", "x"}, modeTUI, []string{"x"}},
+		{"serve", []string{"serve", "--addr", ":9"}, modeServe, []string{"--addr", ":9"}},
 		{"unknown arg", []string{"xyz"}, modeGUI, []string{"xyz"}},
+		{"deep link", []string{"--table", "orders", "--key", "pk=1"}, modeTUI, []string{"--table", "orders", "--key", "pk=1"}},
+		{"local", []string{"--local", "--table", "orders"}, modeTUI, []string{"--local", "--table", "orders"}},
+		{"endpoint", []string{"-endpoint=http://localhost:4566"}, modeTUI, []string{"-endpoint=http://localhost:4566"}},
+		{"other flag", []string{"--port", "9"}, modeGUI, []string{"--port", "9"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -32,3 +39,59 @@ func TestSelectMode(t *testing.T) {
 		})
 	}
 }
+
+func TestTUIConfigFlagsOverrideEnvAndDefaults(t *testing.T) {
+	// No config file in an empty config directory
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	t.Setenv("GODYNAMO_REGION", "eu-west-1")
+	t.Setenv("GODYNAMO_READONLY", "true")
+
+	cfg, err := tuiConfig([]string{"--page-size", "200", "--region", "us-west-2", "--confirm-delete=false", "--dry-run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PageSize != 200 || cfg.Region != "us-west-2" || cfg.ConfirmDelete || !cfg.ConfirmSave || !cfg.ReadOnly || !cfg.DryRun {
+		t.Errorf("config = %+v", cfg)
+	}
+	if _, err := tuiConfig([]string{"--theme", "plaid"}); err == nil {
+		t.Error("an unknown theme should be refused")
+	}
+}
+
+func TestTUIConfigConnectionFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+
+	cfg, err := tuiConfig(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Setup {
+		t.Fatal("without a config file the first run starts the wizard")
+	}
+
+	cfg, err = tuiConfig([]string{"--local", "--profile", "dev", "--table", "orders"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Endpoint != app.LocalEndpoint || cfg.Profile != "dev" || cfg.Table != "orders" || cfg.Setup {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	cfg, err = tuiConfig([]string{"--endpoint", "http://localhost:4566"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Endpoint != "http://localhost:4566" || cfg.Setup {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	if _, err := tuiConfig([]string{"--local", "--endpoint", "http://localhost:4566"}); err == nil {
+		t.Error("--local and --endpoint together should be refused")
+	}
+}
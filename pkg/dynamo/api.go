@@ -0,0 +1,54 @@
+package dynamo
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// API is every operation the TUI performs against a table. *Client
+// implements it over AWS; dynamotest.Fake implements it in memory, so the
+// TUI's workflows can be tested without an account.
+type API interface {
+	// Tables and their schema
+	ListTables(ctx context.Context) ([]string, error)
+	DescribeTable(ctx context.Context, tableName string) (*TableInfo, error)
+	RefreshTable(ctx context.Context, tableName string) (*TableInfo, error)
+	InvalidateSchemaCache()
+	CreateTable(ctx context.Context, input CreateTableInput) error
+	Ping(ctx context.Context) (time.Duration, error)
+
+	// Reads
+	ScanTable(ctx context.Context, input ScanInput, opts ScanOptions) (*ScanResult, error)
+	ScanTableContinuous(ctx context.Context, input ScanInput, opts ScanOptions) (*ContinuousScanResult, error)
+	ParallelScan(ctx context.Context, tableName string, segments int, onPage func(ScanPage) error) error
+	SampleScan(ctx context.Context, input SampleInput) (*SampleResult, error)
+	QueryTable(ctx context.Context, input QueryInput) (*QueryResult, error)
+	GetItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (map[string]types.AttributeValue, error)
+	ExecuteStatement(ctx context.Context, statement string, nextToken *string, limit int32) (*StatementResult, error)
+
+	// Writes
+	PutItem(ctx context.Context, tableName string, item map[string]types.AttributeValue) error
+	DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (bool, error)
+	WriteItems(ctx context.Context, tableName string, items []map[string]types.AttributeValue) error
+	RenameAttribute(ctx context.Context, in RenameInput, onPage func(RenameProgress)) (RenameProgress, error)
+	CopyTable(ctx context.Context, in CopyInput, onPage func(CopyProgress)) (CopyProgress, error)
+	VerifyCopy(ctx context.Context, in VerifyInput) (VerifyReport, error)
+
+	// Table settings and the services around DynamoDB
+	DescribeAutoScaling(ctx context.Context, info *TableInfo) ([]ScalingTarget, error)
+	UpdateAutoScaling(ctx context.Context, table string, t ScalingTarget) error
+	DescribeContributorInsights(ctx context.Context, tableName string) (*ContributorInsights, error)
+	SetContributorInsights(ctx context.Context, tableName string, enable bool) (string, error)
+	TopContributors(ctx context.Context, rule string, window time.Duration, limit int32) (*InsightReport, error)
+	ResourcePolicy(ctx context.Context, arn string) (string, error)
+	StartS3Export(ctx context.Context, input S3ExportInput) (*S3Export, error)
+	DescribeS3Export(ctx context.Context, exportArn string) (*S3Export, error)
+	StartS3Import(ctx context.Context, input S3ImportInput) (*S3Import, error)
+	DescribeS3Import(ctx context.Context, importArn string) (*S3Import, error)
+	ListStreamShards(ctx context.Context, streamArn string) ([]StreamShard, error)
+	OpenStream(ctx context.Context, streamArn string, shards []string, start StreamStart, at time.Time) (*StreamReader, error)
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,210 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	astypes "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+)
+
+// autoScalingAPI is the subset of the Application Auto Scaling client used here
+type autoScalingAPI interface {
+	DescribeScalableTargets(context.Context, *applicationautoscaling.DescribeScalableTargetsInput, ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalableTargetsOutput, error)
+	DescribeScalingPolicies(context.Context, *applicationautoscaling.DescribeScalingPoliciesInput, ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalingPoliciesOutput, error)
+	RegisterScalableTarget(context.Context, *applicationautoscaling.RegisterScalableTargetInput, ...func(*applicationautoscaling.Options)) (*applicationautoscaling.RegisterScalableTargetOutput, error)
+	PutScalingPolicy(context.Context, *applicationautoscaling.PutScalingPolicyInput, ...func(*applicationautoscaling.Options)) (*applicationautoscaling.PutScalingPolicyOutput, error)
+}
+
+var _ autoScalingAPI = (*applicationautoscaling.Client)(nil)
+
+// DynamoDB's target tracking accepts utilizations in this range
+const (
+	MinTargetUtilization = 20
+	MaxTargetUtilization = 90
+)
+
+// ScalingTarget is the auto scaling of the read or write capacity of a
+// table or one of its GSIs.
+type ScalingTarget struct {
+	Index             string // "" for the table itself
+	Write             bool   // write capacity; read capacity otherwise
+	Min, Max          int32
+	Policy            string  // target tracking policy name, "" if there is none
+	TargetUtilization float64 // percent of provisioned capacity, 0 without a policy
+
+	// tracking is the policy as read, so updating the utilization keeps its
+	// cooldowns and scale-in setting
+	tracking *astypes.TargetTrackingScalingPolicyConfiguration
+}
+
+// Validate checks the capacity range and utilization before they are sent
+func (t ScalingTarget) Validate() error {
+	switch {
+	case t.Min < 1:
+		return fmt.Errorf("minimum capacity must be at least 1")
+	case t.Max < t.Min:
+		return fmt.Errorf("maximum capacity must be at least the minimum")
+	case t.TargetUtilization != 0 && (t.TargetUtilization < MinTargetUtilization || t.TargetUtilization > MaxTargetUtilization):
+		return fmt.Errorf("target utilization must be between %d and %d%%", MinTargetUtilization, MaxTargetUtilization)
+	}
+	return nil
+}
+
+func (t ScalingTarget) resourceID(table string) string {
+	if t.Index == "" {
+		return "table/" + table
+	}
+	return "table/" + table + "/index/" + t.Index
+}
+
+func (t ScalingTarget) dimension() astypes.ScalableDimension {
+	switch {
+	case t.Index == "" && t.Write:
+		return astypes.ScalableDimensionDynamoDBTableWriteCapacityUnits
+	case t.Index == "":
+		return astypes.ScalableDimensionDynamoDBTableReadCapacityUnits
+	case t.Write:
+		return astypes.ScalableDimensionDynamoDBIndexWriteCapacityUnits
+	}
+	return astypes.ScalableDimensionDynamoDBIndexReadCapacityUnits
+}
+
+func (t ScalingTarget) metric() astypes.MetricType {
+	if t.Write {
+		return astypes.MetricTypeDynamoDBWriteCapacityUtilization
+	}
+	return astypes.MetricTypeDynamoDBReadCapacityUtilization
+}
+
+// DescribeAutoScaling returns the scaling targets registered for the table
+// and its GSIs, with their target tracking policies. Capacity that is not
+// auto scaled has no entry.
+func (c *Client) DescribeAutoScaling(ctx context.Context, info *TableInfo) ([]ScalingTarget, error) {
+	ids := []string{"table/" + info.Name}
+	indexOf := map[string]string{ids[0]: ""}
+	for _, gsi := range info.GSIs {
+		id := ScalingTarget{Index: gsi.Name}.resourceID(info.Name)
+		ids = append(ids, id)
+		indexOf[id] = gsi.Name
+	}
+
+	var targets []ScalingTarget
+	input := &applicationautoscaling.DescribeScalableTargetsInput{
+		ServiceNamespace: astypes.ServiceNamespaceDynamodb,
+		ResourceIds:      ids,
+	}
+	for {
+		out, err := c.scaling.DescribeScalableTargets(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe scalable targets: %w", err)
+		}
+		for _, st := range out.ScalableTargets {
+			t := ScalingTarget{
+				Index: indexOf[aws.ToString(st.ResourceId)],
+				Write: st.ScalableDimension == astypes.ScalableDimensionDynamoDBTableWriteCapacityUnits ||
+					st.ScalableDimension == astypes.ScalableDimensionDynamoDBIndexWriteCapacityUnits,
+				Min: aws.ToInt32(st.MinCapacity),
+				Max: aws.ToInt32(st.MaxCapacity),
+			}
+			targets = append(targets, t)
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	// Table before its indexes, read before write
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Index != targets[j].Index {
+			return targets[i].Index < targets[j].Index
+		}
+		return !targets[i].Write && targets[j].Write
+	})
+
+	for i, t := range targets {
+		policy, err := c.scalingPolicy(ctx, info.Name, t)
+		if err != nil {
+			return nil, err
+		}
+		if policy != nil {
+			targets[i].Policy = aws.ToString(policy.PolicyName)
+			targets[i].TargetUtilization = aws.ToFloat64(policy.TargetTrackingScalingPolicyConfiguration.TargetValue)
+			targets[i].tracking = policy.TargetTrackingScalingPolicyConfiguration
+		}
+	}
+	return targets, nil
+}
+
+// scalingPolicy returns the target's target tracking policy, if it has one
+func (c *Client) scalingPolicy(ctx context.Context, table string, t ScalingTarget) (*astypes.ScalingPolicy, error) {
+	input := &applicationautoscaling.DescribeScalingPoliciesInput{
+		ServiceNamespace:  astypes.ServiceNamespaceDynamodb,
+		ResourceId:        aws.String(t.resourceID(table)),
+		ScalableDimension: t.dimension(),
+	}
+	for {
+		out, err := c.scaling.DescribeScalingPolicies(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe scaling policies: %w", err)
+		}
+		for i, p := range out.ScalingPolicies {
+			if p.PolicyType == astypes.PolicyTypeTargetTrackingScaling && p.TargetTrackingScalingPolicyConfiguration != nil {
+				return &out.ScalingPolicies[i], nil
+			}
+		}
+		if out.NextToken == nil {
+			return nil, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// UpdateAutoScaling registers the target's capacity range and, when it has
+// a target utilization, creates or updates its target tracking policy.
+func (c *Client) UpdateAutoScaling(ctx context.Context, table string, t ScalingTarget) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	id := t.resourceID(table)
+	_, err := c.scaling.RegisterScalableTarget(ctx, &applicationautoscaling.RegisterScalableTargetInput{
+		ServiceNamespace:  astypes.ServiceNamespaceDynamodb,
+		ResourceId:        aws.String(id),
+		ScalableDimension: t.dimension(),
+		MinCapacity:       aws.Int32(t.Min),
+		MaxCapacity:       aws.Int32(t.Max),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register scalable target: %w", err)
+	}
+	if t.TargetUtilization == 0 {
+		return nil
+	}
+
+	tracking := astypes.TargetTrackingScalingPolicyConfiguration{
+		PredefinedMetricSpecification: &astypes.PredefinedMetricSpecification{PredefinedMetricType: t.metric()},
+	}
+	if t.tracking != nil {
+		tracking = *t.tracking
+	}
+	tracking.TargetValue = aws.Float64(t.TargetUtilization)
+	name := t.Policy
+	if name == "" {
+		// The name the AWS console gives the policies it creates
+		name = string(t.metric()) + ":" + id
+	}
+	_, err = c.scaling.PutScalingPolicy(ctx, &applicationautoscaling.PutScalingPolicyInput{
+		ServiceNamespace:                         astypes.ServiceNamespaceDynamodb,
+		ResourceId:                               aws.String(id),
+		ScalableDimension:                        t.dimension(),
+		PolicyName:                               aws.String(name),
+		PolicyType:                               astypes.PolicyTypeTargetTrackingScaling,
+		TargetTrackingScalingPolicyConfiguration: &tracking,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put scaling policy: %w", err)
+	}
+	return nil
+}
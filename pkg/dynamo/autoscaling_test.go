@@ -0,0 +1,116 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	astypes "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+)
+
+type scalingFake struct {
+	targets    []astypes.ScalableTarget
+	policies   map[string][]astypes.ScalingPolicy // by resource ID + dimension
+	lastTarget *applicationautoscaling.DescribeScalableTargetsInput
+	registered *applicationautoscaling.RegisterScalableTargetInput
+	put        *applicationautoscaling.PutScalingPolicyInput
+}
+
+func (f *scalingFake) DescribeScalableTargets(_ context.Context, in *applicationautoscaling.DescribeScalableTargetsInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalableTargetsOutput, error) {
+	f.lastTarget = in
+	return &applicationautoscaling.DescribeScalableTargetsOutput{ScalableTargets: f.targets}, nil
+}
+func (f *scalingFake) DescribeScalingPolicies(_ context.Context, in *applicationautoscaling.DescribeScalingPoliciesInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalingPoliciesOutput, error) {
+	return &applicationautoscaling.DescribeScalingPoliciesOutput{
+		ScalingPolicies: f.policies[aws.ToString(in.ResourceId)+" "+string(in.ScalableDimension)],
+	}, nil
+}
+func (f *scalingFake) RegisterScalableTarget(_ context.Context, in *applicationautoscaling.RegisterScalableTargetInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.RegisterScalableTargetOutput, error) {
+	f.registered = in
+	return &applicationautoscaling.RegisterScalableTargetOutput{}, nil
+}
+func (f *scalingFake) PutScalingPolicy(_ context.Context, in *applicationautoscaling.PutScalingPolicyInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.PutScalingPolicyOutput, error) {
+	f.put = in
+	return &applicationautoscaling.PutScalingPolicyOutput{}, nil
+}
+
+func TestDescribeAutoScalingReadsTargetsAndPolicies(t *testing.T) {
+	f := &scalingFake{
+		targets: []astypes.ScalableTarget{
+			{ResourceId: aws.String("table/Users/index/byEmail"), ScalableDimension: astypes.ScalableDimensionDynamoDBIndexReadCapacityUnits, MinCapacity: aws.Int32(1), MaxCapacity: aws.Int32(10)},
+			{ResourceId: aws.String("table/Users"), ScalableDimension: astypes.ScalableDimensionDynamoDBTableWriteCapacityUnits, MinCapacity: aws.Int32(5), MaxCapacity: aws.Int32(50)},
+			{ResourceId: aws.String("table/Users"), ScalableDimension: astypes.ScalableDimensionDynamoDBTableReadCapacityUnits, MinCapacity: aws.Int32(5), MaxCapacity: aws.Int32(100)},
+		},
+		policies: map[string][]astypes.ScalingPolicy{
+			"table/Users dynamodb:table:ReadCapacityUnits": {{
+				PolicyName: aws.String("reads"),
+				PolicyType: astypes.PolicyTypeTargetTrackingScaling,
+				TargetTrackingScalingPolicyConfiguration: &astypes.TargetTrackingScalingPolicyConfiguration{
+					TargetValue: aws.Float64(70), ScaleInCooldown: aws.Int32(300),
+				},
+			}},
+		},
+	}
+	c := &Client{scaling: f}
+	info := &TableInfo{Name: "Users", GSIs: []IndexInfo{{Name: "byEmail"}}}
+	targets, err := c.DescribeAutoScaling(context.Background(), info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.lastTarget.ResourceIds) != 2 {
+		t.Errorf("resource IDs = %v, want the table and its GSI", f.lastTarget.ResourceIds)
+	}
+	if len(targets) != 3 || targets[0].Index != "" || targets[0].Write || !targets[1].Write || targets[2].Index != "byEmail" {
+		t.Fatalf("targets not ordered table read, table write, index: %+v", targets)
+	}
+	if targets[0].Policy != "reads" || targets[0].TargetUtilization != 70 || targets[1].Policy != "" {
+		t.Fatalf("policies = %+v", targets)
+	}
+
+	// Changing the utilization keeps the rest of the policy
+	reads := targets[0]
+	reads.Max, reads.TargetUtilization = 200, 60
+	if err := c.UpdateAutoScaling(context.Background(), "Users", reads); err != nil {
+		t.Fatal(err)
+	}
+	if *f.registered.MaxCapacity != 200 || *f.registered.ResourceId != "table/Users" {
+		t.Errorf("registered %+v", f.registered)
+	}
+	cfg := f.put.TargetTrackingScalingPolicyConfiguration
+	if *f.put.PolicyName != "reads" || *cfg.TargetValue != 60 || aws.ToInt32(cfg.ScaleInCooldown) != 300 {
+		t.Errorf("put policy %s with %+v", *f.put.PolicyName, cfg)
+	}
+}
+
+func TestUpdateAutoScalingNamesNewPolicies(t *testing.T) {
+	f := &scalingFake{}
+	c := &Client{scaling: f}
+	target := ScalingTarget{Index: "byEmail", Write: true, Min: 1, Max: 5, TargetUtilization: 50}
+	if err := c.UpdateAutoScaling(context.Background(), "Users", target); err != nil {
+		t.Fatal(err)
+	}
+	if got := *f.put.PolicyName; got != "DynamoDBWriteCapacityUtilization:table/Users/index/byEmail" {
+		t.Errorf("policy name = %q", got)
+	}
+	if f.put.ScalableDimension != astypes.ScalableDimensionDynamoDBIndexWriteCapacityUnits {
+		t.Errorf("dimension = %s", f.put.ScalableDimension)
+	}
+}
+
+func TestScalingTargetValidate(t *testing.T) {
+	bad := []ScalingTarget{
+		{Min: 0, Max: 5},
+		{Min: 10, Max: 5},
+		{Min: 1, Max: 5, TargetUtilization: 95},
+	}
+	for _, target := range bad {
+		if target.Validate() == nil {
+			t.Errorf("%+v should not validate", target)
+		}
+	}
+	f := &scalingFake{}
+	if err := (&Client{scaling: f}).UpdateAutoScaling(context.Background(), "Users", bad[1]); err == nil || f.registered != nil {
+		t.Error("an invalid target must not reach AWS")
+	}
+}
@@ -0,0 +1,59 @@
+package dynamo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+)
+
+// ErrorDetail is what AWS said about a request it refused. RequestID is
+// what AWS Support asks for when looking into a failure.
+type ErrorDetail struct {
+	Operation  string // e.g. Scan; "" when the SDK didn't name it
+	Code       string // e.g. ProvisionedThroughputExceededException
+	Message    string
+	RequestID  string
+	HTTPStatus int
+}
+
+// DescribeError finds the AWS error in err's chain. ok is false for errors
+// that never got an answer from AWS, such as a timeout or a refused
+// connection.
+func DescribeError(err error) (d ErrorDetail, ok bool) {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return d, false
+	}
+	d.Code, d.Message = apiErr.ErrorCode(), apiErr.ErrorMessage()
+	var opErr *smithy.OperationError
+	if errors.As(err, &opErr) {
+		d.Operation = opErr.OperationName
+	}
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		d.RequestID, d.HTTPStatus = respErr.ServiceRequestID(), respErr.HTTPStatusCode()
+	}
+	return d, true
+}
+
+// String is the detail as lines of "Name: value", for copying into a ticket
+func (d ErrorDetail) String() string {
+	var b strings.Builder
+	for _, f := range []struct{ name, value string }{
+		{"Operation", d.Operation},
+		{"Code", d.Code},
+		{"Message", d.Message},
+		{"Request ID", d.RequestID},
+	} {
+		if f.value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", f.name, f.value)
+		}
+	}
+	if d.HTTPStatus != 0 {
+		fmt.Fprintf(&b, "HTTP status: %d\n", d.HTTPStatus)
+	}
+	return b.String()
+}
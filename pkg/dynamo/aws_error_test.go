@@ -0,0 +1,38 @@
+package dynamo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestDescribeErrorFindsTheAWSError(t *testing.T) {
+	err := fmt.Errorf("failed to scan table: %w", &smithy.OperationError{
+		ServiceID: "DynamoDB", OperationName: "Scan",
+		Err: &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 400}},
+				Err:      &smithy.GenericAPIError{Code: "ValidationException", Message: "bad filter"},
+			},
+			RequestID: "REQ123",
+		},
+	})
+	d, ok := DescribeError(err)
+	want := ErrorDetail{Operation: "Scan", Code: "ValidationException", Message: "bad filter", RequestID: "REQ123", HTTPStatus: 400}
+	if !ok || d != want {
+		t.Fatalf("detail = %+v, %v", d, ok)
+	}
+	if s := d.String(); !strings.Contains(s, "Request ID: REQ123\n") || !strings.Contains(s, "HTTP status: 400") {
+		t.Errorf("String() = %q", s)
+	}
+
+	if _, ok := DescribeError(errors.New("dial tcp: connection refused")); ok {
+		t.Error("an error AWS never answered has no detail")
+	}
+}
@@ -0,0 +1,124 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// batchWriteSize is BatchWriteItem's limit of put requests per call
+const batchWriteSize = 25
+
+// batchWriteRetries bounds the resends of items DynamoDB left unprocessed,
+// usually because the table is throttling; the delay doubles each time
+var (
+	batchWriteRetries = 6
+	batchWriteBackoff = 50 * time.Millisecond
+)
+
+// FailedWrite is an item a bulk write gave up on, and why
+type FailedWrite struct {
+	Item map[string]types.AttributeValue
+	Err  error
+}
+
+// WriteFailures is the error of a bulk write that finished but gave up on
+// some items; the caller can keep them to retry later
+type WriteFailures struct {
+	Failed []FailedWrite
+}
+
+func (e *WriteFailures) Error() string {
+	return fmt.Sprintf("%d items could not be written; first error: %s", len(e.Failed), e.Failed[0].Err)
+}
+
+// WriteItems puts items with BatchWriteItem, 25 at a time, resending the
+// ones DynamoDB returns unprocessed. Existing items with the same key are
+// replaced, as with PutItem.
+//
+// Items still unprocessed after the retries, and those of a batch DynamoDB
+// rejects outright (one invalid item fails its whole batch, so they are
+// then put one by one), don't stop the write: they are returned in a
+// *WriteFailures once the rest is written. Errors that would fail every
+// item, such as a missing table or a cancelled context, stop it at once.
+func (c *Client) WriteItems(ctx context.Context, tableName string, items []map[string]types.AttributeValue) error {
+	var failed []FailedWrite
+	for start := 0; start < len(items); start += batchWriteSize {
+		batch := items[start:min(start+batchWriteSize, len(items))]
+		requests := make([]types.WriteRequest, len(batch))
+		for i, item := range batch {
+			requests[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: item}}
+		}
+		left, err := c.writeBatch(ctx, tableName, requests)
+		switch {
+		case err == nil:
+			for _, r := range left {
+				failed = append(failed, FailedWrite{r.PutRequest.Item, fmt.Errorf("still unprocessed after %d retries; the table may be throttling", batchWriteRetries)})
+			}
+		case fatalWriteError(err):
+			return err
+		default:
+			for _, item := range batch {
+				if _, err := c.db.PutItem(ctx, &dynamodb.PutItemInput{
+					TableName:              aws.String(tableName),
+					Item:                   item,
+					ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+				}); err != nil {
+					if fatalWriteError(err) {
+						return fmt.Errorf("failed to write items: %w", err)
+					}
+					failed = append(failed, FailedWrite{item, err})
+				}
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return &WriteFailures{Failed: failed}
+	}
+	return nil
+}
+
+// writeBatch sends one batch and returns the requests still unprocessed
+// after the retries
+func (c *Client) writeBatch(ctx context.Context, tableName string, requests []types.WriteRequest) ([]types.WriteRequest, error) {
+	delay := batchWriteBackoff
+	for attempt := 0; ; attempt++ {
+		out, err := c.db.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems:           map[string][]types.WriteRequest{tableName: requests},
+			ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write items: %w", err)
+		}
+		requests = out.UnprocessedItems[tableName]
+		if len(requests) == 0 || attempt == batchWriteRetries {
+			return requests, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// fatalWriteError reports whether err would fail any item written to the
+// table, so there is no point in trying the rest
+func fatalWriteError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDeniedException"
+}
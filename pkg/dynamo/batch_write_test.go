@@ -0,0 +1,63 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestWriteItemsBatchesAndResendsUnprocessed(t *testing.T) {
+	batchWriteBackoff = 0
+	items := make([]map[string]types.AttributeValue, 30)
+	for i := range items {
+		items[i] = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: strconv.Itoa(i)}}
+	}
+	f := &fakeAPI{throttled: []int{3}}
+	if err := newTestClient(f).WriteItems(context.Background(), "Users", items); err != nil {
+		t.Fatal(err)
+	}
+	sizes := make([]int, len(f.batches))
+	for i, b := range f.batches {
+		sizes[i] = len(b.RequestItems["Users"])
+	}
+	if len(sizes) != 3 || sizes[0] != 25 || sizes[1] != 3 || sizes[2] != 5 {
+		t.Fatalf("batch sizes = %v, want 25, the 3 unprocessed, then 5", sizes)
+	}
+}
+
+func TestWriteItemsGivesUpWhenThrottled(t *testing.T) {
+	batchWriteBackoff = 0
+	f := &fakeAPI{throttled: []int{1, 1, 1, 1, 1, 1, 1, 1}}
+	items := []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "1"}}}
+	err := newTestClient(f).WriteItems(context.Background(), "Users", items)
+	var wf *WriteFailures
+	if !errors.As(err, &wf) || len(wf.Failed) != 1 {
+		t.Fatalf("items left unprocessed after every retry should be returned as failures, got %v", err)
+	}
+	if len(f.batches) != batchWriteRetries+1 {
+		t.Errorf("%d calls, want %d", len(f.batches), batchWriteRetries+1)
+	}
+}
+
+func TestWriteItemsPutsARejectedBatchOneByOne(t *testing.T) {
+	items := []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "1"}},
+		{"id": &types.AttributeValueMemberS{Value: "2"}},
+	}
+	f := &fakeAPI{batchErr: errors.New("ValidationException: item too large"), putErr: errors.New("ValidationException: item too large")}
+	err := newTestClient(f).WriteItems(context.Background(), "Users", items)
+	var wf *WriteFailures
+	if !errors.As(err, &wf) || len(wf.Failed) != 2 || f.lastPut == nil {
+		t.Fatalf("each item of a rejected batch should be tried and kept, got %v", err)
+	}
+
+	f = &fakeAPI{batchErr: &types.ResourceNotFoundException{Message: aws.String("no table")}}
+	err = newTestClient(f).WriteItems(context.Background(), "Users", items)
+	if err == nil || errors.As(err, &wf) || f.lastPut != nil {
+		t.Fatalf("a missing table should stop the write without per-item puts, got %v", err)
+	}
+}
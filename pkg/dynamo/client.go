@@ -4,14 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sync"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 )
 
 // AWS Regions list
@@ -54,8 +55,8 @@ type RegionInfo struct {
 }
 
 // DiscoverRegionsWithTables scans all regions and returns those with DynamoDB tables
-func DiscoverRegionsWithTables(ctx context.Context, profile string, useLocal bool, endpoint string) ([]RegionInfo, error) {
-	if useLocal {
+func DiscoverRegionsWithTables(ctx context.Context, conn ConnectionConfig) ([]RegionInfo, error) {
+	if conn.UseLocal {
 		// For local DynamoDB, just return a single "local" region
 		cfg, err := config.LoadDefaultConfig(ctx,
 			config.WithRegion("us-east-1"),
@@ -68,7 +69,7 @@ func DiscoverRegionsWithTables(ctx context.Context, profile string, useLocal boo
 		}
 
 		client := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
-			o.BaseEndpoint = aws.String(endpoint)
+			o.BaseEndpoint = aws.String(conn.Endpoint)
 		})
 
 		tables, err := client.ListTables(ctx, &dynamodb.ListTablesInput{})
@@ -84,62 +85,9 @@ func DiscoverRegionsWithTables(ctx context.Context, profile string, useLocal boo
 	}
 
 	var results []RegionInfo
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	// Scan every region concurrently. These are cheap ListTables calls and each
-	// goroutine has its own 8s deadline (below), so a single unreachable region
-	// no longer holds back the others. Sizing the semaphore to the full region
-	// list removes batching, so the worst case is one 8s timeout, not 8s per batch.
-	sem := make(chan struct{}, len(AWSRegions))
-
-	for _, region := range AWSRegions {
-		wg.Add(1)
-		go func(r string) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			// Per-region timeout: an unreachable opt-in region (e.g. me-south-1)
-			// can stall ~66s on SDK retry/backoff and, without a deadline, would
-			// block discovery of every other region. Legit regions answer in <1s,
-			// so 8s leaves a ~10x safety margin while capping dead regions.
-			regionCtx, cancel := context.WithTimeout(ctx, 8*time.Second)
-			defer cancel()
-
-			loadOpts := []func(*config.LoadOptions) error{config.WithRegion(r)}
-			if profile != "" {
-				loadOpts = append(loadOpts, config.WithSharedConfigProfile(profile))
-			}
-			cfg, err := config.LoadDefaultConfig(regionCtx, loadOpts...)
-			if err != nil {
-				return
-			}
-
-			client := dynamodb.NewFromConfig(cfg)
-
-			// Quick check - just get the first page
-			tables, err := client.ListTables(regionCtx, &dynamodb.ListTablesInput{
-				Limit: aws.Int32(100),
-			})
-			if err != nil {
-				return
-			}
-
-			if len(tables.TableNames) > 0 {
-				mu.Lock()
-				results = append(results, RegionInfo{
-					Region:     r,
-					TableCount: len(tables.TableNames),
-					Tables:     tables.TableNames,
-				})
-				mu.Unlock()
-			}
-		}(region)
+	for info := range StreamRegionsWithTables(ctx, conn) {
+		results = append(results, info)
 	}
-
-	wg.Wait()
-
 	return results, nil
 }
 
@@ -153,8 +101,18 @@ type dynamoAPI interface {
 	Query(context.Context, *dynamodb.QueryInput, ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
 	PutItem(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
 	DeleteItem(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	UpdateItem(context.Context, *dynamodb.UpdateItemInput, ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	BatchWriteItem(context.Context, *dynamodb.BatchWriteItemInput, ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
 	CreateTable(context.Context, *dynamodb.CreateTableInput, ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
 	GetItem(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	ExportTableToPointInTime(context.Context, *dynamodb.ExportTableToPointInTimeInput, ...func(*dynamodb.Options)) (*dynamodb.ExportTableToPointInTimeOutput, error)
+	DescribeExport(context.Context, *dynamodb.DescribeExportInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeExportOutput, error)
+	ImportTable(context.Context, *dynamodb.ImportTableInput, ...func(*dynamodb.Options)) (*dynamodb.ImportTableOutput, error)
+	DescribeImport(context.Context, *dynamodb.DescribeImportInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeImportOutput, error)
+	ExecuteStatement(context.Context, *dynamodb.ExecuteStatementInput, ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error)
+	DescribeContributorInsights(context.Context, *dynamodb.DescribeContributorInsightsInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeContributorInsightsOutput, error)
+	UpdateContributorInsights(context.Context, *dynamodb.UpdateContributorInsightsInput, ...func(*dynamodb.Options)) (*dynamodb.UpdateContributorInsightsOutput, error)
+	GetResourcePolicy(context.Context, *dynamodb.GetResourcePolicyInput, ...func(*dynamodb.Options)) (*dynamodb.GetResourcePolicyOutput, error)
 }
 
 // Compile-time guarantee that the real client satisfies the seam (fails fast if
@@ -164,8 +122,12 @@ var _ dynamoAPI = (*dynamodb.Client)(nil)
 // Client wraps the DynamoDB client with helper methods
 type Client struct {
 	db       dynamoAPI
+	streams  streamsAPI
+	insights insightsAPI
+	scaling  autoScalingAPI
 	endpoint string
 	region   string
+	schemas  schemaCache
 }
 
 // ConnectionConfig holds connection settings
@@ -176,28 +138,25 @@ type ConnectionConfig struct {
 	SecretKey string
 	UseLocal  bool
 	Profile   string
+	Network   Network  // transport for proxies and private CAs
+	Stats     *OpStats // records the client's calls when set
 }
 
-// NewClient creates a new DynamoDB client
+// NewClient creates a new DynamoDB client. It loads the AWS config like
+// RegionClient and EndpointClient do.
 func NewClient(cfg ConnectionConfig) (*Client, error) {
-	var opts []func(*config.LoadOptions) error
-
-	opts = append(opts, config.WithRegion(cfg.Region))
-
-	if cfg.Profile != "" {
-		opts = append(opts, config.WithSharedConfigProfile(cfg.Profile))
+	awsCfg, err := loadAWSConfig(context.TODO(), cfg.Profile, cfg.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if cfg.Region != "" {
+		awsCfg.Region = cfg.Region
 	}
-
 	if cfg.UseLocal {
-		opts = append(opts, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""),
-		))
+		awsCfg.Credentials = credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, "")
 	}
 
-	awsCfg, err := config.LoadDefaultConfig(context.TODO(), opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
-	}
+	awsCfg = recordOpStats(awsCfg, cfg.Stats)
 
 	var dbOpts []func(*dynamodb.Options)
 	if cfg.Endpoint != "" {
@@ -208,8 +167,18 @@ func NewClient(cfg ConnectionConfig) (*Client, error) {
 
 	client := dynamodb.NewFromConfig(awsCfg, dbOpts...)
 
+	var streamOpts []func(*dynamodbstreams.Options)
+	if cfg.Endpoint != "" {
+		streamOpts = append(streamOpts, func(o *dynamodbstreams.Options) {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		})
+	}
+
 	return &Client{
 		db:       client,
+		streams:  dynamodbstreams.NewFromConfig(awsCfg, streamOpts...),
+		insights: cloudwatch.NewFromConfig(awsCfg),
+		scaling:  applicationautoscaling.NewFromConfig(awsCfg),
 		endpoint: cfg.Endpoint,
 		region:   cfg.Region,
 	}, nil
@@ -242,6 +211,7 @@ func (c *Client) ListTables(ctx context.Context) ([]string, error) {
 // TableInfo contains table metadata
 type TableInfo struct {
 	Name           string
+	Arn            string
 	Status         string
 	ItemCount      int64
 	SizeBytes      int64
@@ -251,6 +221,11 @@ type TableInfo struct {
 	SortKeyType    string
 	GSIs           []IndexInfo
 	LSIs           []IndexInfo
+	BillingMode    string // PROVISIONED or PAY_PER_REQUEST
+	ReadCapacity   int64  // provisioned units, 0 when on-demand
+	WriteCapacity  int64
+	StreamArn      string // latest stream, "" when streams are off
+	StreamViewType string
 	RawJSON        string // Full JSON response from DescribeTable
 }
 
@@ -260,10 +235,12 @@ type IndexInfo struct {
 	PartitionKey string
 	SortKey      string
 	Status       string
+	Backfilling  bool // a GSI added to an existing table is still being filled
 }
 
-// DescribeTable returns table metadata
-func (c *Client) DescribeTable(ctx context.Context, tableName string) (*TableInfo, error) {
+// describeTable issues DescribeTable and converts the response; see
+// DescribeTable for the cached entry point.
+func (c *Client) describeTable(ctx context.Context, tableName string) (*TableInfo, error) {
 	output, err := c.db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
 		TableName: aws.String(tableName),
 	})
@@ -276,12 +253,23 @@ func (c *Client) DescribeTable(ctx context.Context, tableName string) (*TableInf
 
 	info := &TableInfo{
 		Name:      *output.Table.TableName,
+		Arn:       aws.ToString(output.Table.TableArn),
 		Status:    string(output.Table.TableStatus),
 		ItemCount: *output.Table.ItemCount,
 		SizeBytes: *output.Table.TableSizeBytes,
 		RawJSON:   string(rawJSON),
 	}
 
+	// Tables created before on-demand existed have no billing summary
+	info.BillingMode = string(types.BillingModeProvisioned)
+	if s := output.Table.BillingModeSummary; s != nil && s.BillingMode != "" {
+		info.BillingMode = string(s.BillingMode)
+	}
+	if pt := output.Table.ProvisionedThroughput; pt != nil {
+		info.ReadCapacity = aws.ToInt64(pt.ReadCapacityUnits)
+		info.WriteCapacity = aws.ToInt64(pt.WriteCapacityUnits)
+	}
+
 	// Get key schema
 	for _, key := range output.Table.KeySchema {
 		keyType := ""
@@ -304,8 +292,9 @@ func (c *Client) DescribeTable(ctx context.Context, tableName string) (*TableInf
 	// Get GSIs
 	for _, gsi := range output.Table.GlobalSecondaryIndexes {
 		idx := IndexInfo{
-			Name:   *gsi.IndexName,
-			Status: string(gsi.IndexStatus),
+			Name:        *gsi.IndexName,
+			Status:      string(gsi.IndexStatus),
+			Backfilling: aws.ToBool(gsi.Backfilling),
 		}
 		for _, key := range gsi.KeySchema {
 			if key.KeyType == types.KeyTypeHash {
@@ -332,6 +321,11 @@ func (c *Client) DescribeTable(ctx context.Context, tableName string) (*TableInf
 		info.LSIs = append(info.LSIs, idx)
 	}
 
+	if spec := output.Table.StreamSpecification; spec != nil && aws.ToBool(spec.StreamEnabled) {
+		info.StreamArn = aws.ToString(output.Table.LatestStreamArn)
+		info.StreamViewType = string(spec.StreamViewType)
+	}
+
 	return info, nil
 }
 
@@ -341,29 +335,42 @@ type ScanResult struct {
 	LastEvaluatedKey map[string]types.AttributeValue
 	Count            int32
 	ScannedCount     int32
+	ConsumedCapacity float64 // read capacity units the page cost
+}
+
+// ScanInput contains scan parameters
+type ScanInput struct {
+	TableName                string
+	FilterExpression         string
+	ExpressionAttributeNames map[string]string
+	ExpressionValues         map[string]interface{}
+	Limit                    int32 // page size for ScanTable; items to collect for ScanTableContinuous
+	StartKey                 map[string]types.AttributeValue
 }
 
 // ScanTable performs a scan operation
-func (c *Client) ScanTable(ctx context.Context, tableName string, limit int32, startKey map[string]types.AttributeValue, filterExpression string, expressionNames map[string]string, expressionValues map[string]interface{}) (*ScanResult, error) {
+func (c *Client) ScanTable(ctx context.Context, in ScanInput, opts ScanOptions) (*ScanResult, error) {
 	input := &dynamodb.ScanInput{
-		TableName: aws.String(tableName),
-		Limit:     aws.Int32(limit),
+		TableName:              aws.String(in.TableName),
+		Limit:                  aws.Int32(in.Limit),
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 	}
 
-	if startKey != nil {
-		input.ExclusiveStartKey = startKey
+	if in.StartKey != nil {
+		input.ExclusiveStartKey = in.StartKey
 	}
+	applyScanSegment(opts.Segment, input)
 
-	if filterExpression != "" {
-		input.FilterExpression = aws.String(filterExpression)
+	if in.FilterExpression != "" {
+		input.FilterExpression = aws.String(in.FilterExpression)
 		
-		if len(expressionNames) > 0 {
-			input.ExpressionAttributeNames = expressionNames
+		if len(in.ExpressionAttributeNames) > 0 {
+			input.ExpressionAttributeNames = in.ExpressionAttributeNames
 		}
 		
-		if len(expressionValues) > 0 {
+		if len(in.ExpressionValues) > 0 {
 			attrValues := make(map[string]types.AttributeValue)
-			for k, v := range expressionValues {
+			for k, v := range in.ExpressionValues {
 				attrValues[k] = interfaceToAttributeValue(v)
 			}
 			input.ExpressionAttributeValues = attrValues
@@ -380,6 +387,7 @@ func (c *Client) ScanTable(ctx context.Context, tableName string, limit int32, s
 		LastEvaluatedKey: output.LastEvaluatedKey,
 		Count:            output.Count,
 		ScannedCount:     output.ScannedCount,
+		ConsumedCapacity: capacityUnits(output.ConsumedCapacity),
 	}, nil
 }
 
@@ -389,23 +397,26 @@ type ContinuousScanResult struct {
 	LastEvaluatedKey map[string]types.AttributeValue
 	TotalScanned     int64
 	HasMore          bool
-	TimedOut         bool
+	TimedOut         bool    // stopped by the context deadline or a ScanBudget
+	ConsumedCapacity float64 // read capacity units of every page scanned
 }
 
-// ScanTableContinuous performs a continuous scan until targetCount items are found or table is exhausted
+// ScanTableContinuous performs a continuous scan until in.Limit items are found or table is exhausted
 // It will scan in batches and accumulate results until the target is reached
 // The scan can be cancelled via context
-func (c *Client) ScanTableContinuous(ctx context.Context, tableName string, targetCount int, startKey map[string]types.AttributeValue, filterExpression string, expressionNames map[string]string, expressionValues map[string]interface{}) (*ContinuousScanResult, error) {
+func (c *Client) ScanTableContinuous(ctx context.Context, in ScanInput, opts ScanOptions) (*ContinuousScanResult, error) {
 	var allItems []map[string]types.AttributeValue
-	var lastKey map[string]types.AttributeValue = startKey
+	var lastKey map[string]types.AttributeValue = in.StartKey
 	var totalScanned int64 = 0
+	var consumed float64
 	batchSize := int32(500) // Scan in larger batches for efficiency
+	pages := 0
 
 	// Convert expression values once
 	var attrValues map[string]types.AttributeValue
-	if len(expressionValues) > 0 {
+	if len(in.ExpressionValues) > 0 {
 		attrValues = make(map[string]types.AttributeValue)
-		for k, v := range expressionValues {
+		for k, v := range in.ExpressionValues {
 			attrValues[k] = interfaceToAttributeValue(v)
 		}
 	}
@@ -420,23 +431,26 @@ func (c *Client) ScanTableContinuous(ctx context.Context, tableName string, targ
 				TotalScanned:     totalScanned,
 				HasMore:          lastKey != nil,
 				TimedOut:         true,
+				ConsumedCapacity: consumed,
 			}, nil
 		default:
 		}
 
 		input := &dynamodb.ScanInput{
-			TableName: aws.String(tableName),
-			Limit:     aws.Int32(batchSize),
+			TableName:              aws.String(in.TableName),
+			Limit:                  aws.Int32(batchSize),
+			ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 		}
 
 		if lastKey != nil {
 			input.ExclusiveStartKey = lastKey
 		}
+		applyScanSegment(opts.Segment, input)
 
-		if filterExpression != "" {
-			input.FilterExpression = aws.String(filterExpression)
-			if len(expressionNames) > 0 {
-				input.ExpressionAttributeNames = expressionNames
+		if in.FilterExpression != "" {
+			input.FilterExpression = aws.String(in.FilterExpression)
+			if len(in.ExpressionAttributeNames) > 0 {
+				input.ExpressionAttributeNames = in.ExpressionAttributeNames
 			}
 			if attrValues != nil {
 				input.ExpressionAttributeValues = attrValues
@@ -453,6 +467,7 @@ func (c *Client) ScanTableContinuous(ctx context.Context, tableName string, targ
 					TotalScanned:     totalScanned,
 					HasMore:          true,
 					TimedOut:         true,
+					ConsumedCapacity: consumed,
 				}, nil
 			}
 			return nil, fmt.Errorf("failed to scan table: %w", err)
@@ -460,12 +475,27 @@ func (c *Client) ScanTableContinuous(ctx context.Context, tableName string, targ
 
 		allItems = append(allItems, output.Items...)
 		totalScanned += int64(output.ScannedCount)
+		consumed += capacityUnits(output.ConsumedCapacity)
 		lastKey = output.LastEvaluatedKey
+		pages++
+		if opts.Progress != nil {
+			opts.Progress(len(allItems), totalScanned)
+		}
 
 		// Check if we have enough items or if we've reached the end
-		if len(allItems) >= targetCount || lastKey == nil {
+		if len(allItems) >= int(in.Limit) || lastKey == nil {
 			break
 		}
+		if opts.Budget.spent(pages, totalScanned) {
+			return &ContinuousScanResult{
+				Items:            allItems,
+				LastEvaluatedKey: lastKey,
+				TotalScanned:     totalScanned,
+				HasMore:          true,
+				TimedOut:         true,
+				ConsumedCapacity: consumed,
+			}, nil
+		}
 	}
 
 	return &ContinuousScanResult{
@@ -474,6 +504,7 @@ func (c *Client) ScanTableContinuous(ctx context.Context, tableName string, targ
 		TotalScanned:     totalScanned,
 		HasMore:          lastKey != nil,
 		TimedOut:         false,
+		ConsumedCapacity: consumed,
 	}, nil
 }
 
@@ -514,6 +545,7 @@ type QueryResult struct {
 	LastEvaluatedKey map[string]types.AttributeValue
 	Count            int32
 	ScannedCount     int32
+	ConsumedCapacity float64 // read capacity units the page cost
 }
 
 // QueryTable performs a query operation
@@ -522,6 +554,7 @@ func (c *Client) QueryTable(ctx context.Context, input QueryInput) (*QueryResult
 		TableName:              aws.String(input.TableName),
 		KeyConditionExpression: aws.String(input.KeyConditionExpression),
 		ScanIndexForward:       aws.Bool(input.ScanIndexForward),
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 	}
 
 	// Convert expression values
@@ -563,14 +596,24 @@ func (c *Client) QueryTable(ctx context.Context, input QueryInput) (*QueryResult
 		LastEvaluatedKey: output.LastEvaluatedKey,
 		Count:            output.Count,
 		ScannedCount:     output.ScannedCount,
+		ConsumedCapacity: capacityUnits(output.ConsumedCapacity),
 	}, nil
 }
 
+// capacityUnits is the capacity a read reported, 0 when it reported none
+func capacityUnits(cc *types.ConsumedCapacity) float64 {
+	if cc == nil || cc.CapacityUnits == nil {
+		return 0
+	}
+	return *cc.CapacityUnits
+}
+
 // PutItem creates or updates an item
 func (c *Client) PutItem(ctx context.Context, tableName string, item map[string]types.AttributeValue) error {
 	_, err := c.db.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(tableName),
-		Item:      item,
+		TableName:              aws.String(tableName),
+		Item:                   item,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to put item: %w", err)
@@ -578,16 +621,19 @@ func (c *Client) PutItem(ctx context.Context, tableName string, item map[string]
 	return nil
 }
 
-// DeleteItem removes an item
-func (c *Client) DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) error {
-	_, err := c.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
-		TableName: aws.String(tableName),
-		Key:       key,
+// DeleteItem removes an item and reports whether an item had the key;
+// deleting a key no item has changes nothing and is not an error
+func (c *Client) DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (bool, error) {
+	out, err := c.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:              aws.String(tableName),
+		Key:                    key,
+		ReturnValues:           types.ReturnValueAllOld,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to delete item: %w", err)
+		return false, fmt.Errorf("failed to delete item: %w", err)
 	}
-	return nil
+	return len(out.Attributes) > 0, nil
 }
 
 // CreateTableInput contains table creation parameters
@@ -604,6 +650,27 @@ type CreateTableInput struct {
 
 // CreateTable creates a new table
 func (c *Client) CreateTable(ctx context.Context, input CreateTableInput) error {
+	keySchema, attrDefs := input.keySchema()
+
+	createInput := &dynamodb.CreateTableInput{
+		TableName:            aws.String(input.TableName),
+		KeySchema:            keySchema,
+		AttributeDefinitions: attrDefs,
+	}
+
+	createInput.BillingMode, createInput.ProvisionedThroughput = input.billing()
+
+	_, err := c.db.CreateTable(ctx, createInput)
+	if err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+	c.schemas.forget(input.TableName)
+
+	return nil
+}
+
+// keySchema returns the key schema and the attribute definitions it needs
+func (input CreateTableInput) keySchema() ([]types.KeySchemaElement, []types.AttributeDefinition) {
 	keySchema := []types.KeySchemaElement{
 		{
 			AttributeName: aws.String(input.PartitionKey),
@@ -629,28 +696,18 @@ func (c *Client) CreateTable(ctx context.Context, input CreateTableInput) error
 		})
 	}
 
-	createInput := &dynamodb.CreateTableInput{
-		TableName:            aws.String(input.TableName),
-		KeySchema:            keySchema,
-		AttributeDefinitions: attrDefs,
-	}
+	return keySchema, attrDefs
+}
 
+// billing returns the billing mode and, for provisioned tables, the throughput
+func (input CreateTableInput) billing() (types.BillingMode, *types.ProvisionedThroughput) {
 	if input.BillingMode == "PAY_PER_REQUEST" {
-		createInput.BillingMode = types.BillingModePayPerRequest
-	} else {
-		createInput.BillingMode = types.BillingModeProvisioned
-		createInput.ProvisionedThroughput = &types.ProvisionedThroughput{
-			ReadCapacityUnits:  aws.Int64(input.ReadCapacity),
-			WriteCapacityUnits: aws.Int64(input.WriteCapacity),
-		}
+		return types.BillingModePayPerRequest, nil
 	}
-
-	_, err := c.db.CreateTable(ctx, createInput)
-	if err != nil {
-		return fmt.Errorf("failed to create table: %w", err)
+	return types.BillingModeProvisioned, &types.ProvisionedThroughput{
+		ReadCapacityUnits:  aws.Int64(input.ReadCapacity),
+		WriteCapacityUnits: aws.Int64(input.WriteCapacity),
 	}
-
-	return nil
 }
 
 // GetItem retrieves a single item
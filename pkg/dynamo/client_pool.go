@@ -0,0 +1,170 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+)
+
+// loadAWSConfig reads the shared config files and credential chain, sending
+// requests over n. It is a variable so tests can count loads without
+// touching ~/.aws.
+var loadAWSConfig = func(ctx context.Context, profile string, n Network) (aws.Config, error) {
+	var opts []func(*config.LoadOptions) error
+	client, err := newHTTPClient(n)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	if client != nil {
+		opts = append(opts, config.WithHTTPClient(client))
+	}
+	if profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+	return config.LoadDefaultConfig(ctx, opts...)
+}
+
+// configKey is a profile's config loaded over one network
+type configKey struct {
+	profile string
+	network Network
+}
+
+// clientKey is a pooled client: a region of a loaded config, at a custom
+// endpoint if one is set, recording its calls to stats
+type clientKey struct {
+	configKey
+	region   string
+	endpoint string
+	stats    *OpStats
+}
+
+// configLoad is a config being loaded; done is closed once cfg and err are
+// set
+type configLoad struct {
+	done chan struct{}
+	cfg  aws.Config
+	err  error
+}
+
+// clientPool loads each profile's AWS config once and hands out one Client
+// per profile+region built from it. Every region then shares the same
+// credential cache, so discovery and region switching resolve credentials
+// (SSO, assume-role, IMDS) a single time.
+type clientPool struct {
+	mu      sync.Mutex
+	configs map[configKey]*configLoad
+	clients map[clientKey]*Client
+}
+
+var pool clientPool
+
+// baseConfig returns the profile's config, loading it on first use.
+// Concurrent callers for the same key wait for one load rather than each
+// starting their own; the lock is not held across it, so other profiles
+// load meanwhile. A failed load is forgotten, so the next call retries.
+func (p *clientPool) baseConfig(ctx context.Context, key configKey) (aws.Config, error) {
+	p.mu.Lock()
+	load, loading := p.configs[key]
+	if !loading {
+		load = &configLoad{done: make(chan struct{})}
+		if p.configs == nil {
+			p.configs = make(map[configKey]*configLoad)
+		}
+		p.configs[key] = load
+	}
+	p.mu.Unlock()
+
+	if !loading {
+		load.cfg, load.err = loadAWSConfig(ctx, key.profile, key.network)
+		if load.err != nil {
+			load.err = fmt.Errorf("failed to load AWS config: %w", load.err)
+			p.mu.Lock()
+			if p.configs[key] == load {
+				delete(p.configs, key)
+			}
+			p.mu.Unlock()
+		}
+		close(load.done)
+	}
+	select {
+	case <-load.done:
+		return load.cfg, load.err
+	case <-ctx.Done():
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", ctx.Err())
+	}
+}
+
+func (p *clientPool) client(ctx context.Context, conn ConnectionConfig) (*Client, error) {
+	key := clientKey{
+		configKey: configKey{conn.Profile, conn.Network},
+		region:    conn.Region,
+		endpoint:  conn.Endpoint,
+		stats:     conn.Stats,
+	}
+	region, endpoint := conn.Region, conn.Endpoint
+	p.mu.Lock()
+	c, ok := p.clients[key]
+	p.mu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	cfg, err := p.baseConfig(ctx, key.configKey)
+	if err != nil {
+		return nil, err
+	}
+	cfg = recordOpStats(cfg, conn.Stats)
+	c = &Client{
+		db: dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+			o.Region = region
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+			}
+		}),
+		streams: dynamodbstreams.NewFromConfig(cfg, func(o *dynamodbstreams.Options) {
+			o.Region = region
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+			}
+		}),
+		insights: cloudwatch.NewFromConfig(cfg, func(o *cloudwatch.Options) { o.Region = region }),
+		scaling:  applicationautoscaling.NewFromConfig(cfg, func(o *applicationautoscaling.Options) { o.Region = region }),
+		endpoint: endpoint,
+		region:   region,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if existing, ok := p.clients[key]; ok {
+		return existing, nil
+	}
+	if p.clients == nil {
+		p.clients = make(map[clientKey]*Client)
+	}
+	p.clients[key] = c
+	return c, nil
+}
+
+// RegionClient returns the shared Client for cfg's AWS profile and region,
+// and its endpoint if it names one, creating it on first use. Switching back to a region reuses its client,
+// including the tables it has already described.
+func RegionClient(cfg ConnectionConfig) (*Client, error) {
+	return pool.client(context.TODO(), cfg)
+}
+
+// ResetClientPool drops every cached config and client, so the next
+// connection re-reads ~/.aws (e.g. after `aws sso login`).
+func ResetClientPool() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.configs = nil
+	pool.clients = nil
+}
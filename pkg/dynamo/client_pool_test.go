@@ -0,0 +1,143 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+func stubAWSConfig(t *testing.T) *atomic.Int32 {
+	t.Helper()
+	var loads atomic.Int32
+	old := loadAWSConfig
+	t.Cleanup(func() {
+		loadAWSConfig = old
+		ResetClientPool()
+	})
+	ResetClientPool()
+	loadAWSConfig = func(context.Context, string, Network) (aws.Config, error) {
+		loads.Add(1)
+		return aws.Config{Region: "us-east-1", Credentials: aws.AnonymousCredentials{}}, nil
+	}
+	return &loads
+}
+
+func TestClientPoolLoadsConfigOncePerProfile(t *testing.T) {
+	loads := stubAWSConfig(t)
+
+	var wg sync.WaitGroup
+	for _, region := range AWSRegions {
+		wg.Add(1)
+		go func(r string) {
+			defer wg.Done()
+			if _, err := RegionClient(ConnectionConfig{Region: r}); err != nil {
+				t.Error(err)
+			}
+		}(region)
+	}
+	wg.Wait()
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("config loaded %d times for %d regions, want 1", n, len(AWSRegions))
+	}
+
+	if _, err := RegionClient(ConnectionConfig{Profile: "work", Region: "us-east-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := loads.Load(); n != 2 {
+		t.Fatalf("a second profile should load its own config, loads = %d", n)
+	}
+
+	if _, err := RegionClient(ConnectionConfig{Profile: "work", Region: "us-east-1", Network: Network{Proxy: "http://proxy.corp:3128"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := loads.Load(); n != 3 {
+		t.Fatalf("another network should load its own config, loads = %d", n)
+	}
+}
+
+func TestRegionClientReusesClientAndRegion(t *testing.T) {
+	stubAWSConfig(t)
+
+	a, _ := RegionClient(ConnectionConfig{Region: "eu-west-1"})
+	b, _ := RegionClient(ConnectionConfig{Region: "eu-west-1"})
+	c, _ := RegionClient(ConnectionConfig{Region: "sa-east-1"})
+	if a != b {
+		t.Fatal("switching back to a region should reuse its client")
+	}
+	if a == c || c.db.(*dynamodb.Client).Options().Region != "sa-east-1" {
+		t.Fatalf("clients not per region: %q", c.db.(*dynamodb.Client).Options().Region)
+	}
+}
+
+func TestRegionClientKeepsTheEndpoint(t *testing.T) {
+	stubAWSConfig(t)
+
+	aws1, _ := RegionClient(ConnectionConfig{Profile: "work", Region: "us-east-1"})
+	local, err := RegionClient(ConnectionConfig{Profile: "work", Region: "us-east-1", Endpoint: "http://localhost:8000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local == aws1 {
+		t.Fatal("a client at an endpoint should not be shared with the AWS one")
+	}
+	if got := aws.ToString(local.db.(*dynamodb.Client).Options().BaseEndpoint); got != "http://localhost:8000" || local.endpoint != got {
+		t.Errorf("pooled client dropped the endpoint, got %q", got)
+	}
+}
+
+func TestClientPoolLoadsProfilesConcurrently(t *testing.T) {
+	stubAWSConfig(t)
+	release := make(chan struct{})
+	loadAWSConfig = func(ctx context.Context, profile string, _ Network) (aws.Config, error) {
+		if profile == "slow" {
+			<-release
+		}
+		return aws.Config{Region: "us-east-1", Credentials: aws.AnonymousCredentials{}}, nil
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := RegionClient(ConnectionConfig{Profile: "slow", Region: "us-east-1"})
+		done <- err
+	}()
+	fast := make(chan error)
+	go func() {
+		_, err := RegionClient(ConnectionConfig{Profile: "fast", Region: "us-east-1"})
+		fast <- err
+	}()
+	select {
+	case err := <-fast:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a slow profile's config load held up another profile")
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientPoolRetriesAFailedLoad(t *testing.T) {
+	loads := stubAWSConfig(t)
+	loadAWSConfig = func(context.Context, string, Network) (aws.Config, error) {
+		if loads.Add(1) == 1 {
+			return aws.Config{}, errors.New("sso session expired")
+		}
+		return aws.Config{Region: "us-east-1", Credentials: aws.AnonymousCredentials{}}, nil
+	}
+
+	if _, err := RegionClient(ConnectionConfig{Region: "us-east-1"}); err == nil {
+		t.Fatal("want the load error")
+	}
+	if _, err := RegionClient(ConnectionConfig{Region: "us-east-1"}); err != nil {
+		t.Fatalf("a failed load should not be cached: %v", err)
+	}
+}
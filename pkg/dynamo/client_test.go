@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -16,21 +17,44 @@ type fakeAPI struct {
 	listOuts  []*dynamodb.ListTablesOutput
 	listCalls int
 	describe  *dynamodb.DescribeTableOutput
+	descCalls int
 	scanOuts  []*dynamodb.ScanOutput
 	scanCalls int
 	scanErr   error
 	query     *dynamodb.QueryOutput
 	queryErr  error
 	getOut    *dynamodb.GetItemOutput
+	getFn     func(*dynamodb.GetItemInput) *dynamodb.GetItemOutput // overrides getOut
 	putErr    error
 	delErr    error
+	delOld    map[string]types.AttributeValue
 	createErr error
+	exportOut *dynamodb.ExportTableToPointInTimeOutput
+	exportErr error
+	descExp   *dynamodb.DescribeExportOutput
+	importOut *dynamodb.ImportTableOutput
+	importErr error
+	descImp   *dynamodb.DescribeImportOutput
+	stmtOut   *dynamodb.ExecuteStatementOutput
+	stmtErr   error
+	ciOut     *dynamodb.DescribeContributorInsightsOutput
+	policyOut *dynamodb.GetResourcePolicyOutput
+	policyErr error
 
 	lastScan   *dynamodb.ScanInput
 	lastQuery  *dynamodb.QueryInput
 	lastCreate *dynamodb.CreateTableInput
 	lastPut    *dynamodb.PutItemInput
 	lastDelete *dynamodb.DeleteItemInput
+	updates    []*dynamodb.UpdateItemInput
+	updateErrs []error // by update, nil past the end
+	batches    []*dynamodb.BatchWriteItemInput
+	throttled  []int // by batch call: how many of its requests to hand back
+	batchErr   error
+	lastExport *dynamodb.ExportTableToPointInTimeInput
+	lastImport *dynamodb.ImportTableInput
+	lastStmt   *dynamodb.ExecuteStatementInput
+	lastCI     *dynamodb.UpdateContributorInsightsInput
 }
 
 func (f *fakeAPI) ListTables(_ context.Context, _ *dynamodb.ListTablesInput, _ ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
@@ -39,6 +63,7 @@ func (f *fakeAPI) ListTables(_ context.Context, _ *dynamodb.ListTablesInput, _ .
 	return out, nil
 }
 func (f *fakeAPI) DescribeTable(_ context.Context, _ *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	f.descCalls++
 	return f.describe, nil
 }
 func (f *fakeAPI) Scan(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
@@ -60,30 +85,90 @@ func (f *fakeAPI) PutItem(_ context.Context, in *dynamodb.PutItemInput, _ ...fun
 }
 func (f *fakeAPI) DeleteItem(_ context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
 	f.lastDelete = in
-	return &dynamodb.DeleteItemOutput{}, f.delErr
+	return &dynamodb.DeleteItemOutput{Attributes: f.delOld}, f.delErr
+}
+func (f *fakeAPI) UpdateItem(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	var err error
+	if len(f.updates) < len(f.updateErrs) {
+		err = f.updateErrs[len(f.updates)]
+	}
+	f.updates = append(f.updates, in)
+	return &dynamodb.UpdateItemOutput{}, err
+}
+func (f *fakeAPI) BatchWriteItem(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	out := &dynamodb.BatchWriteItemOutput{}
+	if n := len(f.batches); n < len(f.throttled) && f.throttled[n] > 0 {
+		for table, reqs := range in.RequestItems {
+			out.UnprocessedItems = map[string][]types.WriteRequest{table: reqs[:f.throttled[n]]}
+		}
+	}
+	f.batches = append(f.batches, in)
+	if f.batchErr != nil {
+		return nil, f.batchErr
+	}
+	return out, nil
 }
 func (f *fakeAPI) CreateTable(_ context.Context, in *dynamodb.CreateTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
 	f.lastCreate = in
 	return &dynamodb.CreateTableOutput{}, f.createErr
 }
-func (f *fakeAPI) GetItem(_ context.Context, _ *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+func (f *fakeAPI) GetItem(_ context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if f.getFn != nil {
+		return f.getFn(in), nil
+	}
 	return f.getOut, nil
 }
 
+func (f *fakeAPI) ExportTableToPointInTime(_ context.Context, in *dynamodb.ExportTableToPointInTimeInput, _ ...func(*dynamodb.Options)) (*dynamodb.ExportTableToPointInTimeOutput, error) {
+	f.lastExport = in
+	return f.exportOut, f.exportErr
+}
+func (f *fakeAPI) DescribeExport(_ context.Context, _ *dynamodb.DescribeExportInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeExportOutput, error) {
+	return f.descExp, nil
+}
+func (f *fakeAPI) ImportTable(_ context.Context, in *dynamodb.ImportTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.ImportTableOutput, error) {
+	f.lastImport = in
+	return f.importOut, f.importErr
+}
+func (f *fakeAPI) DescribeImport(_ context.Context, _ *dynamodb.DescribeImportInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeImportOutput, error) {
+	return f.descImp, nil
+}
+
+func (f *fakeAPI) ExecuteStatement(_ context.Context, in *dynamodb.ExecuteStatementInput, _ ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+	f.lastStmt = in
+	return f.stmtOut, f.stmtErr
+}
+
+func (f *fakeAPI) DescribeContributorInsights(_ context.Context, _ *dynamodb.DescribeContributorInsightsInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeContributorInsightsOutput, error) {
+	return f.ciOut, nil
+}
+func (f *fakeAPI) UpdateContributorInsights(_ context.Context, in *dynamodb.UpdateContributorInsightsInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateContributorInsightsOutput, error) {
+	f.lastCI = in
+	status := types.ContributorInsightsStatusDisabling
+	if in.ContributorInsightsAction == types.ContributorInsightsActionEnable {
+		status = types.ContributorInsightsStatusEnabling
+	}
+	return &dynamodb.UpdateContributorInsightsOutput{ContributorInsightsStatus: status}, nil
+}
+
+func (f *fakeAPI) GetResourcePolicy(_ context.Context, _ *dynamodb.GetResourcePolicyInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetResourcePolicyOutput, error) {
+	return f.policyOut, f.policyErr
+}
+
 func newTestClient(f *fakeAPI) *Client {
 	return &Client{db: f, region: "us-east-1"}
 }
 
 func TestScanTablePropagatesError(t *testing.T) {
 	f := &fakeAPI{scanErr: errors.New("boom")}
-	if _, err := newTestClient(f).ScanTable(context.Background(), "T", 10, nil, "", nil, nil); err == nil {
+	if _, err := newTestClient(f).ScanTable(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{}); err == nil {
 		t.Fatal("ScanTable should propagate the SDK error")
 	}
 }
 
 func TestScanTableContinuousPropagatesError(t *testing.T) {
 	f := &fakeAPI{scanErr: errors.New("boom")}
-	if _, err := newTestClient(f).ScanTableContinuous(context.Background(), "T", 10, nil, "", nil, nil); err == nil {
+	if _, err := newTestClient(f).ScanTableContinuous(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{}); err == nil {
 		t.Fatal("ScanTableContinuous should propagate a non-cancellation SDK error")
 	}
 }
@@ -153,6 +238,33 @@ func TestDescribeTableParsesSchema(t *testing.T) {
 	}
 }
 
+func TestDescribeTableIsCachedUntilInvalidated(t *testing.T) {
+	f := &fakeAPI{describe: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+		TableName: aws.String("Users"), ItemCount: aws.Int64(0), TableSizeBytes: aws.Int64(0),
+	}}}
+	c := newTestClient(f)
+	for i := 0; i < 3; i++ {
+		if _, err := c.DescribeTable(context.Background(), "Users"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if f.descCalls != 1 {
+		t.Fatalf("DescribeTable hit the API %d times, want 1", f.descCalls)
+	}
+	c.InvalidateSchemaCache()
+	c.DescribeTable(context.Background(), "Users")
+	if f.descCalls != 2 {
+		t.Fatalf("invalidation did not force a fresh DescribeTable (%d calls)", f.descCalls)
+	}
+
+	// Counts and status go stale, so an old description is not reused
+	c.schemas.tables["Users"] = cachedSchema{info: c.schemas.tables["Users"].info, at: time.Now().Add(-schemaTTL)}
+	c.DescribeTable(context.Background(), "Users")
+	if f.descCalls != 3 {
+		t.Fatalf("an expired description was reused (%d calls)", f.descCalls)
+	}
+}
+
 func TestScanTablePassesFilterAndConvertsValues(t *testing.T) {
 	f := &fakeAPI{scanOuts: []*dynamodb.ScanOutput{{
 		Items: []map[string]types.AttributeValue{
@@ -161,8 +273,13 @@ func TestScanTablePassesFilterAndConvertsValues(t *testing.T) {
 		Count:        1,
 		ScannedCount: 5,
 	}}}
-	res, err := newTestClient(f).ScanTable(context.Background(), "T", 100, nil,
-		"#a = :v", map[string]string{"#a": "name"}, map[string]interface{}{":v": "alice"})
+	res, err := newTestClient(f).ScanTable(context.Background(), ScanInput{
+		TableName:                "T",
+		FilterExpression:         "#a = :v",
+		ExpressionAttributeNames: map[string]string{"#a": "name"},
+		ExpressionValues:         map[string]interface{}{":v": "alice"},
+		Limit:                    100,
+	}, ScanOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -185,7 +302,7 @@ func TestScanTableContinuousAccumulatesAcrossPages(t *testing.T) {
 		{Items: []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "2"}}},
 			ScannedCount: 4},
 	}}
-	res, err := newTestClient(f).ScanTableContinuous(context.Background(), "T", 10, nil, "", nil, nil)
+	res, err := newTestClient(f).ScanTableContinuous(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -200,11 +317,29 @@ func TestScanTableContinuousAccumulatesAcrossPages(t *testing.T) {
 	}
 }
 
+func TestScanTableContinuousSumsConsumedCapacity(t *testing.T) {
+	f := &fakeAPI{scanOuts: []*dynamodb.ScanOutput{
+		{LastEvaluatedKey: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}},
+			ConsumedCapacity: &types.ConsumedCapacity{CapacityUnits: aws.Float64(2.5)}},
+		{ConsumedCapacity: &types.ConsumedCapacity{CapacityUnits: aws.Float64(1)}},
+	}}
+	res, err := newTestClient(f).ScanTableContinuous(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ConsumedCapacity != 3.5 {
+		t.Errorf("ConsumedCapacity=%v want 3.5", res.ConsumedCapacity)
+	}
+	if f.lastScan.ReturnConsumedCapacity != types.ReturnConsumedCapacityTotal {
+		t.Errorf("scan should ask for its capacity, got %q", f.lastScan.ReturnConsumedCapacity)
+	}
+}
+
 func TestScanTableContinuousCancelledContext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	f := &fakeAPI{}
-	res, err := newTestClient(f).ScanTableContinuous(ctx, "T", 10, nil, "", nil, nil)
+	res, err := newTestClient(f).ScanTableContinuous(ctx, ScanInput{TableName: "T", Limit: 10}, ScanOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -278,13 +413,29 @@ func TestCreateTableBillingModes(t *testing.T) {
 	})
 }
 
+func TestDeleteItemReportsWhetherTheItemExisted(t *testing.T) {
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}
+	f := &fakeAPI{}
+	found, err := newTestClient(f).DeleteItem(context.Background(), "T", key)
+	if err != nil || found {
+		t.Fatalf("found = %v, err = %v for a missing item", found, err)
+	}
+	if f.lastDelete.ReturnValues != types.ReturnValueAllOld {
+		t.Errorf("ReturnValues = %q", f.lastDelete.ReturnValues)
+	}
+	f.delOld = key
+	if found, _ := newTestClient(f).DeleteItem(context.Background(), "T", key); !found {
+		t.Error("an item returned by ALL_OLD was there")
+	}
+}
+
 func TestPutAndDeletePropagateErrors(t *testing.T) {
 	f := &fakeAPI{putErr: errors.New("boom")}
 	if err := newTestClient(f).PutItem(context.Background(), "T", nil); err == nil {
 		t.Fatal("PutItem should propagate the error")
 	}
 	f2 := &fakeAPI{delErr: errors.New("boom")}
-	if err := newTestClient(f2).DeleteItem(context.Background(), "T", nil); err == nil {
+	if _, err := newTestClient(f2).DeleteItem(context.Background(), "T", nil); err == nil {
 		t.Fatal("DeleteItem should propagate the error")
 	}
 }
@@ -0,0 +1,140 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// insightsAPI is the CloudWatch call that reads Contributor Insights rules
+type insightsAPI interface {
+	GetInsightRuleReport(context.Context, *cloudwatch.GetInsightRuleReportInput, ...func(*cloudwatch.Options)) (*cloudwatch.GetInsightRuleReportOutput, error)
+}
+
+var _ insightsAPI = (*cloudwatch.Client)(nil)
+
+// ContributorInsights is whether a table has Contributor Insights turned on,
+// and the CloudWatch rules DynamoDB created for it.
+type ContributorInsights struct {
+	Status      string // ENABLING, ENABLED, DISABLING, DISABLED or FAILED
+	Rules       []string
+	LastUpdated time.Time
+	Failure     string
+}
+
+// DescribeContributorInsights returns the table's Contributor Insights state
+func (c *Client) DescribeContributorInsights(ctx context.Context, tableName string) (*ContributorInsights, error) {
+	out, err := c.db.DescribeContributorInsights(ctx, &dynamodb.DescribeContributorInsightsInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe contributor insights: %w", err)
+	}
+	ci := &ContributorInsights{
+		Status:      string(out.ContributorInsightsStatus),
+		Rules:       out.ContributorInsightsRuleList,
+		LastUpdated: aws.ToTime(out.LastUpdateDateTime),
+	}
+	if f := out.FailureException; f != nil {
+		ci.Failure = aws.ToString(f.ExceptionName) + ": " + aws.ToString(f.ExceptionDescription)
+	}
+	return ci, nil
+}
+
+// SetContributorInsights turns Contributor Insights on or off for the table
+// and returns the status DynamoDB reports, usually ENABLING or DISABLING.
+func (c *Client) SetContributorInsights(ctx context.Context, tableName string, enable bool) (string, error) {
+	action := types.ContributorInsightsActionDisable
+	if enable {
+		action = types.ContributorInsightsActionEnable
+	}
+	out, err := c.db.UpdateContributorInsights(ctx, &dynamodb.UpdateContributorInsightsInput{
+		TableName:                 aws.String(tableName),
+		ContributorInsightsAction: action,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update contributor insights: %w", err)
+	}
+	return string(out.ContributorInsightsStatus), nil
+}
+
+// InsightRuleLabel describes what a rule DynamoDB created counts, from the
+// PKC/PKT/SKC/SKT part of its name.
+func InsightRuleLabel(rule string) string {
+	switch {
+	case strings.Contains(rule, "-PKC-"):
+		return "Most accessed partition keys"
+	case strings.Contains(rule, "-PKT-"):
+		return "Most throttled partition keys"
+	case strings.Contains(rule, "-SKC-"):
+		return "Most accessed items (partition + sort key)"
+	case strings.Contains(rule, "-SKT-"):
+		return "Most throttled items (partition + sort key)"
+	}
+	return rule
+}
+
+// Contributor is one key and how many requests it accounted for
+type Contributor struct {
+	Keys  []string
+	Count float64
+}
+
+// InsightReport is a rule's top contributors over a time window
+type InsightReport struct {
+	Rule         string
+	Contributors []Contributor
+	Total        float64 // requests counted by the rule, top keys or not
+	UniqueKeys   int64
+}
+
+// insightPeriods are the report periods TopContributors picks from,
+// coarsest first
+var insightPeriods = []time.Duration{24 * time.Hour, 6 * time.Hour, time.Hour, 5 * time.Minute, time.Minute}
+
+// reportPeriod is the coarsest of insightPeriods that divides window, in
+// seconds; a minute when none does
+func reportPeriod(window time.Duration) int32 {
+	for _, p := range insightPeriods {
+		if window >= p && window%p == 0 {
+			return int32(p / time.Second)
+		}
+	}
+	return 60
+}
+
+// TopContributors reads the keys that contributed most to rule over the
+// window ending now, busiest first.
+func (c *Client) TopContributors(ctx context.Context, rule string, window time.Duration, limit int32) (*InsightReport, error) {
+	end := time.Now().Truncate(time.Minute)
+	// The report is aggregated over the whole window whatever the period, so
+	// pick the coarsest period that still divides it.
+	period := reportPeriod(window)
+	out, err := c.insights.GetInsightRuleReport(ctx, &cloudwatch.GetInsightRuleReportInput{
+		RuleName:            aws.String(rule),
+		StartTime:           aws.Time(end.Add(-window)),
+		EndTime:             aws.Time(end),
+		Period:              aws.Int32(period),
+		MaxContributorCount: aws.Int32(limit),
+		Metrics:             []string{"Sum", "UniqueContributors"},
+		OrderBy:             aws.String("Sum"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read insight rule %s: %w", rule, err)
+	}
+	r := &InsightReport{
+		Rule:       rule,
+		Total:      aws.ToFloat64(out.AggregateValue),
+		UniqueKeys: aws.ToInt64(out.ApproximateUniqueCount),
+	}
+	for _, ct := range out.Contributors {
+		r.Contributors = append(r.Contributors, Contributor{Keys: ct.Keys, Count: aws.ToFloat64(ct.ApproximateAggregateValue)})
+	}
+	return r, nil
+}
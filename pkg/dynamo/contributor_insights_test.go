@@ -0,0 +1,90 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type insightsFake struct {
+	last *cloudwatch.GetInsightRuleReportInput
+	out  *cloudwatch.GetInsightRuleReportOutput
+}
+
+func (f *insightsFake) GetInsightRuleReport(_ context.Context, in *cloudwatch.GetInsightRuleReportInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.GetInsightRuleReportOutput, error) {
+	f.last = in
+	return f.out, nil
+}
+
+func TestDescribeAndToggleContributorInsights(t *testing.T) {
+	f := &fakeAPI{ciOut: &dynamodb.DescribeContributorInsightsOutput{
+		ContributorInsightsStatus:   types.ContributorInsightsStatusEnabled,
+		ContributorInsightsRuleList: []string{"DynamoDBContributorInsights-PKC-Users-1700000000000"},
+	}}
+	c := newTestClient(f)
+	ci, err := c.DescribeContributorInsights(context.Background(), "Users")
+	if err != nil || ci.Status != "ENABLED" || len(ci.Rules) != 1 {
+		t.Fatalf("got %+v, %v", ci, err)
+	}
+
+	status, err := c.SetContributorInsights(context.Background(), "Users", false)
+	if err != nil || status != "DISABLING" || f.lastCI.ContributorInsightsAction != types.ContributorInsightsActionDisable {
+		t.Fatalf("status = %q, err = %v, input = %+v", status, err, f.lastCI)
+	}
+}
+
+func TestTopContributorsOrdersBySumOverWindow(t *testing.T) {
+	f := &insightsFake{out: &cloudwatch.GetInsightRuleReportOutput{
+		AggregateValue:         aws.Float64(1200),
+		ApproximateUniqueCount: aws.Int64(40),
+		Contributors: []cwtypes.InsightRuleContributor{
+			{Keys: []string{"user#1"}, ApproximateAggregateValue: aws.Float64(900)},
+			{Keys: []string{"user#2"}, ApproximateAggregateValue: aws.Float64(120)},
+		},
+	}}
+	c := &Client{insights: f}
+	r, err := c.TopContributors(context.Background(), "rule", 24*time.Hour, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Contributors) != 2 || r.Contributors[0].Keys[0] != "user#1" || r.Total != 1200 || r.UniqueKeys != 40 {
+		t.Fatalf("report = %+v", r)
+	}
+	in := f.last
+	if got := in.EndTime.Sub(*in.StartTime); got != 24*time.Hour {
+		t.Errorf("window = %s", got)
+	}
+	if *in.Period != 86400 || *in.OrderBy != "Sum" || *in.MaxContributorCount != 10 {
+		t.Errorf("period = %d, order = %s, max = %d", *in.Period, *in.OrderBy, *in.MaxContributorCount)
+	}
+}
+
+func TestReportPeriodIsTheCoarsestDividingTheWindow(t *testing.T) {
+	for window, want := range map[time.Duration]int32{
+		24 * time.Hour:   86400,
+		12 * time.Hour:   21600,
+		3 * time.Hour:    3600,
+		15 * time.Minute: 300,
+		7 * time.Minute:  60,
+		30 * time.Second: 60,
+	} {
+		if got := reportPeriod(window); got != want {
+			t.Errorf("period for %s = %d, want %d", window, got, want)
+		}
+	}
+}
+
+func TestInsightRuleLabel(t *testing.T) {
+	if got := InsightRuleLabel("DynamoDBContributorInsights-PKT-Users-1"); got != "Most throttled partition keys" {
+		t.Errorf("label = %q", got)
+	}
+	if got := InsightRuleLabel("custom"); got != "custom" {
+		t.Errorf("unknown rules should keep their name, got %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// CopyInput copies every item of one table into another
+type CopyInput struct {
+	Source, Dest string
+	DestKey      []string // the destination's key attributes, which every item needs
+	Segments     int      // parallel scan workers on the source
+
+	// Transform reshapes each item before it is written, e.g. for a new key
+	// schema; nil copies items as they are
+	Transform func(map[string]types.AttributeValue) map[string]types.AttributeValue
+}
+
+// CopyProgress counts a copy as it goes
+type CopyProgress struct {
+	Scanned int64
+	Copied  int
+}
+
+// CopyTable scans the source with a parallel scan and writes each page to
+// the destination with WriteItems, replacing items with the same key. An
+// item missing a destination key attribute after its transform stops the
+// copy before that page is written. Items the destination refuses don't:
+// they are returned in a *WriteFailures at the end. onPage reports after
+// every page.
+func (c *Client) CopyTable(ctx context.Context, in CopyInput, onPage func(CopyProgress)) (CopyProgress, error) {
+	var p CopyProgress
+	if in.Source == "" || in.Dest == "" {
+		return p, fmt.Errorf("name the source and destination tables")
+	}
+	if in.Source == in.Dest {
+		return p, fmt.Errorf("the destination is the source table")
+	}
+	var failed []FailedWrite
+	err := c.ParallelScan(ctx, in.Source, in.Segments, func(page ScanPage) error {
+		p.Scanned += page.Scanned
+		items := page.Items
+		if in.Transform != nil {
+			items = make([]map[string]types.AttributeValue, len(page.Items))
+			for i, item := range page.Items {
+				items[i] = in.Transform(item)
+			}
+		}
+		for _, item := range items {
+			for _, k := range in.DestKey {
+				if _, ok := item[k]; !ok {
+					return fmt.Errorf("an item has no %s, a key attribute of %s; %d items were copied", k, in.Dest, p.Copied)
+				}
+			}
+		}
+		written := len(items)
+		if err := c.WriteItems(ctx, in.Dest, items); err != nil {
+			var wf *WriteFailures
+			if !errors.As(err, &wf) {
+				return err
+			}
+			failed = append(failed, wf.Failed...)
+			written -= len(wf.Failed)
+		}
+		p.Copied += written
+		if onPage != nil {
+			onPage(p)
+		}
+		return nil
+	})
+	if err == nil && len(failed) > 0 {
+		err = &WriteFailures{Failed: failed}
+	}
+	return p, err
+}
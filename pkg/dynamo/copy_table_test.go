@@ -0,0 +1,57 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func copyFake() *fakeAPI {
+	item := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+	return &fakeAPI{scanOuts: []*dynamodb.ScanOutput{
+		{Items: []map[string]types.AttributeValue{item("a"), item("b")}, ScannedCount: 2, LastEvaluatedKey: item("b")},
+		{Items: []map[string]types.AttributeValue{item("c")}, ScannedCount: 1},
+	}}
+}
+
+func TestCopyTableTransformsEachItem(t *testing.T) {
+	f := copyFake()
+	rename := func(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"pk": item["id"]}
+	}
+	var pages []CopyProgress
+	p, err := newTestClient(f).CopyTable(context.Background(), CopyInput{
+		Source: "Users", Dest: "UsersV2", DestKey: []string{"pk"}, Segments: 1, Transform: rename,
+	}, func(p CopyProgress) { pages = append(pages, p) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Copied != 3 || p.Scanned != 3 || len(pages) != 2 {
+		t.Errorf("progress = %+v after %d pages", p, len(pages))
+	}
+	if aws.ToString(f.lastScan.TableName) != "Users" || len(f.batches) != 2 {
+		t.Fatalf("scanned %s, %d batches", aws.ToString(f.lastScan.TableName), len(f.batches))
+	}
+	put := f.batches[1].RequestItems["UsersV2"][0].PutRequest.Item
+	if put["pk"].(*types.AttributeValueMemberS).Value != "c" || put["id"] != nil {
+		t.Errorf("wrote %v, want the transformed item", put)
+	}
+}
+
+func TestCopyTableStopsOnMissingKey(t *testing.T) {
+	f := copyFake()
+	p, err := newTestClient(f).CopyTable(context.Background(), CopyInput{
+		Source: "Users", Dest: "UsersV2", DestKey: []string{"pk"}, Segments: 1,
+	}, nil)
+	if err == nil || p.Copied != 0 || len(f.batches) != 0 {
+		t.Fatalf("items without the destination key must not be written: %v, %+v", err, p)
+	}
+	if _, err := newTestClient(copyFake()).CopyTable(context.Background(), CopyInput{Source: "Users", Dest: "Users"}, nil); err == nil {
+		t.Error("copying a table onto itself should be refused")
+	}
+}
@@ -0,0 +1,193 @@
+package dynamo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// VerifyInput checks a finished CopyTable: the same tables, destination key
+// and transform, plus how many items to compare one by one
+type VerifyInput struct {
+	CopyInput
+	Samples int
+}
+
+// VerifyReport is what a verification found. Missing and Different hold
+// the destination keys of sampled items, as "attr=value" pairs.
+type VerifyReport struct {
+	SourceCount int64
+	DestCount   int64
+	Sampled     int
+	Missing     []string
+	Different   []string
+}
+
+// OK reports whether the counts match and every sampled item was found equal
+func (r VerifyReport) OK() bool {
+	return r.SourceCount == r.DestCount && len(r.Missing) == 0 && len(r.Different) == 0
+}
+
+// VerifyCopy counts both tables and compares a random sample of source
+// items, transformed as the copy did, with the destination's by hash. The
+// source is read in full to count and sample it; the destination is only
+// counted (Select COUNT) and read by key for the samples.
+func (c *Client) VerifyCopy(ctx context.Context, in VerifyInput) (VerifyReport, error) {
+	var r VerifyReport
+	if in.Source == "" || in.Dest == "" || len(in.DestKey) == 0 {
+		return r, fmt.Errorf("name the source and destination tables and the destination key")
+	}
+
+	// Reservoir sampling keeps a uniform sample in one pass
+	var sample []map[string]types.AttributeValue
+	err := c.ParallelScan(ctx, in.Source, in.Segments, func(page ScanPage) error {
+		for _, item := range page.Items {
+			r.SourceCount++
+			switch {
+			case len(sample) < in.Samples:
+				sample = append(sample, item)
+			case in.Samples > 0:
+				if i := rand.Int64N(r.SourceCount); i < int64(in.Samples) {
+					sample[i] = item
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return r, err
+	}
+	if r.DestCount, err = c.countItems(ctx, in.Dest); err != nil {
+		return r, err
+	}
+
+	for _, item := range sample {
+		if in.Transform != nil {
+			item = in.Transform(item)
+		}
+		key := make(map[string]types.AttributeValue, len(in.DestKey))
+		for _, k := range in.DestKey {
+			v, ok := item[k]
+			if !ok {
+				return r, fmt.Errorf("a sampled item has no %s, a key attribute of %s", k, in.Dest)
+			}
+			key[k] = v
+		}
+		got, err := c.GetItem(ctx, in.Dest, key)
+		if err != nil {
+			return r, err
+		}
+		r.Sampled++
+		switch {
+		case got == nil:
+			r.Missing = append(r.Missing, keyString(key, in.DestKey))
+		case itemHash(got) != itemHash(item):
+			r.Different = append(r.Different, keyString(key, in.DestKey))
+		}
+	}
+	return r, nil
+}
+
+// countItems counts a table's items with a COUNT scan, which reads the
+// whole table but transfers no items
+func (c *Client) countItems(ctx context.Context, tableName string) (int64, error) {
+	var n int64
+	var lastKey map[string]types.AttributeValue
+	for {
+		out, err := c.db.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(tableName),
+			Select:            types.SelectCount,
+			ExclusiveStartKey: lastKey,
+		})
+		if err != nil {
+			return n, fmt.Errorf("failed to count %s: %w", tableName, err)
+		}
+		n += int64(out.Count)
+		if lastKey = out.LastEvaluatedKey; lastKey == nil {
+			return n, nil
+		}
+	}
+}
+
+func keyString(key map[string]types.AttributeValue, attrs []string) string {
+	parts := make([]string, len(attrs))
+	for i, k := range attrs {
+		switch v := key[k].(type) {
+		case *types.AttributeValueMemberS:
+			parts[i] = k + "=" + v.Value
+		case *types.AttributeValueMemberN:
+			parts[i] = k + "=" + v.Value
+		case *types.AttributeValueMemberB:
+			parts[i] = k + "=" + base64.StdEncoding.EncodeToString(v.Value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// itemHash digests an item independently of attribute and set member order,
+// which DynamoDB doesn't preserve
+func itemHash(item map[string]types.AttributeValue) string {
+	h := sha256.New()
+	writeCanonical(h, &types.AttributeValueMemberM{Value: item})
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func writeCanonical(w io.Writer, av types.AttributeValue) {
+	sorted := func(tag string, values []string) {
+		values = slices.Clone(values)
+		sort.Strings(values)
+		fmt.Fprintf(w, "%s[%q]", tag, strings.Join(values, "\x00"))
+	}
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		fmt.Fprintf(w, "S%q", v.Value)
+	case *types.AttributeValueMemberN:
+		fmt.Fprintf(w, "N%s", v.Value)
+	case *types.AttributeValueMemberB:
+		fmt.Fprintf(w, "B%s", base64.StdEncoding.EncodeToString(v.Value))
+	case *types.AttributeValueMemberBOOL:
+		fmt.Fprintf(w, "BOOL%t", v.Value)
+	case *types.AttributeValueMemberNULL:
+		io.WriteString(w, "NULL")
+	case *types.AttributeValueMemberSS:
+		sorted("SS", v.Value)
+	case *types.AttributeValueMemberNS:
+		sorted("NS", v.Value)
+	case *types.AttributeValueMemberBS:
+		members := make([]string, len(v.Value))
+		for i, b := range v.Value {
+			members[i] = base64.StdEncoding.EncodeToString(b)
+		}
+		sorted("BS", members)
+	case *types.AttributeValueMemberL:
+		io.WriteString(w, "L[")
+		for _, e := range v.Value {
+			writeCanonical(w, e)
+			io.WriteString(w, ",")
+		}
+		io.WriteString(w, "]")
+	case *types.AttributeValueMemberM:
+		keys := make([]string, 0, len(v.Value))
+		for k := range v.Value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		io.WriteString(w, "M{")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%q:", k)
+			writeCanonical(w, v.Value[k])
+			io.WriteString(w, ",")
+		}
+		io.WriteString(w, "}")
+	}
+}
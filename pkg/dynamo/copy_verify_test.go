@@ -0,0 +1,55 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestVerifyCopyReportsMismatches(t *testing.T) {
+	f := copyFake() // a, b, c over two pages
+	f.scanOuts = append(f.scanOuts, &dynamodb.ScanOutput{Count: 2})
+	f.getFn = func(in *dynamodb.GetItemInput) *dynamodb.GetItemOutput {
+		switch id := in.Key["pk"].(*types.AttributeValueMemberS).Value; id {
+		case "a":
+			return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{"pk": in.Key["pk"]}}
+		case "b":
+			return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{"pk": in.Key["pk"], "extra": &types.AttributeValueMemberBOOL{}}}
+		}
+		return &dynamodb.GetItemOutput{}
+	}
+	rename := func(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{"pk": item["id"]}
+	}
+	r, err := newTestClient(f).VerifyCopy(context.Background(), VerifyInput{
+		CopyInput: CopyInput{Source: "Users", Dest: "UsersV2", DestKey: []string{"pk"}, Segments: 1, Transform: rename},
+		Samples:   10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.SourceCount != 3 || r.DestCount != 2 || r.Sampled != 3 || r.OK() {
+		t.Errorf("report = %+v", r)
+	}
+	if len(r.Different) != 1 || r.Different[0] != "pk=b" || len(r.Missing) != 1 || r.Missing[0] != "pk=c" {
+		t.Errorf("different %v, missing %v", r.Different, r.Missing)
+	}
+	if f.lastScan.Select != types.SelectCount || aws.ToString(f.lastScan.TableName) != "UsersV2" {
+		t.Errorf("the destination should be counted, not read: %+v", f.lastScan)
+	}
+}
+
+func TestItemHashIgnoresSetOrder(t *testing.T) {
+	a := map[string]types.AttributeValue{"tags": &types.AttributeValueMemberSS{Value: []string{"x", "y"}}, "n": &types.AttributeValueMemberN{Value: "1"}}
+	b := map[string]types.AttributeValue{"n": &types.AttributeValueMemberN{Value: "1"}, "tags": &types.AttributeValueMemberSS{Value: []string{"y", "x"}}}
+	if itemHash(a) != itemHash(b) {
+		t.Error("set member order should not change the hash")
+	}
+	b["n"] = &types.AttributeValueMemberS{Value: "1"}
+	if itemHash(a) == itemHash(b) {
+		t.Error("a string and a number should differ")
+	}
+}
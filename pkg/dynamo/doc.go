@@ -0,0 +1,8 @@
+// Package dynamo is GoDynamo's data layer: connecting with a profile,
+// region or local endpoint, discovering the regions that have tables, and
+// reading and writing them. ScanTable reads one page and
+// ScanTableContinuous keeps scanning until it has enough matches, which a
+// filtered scan of a large table needs. Other Go programs can import it to
+// reuse that connection handling; API is the interface to depend on, and
+// dynamotest.Fake implements it in memory.
+package dynamo
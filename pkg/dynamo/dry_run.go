@@ -0,0 +1,419 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// PlannedOp is a change dry-run mode recorded instead of making it. Item is
+// set for puts, Key for deletes and updates; Detail says the rest, such as
+// an update expression or a PartiQL statement.
+type PlannedOp struct {
+	At     time.Time
+	Op     string
+	Table  string
+	Item   map[string]types.AttributeValue
+	Key    map[string]types.AttributeValue
+	Detail string
+}
+
+// Plan collects the operations of a dry run. Bulk jobs write from several
+// goroutines, so it is safe for concurrent use.
+type Plan struct {
+	mu  sync.Mutex
+	ops []PlannedOp
+}
+
+// Ops returns a copy of the operations planned so far, oldest first
+func (p *Plan) Ops() []PlannedOp {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedOp(nil), p.ops...)
+}
+
+// Len is how many operations are planned
+func (p *Plan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.ops)
+}
+
+// Clear forgets every planned operation
+func (p *Plan) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ops = nil
+}
+
+func (p *Plan) add(op PlannedOp) {
+	op.At = time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ops = append(p.ops, op)
+}
+
+// DryRun returns api with every change recorded in plan instead of made;
+// reads still go through. A *Client is intercepted at its DynamoDB and auto
+// scaling calls, so bulk operations plan each item they would write. Any
+// other API is wrapped method by method. A nil plan returns api as it is.
+func DryRun(api API, plan *Plan) API {
+	if plan == nil {
+		return api
+	}
+	if c, ok := api.(*Client); ok {
+		return c.dryRun(plan)
+	}
+	return &dryRunClient{api: api, plan: plan}
+}
+
+// dryRun returns a copy of c that plans its changes. c itself, which may be
+// shared through the client pool, still makes them.
+func (c *Client) dryRun(plan *Plan) *Client {
+	return &Client{
+		db:       &dryRunAPI{db: c.db, plan: plan},
+		streams:  c.streams,
+		insights: c.insights,
+		scaling:  &dryRunScaling{scaling: c.scaling, plan: plan},
+		endpoint: c.endpoint,
+		region:   c.region,
+	}
+}
+
+// isSelect reports whether a PartiQL statement only reads
+func isSelect(stmt string) bool {
+	// A newline follows the verb as often as a space does
+	words := strings.Fields(stmt)
+	return len(words) > 0 && strings.EqualFold(words[0], "SELECT")
+}
+
+// dryRunAPI passes reads through and records writes. It spells out every
+// method rather than embedding dynamoAPI, so a call added to the seam can't
+// reach DynamoDB unplanned.
+type dryRunAPI struct {
+	db   dynamoAPI
+	plan *Plan
+}
+
+var _ dynamoAPI = (*dryRunAPI)(nil)
+
+func (d *dryRunAPI) ListTables(ctx context.Context, in *dynamodb.ListTablesInput, opts ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	return d.db.ListTables(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) DescribeTable(ctx context.Context, in *dynamodb.DescribeTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return d.db.DescribeTable(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) Scan(ctx context.Context, in *dynamodb.ScanInput, opts ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	return d.db.Scan(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) Query(ctx context.Context, in *dynamodb.QueryInput, opts ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return d.db.Query(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) GetItem(ctx context.Context, in *dynamodb.GetItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return d.db.GetItem(ctx, in, opts...)
+}
+
+// ExportTableToPointInTime runs: an export reads the table and writes only
+// to the bucket it is given
+func (d *dryRunAPI) ExportTableToPointInTime(ctx context.Context, in *dynamodb.ExportTableToPointInTimeInput, opts ...func(*dynamodb.Options)) (*dynamodb.ExportTableToPointInTimeOutput, error) {
+	return d.db.ExportTableToPointInTime(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) DescribeExport(ctx context.Context, in *dynamodb.DescribeExportInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeExportOutput, error) {
+	return d.db.DescribeExport(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) DescribeImport(ctx context.Context, in *dynamodb.DescribeImportInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeImportOutput, error) {
+	return d.db.DescribeImport(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) DescribeContributorInsights(ctx context.Context, in *dynamodb.DescribeContributorInsightsInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeContributorInsightsOutput, error) {
+	return d.db.DescribeContributorInsights(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) GetResourcePolicy(ctx context.Context, in *dynamodb.GetResourcePolicyInput, opts ...func(*dynamodb.Options)) (*dynamodb.GetResourcePolicyOutput, error) {
+	return d.db.GetResourcePolicy(ctx, in, opts...)
+}
+
+func (d *dryRunAPI) PutItem(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	d.plan.add(PlannedOp{Op: "PutItem", Table: aws.ToString(in.TableName), Item: in.Item, Detail: aws.ToString(in.ConditionExpression)})
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (d *dryRunAPI) DeleteItem(_ context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	d.plan.add(PlannedOp{Op: "DeleteItem", Table: aws.ToString(in.TableName), Key: in.Key, Detail: aws.ToString(in.ConditionExpression)})
+	return &dynamodb.DeleteItemOutput{Attributes: in.Key}, nil // as if the item was there
+}
+
+func (d *dryRunAPI) UpdateItem(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	d.plan.add(PlannedOp{Op: "UpdateItem", Table: aws.ToString(in.TableName), Key: in.Key, Detail: aws.ToString(in.UpdateExpression)})
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+// BatchWriteItem plans each request of the batch on its own, and reports
+// none of them unprocessed
+func (d *dryRunAPI) BatchWriteItem(_ context.Context, in *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	for table, reqs := range in.RequestItems {
+		for _, r := range reqs {
+			switch {
+			case r.PutRequest != nil:
+				d.plan.add(PlannedOp{Op: "PutItem", Table: table, Item: r.PutRequest.Item, Detail: "batch"})
+			case r.DeleteRequest != nil:
+				d.plan.add(PlannedOp{Op: "DeleteItem", Table: table, Key: r.DeleteRequest.Key, Detail: "batch"})
+			}
+		}
+	}
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func (d *dryRunAPI) CreateTable(_ context.Context, in *dynamodb.CreateTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	keys := make([]string, len(in.KeySchema))
+	for i, k := range in.KeySchema {
+		keys[i] = fmt.Sprintf("%s (%s)", aws.ToString(k.AttributeName), k.KeyType)
+	}
+	detail := "key " + strings.Join(keys, ", ")
+	if in.BillingMode != "" {
+		detail += ", " + string(in.BillingMode)
+	}
+	d.plan.add(PlannedOp{Op: "CreateTable", Table: aws.ToString(in.TableName), Detail: detail})
+	return &dynamodb.CreateTableOutput{}, nil
+}
+
+func (d *dryRunAPI) ImportTable(_ context.Context, in *dynamodb.ImportTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.ImportTableOutput, error) {
+	var table, source string
+	if in.TableCreationParameters != nil {
+		table = aws.ToString(in.TableCreationParameters.TableName)
+	}
+	if in.S3BucketSource != nil {
+		source = "s3://" + aws.ToString(in.S3BucketSource.S3Bucket) + "/" + aws.ToString(in.S3BucketSource.S3KeyPrefix)
+	}
+	d.plan.add(PlannedOp{Op: "ImportTable", Table: table, Detail: "from " + source})
+	return &dynamodb.ImportTableOutput{}, nil
+}
+
+// ExecuteStatement runs SELECTs, which only read, and plans the other
+// PartiQL statements
+func (d *dryRunAPI) ExecuteStatement(ctx context.Context, in *dynamodb.ExecuteStatementInput, opts ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+	stmt := strings.TrimSpace(aws.ToString(in.Statement))
+	if isSelect(stmt) {
+		return d.db.ExecuteStatement(ctx, in, opts...)
+	}
+	d.plan.add(PlannedOp{Op: "ExecuteStatement", Detail: stmt})
+	return &dynamodb.ExecuteStatementOutput{}, nil
+}
+
+func (d *dryRunAPI) UpdateContributorInsights(_ context.Context, in *dynamodb.UpdateContributorInsightsInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateContributorInsightsOutput, error) {
+	detail := string(in.ContributorInsightsAction)
+	if in.IndexName != nil {
+		detail += " on " + aws.ToString(in.IndexName)
+	}
+	d.plan.add(PlannedOp{Op: "UpdateContributorInsights", Table: aws.ToString(in.TableName), Detail: detail})
+	return &dynamodb.UpdateContributorInsightsOutput{}, nil
+}
+
+// dryRunScaling does the same for auto scaling settings
+type dryRunScaling struct {
+	scaling autoScalingAPI
+	plan    *Plan
+}
+
+var _ autoScalingAPI = (*dryRunScaling)(nil)
+
+func (d *dryRunScaling) DescribeScalableTargets(ctx context.Context, in *applicationautoscaling.DescribeScalableTargetsInput, opts ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalableTargetsOutput, error) {
+	return d.scaling.DescribeScalableTargets(ctx, in, opts...)
+}
+
+func (d *dryRunScaling) DescribeScalingPolicies(ctx context.Context, in *applicationautoscaling.DescribeScalingPoliciesInput, opts ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalingPoliciesOutput, error) {
+	return d.scaling.DescribeScalingPolicies(ctx, in, opts...)
+}
+
+func (d *dryRunScaling) RegisterScalableTarget(_ context.Context, in *applicationautoscaling.RegisterScalableTargetInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.RegisterScalableTargetOutput, error) {
+	d.plan.add(PlannedOp{
+		Op:     "RegisterScalableTarget",
+		Table:  aws.ToString(in.ResourceId),
+		Detail: fmt.Sprintf("%s %d-%d", in.ScalableDimension, aws.ToInt32(in.MinCapacity), aws.ToInt32(in.MaxCapacity)),
+	})
+	return &applicationautoscaling.RegisterScalableTargetOutput{}, nil
+}
+
+func (d *dryRunScaling) PutScalingPolicy(_ context.Context, in *applicationautoscaling.PutScalingPolicyInput, _ ...func(*applicationautoscaling.Options)) (*applicationautoscaling.PutScalingPolicyOutput, error) {
+	detail := aws.ToString(in.PolicyName)
+	if t := in.TargetTrackingScalingPolicyConfiguration; t != nil {
+		detail += fmt.Sprintf(" at %g%%", aws.ToFloat64(t.TargetValue))
+	}
+	d.plan.add(PlannedOp{Op: "PutScalingPolicy", Table: aws.ToString(in.ResourceId), Detail: detail})
+	return &applicationautoscaling.PutScalingPolicyOutput{}, nil
+}
+
+// dryRunClient plans the changes of an API that isn't a *Client, such as
+// an injected one. Bulk operations are planned as a whole.
+type dryRunClient struct {
+	api  API
+	plan *Plan
+}
+
+var _ API = (*dryRunClient)(nil)
+
+func (d *dryRunClient) ListTables(ctx context.Context) ([]string, error) {
+	return d.api.ListTables(ctx)
+}
+
+func (d *dryRunClient) DescribeTable(ctx context.Context, tableName string) (*TableInfo, error) {
+	return d.api.DescribeTable(ctx, tableName)
+}
+
+func (d *dryRunClient) RefreshTable(ctx context.Context, tableName string) (*TableInfo, error) {
+	return d.api.RefreshTable(ctx, tableName)
+}
+
+func (d *dryRunClient) InvalidateSchemaCache() {
+	d.api.InvalidateSchemaCache()
+}
+
+func (d *dryRunClient) CreateTable(_ context.Context, input CreateTableInput) error {
+	d.plan.add(PlannedOp{Op: "CreateTable", Table: input.TableName, Detail: "key " + input.PartitionKey})
+	return nil
+}
+
+func (d *dryRunClient) Ping(ctx context.Context) (time.Duration, error) {
+	return d.api.Ping(ctx)
+}
+
+func (d *dryRunClient) ScanTable(ctx context.Context, input ScanInput, opts ScanOptions) (*ScanResult, error) {
+	return d.api.ScanTable(ctx, input, opts)
+}
+
+func (d *dryRunClient) ScanTableContinuous(ctx context.Context, input ScanInput, opts ScanOptions) (*ContinuousScanResult, error) {
+	return d.api.ScanTableContinuous(ctx, input, opts)
+}
+
+func (d *dryRunClient) ParallelScan(ctx context.Context, tableName string, segments int, onPage func(ScanPage) error) error {
+	return d.api.ParallelScan(ctx, tableName, segments, onPage)
+}
+
+func (d *dryRunClient) SampleScan(ctx context.Context, input SampleInput) (*SampleResult, error) {
+	return d.api.SampleScan(ctx, input)
+}
+
+func (d *dryRunClient) QueryTable(ctx context.Context, input QueryInput) (*QueryResult, error) {
+	return d.api.QueryTable(ctx, input)
+}
+
+func (d *dryRunClient) GetItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	return d.api.GetItem(ctx, tableName, key)
+}
+
+func (d *dryRunClient) ExecuteStatement(ctx context.Context, statement string, nextToken *string, limit int32) (*StatementResult, error) {
+	if isSelect(statement) {
+		return d.api.ExecuteStatement(ctx, statement, nextToken, limit)
+	}
+	d.plan.add(PlannedOp{Op: "ExecuteStatement", Detail: strings.TrimSpace(statement)})
+	return &StatementResult{}, nil
+}
+
+func (d *dryRunClient) PutItem(_ context.Context, tableName string, item map[string]types.AttributeValue) error {
+	d.plan.add(PlannedOp{Op: "PutItem", Table: tableName, Item: item})
+	return nil
+}
+
+func (d *dryRunClient) DeleteItem(_ context.Context, tableName string, key map[string]types.AttributeValue) (bool, error) {
+	d.plan.add(PlannedOp{Op: "DeleteItem", Table: tableName, Key: key})
+	return true, nil
+}
+
+func (d *dryRunClient) WriteItems(_ context.Context, tableName string, items []map[string]types.AttributeValue) error {
+	for _, item := range items {
+		d.plan.add(PlannedOp{Op: "PutItem", Table: tableName, Item: item, Detail: "batch"})
+	}
+	return nil
+}
+
+// RenameAttribute counts the items the rename would change, as a rename's
+// own dry run does, and plans it as one operation
+func (d *dryRunClient) RenameAttribute(ctx context.Context, in RenameInput, onPage func(RenameProgress)) (RenameProgress, error) {
+	wanted := in.DryRun
+	in.DryRun = true
+	p, err := d.api.RenameAttribute(ctx, in, onPage)
+	if err == nil && !wanted {
+		d.plan.add(PlannedOp{Op: "RenameAttribute", Table: in.Table, Detail: fmt.Sprintf("%s to %s on %d items", in.From, in.To, p.Matched)})
+	}
+	return p, err
+}
+
+func (d *dryRunClient) CopyTable(_ context.Context, in CopyInput, _ func(CopyProgress)) (CopyProgress, error) {
+	d.plan.add(PlannedOp{Op: "CopyTable", Table: in.Dest, Detail: "from " + in.Source})
+	return CopyProgress{}, nil
+}
+
+func (d *dryRunClient) VerifyCopy(ctx context.Context, in VerifyInput) (VerifyReport, error) {
+	return d.api.VerifyCopy(ctx, in)
+}
+
+func (d *dryRunClient) DescribeAutoScaling(ctx context.Context, info *TableInfo) ([]ScalingTarget, error) {
+	return d.api.DescribeAutoScaling(ctx, info)
+}
+
+func (d *dryRunClient) UpdateAutoScaling(_ context.Context, table string, t ScalingTarget) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	d.plan.add(PlannedOp{Op: "UpdateAutoScaling", Table: table, Detail: fmt.Sprintf("%d-%d at %g%%", t.Min, t.Max, t.TargetUtilization)})
+	return nil
+}
+
+func (d *dryRunClient) DescribeContributorInsights(ctx context.Context, tableName string) (*ContributorInsights, error) {
+	return d.api.DescribeContributorInsights(ctx, tableName)
+}
+
+func (d *dryRunClient) SetContributorInsights(_ context.Context, tableName string, enable bool) (string, error) {
+	action := types.ContributorInsightsActionDisable
+	if enable {
+		action = types.ContributorInsightsActionEnable
+	}
+	d.plan.add(PlannedOp{Op: "UpdateContributorInsights", Table: tableName, Detail: string(action)})
+	return "", nil
+}
+
+func (d *dryRunClient) TopContributors(ctx context.Context, rule string, window time.Duration, limit int32) (*InsightReport, error) {
+	return d.api.TopContributors(ctx, rule, window, limit)
+}
+
+func (d *dryRunClient) ResourcePolicy(ctx context.Context, arn string) (string, error) {
+	return d.api.ResourcePolicy(ctx, arn)
+}
+
+func (d *dryRunClient) StartS3Export(ctx context.Context, input S3ExportInput) (*S3Export, error) {
+	return d.api.StartS3Export(ctx, input)
+}
+
+func (d *dryRunClient) DescribeS3Export(ctx context.Context, exportArn string) (*S3Export, error) {
+	return d.api.DescribeS3Export(ctx, exportArn)
+}
+
+func (d *dryRunClient) StartS3Import(_ context.Context, input S3ImportInput) (*S3Import, error) {
+	d.plan.add(PlannedOp{Op: "ImportTable", Table: input.Table.TableName, Detail: "from s3://" + input.Bucket + "/" + input.Prefix})
+	return &S3Import{}, nil
+}
+
+func (d *dryRunClient) DescribeS3Import(ctx context.Context, importArn string) (*S3Import, error) {
+	return d.api.DescribeS3Import(ctx, importArn)
+}
+
+func (d *dryRunClient) ListStreamShards(ctx context.Context, streamArn string) ([]StreamShard, error) {
+	return d.api.ListStreamShards(ctx, streamArn)
+}
+
+func (d *dryRunClient) OpenStream(ctx context.Context, streamArn string, shards []string, start StreamStart, at time.Time) (*StreamReader, error) {
+	return d.api.OpenStream(ctx, streamArn, shards, start, at)
+}
@@ -0,0 +1,100 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func dryRunTestClient(t *testing.T, f *fakeAPI) (*Client, *Plan) {
+	t.Helper()
+	plan := &Plan{}
+	return DryRun(newTestClient(f), plan).(*Client), plan
+}
+
+func TestDryRunPlansWritesInsteadOfSendingThem(t *testing.T) {
+	f := &fakeAPI{}
+	c, plan := dryRunTestClient(t, f)
+	ctx := context.Background()
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "a"}}
+
+	if err := c.PutItem(ctx, "Users", key); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := c.DeleteItem(ctx, "Users", key); err != nil || !found {
+		t.Fatal(found, err)
+	}
+	if err := c.CreateTable(ctx, CreateTableInput{TableName: "New", PartitionKey: "pk", PartitionType: "S"}); err != nil {
+		t.Fatal(err)
+	}
+	items := []map[string]types.AttributeValue{key, {"id": &types.AttributeValueMemberS{Value: "b"}}}
+	if err := c.WriteItems(ctx, "Users", items); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ExecuteStatement(ctx, `DELETE FROM "Users" WHERE id = 'a'`, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if f.lastPut != nil || f.lastDelete != nil || f.lastCreate != nil || len(f.batches) != 0 || f.lastStmt != nil {
+		t.Fatalf("a dry run reached DynamoDB: %+v", f)
+	}
+	var got []string
+	for _, op := range plan.Ops() {
+		got = append(got, op.Op+" "+op.Table)
+	}
+	want := []string{"PutItem Users", "DeleteItem Users", "CreateTable New", "PutItem Users", "PutItem Users", "ExecuteStatement "}
+	if len(got) != len(want) {
+		t.Fatalf("planned %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("op %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if ops := plan.Ops(); ops[1].Key["id"] == nil || ops[0].Item["id"] == nil {
+		t.Errorf("planned ops lost their key or item: %+v", ops[:2])
+	}
+}
+
+func TestDryRunStillReads(t *testing.T) {
+	f := &fakeAPI{stmtOut: &dynamodb.ExecuteStatementOutput{Items: []map[string]types.AttributeValue{{}}}}
+	c, plan := dryRunTestClient(t, f)
+	res, err := c.ExecuteStatement(context.Background(), `select * FROM "Users"`, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.lastStmt == nil || len(res.Items) != 1 {
+		t.Error("a SELECT should run in a dry run")
+	}
+	if plan.Len() != 0 {
+		t.Errorf("a SELECT was planned: %+v", plan.Ops())
+	}
+}
+
+func TestDryRunReadsASelectSplitOverLines(t *testing.T) {
+	f := &fakeAPI{stmtOut: &dynamodb.ExecuteStatementOutput{}}
+	c, plan := dryRunTestClient(t, f)
+	if _, err := c.ExecuteStatement(context.Background(), "SELECT\n  *\nFROM \"Users\"", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if f.lastStmt == nil || plan.Len() != 0 {
+		t.Errorf("a SELECT followed by a newline was planned: %+v", plan.Ops())
+	}
+}
+
+func TestDryRunOffLeavesClientsAlone(t *testing.T) {
+	f := &fakeAPI{}
+	c := newTestClient(f)
+	if DryRun(c, nil) != API(c) {
+		t.Fatal("without a plan the client should be returned as it is")
+	}
+	DryRun(c, &Plan{}) // planning with a copy leaves c alone
+	if err := c.PutItem(context.Background(), "Users", map[string]types.AttributeValue{}); err != nil {
+		t.Fatal(err)
+	}
+	if f.lastPut == nil {
+		t.Error("without a plan writes should be sent")
+	}
+}
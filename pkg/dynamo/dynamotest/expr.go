@@ -0,0 +1,377 @@
+package dynamotest
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/models"
+)
+
+// condition tells whether an item satisfies an expression
+type condition func(item map[string]types.AttributeValue) (bool, error)
+
+// compile parses a filter or key condition expression: comparisons (=, <>,
+// <, <=, >, >=), BETWEEN, IN, attribute_exists, attribute_not_exists,
+// begins_with and contains, joined by AND, OR and NOT with parentheses.
+// An empty expression matches every item.
+func compile(expr string, names map[string]string, values map[string]interface{}) (condition, error) {
+	if strings.TrimSpace(expr) == "" {
+		return func(map[string]types.AttributeValue) (bool, error) { return true, nil }, nil
+	}
+	p := &parser{tokens: tokenize(expr), names: names, values: values}
+	c, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("dynamotest: %q: %w", expr, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("dynamotest: %q: unexpected %q", expr, p.tokens[p.pos])
+	}
+	return c, nil
+}
+
+func tokenize(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		r := rune(s[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("(),", r):
+			tokens = append(tokens, string(r))
+			i++
+		case strings.ContainsRune("<>=", r):
+			j := i + 1
+			for j < len(s) && strings.ContainsRune("<>=", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && !strings.ContainsRune("(),<>=", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+	names  map[string]string
+	values map[string]interface{}
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(t string) error {
+	if got := p.next(); got != t {
+		return fmt.Errorf("want %q, got %q", t, got)
+	}
+	return nil
+}
+
+func (p *parser) or() (condition, error) {
+	left, err := p.and()
+	for err == nil && strings.EqualFold(p.peek(), "OR") {
+		p.next()
+		var right condition
+		if right, err = p.and(); err == nil {
+			l := left
+			left = func(item map[string]types.AttributeValue) (bool, error) {
+				if ok, err := l(item); ok || err != nil {
+					return ok, err
+				}
+				return right(item)
+			}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) and() (condition, error) {
+	left, err := p.not()
+	for err == nil && strings.EqualFold(p.peek(), "AND") {
+		p.next()
+		var right condition
+		if right, err = p.not(); err == nil {
+			l := left
+			left = func(item map[string]types.AttributeValue) (bool, error) {
+				if ok, err := l(item); !ok || err != nil {
+					return ok, err
+				}
+				return right(item)
+			}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) not() (condition, error) {
+	if !strings.EqualFold(p.peek(), "NOT") {
+		return p.primary()
+	}
+	p.next()
+	c, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	return func(item map[string]types.AttributeValue) (bool, error) {
+		ok, err := c(item)
+		return !ok, err
+	}, nil
+}
+
+// operand is an attribute's value in an item, or a placeholder's value;
+// absent is false for an item without the attribute
+type operand func(item map[string]types.AttributeValue) (v interface{}, present bool)
+
+func (p *parser) primary() (condition, error) {
+	if p.peek() == "(" {
+		p.next()
+		c, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return c, p.expect(")")
+	}
+	if fn := strings.ToLower(p.peek()); p.pos+1 < len(p.tokens) && p.tokens[p.pos+1] == "(" {
+		return p.function(fn)
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.next(); strings.ToUpper(op) {
+	case "BETWEEN":
+		lo, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("AND"); err != nil {
+			return nil, err
+		}
+		hi, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return func(item map[string]types.AttributeValue) (bool, error) {
+			v, ok := left(item)
+			l, _ := lo(item)
+			h, _ := hi(item)
+			return ok && compare(v, l) >= 0 && compare(v, h) <= 0, nil
+		}, nil
+	case "IN":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		var list []operand
+		for {
+			o, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, o)
+			if p.peek() != "," {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return func(item map[string]types.AttributeValue) (bool, error) {
+			v, ok := left(item)
+			for _, o := range list {
+				if w, _ := o(item); ok && equal(v, w) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}, nil
+	case "=", "<>", "<", "<=", ">", ">=":
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return func(item map[string]types.AttributeValue) (bool, error) {
+			a, aok := left(item)
+			b, bok := right(item)
+			if !aok || !bok {
+				return op == "<>", nil
+			}
+			switch op {
+			case "=":
+				return equal(a, b), nil
+			case "<>":
+				return !equal(a, b), nil
+			case "<":
+				return compare(a, b) < 0, nil
+			case "<=":
+				return compare(a, b) <= 0, nil
+			case ">":
+				return compare(a, b) > 0, nil
+			}
+			return compare(a, b) >= 0, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+}
+
+func (p *parser) function(name string) (condition, error) {
+	p.next()
+	p.next() // (
+	var args []operand
+	for p.peek() != ")" {
+		o, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, o)
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.next()
+
+	arity := map[string]int{"attribute_exists": 1, "attribute_not_exists": 1, "begins_with": 2, "contains": 2}
+	if n, ok := arity[name]; !ok {
+		return nil, fmt.Errorf("unsupported function %s", name)
+	} else if len(args) != n {
+		return nil, fmt.Errorf("%s takes %d argument(s)", name, n)
+	}
+	return func(item map[string]types.AttributeValue) (bool, error) {
+		v, ok := args[0](item)
+		switch name {
+		case "attribute_exists":
+			return ok, nil
+		case "attribute_not_exists":
+			return !ok, nil
+		}
+		w, _ := args[1](item)
+		if !ok {
+			return false, nil
+		}
+		if name == "begins_with" {
+			s, isString := v.(string)
+			prefix, _ := w.(string)
+			return isString && strings.HasPrefix(s, prefix), nil
+		}
+		if s, isString := v.(string); isString {
+			sub, _ := w.(string)
+			return strings.Contains(s, sub), nil
+		}
+		if list := reflect.ValueOf(v); list.Kind() == reflect.Slice {
+			for i := range list.Len() {
+				if equal(list.Index(i).Interface(), w) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}, nil
+}
+
+func (p *parser) operand() (operand, error) {
+	t := p.next()
+	switch {
+	case t == "" || strings.ContainsAny(t, "(),"):
+		return nil, fmt.Errorf("want an attribute or value, got %q", t)
+	case strings.HasPrefix(t, ":"):
+		v, ok := p.values[t]
+		if !ok {
+			return nil, fmt.Errorf("no value for %s", t)
+		}
+		return func(map[string]types.AttributeValue) (interface{}, bool) { return v, true }, nil
+	}
+	name := t
+	if strings.HasPrefix(t, "#") {
+		n, ok := p.names[t]
+		if !ok {
+			return nil, fmt.Errorf("no name for %s", t)
+		}
+		name = n
+	}
+	return func(item map[string]types.AttributeValue) (interface{}, bool) {
+		av, ok := item[name]
+		if !ok {
+			return nil, false
+		}
+		return models.AttributeValueToInterface(av), true
+	}, nil
+}
+
+// attr is an item's attribute as a Go value, nil when it lacks it
+func attr(item map[string]types.AttributeValue, name string) interface{} {
+	if av, ok := item[name]; ok {
+		return models.AttributeValueToInterface(av)
+	}
+	return nil
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func equal(a, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare orders two values of the same type: numbers, strings or binary.
+// Values of different types order by type, so they are never equal.
+func compare(a, b interface{}) int {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return cmp.Compare(x, y)
+		}
+		return -1
+	}
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	case []byte:
+		if y, ok := b.([]byte); ok {
+			return bytes.Compare(x, y)
+		}
+	}
+	if equal(a, b) {
+		return 0
+	}
+	return cmp.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b))
+}
@@ -0,0 +1,433 @@
+// Package dynamotest provides an in-memory dynamo.API for tests of code
+// that reads and writes tables, such as the TUI's workflows.
+package dynamotest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/models"
+)
+
+// Fake keeps tables in memory. Scans return items in the order they were
+// put; filter and key condition expressions are evaluated for the forms
+// query.BuildExpression and the query planner write. Operations on the
+// services around DynamoDB (streams, S3, auto scaling, ...) and PartiQL
+// return an error. A Fake is safe for concurrent use.
+type Fake struct {
+	mu     sync.Mutex
+	tables map[string]*table
+}
+
+type table struct {
+	info  dynamo.TableInfo
+	items []map[string]types.AttributeValue
+}
+
+var _ dynamo.API = (*Fake)(nil)
+
+// New returns a Fake without tables
+func New() *Fake {
+	return &Fake{tables: map[string]*table{}}
+}
+
+// AddTable creates a table holding items. Info needs a Name and a
+// PartitionKey; an empty Status is ACTIVE.
+func (f *Fake) AddTable(info dynamo.TableInfo, items ...map[string]types.AttributeValue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if info.Status == "" {
+		info.Status = "ACTIVE"
+	}
+	t := &table{info: info}
+	for _, item := range items {
+		t.put(item)
+	}
+	f.tables[info.Name] = t
+}
+
+// Items is a copy of a table's items, in scan order
+func (f *Fake) Items(tableName string) []map[string]types.AttributeValue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tables[tableName]
+	if !ok {
+		return nil
+	}
+	return slices.Clone(t.items)
+}
+
+// unsupported is the error of an operation the Fake doesn't emulate
+func unsupported(op string) error {
+	return fmt.Errorf("dynamotest: %s is not supported by the fake", op)
+}
+
+func (f *Fake) table(name string) (*table, error) {
+	t, ok := f.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("ResourceNotFoundException: Requested resource not found: Table: %s not found", name)
+	}
+	return t, nil
+}
+
+// keyOf is item's primary key under info's key schema
+func keyOf(pk, sk string, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	key := map[string]types.AttributeValue{pk: item[pk]}
+	if sk != "" {
+		key[sk] = item[sk]
+	}
+	return key
+}
+
+// sameKey reports whether item has key's values
+func sameKey(item, key map[string]types.AttributeValue) bool {
+	for name, v := range key {
+		got, ok := item[name]
+		if !ok || compare(models.AttributeValueToInterface(got), models.AttributeValueToInterface(v)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *table) find(key map[string]types.AttributeValue) int {
+	return slices.IndexFunc(t.items, func(item map[string]types.AttributeValue) bool { return sameKey(item, key) })
+}
+
+func (t *table) put(item map[string]types.AttributeValue) error {
+	for _, name := range []string{t.info.PartitionKey, t.info.SortKey} {
+		if _, ok := item[name]; name != "" && !ok {
+			return fmt.Errorf("ValidationException: One of the required keys was not given a value: %s", name)
+		}
+	}
+	if i := t.find(keyOf(t.info.PartitionKey, t.info.SortKey, item)); i >= 0 {
+		t.items[i] = item
+	} else {
+		t.items = append(t.items, item)
+	}
+	return nil
+}
+
+func (f *Fake) ListTables(ctx context.Context) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.tables))
+	for name := range f.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (f *Fake) DescribeTable(ctx context.Context, tableName string) (*dynamo.TableInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	info := t.info
+	info.ItemCount = int64(len(t.items))
+	return &info, nil
+}
+
+func (f *Fake) RefreshTable(ctx context.Context, tableName string) (*dynamo.TableInfo, error) {
+	return f.DescribeTable(ctx, tableName)
+}
+
+func (f *Fake) InvalidateSchemaCache() {}
+
+func (f *Fake) CreateTable(ctx context.Context, input dynamo.CreateTableInput) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.tables[input.TableName]; ok {
+		return fmt.Errorf("ResourceInUseException: Table already exists: %s", input.TableName)
+	}
+	f.tables[input.TableName] = &table{info: dynamo.TableInfo{
+		Name:          input.TableName,
+		Status:        "ACTIVE",
+		PartitionKey:  input.PartitionKey,
+		PartitionType: input.PartitionType,
+		SortKey:       input.SortKey,
+		SortKeyType:   input.SortKeyType,
+		BillingMode:   cmp.Or(input.BillingMode, "PAY_PER_REQUEST"),
+		ReadCapacity:  input.ReadCapacity,
+		WriteCapacity: input.WriteCapacity,
+	}}
+	return nil
+}
+
+func (f *Fake) Ping(ctx context.Context) (time.Duration, error) {
+	return 0, nil
+}
+
+// page reads up to limit of items from the one after startKey, like a
+// Scan or Query page: limit counts the items read, before filter drops
+// any. A limit of 0 reads them all.
+func page(items []map[string]types.AttributeValue, pk, sk string, limit int, startKey map[string]types.AttributeValue, filter func(map[string]types.AttributeValue) (bool, error)) (matched []map[string]types.AttributeValue, read int, lastKey map[string]types.AttributeValue, err error) {
+	start := 0
+	if startKey != nil {
+		start = slices.IndexFunc(items, func(item map[string]types.AttributeValue) bool { return sameKey(item, startKey) }) + 1
+	}
+	end := len(items)
+	if limit > 0 {
+		end = min(start+limit, len(items))
+	}
+	for _, item := range items[start:end] {
+		ok, err := filter(item)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+	if end < len(items) && end > start {
+		lastKey = keyOf(pk, sk, items[end-1])
+	}
+	return matched, end - start, lastKey, nil
+}
+
+func (f *Fake) ScanTable(ctx context.Context, input dynamo.ScanInput, opts dynamo.ScanOptions) (*dynamo.ScanResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := compile(input.FilterExpression, input.ExpressionAttributeNames, input.ExpressionValues)
+	if err != nil {
+		return nil, err
+	}
+	items, read, lastKey, err := page(t.items, t.info.PartitionKey, t.info.SortKey, int(input.Limit), input.StartKey, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamo.ScanResult{Items: items, LastEvaluatedKey: lastKey, Count: int32(len(items)), ScannedCount: int32(read)}, nil
+}
+
+func (f *Fake) ScanTableContinuous(ctx context.Context, input dynamo.ScanInput, opts dynamo.ScanOptions) (*dynamo.ContinuousScanResult, error) {
+	result := &dynamo.ContinuousScanResult{LastEvaluatedKey: input.StartKey}
+	next := input
+	next.Limit = 100
+	for {
+		next.StartKey = result.LastEvaluatedKey
+		res, err := f.ScanTable(ctx, next, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, res.Items...)
+		result.TotalScanned += int64(res.ScannedCount)
+		result.LastEvaluatedKey = res.LastEvaluatedKey
+		result.HasMore = res.LastEvaluatedKey != nil
+		if !result.HasMore || len(result.Items) >= int(input.Limit) {
+			return result, nil
+		}
+	}
+}
+
+func (f *Fake) ParallelScan(ctx context.Context, tableName string, segments int, onPage func(dynamo.ScanPage) error) error {
+	items := f.Items(tableName)
+	if _, err := f.DescribeTable(ctx, tableName); err != nil {
+		return err
+	}
+	segments = max(segments, 1)
+	for s := range segments {
+		var seg []map[string]types.AttributeValue
+		for i := s; i < len(items); i += segments {
+			seg = append(seg, items[i])
+		}
+		if err := onPage(dynamo.ScanPage{Segment: s, Items: seg, Scanned: int64(len(seg)), SegmentDone: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Fake) SampleScan(ctx context.Context, input dynamo.SampleInput) (*dynamo.SampleResult, error) {
+	res, err := f.ScanTable(ctx, dynamo.ScanInput{
+		TableName:                input.TableName,
+		FilterExpression:         input.FilterExpression,
+		ExpressionAttributeNames: input.ExpressionAttributeNames,
+		ExpressionValues:         input.ExpressionValues,
+		Limit:                    input.Limit * int32(max(input.Segments, 1)),
+	}, dynamo.ScanOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &dynamo.SampleResult{Items: res.Items, Segments: []int{0}, TotalSegments: max(input.TotalSegments, 1), ScannedCount: int64(res.ScannedCount)}, nil
+}
+
+func (f *Fake) QueryTable(ctx context.Context, input dynamo.QueryInput) (*dynamo.QueryResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	pk, sk := t.info.PartitionKey, t.info.SortKey
+	if input.IndexName != "" {
+		indexes := append(slices.Clone(t.info.GSIs), t.info.LSIs...)
+		i := slices.IndexFunc(indexes, func(ix dynamo.IndexInfo) bool { return ix.Name == input.IndexName })
+		if i < 0 {
+			return nil, fmt.Errorf("ValidationException: The table does not have the specified index: %s", input.IndexName)
+		}
+		pk, sk = indexes[i].PartitionKey, indexes[i].SortKey
+	}
+	if input.KeyConditionExpression == "" {
+		return nil, fmt.Errorf("ValidationException: KeyConditionExpression is required")
+	}
+	keyCond, err := compile(input.KeyConditionExpression, input.ExpressionAttributeNames, input.ExpressionValues)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := compile(input.FilterExpression, input.ExpressionAttributeNames, input.ExpressionValues)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []map[string]types.AttributeValue
+	for _, item := range t.items {
+		if _, ok := item[pk]; !ok {
+			continue // not in the index
+		}
+		if ok, err := keyCond(item); err != nil {
+			return nil, err
+		} else if ok {
+			items = append(items, item)
+		}
+	}
+	if sk != "" {
+		slices.SortStableFunc(items, func(a, b map[string]types.AttributeValue) int {
+			c := compare(attr(a, sk), attr(b, sk))
+			if !input.ScanIndexForward {
+				c = -c
+			}
+			return c
+		})
+	}
+	matched, read, lastKey, err := page(items, t.info.PartitionKey, t.info.SortKey, int(input.Limit), input.StartKey, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamo.QueryResult{Items: matched, LastEvaluatedKey: lastKey, Count: int32(len(matched)), ScannedCount: int32(read)}, nil
+}
+
+func (f *Fake) GetItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	if i := t.find(key); i >= 0 {
+		return t.items[i], nil
+	}
+	return nil, nil
+}
+
+func (f *Fake) ExecuteStatement(ctx context.Context, statement string, nextToken *string, limit int32) (*dynamo.StatementResult, error) {
+	return nil, unsupported("ExecuteStatement")
+}
+
+func (f *Fake) PutItem(ctx context.Context, tableName string, item map[string]types.AttributeValue) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(tableName)
+	if err != nil {
+		return err
+	}
+	return t.put(item)
+}
+
+func (f *Fake) DeleteItem(ctx context.Context, tableName string, key map[string]types.AttributeValue) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(tableName)
+	if err != nil {
+		return false, err
+	}
+	i := t.find(key)
+	if i < 0 {
+		return false, nil
+	}
+	t.items = slices.Delete(t.items, i, i+1)
+	return true, nil
+}
+
+func (f *Fake) WriteItems(ctx context.Context, tableName string, items []map[string]types.AttributeValue) error {
+	for _, item := range items {
+		if err := f.PutItem(ctx, tableName, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Fake) RenameAttribute(ctx context.Context, in dynamo.RenameInput, onPage func(dynamo.RenameProgress)) (dynamo.RenameProgress, error) {
+	return dynamo.RenameProgress{}, unsupported("RenameAttribute")
+}
+
+func (f *Fake) CopyTable(ctx context.Context, in dynamo.CopyInput, onPage func(dynamo.CopyProgress)) (dynamo.CopyProgress, error) {
+	return dynamo.CopyProgress{}, unsupported("CopyTable")
+}
+
+func (f *Fake) VerifyCopy(ctx context.Context, in dynamo.VerifyInput) (dynamo.VerifyReport, error) {
+	return dynamo.VerifyReport{}, unsupported("VerifyCopy")
+}
+
+func (f *Fake) DescribeAutoScaling(ctx context.Context, info *dynamo.TableInfo) ([]dynamo.ScalingTarget, error) {
+	return nil, unsupported("DescribeAutoScaling")
+}
+
+func (f *Fake) UpdateAutoScaling(ctx context.Context, table string, t dynamo.ScalingTarget) error {
+	return unsupported("UpdateAutoScaling")
+}
+
+func (f *Fake) DescribeContributorInsights(ctx context.Context, tableName string) (*dynamo.ContributorInsights, error) {
+	return nil, unsupported("DescribeContributorInsights")
+}
+
+func (f *Fake) SetContributorInsights(ctx context.Context, tableName string, enable bool) (string, error) {
+	return "", unsupported("SetContributorInsights")
+}
+
+func (f *Fake) TopContributors(ctx context.Context, rule string, window time.Duration, limit int32) (*dynamo.InsightReport, error) {
+	return nil, unsupported("TopContributors")
+}
+
+func (f *Fake) ResourcePolicy(ctx context.Context, arn string) (string, error) {
+	return "", unsupported("ResourcePolicy")
+}
+
+func (f *Fake) StartS3Export(ctx context.Context, input dynamo.S3ExportInput) (*dynamo.S3Export, error) {
+	return nil, unsupported("StartS3Export")
+}
+
+func (f *Fake) DescribeS3Export(ctx context.Context, exportArn string) (*dynamo.S3Export, error) {
+	return nil, unsupported("DescribeS3Export")
+}
+
+func (f *Fake) StartS3Import(ctx context.Context, input dynamo.S3ImportInput) (*dynamo.S3Import, error) {
+	return nil, unsupported("StartS3Import")
+}
+
+func (f *Fake) DescribeS3Import(ctx context.Context, importArn string) (*dynamo.S3Import, error) {
+	return nil, unsupported("DescribeS3Import")
+}
+
+func (f *Fake) ListStreamShards(ctx context.Context, streamArn string) ([]dynamo.StreamShard, error) {
+	return nil, unsupported("ListStreamShards")
+}
+
+func (f *Fake) OpenStream(ctx context.Context, streamArn string, shards []string, start dynamo.StreamStart, at time.Time) (*dynamo.StreamReader, error) {
+	return nil, unsupported("OpenStream")
+}
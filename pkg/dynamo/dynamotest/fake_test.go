@@ -0,0 +1,111 @@
+package dynamotest
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/dynamo"
+	"github.com/godynamo/pkg/query"
+)
+
+func orders() *Fake {
+	f := New()
+	info := dynamo.TableInfo{Name: "orders", PartitionKey: "customer", SortKey: "n", SortKeyType: "N"}
+	var items []map[string]types.AttributeValue
+	for i := range 6 {
+		customer := "alice"
+		if i%2 == 1 {
+			customer = "bob"
+		}
+		items = append(items, map[string]types.AttributeValue{
+			"customer": &types.AttributeValueMemberS{Value: customer},
+			"n":        &types.AttributeValueMemberN{Value: strconv.Itoa(i)},
+			"status":   &types.AttributeValueMemberS{Value: []string{"open", "shipped"}[i%3/2]},
+		})
+	}
+	f.AddTable(info, items...)
+	return f
+}
+
+func TestScanPagesAndFilters(t *testing.T) {
+	f, ctx := orders(), context.Background()
+	res, err := f.ScanTable(ctx, dynamo.ScanInput{TableName: "orders", Limit: 4}, dynamo.ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 4 || res.LastEvaluatedKey == nil {
+		t.Fatalf("first page: %d items, last key %v", len(res.Items), res.LastEvaluatedKey)
+	}
+	res, _ = f.ScanTable(ctx, dynamo.ScanInput{TableName: "orders", Limit: 4, StartKey: res.LastEvaluatedKey}, dynamo.ScanOptions{})
+	if len(res.Items) != 2 || res.LastEvaluatedKey != nil {
+		t.Fatalf("second page: %d items, last key %v", len(res.Items), res.LastEvaluatedKey)
+	}
+
+	expr, names, values := query.BuildExpression([]query.Condition{
+		{Name: "status", Operator: query.OpEquals, Value: "shipped"},
+		{Name: "n", Operator: query.OpGreaterThan, Value: "2"},
+	})
+	res, err = f.ScanTable(ctx, dynamo.ScanInput{TableName: "orders", FilterExpression: expr, ExpressionAttributeNames: names, ExpressionValues: values, Limit: 100}, dynamo.ScanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 1 || res.ScannedCount != 6 {
+		t.Fatalf("filtered: %d items of %d scanned", len(res.Items), res.ScannedCount)
+	}
+
+	if _, err := f.ScanTable(ctx, dynamo.ScanInput{TableName: "orders", FilterExpression: "size(#a) > :v", ExpressionAttributeNames: map[string]string{"#a": "n"}, ExpressionValues: map[string]interface{}{":v": 1.0}, Limit: 1}, dynamo.ScanOptions{}); err == nil {
+		t.Error("an expression the fake can't evaluate should fail, not match everything")
+	}
+}
+
+func TestQueryOrdersBySortKey(t *testing.T) {
+	f := orders()
+	res, err := f.QueryTable(context.Background(), dynamo.QueryInput{
+		TableName:                "orders",
+		KeyConditionExpression:   "#pk = :pk AND #sk BETWEEN :lo AND :hi",
+		ExpressionAttributeNames: map[string]string{"#pk": "customer", "#sk": "n"},
+		ExpressionValues:         map[string]interface{}{":pk": "alice", ":lo": 0.0, ":hi": 3.0},
+		ScanIndexForward:         false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 2 || res.Items[0]["n"].(*types.AttributeValueMemberN).Value != "2" {
+		t.Fatalf("items %v", res.Items)
+	}
+}
+
+func TestWritesReplaceByKey(t *testing.T) {
+	f, ctx := orders(), context.Background()
+	key := map[string]types.AttributeValue{
+		"customer": &types.AttributeValueMemberS{Value: "bob"},
+		"n":        &types.AttributeValueMemberN{Value: "1"},
+	}
+	item := map[string]types.AttributeValue{"customer": key["customer"], "n": key["n"], "status": &types.AttributeValueMemberS{Value: "lost"}}
+	if err := f.PutItem(ctx, "orders", item); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := f.GetItem(ctx, "orders", key)
+	if got["status"].(*types.AttributeValueMemberS).Value != "lost" || len(f.Items("orders")) != 6 {
+		t.Fatalf("put should replace the item with the same key: %v", got)
+	}
+	if err := f.PutItem(ctx, "orders", map[string]types.AttributeValue{"customer": key["customer"]}); err == nil {
+		t.Error("an item without its sort key should be refused")
+	}
+
+	if found, err := f.DeleteItem(ctx, "orders", key); err != nil || !found {
+		t.Fatal(found, err)
+	}
+	if found, _ := f.DeleteItem(ctx, "orders", key); found {
+		t.Error("deleting the same key twice should find nothing")
+	}
+	if got, _ := f.GetItem(ctx, "orders", key); got != nil || len(f.Items("orders")) != 5 {
+		t.Fatalf("deleted item still there: %v", got)
+	}
+	if _, err := f.DescribeTable(ctx, "missing"); err == nil {
+		t.Error("a missing table should be an error")
+	}
+}
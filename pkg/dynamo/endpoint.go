@@ -0,0 +1,75 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+)
+
+// DefaultEndpointRegion signs requests to a custom endpoint when no region
+// is configured; emulators such as DynamoDB Local accept any region.
+const DefaultEndpointRegion = "us-east-1"
+
+// EndpointClient connects to the DynamoDB-compatible endpoint conn names,
+// such as DynamoDB Local or LocalStack. When the profile's credential chain
+// has nothing to offer and the endpoint is on this machine, requests are
+// signed with placeholder credentials, which local emulators accept; any
+// other endpoint gets the credential error instead.
+func EndpointClient(ctx context.Context, conn ConnectionConfig) (*Client, error) {
+	region, endpoint := conn.Region, conn.Endpoint
+	if region == "" {
+		region = DefaultEndpointRegion
+	}
+	cfg, err := loadAWSConfig(ctx, conn.Profile, conn.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	cfg.Region = region
+	if err := retrieveCredentials(ctx, cfg); err != nil {
+		if !isLocalEndpoint(endpoint) {
+			return nil, fmt.Errorf("failed to load credentials for %s: %w", endpoint, err)
+		}
+		cfg.Credentials = credentials.NewStaticCredentialsProvider("local", "local", "")
+	}
+	cfg = recordOpStats(cfg, conn.Stats)
+	return &Client{
+		db:       dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) { o.BaseEndpoint = aws.String(endpoint) }),
+		streams:  dynamodbstreams.NewFromConfig(cfg, func(o *dynamodbstreams.Options) { o.BaseEndpoint = aws.String(endpoint) }),
+		insights: cloudwatch.NewFromConfig(cfg),
+		scaling:  applicationautoscaling.NewFromConfig(cfg),
+		endpoint: endpoint,
+		region:   region,
+	}, nil
+}
+
+func retrieveCredentials(ctx context.Context, cfg aws.Config) error {
+	if cfg.Credentials == nil {
+		return errors.New("no credentials configured")
+	}
+	_, err := cfg.Credentials.Retrieve(ctx)
+	return err
+}
+
+// isLocalEndpoint reports whether endpoint points at this machine.
+func isLocalEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,39 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestEndpointClientFallsBackToPlaceholderCredentials(t *testing.T) {
+	old := loadAWSConfig
+	t.Cleanup(func() { loadAWSConfig = old })
+	loadAWSConfig = func(context.Context, string, Network) (aws.Config, error) {
+		return aws.Config{}, nil // no credentials anywhere in the chain
+	}
+
+	c, err := EndpointClient(context.Background(), ConnectionConfig{Endpoint: "http://localhost:8000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.region != DefaultEndpointRegion || c.endpoint != "http://localhost:8000" {
+		t.Errorf("region %q, endpoint %q", c.region, c.endpoint)
+	}
+}
+
+func TestEndpointClientNeedsCredentialsForRemoteEndpoints(t *testing.T) {
+	old := loadAWSConfig
+	t.Cleanup(func() { loadAWSConfig = old })
+	loadAWSConfig = func(context.Context, string, Network) (aws.Config, error) {
+		return aws.Config{}, nil
+	}
+
+	if _, err := EndpointClient(context.Background(), ConnectionConfig{Endpoint: "https://dynamodb.example.com"}); err == nil {
+		t.Fatal("expected a credential error for a remote endpoint")
+	}
+	if _, err := EndpointClient(context.Background(), ConnectionConfig{Endpoint: "http://127.0.0.1:4566"}); err != nil {
+		t.Fatalf("loopback endpoint: %v", err)
+	}
+}
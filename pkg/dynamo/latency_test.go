@@ -0,0 +1,19 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+func TestPingTimesOneListTables(t *testing.T) {
+	f := &fakeAPI{listOuts: []*dynamodb.ListTablesOutput{{}}}
+	c := &Client{db: f}
+	if _, err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if f.listCalls != 1 {
+		t.Errorf("ListTables called %d times", f.listCalls)
+	}
+}
@@ -0,0 +1,71 @@
+package dynamo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+)
+
+// Network is the transport for networks where the default one fails, such
+// as corporate proxies that re-sign TLS with their own CA
+type Network struct {
+	Proxy    string // http://, https:// or socks5:// URL; "" honors HTTPS_PROXY and friends
+	CABundle string // PEM file of CAs to trust besides the system ones
+}
+
+// Validate reports a proxy or CA bundle no client could connect with, so
+// it can be caught before the first connection
+func (n Network) Validate() error {
+	_, err := newHTTPClient(n)
+	return err
+}
+
+// newHTTPClient builds n's transport; nil keeps the SDK's default
+func newHTTPClient(n Network) (aws.HTTPClient, error) {
+	if n.Proxy == "" && n.CABundle == "" {
+		return nil, nil
+	}
+	var proxy *url.URL
+	if n.Proxy != "" {
+		u, err := url.Parse(n.Proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("proxy %q is not a URL", n.Proxy)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("proxy %q must use http, https or socks5", n.Proxy)
+		}
+		proxy = u
+	}
+	var roots *x509.CertPool
+	if n.CABundle != "" {
+		pem, err := os.ReadFile(n.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if roots, err = x509.SystemCertPool(); err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s has no PEM certificates", n.CABundle)
+		}
+	}
+	return awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		if proxy != nil {
+			tr.Proxy = http.ProxyURL(proxy)
+		}
+		if roots != nil {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			tr.TLSClientConfig.RootCAs = roots
+		}
+	}), nil
+}
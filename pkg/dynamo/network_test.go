@@ -0,0 +1,65 @@
+package dynamo
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+func TestNewHTTPClientUsesProxy(t *testing.T) {
+	client, err := newHTTPClient(Network{Proxy: "http://proxy.corp:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", "https://dynamodb.us-east-1.amazonaws.com", nil)
+	u, err := client.(*awshttp.BuildableClient).GetTransport().Proxy(req)
+	if err != nil || u == nil || u.Host != "proxy.corp:3128" {
+		t.Fatalf("proxy = %v, %v", u, err)
+	}
+
+	if client, _ := newHTTPClient(Network{}); client != nil {
+		t.Error("without settings the SDK's default transport should be kept")
+	}
+}
+
+func TestNewHTTPClientRejectsBadSettings(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []Network{
+		{Proxy: "proxy.corp:3128"},
+		{Proxy: "ftp://proxy.corp"},
+		{CABundle: filepath.Join(t.TempDir(), "missing.pem")},
+		{CABundle: notPEM},
+	} {
+		if _, err := newHTTPClient(n); err == nil {
+			t.Errorf("%+v should be rejected", n)
+		}
+	}
+}
+
+func TestNewClientUsesTheNetwork(t *testing.T) {
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	c, err := NewClient(ConnectionConfig{
+		Region: "eu-west-1", Endpoint: "http://localhost:8000", UseLocal: true, AccessKey: "k", SecretKey: "s",
+		Network: Network{Proxy: "http://proxy.corp:3128"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := c.db.(*dynamodb.Client).Options()
+	req, _ := http.NewRequest("GET", "http://localhost:8000", nil)
+	if u, _ := opts.HTTPClient.(*awshttp.BuildableClient).GetTransport().Proxy(req); u == nil || u.Host != "proxy.corp:3128" {
+		t.Errorf("NewClient ignored the configured proxy, proxy = %v", u)
+	}
+	if opts.Region != "eu-west-1" || aws.ToString(opts.BaseEndpoint) != "http://localhost:8000" {
+		t.Errorf("region %q, endpoint %q", opts.Region, aws.ToString(opts.BaseEndpoint))
+	}
+}
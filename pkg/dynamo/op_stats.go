@@ -0,0 +1,235 @@
+package dynamo
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// OpRecord is one AWS call of a client, to DynamoDB or the services around
+// it: how long it took, retries included, how many items it returned and
+// the read or write capacity it consumed
+type OpRecord struct {
+	At        time.Time
+	Op        string // e.g. Scan
+	Region    string
+	Table     string
+	Duration  time.Duration
+	Items     int
+	Capacity  float64
+	Err       bool
+	Throttled bool
+}
+
+// maxOpRecords bounds the calls OpStats keeps; the oldest are dropped
+const maxOpRecords = 10000
+
+// OpStats records the AWS calls of the clients connected with it as their
+// ConnectionConfig.Stats. It is safe for concurrent use.
+type OpStats struct {
+	mu      sync.Mutex
+	records []OpRecord // a ring once full, its oldest call at next
+	next    int
+}
+
+// Record adds a call, overwriting the oldest once maxOpRecords are kept
+func (s *OpStats) Record(r OpRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.records) < maxOpRecords {
+		s.records = append(s.records, r)
+		return
+	}
+	s.records[s.next] = r
+	s.next = (s.next + 1) % maxOpRecords
+}
+
+// Records is a copy of the calls recorded, oldest first
+func (s *OpStats) Records() []OpRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(slices.Clone(s.records[s.next:]), s.records[:s.next]...)
+}
+
+// Reset forgets every call
+func (s *OpStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records, s.next = nil, 0
+}
+
+// OpSummary sums up the calls sharing an operation, or a table
+type OpSummary struct {
+	Name      string // operation, or region and table
+	Calls     int
+	Errors    int
+	Throttled int
+	P50, P95  time.Duration
+	Max       time.Duration
+	Items     int
+	Capacity  float64
+}
+
+// ByOperation sums the calls up per operation, most called first
+func (s *OpStats) ByOperation() []OpSummary {
+	return summarize(s.Records(), func(r OpRecord) string { return r.Op }, func(a, b OpSummary) bool {
+		return a.Calls > b.Calls
+	})
+}
+
+// ByTable sums the calls naming a table up per region and table, slowest
+// first by their 95th percentile
+func (s *OpStats) ByTable() []OpSummary {
+	var named []OpRecord
+	for _, r := range s.Records() {
+		if r.Table != "" {
+			named = append(named, r)
+		}
+	}
+	return summarize(named, func(r OpRecord) string { return r.Region + " " + r.Table }, func(a, b OpSummary) bool {
+		return a.P95 > b.P95
+	})
+}
+
+func summarize(records []OpRecord, group func(OpRecord) string, less func(a, b OpSummary) bool) []OpSummary {
+	durations := map[string][]time.Duration{}
+	sums := map[string]*OpSummary{}
+	for _, r := range records {
+		name := group(r)
+		sum, ok := sums[name]
+		if !ok {
+			sum = &OpSummary{Name: name}
+			sums[name] = sum
+		}
+		sum.Calls++
+		sum.Items += r.Items
+		sum.Capacity += r.Capacity
+		if r.Err {
+			sum.Errors++
+		}
+		if r.Throttled {
+			sum.Throttled++
+		}
+		durations[name] = append(durations[name], r.Duration)
+	}
+	out := make([]OpSummary, 0, len(sums))
+	for name, sum := range sums {
+		d := durations[name]
+		slices.Sort(d)
+		sum.P50, sum.P95, sum.Max = percentile(d, 50), percentile(d, 95), d[len(d)-1]
+		out = append(out, *sum)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if less(out[i], out[j]) != less(out[j], out[i]) {
+			return less(out[i], out[j])
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// percentile is the nearest-rank p-th percentile of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// recordOpStats adds the middleware timing every call into s to cfg, so
+// each service client built from it records its calls; a nil s records
+// nothing
+func recordOpStats(cfg aws.Config, s *OpStats) aws.Config {
+	if s == nil {
+		return cfg
+	}
+	cfg.APIOptions = append(slices.Clip(cfg.APIOptions), func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("GoDynamoOpStats", s.timeOp), middleware.After)
+	})
+	return cfg
+}
+
+func (s *OpStats) timeOp(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	if ctx.Value(pingKey{}) != nil {
+		return next.HandleInitialize(ctx, in)
+	}
+	start := time.Now()
+	out, meta, err := next.HandleInitialize(ctx, in)
+	r := OpRecord{
+		At:       start,
+		Op:       awsmiddleware.GetOperationName(ctx),
+		Region:   awsmiddleware.GetRegion(ctx),
+		Table:    opTable(in.Parameters),
+		Duration: time.Since(start),
+		Err:      err != nil,
+	}
+	if err == nil {
+		r.Items, r.Capacity = opResult(out.Result)
+	} else if d, ok := DescribeError(err); ok {
+		r.Throttled = d.Code == "ProvisionedThroughputExceededException" || d.Code == "ThrottlingException" || d.Code == "RequestLimitExceeded"
+	}
+	s.Record(r)
+	return out, meta, err
+}
+
+// opTable is the table a call's input names, "" for calls without one
+func opTable(params interface{}) string {
+	switch in := params.(type) {
+	case *dynamodb.ScanInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.QueryInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.GetItemInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.PutItemInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.DeleteItemInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.UpdateItemInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.DescribeTableInput:
+		return aws.ToString(in.TableName)
+	case *dynamodb.BatchWriteItemInput:
+		for table := range in.RequestItems {
+			return table // the TUI writes one table per batch
+		}
+	}
+	return ""
+}
+
+// opResult is the items a call returned and the capacity it consumed
+func opResult(result interface{}) (items int, capacity float64) {
+	switch out := result.(type) {
+	case *dynamodb.ScanOutput:
+		return int(out.Count), capacityUnits(out.ConsumedCapacity)
+	case *dynamodb.QueryOutput:
+		return int(out.Count), capacityUnits(out.ConsumedCapacity)
+	case *dynamodb.GetItemOutput:
+		if out.Item != nil {
+			items = 1
+		}
+		return items, capacityUnits(out.ConsumedCapacity)
+	case *dynamodb.PutItemOutput:
+		return 0, capacityUnits(out.ConsumedCapacity)
+	case *dynamodb.DeleteItemOutput:
+		return 0, capacityUnits(out.ConsumedCapacity)
+	case *dynamodb.UpdateItemOutput:
+		return 0, capacityUnits(out.ConsumedCapacity)
+	case *dynamodb.BatchWriteItemOutput:
+		for _, cc := range out.ConsumedCapacity {
+			capacity += capacityUnits(&cc)
+		}
+		return 0, capacity
+	case *dynamodb.ExecuteStatementOutput:
+		return len(out.Items), capacityUnits(out.ConsumedCapacity)
+	}
+	return 0, 0
+}
@@ -0,0 +1,184 @@
+package dynamo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+)
+
+func TestPercentileIsNearestRank(t *testing.T) {
+	var d []time.Duration
+	for i := 1; i <= 20; i++ {
+		d = append(d, time.Duration(i)*time.Millisecond)
+	}
+	if got := percentile(d, 50); got != 10*time.Millisecond {
+		t.Errorf("p50 %v", got)
+	}
+	if got := percentile(d, 95); got != 19*time.Millisecond {
+		t.Errorf("p95 %v", got)
+	}
+	if got := percentile(d[:1], 95); got != time.Millisecond {
+		t.Errorf("p95 of one %v", got)
+	}
+}
+
+func TestOpStatsSummaries(t *testing.T) {
+	var s OpStats
+	for i := 1; i <= 4; i++ {
+		s.Record(OpRecord{Op: "Scan", Region: "us-east-1", Table: "Users", Duration: time.Duration(i) * time.Millisecond, Items: 10, Capacity: 0.5})
+	}
+	s.Record(OpRecord{Op: "Query", Region: "eu-west-1", Table: "Orders", Duration: time.Second, Err: true, Throttled: true})
+	s.Record(OpRecord{Op: "ListTables", Region: "us-east-1", Duration: time.Millisecond})
+
+	ops := s.ByOperation()
+	if len(ops) != 3 || ops[0].Name != "Scan" {
+		t.Fatalf("operations %+v", ops)
+	}
+	scan := ops[0]
+	if scan.Calls != 4 || scan.Items != 40 || scan.Capacity != 2 || scan.P50 != 2*time.Millisecond || scan.Max != 4*time.Millisecond {
+		t.Errorf("scan %+v", scan)
+	}
+
+	tables := s.ByTable()
+	if len(tables) != 2 || tables[0].Name != "eu-west-1 Orders" || tables[0].Throttled != 1 || tables[0].Errors != 1 {
+		t.Errorf("tables %+v", tables)
+	}
+}
+
+// statsClient connects to an endpoint answering every call with handler,
+// recording the calls into the stats it returns
+func statsClient(t *testing.T, handler http.HandlerFunc) (*Client, *OpStats) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	old := loadAWSConfig
+	t.Cleanup(func() { loadAWSConfig = old })
+	loadAWSConfig = func(context.Context, string, Network) (aws.Config, error) {
+		return aws.Config{Credentials: credentials.NewStaticCredentialsProvider("k", "s", "")}, nil
+	}
+
+	stats := &OpStats{}
+	c, err := EndpointClient(context.Background(), ConnectionConfig{Endpoint: srv.URL, Stats: stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, stats
+}
+
+func TestClientsRecordTheirCalls(t *testing.T) {
+	c, stats := statsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte(`{"Count":2,"ScannedCount":2,"Items":[],"ConsumedCapacity":{"TableName":"Users","CapacityUnits":1.5}}`))
+	})
+	if _, err := c.db.Scan(context.Background(), &dynamodb.ScanInput{TableName: aws.String("Users")}); err != nil {
+		t.Fatal(err)
+	}
+
+	records := stats.Records()
+	if len(records) != 1 {
+		t.Fatalf("recorded %d calls", len(records))
+	}
+	r := records[0]
+	if r.Op != "Scan" || r.Table != "Users" || r.Region != DefaultEndpointRegion || r.Items != 2 || r.Capacity != 1.5 || r.Err {
+		t.Errorf("record %+v", r)
+	}
+}
+
+func TestWritesRecordTheirCapacity(t *testing.T) {
+	c, stats := statsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case !strings.Contains(string(body), `"ReturnConsumedCapacity":"TOTAL"`):
+			_, _ = w.Write([]byte(`{}`)) // DynamoDB only reports capacity asked for
+		case strings.HasSuffix(r.Header.Get("X-Amz-Target"), ".BatchWriteItem"):
+			_, _ = w.Write([]byte(`{"UnprocessedItems":{},"ConsumedCapacity":[{"TableName":"Users","CapacityUnits":2}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"Items":[],"ConsumedCapacity":{"TableName":"Users","CapacityUnits":1}}`))
+		}
+	})
+	ctx := context.Background()
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}
+	if err := c.PutItem(ctx, "Users", key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteItem(ctx, "Users", key); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.renameOne(ctx, RenameInput{Table: "Users", From: "a", To: "b"}, key); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteItems(ctx, "Users", []map[string]types.AttributeValue{key}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ExecuteStatement(ctx, `UPDATE "Users" SET a = 1 WHERE id = '1'`, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]float64{"PutItem": 1, "DeleteItem": 1, "UpdateItem": 1, "BatchWriteItem": 2, "ExecuteStatement": 1}
+	records := stats.Records()
+	if len(records) != len(want) {
+		t.Fatalf("recorded %d calls: %+v", len(records), records)
+	}
+	for _, r := range records {
+		if r.Capacity != want[r.Op] {
+			t.Errorf("%s recorded %v capacity, want %v", r.Op, r.Capacity, want[r.Op])
+		}
+	}
+}
+
+func TestStreamsCallsAreRecordedToo(t *testing.T) {
+	c, stats := statsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte(`{"StreamDescription":{}}`))
+	})
+	if _, err := c.streams.DescribeStream(context.Background(), &dynamodbstreams.DescribeStreamInput{StreamArn: aws.String("arn")}); err != nil {
+		t.Fatal(err)
+	}
+	if records := stats.Records(); len(records) != 1 || records[0].Op != "DescribeStream" {
+		t.Fatalf("records %+v", records)
+	}
+}
+
+func TestOpStatsKeepsTheNewestCalls(t *testing.T) {
+	var s OpStats
+	for i := range maxOpRecords + 5 {
+		s.Record(OpRecord{Items: i})
+	}
+	records := s.Records()
+	if len(records) != maxOpRecords || records[0].Items != 5 || records[len(records)-1].Items != maxOpRecords+4 {
+		t.Fatalf("kept %d records, %d..%d", len(records), records[0].Items, records[len(records)-1].Items)
+	}
+	s.Reset()
+	s.Record(OpRecord{Items: 1})
+	if records := s.Records(); len(records) != 1 || records[0].Items != 1 {
+		t.Fatalf("after a reset %+v", records)
+	}
+}
+
+func TestPingsAreNotRecorded(t *testing.T) {
+	c, stats := statsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte(`{"TableNames":[]}`))
+	})
+	if _, err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListTables(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if records := stats.Records(); len(records) != 1 {
+		t.Fatalf("want only the ListTables the user asked for, recorded %+v", records)
+	}
+}
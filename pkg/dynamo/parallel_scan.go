@@ -0,0 +1,103 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxScanSegments caps the worker count for parallel scans; beyond this the
+// table's read capacity, not the client, is the bottleneck.
+const MaxScanSegments = 16
+
+// ScanPage is one page read by a parallel scan worker
+type ScanPage struct {
+	Segment     int
+	Items       []map[string]types.AttributeValue
+	Scanned     int64
+	SegmentDone bool // this was the segment's last page
+}
+
+// ParallelScan reads the whole table with a segmented scan across segments
+// workers. Pages are handed to onPage one at a time from the calling
+// goroutine, in the order they arrive, so onPage needs no locking. Returning
+// an error from onPage stops all workers.
+func (c *Client) ParallelScan(ctx context.Context, tableName string, segments int, onPage func(ScanPage) error) error {
+	if segments < 1 {
+		segments = 1
+	}
+	if segments > MaxScanSegments {
+		segments = MaxScanSegments
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan ScanPage, segments)
+	errs := make(chan error, segments)
+	var wg sync.WaitGroup
+
+	for seg := 0; seg < segments; seg++ {
+		wg.Add(1)
+		go func(seg int) {
+			defer wg.Done()
+			var lastKey map[string]types.AttributeValue
+			for {
+				output, err := c.db.Scan(ctx, &dynamodb.ScanInput{
+					TableName:         aws.String(tableName),
+					Segment:           aws.Int32(int32(seg)),
+					TotalSegments:     aws.Int32(int32(segments)),
+					ExclusiveStartKey: lastKey,
+				})
+				if err != nil {
+					errs <- fmt.Errorf("failed to scan segment %d: %w", seg, err)
+					return
+				}
+				lastKey = output.LastEvaluatedKey
+				page := ScanPage{
+					Segment:     seg,
+					Items:       output.Items,
+					Scanned:     int64(output.ScannedCount),
+					SegmentDone: lastKey == nil,
+				}
+				select {
+				case pages <- page:
+				case <-ctx.Done():
+					return
+				}
+				if lastKey == nil {
+					return
+				}
+			}
+		}(seg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(pages)
+	}()
+
+	for {
+		select {
+		case err := <-errs:
+			return err
+		case page, ok := <-pages:
+			if !ok {
+				// All workers finished; a worker may have failed right before.
+				select {
+				case err := <-errs:
+					return err
+				default:
+				}
+				return ctx.Err()
+			}
+			if err := onPage(page); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// segmentFake serves pagesPerSegment pages of one item to every segment.
+// Scan is called concurrently, so it keeps its own lock.
+type segmentFake struct {
+	fakeAPI
+	mu              sync.Mutex
+	pagesPerSegment int
+	failSegment     int // -1 for none
+	totals          map[int32]int32
+}
+
+func (f *segmentFake) Scan(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seg := aws.ToInt32(in.Segment)
+	f.totals[seg] = aws.ToInt32(in.TotalSegments)
+	if int(seg) == f.failSegment {
+		return nil, errors.New("throttled")
+	}
+	page := 0
+	if in.ExclusiveStartKey != nil {
+		fmt.Sscan(in.ExclusiveStartKey["page"].(*types.AttributeValueMemberN).Value, &page)
+	}
+	out := &dynamodb.ScanOutput{
+		Items:        []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: fmt.Sprintf("%d-%d", seg, page)}}},
+		ScannedCount: 1,
+	}
+	if page+1 < f.pagesPerSegment {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{"page": &types.AttributeValueMemberN{Value: fmt.Sprint(page + 1)}}
+	}
+	return out, nil
+}
+
+func TestParallelScanReadsEverySegment(t *testing.T) {
+	f := &segmentFake{pagesPerSegment: 3, failSegment: -1, totals: map[int32]int32{}}
+	c := &Client{db: f}
+
+	seen := map[string]bool{}
+	segmentsDone := 0
+	err := c.ParallelScan(context.Background(), "T", 4, func(p ScanPage) error {
+		for _, item := range p.Items {
+			seen[item["id"].(*types.AttributeValueMemberS).Value] = true
+		}
+		if p.SegmentDone {
+			segmentsDone++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 12 || segmentsDone != 4 {
+		t.Fatalf("items = %d segmentsDone = %d", len(seen), segmentsDone)
+	}
+	for seg, total := range f.totals {
+		if total != 4 {
+			t.Fatalf("segment %d sent TotalSegments=%d", seg, total)
+		}
+	}
+}
+
+func TestParallelScanStopsOnWorkerError(t *testing.T) {
+	f := &segmentFake{pagesPerSegment: 50, failSegment: 2, totals: map[int32]int32{}}
+	err := (&Client{db: f}).ParallelScan(context.Background(), "T", 4, func(ScanPage) error { return nil })
+	if err == nil {
+		t.Fatal("a failing segment should fail the scan")
+	}
+}
+
+func TestParallelScanStopsOnCallbackError(t *testing.T) {
+	f := &segmentFake{pagesPerSegment: 50, failSegment: -1, totals: map[int32]int32{}}
+	stop := errors.New("disk full")
+	err := (&Client{db: f}).ParallelScan(context.Background(), "T", 2, func(ScanPage) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Fatalf("err = %v, want callback error", err)
+	}
+}
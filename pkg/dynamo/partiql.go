@@ -0,0 +1,37 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// StatementResult is one page of PartiQL output. NextToken is nil once the
+// statement has no more results.
+type StatementResult struct {
+	Items     []map[string]types.AttributeValue
+	NextToken *string
+}
+
+// ExecuteStatement runs one PartiQL statement. Pass the previous page's
+// NextToken to continue a SELECT; limit caps the items evaluated per page
+// (0 leaves it to DynamoDB).
+func (c *Client) ExecuteStatement(ctx context.Context, statement string, nextToken *string, limit int32) (*StatementResult, error) {
+	input := &dynamodb.ExecuteStatementInput{
+		Statement:              aws.String(statement),
+		NextToken:              nextToken,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(limit)
+	}
+
+	output, err := c.db.ExecuteStatement(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute statement: %w", err)
+	}
+	return &StatementResult{Items: output.Items, NextToken: output.NextToken}, nil
+}
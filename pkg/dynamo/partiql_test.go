@@ -0,0 +1,39 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestExecuteStatementPassesTokenAndLimit(t *testing.T) {
+	f := &fakeAPI{stmtOut: &dynamodb.ExecuteStatementOutput{
+		Items:     []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "1"}}},
+		NextToken: aws.String("page-3"),
+	}}
+	res, err := newTestClient(f).ExecuteStatement(context.Background(), `SELECT * FROM "Users"`, aws.String("page-2"), 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(f.lastStmt.NextToken) != "page-2" || aws.ToInt32(f.lastStmt.Limit) != 25 {
+		t.Fatalf("input = %+v", f.lastStmt)
+	}
+	if len(res.Items) != 1 || aws.ToString(res.NextToken) != "page-3" {
+		t.Fatalf("result = %+v", res)
+	}
+
+	if _, err := newTestClient(f).ExecuteStatement(context.Background(), "SELECT 1", nil, 0); err != nil || f.lastStmt.Limit != nil {
+		t.Fatalf("limit 0 should be left unset: %+v", f.lastStmt.Limit)
+	}
+}
+
+func TestExecuteStatementWrapsError(t *testing.T) {
+	f := &fakeAPI{stmtErr: errors.New("ValidationException")}
+	if _, err := newTestClient(f).ExecuteStatement(context.Background(), "SELEC", nil, 0); err == nil || !errors.Is(err, f.stmtErr) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package dynamo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// RegionProbeTimeout bounds each region's ListTables during discovery. An
+// unreachable opt-in region (e.g. me-south-1) can stall ~66s on SDK
+// retry/backoff; reachable regions answer well under a second, so a dead
+// region costs at most this long and never holds back the others.
+const RegionProbeTimeout = 3 * time.Second
+
+// listRegionTables returns the first page of table names in conn's region.
+// It is a variable so tests can stand in for AWS.
+var listRegionTables = func(ctx context.Context, conn ConnectionConfig) ([]string, error) {
+	c, err := pool.client(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := c.db.ListTables(ctx, &dynamodb.ListTablesInput{
+		Limit: aws.Int32(100),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tables.TableNames, nil
+}
+
+// StreamRegionsWithTables probes every AWS region concurrently with conn's
+// profile and sends each region that has tables as soon as its ListTables
+// returns. The channel is closed once every region has answered or timed
+// out.
+func StreamRegionsWithTables(ctx context.Context, conn ConnectionConfig) <-chan RegionInfo {
+	found := make(chan RegionInfo, len(AWSRegions))
+	var wg sync.WaitGroup
+
+	for _, region := range AWSRegions {
+		wg.Add(1)
+		go func(r string) {
+			defer wg.Done()
+			regionCtx, cancel := context.WithTimeout(ctx, RegionProbeTimeout)
+			defer cancel()
+
+			probe := conn
+			probe.Region = r
+			tables, err := listRegionTables(regionCtx, probe)
+			if err != nil || len(tables) == 0 {
+				return
+			}
+			found <- RegionInfo{Region: r, TableCount: len(tables), Tables: tables}
+		}(region)
+	}
+
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+	return found
+}
@@ -0,0 +1,52 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStreamRegionsReportsFastRegionsFirst(t *testing.T) {
+	old := listRegionTables
+	t.Cleanup(func() { listRegionTables = old })
+	listRegionTables = func(ctx context.Context, conn ConnectionConfig) ([]string, error) {
+		switch conn.Region {
+		case "us-east-1":
+			return []string{"Users", "Orders"}, nil
+		case "eu-west-1":
+			time.Sleep(20 * time.Millisecond)
+			return []string{"Events"}, nil
+		case "me-south-1":
+			<-ctx.Done() // unreachable region: only the probe timeout ends it
+			return nil, ctx.Err()
+		case "ap-south-1":
+			return nil, errors.New("access denied")
+		}
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := StreamRegionsWithTables(ctx, ConnectionConfig{})
+
+	first := <-ch
+	if first.Region != "us-east-1" || first.TableCount != 2 {
+		t.Fatalf("first region = %+v", first)
+	}
+	second := <-ch
+	if second.Region != "eu-west-1" {
+		t.Fatalf("second region = %+v", second)
+	}
+
+	// Cancelling the parent context ends the stalled probe without waiting
+	// for RegionProbeTimeout.
+	cancel()
+	select {
+	case r, ok := <-ch:
+		if ok {
+			t.Fatalf("unexpected region %+v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream not closed after the probes finished")
+	}
+}
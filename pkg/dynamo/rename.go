@@ -0,0 +1,126 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// RenameInput renames one attribute on every item matching a filter
+type RenameInput struct {
+	Table            string
+	Key              []string // the table's key attributes, which can't be renamed
+	From, To         string
+	FilterExpression string // optional, as applied to a scan
+	ExpressionNames  map[string]string
+	ExpressionValues map[string]interface{}
+	DryRun           bool // count the items that would change without changing them
+}
+
+// RenameProgress counts a rename as it goes
+type RenameProgress struct {
+	Scanned int64
+	Matched int
+	Renamed int
+	Skipped int // changed by someone else between the scan and the update
+}
+
+// Validate rejects renames DynamoDB would refuse or that would lose data
+func (in RenameInput) Validate() error {
+	switch {
+	case in.From == "" || in.To == "":
+		return fmt.Errorf("name the attribute and its new name")
+	case in.From == in.To:
+		return fmt.Errorf("the new name is the same as the old one")
+	case slices.Contains(in.Key, in.From) || slices.Contains(in.Key, in.To):
+		return fmt.Errorf("key attributes can't be renamed")
+	}
+	return nil
+}
+
+// RenameAttribute scans for items holding From and moves its value to To
+// with one UpdateItem per item. Each update only applies while From still
+// exists and To doesn't, so a value written meanwhile is never overwritten;
+// those items are counted as skipped. onPage reports after every page.
+func (c *Client) RenameAttribute(ctx context.Context, in RenameInput, onPage func(RenameProgress)) (RenameProgress, error) {
+	var p RenameProgress
+	if err := in.Validate(); err != nil {
+		return p, err
+	}
+
+	names := maps.Clone(in.ExpressionNames)
+	if names == nil {
+		names = make(map[string]string)
+	}
+	names["#renameFrom"] = in.From
+	filter := "attribute_exists(#renameFrom)"
+	if in.FilterExpression != "" {
+		filter = "(" + in.FilterExpression + ") AND " + filter
+	}
+	projection := make([]string, len(in.Key))
+	for i, k := range in.Key {
+		ph := fmt.Sprintf("#renameKey%d", i)
+		names[ph] = k
+		projection[i] = ph
+	}
+	scan := &dynamodb.ScanInput{
+		TableName:                aws.String(in.Table),
+		FilterExpression:         aws.String(filter),
+		ProjectionExpression:     aws.String(strings.Join(projection, ", ")),
+		ExpressionAttributeNames: names,
+	}
+	if len(in.ExpressionValues) > 0 {
+		scan.ExpressionAttributeValues = make(map[string]types.AttributeValue, len(in.ExpressionValues))
+		for k, v := range in.ExpressionValues {
+			scan.ExpressionAttributeValues[k] = interfaceToAttributeValue(v)
+		}
+	}
+
+	for {
+		out, err := c.db.Scan(ctx, scan)
+		if err != nil {
+			return p, fmt.Errorf("failed to scan table: %w", err)
+		}
+		p.Scanned += int64(out.ScannedCount)
+		p.Matched += len(out.Items)
+		if !in.DryRun {
+			for _, key := range out.Items {
+				if err := c.renameOne(ctx, in, key); err != nil {
+					var changed *types.ConditionalCheckFailedException
+					if !errors.As(err, &changed) {
+						return p, fmt.Errorf("failed to rename %s: %w", in.From, err)
+					}
+					p.Skipped++
+					continue
+				}
+				p.Renamed++
+			}
+		}
+		if onPage != nil {
+			onPage(p)
+		}
+		if out.LastEvaluatedKey == nil {
+			return p, nil
+		}
+		scan.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func (c *Client) renameOne(ctx context.Context, in RenameInput, key map[string]types.AttributeValue) error {
+	_, err := c.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(in.Table),
+		Key:                      key,
+		UpdateExpression:         aws.String("SET #to = #from REMOVE #from"),
+		ConditionExpression:      aws.String("attribute_exists(#from) AND attribute_not_exists(#to)"),
+		ExpressionAttributeNames: map[string]string{"#from": in.From, "#to": in.To},
+		ReturnConsumedCapacity:   types.ReturnConsumedCapacityTotal,
+	})
+	return err
+}
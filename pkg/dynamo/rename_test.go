@@ -0,0 +1,78 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func renameKeys(ids ...string) []map[string]types.AttributeValue {
+	items := make([]map[string]types.AttributeValue, len(ids))
+	for i, id := range ids {
+		items[i] = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	}
+	return items
+}
+
+func TestRenameAttributeUpdatesEveryMatchAcrossPages(t *testing.T) {
+	f := &fakeAPI{
+		scanOuts: []*dynamodb.ScanOutput{
+			{Items: renameKeys("1", "2"), ScannedCount: 10, LastEvaluatedKey: renameKeys("2")[0]},
+			{Items: renameKeys("3"), ScannedCount: 5},
+		},
+		updateErrs: []error{nil, &types.ConditionalCheckFailedException{}},
+	}
+	in := RenameInput{
+		Table: "Users", Key: []string{"id"}, From: "mail", To: "email",
+		FilterExpression: "#s = :s", ExpressionNames: map[string]string{"#s": "status"}, ExpressionValues: map[string]interface{}{":s": "active"},
+	}
+	var pages []RenameProgress
+	p, err := newTestClient(f).RenameAttribute(context.Background(), in, func(p RenameProgress) { pages = append(pages, p) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != (RenameProgress{Scanned: 15, Matched: 3, Renamed: 2, Skipped: 1}) || len(pages) != 2 {
+		t.Fatalf("progress %+v after %d pages", p, len(pages))
+	}
+	if got := aws.ToString(f.lastScan.FilterExpression); got != "(#s = :s) AND attribute_exists(#renameFrom)" {
+		t.Errorf("filter = %q", got)
+	}
+	if f.lastScan.ExclusiveStartKey == nil || aws.ToString(f.lastScan.ProjectionExpression) != "#renameKey0" {
+		t.Errorf("scan = %+v", f.lastScan)
+	}
+	u := f.updates[0]
+	if aws.ToString(u.UpdateExpression) != "SET #to = #from REMOVE #from" || u.ExpressionAttributeNames["#to"] != "email" {
+		t.Errorf("update = %+v", u)
+	}
+	if in.ExpressionNames["#renameFrom"] != "" {
+		t.Error("the caller's names must not be modified")
+	}
+}
+
+func TestRenameAttributeDryRunChangesNothing(t *testing.T) {
+	f := &fakeAPI{scanOuts: []*dynamodb.ScanOutput{{Items: renameKeys("1", "2"), ScannedCount: 2}}}
+	in := RenameInput{Table: "Users", Key: []string{"id"}, From: "mail", To: "email", DryRun: true}
+	p, err := newTestClient(f).RenameAttribute(context.Background(), in, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Matched != 2 || p.Renamed != 0 || len(f.updates) != 0 {
+		t.Fatalf("dry run: %+v, %d updates", p, len(f.updates))
+	}
+}
+
+func TestRenameInputValidate(t *testing.T) {
+	for _, in := range []RenameInput{
+		{From: "a"},
+		{From: "a", To: "a"},
+		{Key: []string{"id"}, From: "id", To: "userId"},
+		{Key: []string{"pk", "sk"}, From: "a", To: "sk"},
+	} {
+		if in.Validate() == nil {
+			t.Errorf("%+v should not validate", in)
+		}
+	}
+}
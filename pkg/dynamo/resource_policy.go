@@ -0,0 +1,27 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ResourcePolicy returns the resource-based policy document attached to a
+// table or stream ARN, or "" when it has none.
+func (c *Client) ResourcePolicy(ctx context.Context, arn string) (string, error) {
+	out, err := c.db.GetResourcePolicy(ctx, &dynamodb.GetResourcePolicyInput{
+		ResourceArn: aws.String(arn),
+	})
+	var notFound *types.PolicyNotFoundException
+	if errors.As(err, &notFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get resource policy: %w", err)
+	}
+	return aws.ToString(out.Policy), nil
+}
@@ -0,0 +1,29 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestResourcePolicy(t *testing.T) {
+	f := &fakeAPI{policyOut: &dynamodb.GetResourcePolicyOutput{Policy: aws.String(`{"Version":"2012-10-17"}`)}}
+	c := newTestClient(f)
+	if p, err := c.ResourcePolicy(context.Background(), "arn"); err != nil || p != `{"Version":"2012-10-17"}` {
+		t.Fatalf("policy = %q, %v", p, err)
+	}
+
+	f.policyErr = &types.PolicyNotFoundException{Message: aws.String("no policy")}
+	if p, err := c.ResourcePolicy(context.Background(), "arn"); err != nil || p != "" {
+		t.Fatalf("a table without a policy should give \"\", got %q, %v", p, err)
+	}
+
+	f.policyErr = errors.New("AccessDenied")
+	if _, err := c.ResourcePolicy(context.Background(), "arn"); !errors.Is(err, f.policyErr) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// S3ExportInput describes a native point-in-time export to S3
+type S3ExportInput struct {
+	TableName string
+	Bucket    string
+	Prefix    string // optional
+	Format    string // DYNAMODB_JSON (default) or ION
+}
+
+// S3Export is the state of a native S3 export
+type S3Export struct {
+	Arn            string
+	Status         string // IN_PROGRESS, COMPLETED or FAILED
+	Bucket         string
+	Prefix         string
+	Manifest       string // S3 key of manifest-summary.json once completed
+	ItemCount      int64
+	BilledBytes    int64
+	FailureMessage string
+}
+
+// Done reports whether the export has finished, successfully or not
+func (e *S3Export) Done() bool {
+	return e.Status != string(types.ExportStatusInProgress)
+}
+
+// Location returns the s3:// URL of the export's data. Once the manifest is
+// known it points at the export's own directory under AWSDynamoDB/.
+func (e *S3Export) Location() string {
+	if e.Manifest != "" {
+		return "s3://" + e.Bucket + "/" + path.Dir(e.Manifest) + "/"
+	}
+	loc := "s3://" + e.Bucket + "/"
+	if e.Prefix != "" {
+		loc += strings.TrimSuffix(e.Prefix, "/") + "/"
+	}
+	return loc
+}
+
+// StartS3Export starts ExportTableToPointInTime for a table. The table must
+// have point-in-time recovery enabled.
+func (c *Client) StartS3Export(ctx context.Context, input S3ExportInput) (*S3Export, error) {
+	desc, err := c.db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(input.TableName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table: %w", err)
+	}
+
+	format := types.ExportFormatDynamodbJson
+	if input.Format != "" {
+		format = types.ExportFormat(input.Format)
+	}
+
+	exportInput := &dynamodb.ExportTableToPointInTimeInput{
+		TableArn:     desc.Table.TableArn,
+		S3Bucket:     aws.String(input.Bucket),
+		ExportFormat: format,
+	}
+	if input.Prefix != "" {
+		exportInput.S3Prefix = aws.String(input.Prefix)
+	}
+
+	output, err := c.db.ExportTableToPointInTime(ctx, exportInput)
+	if err != nil {
+		var pitr *types.PointInTimeRecoveryUnavailableException
+		if errors.As(err, &pitr) {
+			return nil, fmt.Errorf("point-in-time recovery is not enabled on %s: %w", input.TableName, err)
+		}
+		return nil, fmt.Errorf("failed to start export: %w", err)
+	}
+
+	return s3ExportFromDescription(output.ExportDescription)
+}
+
+// DescribeS3Export returns the current state of an export
+func (c *Client) DescribeS3Export(ctx context.Context, exportArn string) (*S3Export, error) {
+	output, err := c.db.DescribeExport(ctx, &dynamodb.DescribeExportInput{
+		ExportArn: aws.String(exportArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe export: %w", err)
+	}
+	return s3ExportFromDescription(output.ExportDescription)
+}
+
+// s3ExportFromDescription refuses a missing description or a status it
+// doesn't know, which Done would otherwise take for finished
+func s3ExportFromDescription(d *types.ExportDescription) (*S3Export, error) {
+	if d == nil {
+		return nil, fmt.Errorf("DynamoDB described no export")
+	}
+	switch d.ExportStatus {
+	case types.ExportStatusInProgress, types.ExportStatusCompleted, types.ExportStatusFailed:
+	default:
+		return nil, fmt.Errorf("export has an unknown status %q", d.ExportStatus)
+	}
+	return &S3Export{
+		Arn:            aws.ToString(d.ExportArn),
+		Status:         string(d.ExportStatus),
+		Bucket:         aws.ToString(d.S3Bucket),
+		Prefix:         aws.ToString(d.S3Prefix),
+		Manifest:       aws.ToString(d.ExportManifest),
+		ItemCount:      aws.ToInt64(d.ItemCount),
+		BilledBytes:    aws.ToInt64(d.BilledSizeBytes),
+		FailureMessage: aws.ToString(d.FailureMessage),
+	}, nil
+}
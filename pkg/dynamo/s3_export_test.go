@@ -0,0 +1,80 @@
+package dynamo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestStartS3ExportUsesTableArn(t *testing.T) {
+	f := &fakeAPI{
+		describe: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+			TableArn: aws.String("arn:aws:dynamodb:us-east-1:123:table/Users"),
+		}},
+		exportOut: &dynamodb.ExportTableToPointInTimeOutput{ExportDescription: &types.ExportDescription{
+			ExportArn:    aws.String("arn:export"),
+			ExportStatus: types.ExportStatusInProgress,
+			S3Bucket:     aws.String("bkt"),
+		}},
+	}
+	exp, err := newTestClient(f).StartS3Export(context.Background(), S3ExportInput{TableName: "Users", Bucket: "bkt", Prefix: "dumps"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := aws.ToString(f.lastExport.TableArn); got != "arn:aws:dynamodb:us-east-1:123:table/Users" {
+		t.Fatalf("TableArn = %q", got)
+	}
+	if f.lastExport.ExportFormat != types.ExportFormatDynamodbJson || aws.ToString(f.lastExport.S3Prefix) != "dumps" {
+		t.Fatalf("unexpected input %+v", f.lastExport)
+	}
+	if exp.Arn != "arn:export" || exp.Done() {
+		t.Fatalf("unexpected export %+v", exp)
+	}
+}
+
+func TestStartS3ExportExplainsMissingPITR(t *testing.T) {
+	f := &fakeAPI{
+		describe:  &dynamodb.DescribeTableOutput{Table: &types.TableDescription{TableArn: aws.String("arn")}},
+		exportErr: &types.PointInTimeRecoveryUnavailableException{Message: aws.String("PITR disabled")},
+	}
+	_, err := newTestClient(f).StartS3Export(context.Background(), S3ExportInput{TableName: "Users", Bucket: "bkt"})
+	if err == nil || !strings.Contains(err.Error(), "point-in-time recovery is not enabled on Users") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestDescribeS3ExportLocation(t *testing.T) {
+	f := &fakeAPI{descExp: &dynamodb.DescribeExportOutput{ExportDescription: &types.ExportDescription{
+		ExportStatus:   types.ExportStatusCompleted,
+		S3Bucket:       aws.String("bkt"),
+		S3Prefix:       aws.String("dumps"),
+		ExportManifest: aws.String("dumps/AWSDynamoDB/0123-abc/manifest-summary.json"),
+		ItemCount:      aws.Int64(42),
+	}}}
+	exp, err := newTestClient(f).DescribeS3Export(context.Background(), "arn:export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exp.Done() || exp.ItemCount != 42 {
+		t.Fatalf("unexpected export %+v", exp)
+	}
+	if got := exp.Location(); got != "s3://bkt/dumps/AWSDynamoDB/0123-abc/" {
+		t.Fatalf("Location = %q", got)
+	}
+	if got := (&S3Export{Bucket: "bkt", Prefix: "dumps/"}).Location(); got != "s3://bkt/dumps/" {
+		t.Fatalf("Location without manifest = %q", got)
+	}
+}
+
+func TestDescribeS3ExportRefusesUnknownStatus(t *testing.T) {
+	for _, desc := range []*types.ExportDescription{nil, {}, {ExportStatus: "PAUSED"}} {
+		f := &fakeAPI{descExp: &dynamodb.DescribeExportOutput{ExportDescription: desc}}
+		if exp, err := newTestClient(f).DescribeS3Export(context.Background(), "arn:export"); err == nil {
+			t.Errorf("%+v: got %+v, want an error", desc, exp)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// S3ImportInput describes a native ImportTable job. ImportTable always
+// creates a new table, so Table carries its name and key schema.
+type S3ImportInput struct {
+	Bucket      string
+	Prefix      string // key prefix of the source objects (optional)
+	Format      string // DYNAMODB_JSON (default), ION or CSV
+	Compression string // NONE (default), GZIP or ZSTD
+	Table       CreateTableInput
+}
+
+// S3Import is the state of a native S3 import
+type S3Import struct {
+	Arn            string
+	Status         string // IN_PROGRESS, COMPLETED, CANCELLING, CANCELLED or FAILED
+	TableName      string
+	ProcessedItems int64
+	ImportedItems  int64
+	ErrorCount     int64 // items rejected, details are in the CloudWatch log group
+	FailureCode    string
+	FailureMessage string
+	LogGroupArn    string
+}
+
+// Done reports whether the import has reached a final state
+func (i *S3Import) Done() bool {
+	switch types.ImportStatus(i.Status) {
+	case types.ImportStatusCompleted, types.ImportStatusCancelled, types.ImportStatusFailed:
+		return true
+	}
+	return false
+}
+
+// Failed reports whether the import ended without completing
+func (i *S3Import) Failed() bool {
+	return i.Done() && types.ImportStatus(i.Status) != types.ImportStatusCompleted
+}
+
+// StartS3Import starts ImportTable, creating the target table from S3 data
+func (c *Client) StartS3Import(ctx context.Context, input S3ImportInput) (*S3Import, error) {
+	keySchema, attrDefs := input.Table.keySchema()
+	billing, throughput := input.Table.billing()
+
+	importInput := &dynamodb.ImportTableInput{
+		InputFormat: types.InputFormatDynamodbJson,
+		S3BucketSource: &types.S3BucketSource{
+			S3Bucket: aws.String(input.Bucket),
+		},
+		TableCreationParameters: &types.TableCreationParameters{
+			TableName:             aws.String(input.Table.TableName),
+			KeySchema:             keySchema,
+			AttributeDefinitions:  attrDefs,
+			BillingMode:           billing,
+			ProvisionedThroughput: throughput,
+		},
+		InputCompressionType: types.InputCompressionTypeNone,
+	}
+	if input.Prefix != "" {
+		importInput.S3BucketSource.S3KeyPrefix = aws.String(input.Prefix)
+	}
+	if input.Format != "" {
+		importInput.InputFormat = types.InputFormat(input.Format)
+	}
+	if input.Compression != "" {
+		importInput.InputCompressionType = types.InputCompressionType(input.Compression)
+	}
+
+	output, err := c.db.ImportTable(ctx, importInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start import: %w", err)
+	}
+	return s3ImportFromDescription(output.ImportTableDescription), nil
+}
+
+// DescribeS3Import returns the current state of an import
+func (c *Client) DescribeS3Import(ctx context.Context, importArn string) (*S3Import, error) {
+	output, err := c.db.DescribeImport(ctx, &dynamodb.DescribeImportInput{
+		ImportArn: aws.String(importArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe import: %w", err)
+	}
+	return s3ImportFromDescription(output.ImportTableDescription), nil
+}
+
+func s3ImportFromDescription(d *types.ImportTableDescription) *S3Import {
+	if d == nil {
+		return &S3Import{}
+	}
+	imp := &S3Import{
+		Arn:            aws.ToString(d.ImportArn),
+		Status:         string(d.ImportStatus),
+		ProcessedItems: d.ProcessedItemCount,
+		ImportedItems:  d.ImportedItemCount,
+		ErrorCount:     d.ErrorCount,
+		FailureCode:    aws.ToString(d.FailureCode),
+		FailureMessage: aws.ToString(d.FailureMessage),
+		LogGroupArn:    aws.ToString(d.CloudWatchLogGroupArn),
+	}
+	if d.TableCreationParameters != nil {
+		imp.TableName = aws.ToString(d.TableCreationParameters.TableName)
+	}
+	return imp
+}
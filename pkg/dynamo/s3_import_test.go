@@ -0,0 +1,63 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestStartS3ImportBuildsInput(t *testing.T) {
+	f := &fakeAPI{importOut: &dynamodb.ImportTableOutput{ImportTableDescription: &types.ImportTableDescription{
+		ImportArn:    aws.String("arn:import"),
+		ImportStatus: types.ImportStatusInProgress,
+	}}}
+	imp, err := newTestClient(f).StartS3Import(context.Background(), S3ImportInput{
+		Bucket:      "bkt",
+		Prefix:      "dumps/",
+		Format:      "CSV",
+		Compression: "GZIP",
+		Table: CreateTableInput{
+			TableName: "Users", PartitionKey: "id", PartitionType: "S",
+			SortKey: "ts", SortKeyType: "N", BillingMode: "PAY_PER_REQUEST",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := f.lastImport
+	if in.InputFormat != types.InputFormatCsv || in.InputCompressionType != types.InputCompressionTypeGzip {
+		t.Fatalf("format/compression = %v/%v", in.InputFormat, in.InputCompressionType)
+	}
+	if aws.ToString(in.S3BucketSource.S3KeyPrefix) != "dumps/" {
+		t.Fatalf("prefix = %v", in.S3BucketSource.S3KeyPrefix)
+	}
+	params := in.TableCreationParameters
+	if aws.ToString(params.TableName) != "Users" || len(params.KeySchema) != 2 || len(params.AttributeDefinitions) != 2 {
+		t.Fatalf("table params = %+v", params)
+	}
+	if params.BillingMode != types.BillingModePayPerRequest || params.ProvisionedThroughput != nil {
+		t.Fatalf("billing = %v throughput = %v", params.BillingMode, params.ProvisionedThroughput)
+	}
+	if imp.Arn != "arn:import" || imp.Done() {
+		t.Fatalf("import = %+v", imp)
+	}
+}
+
+func TestDescribeS3ImportSurfacesFailure(t *testing.T) {
+	f := &fakeAPI{descImp: &dynamodb.DescribeImportOutput{ImportTableDescription: &types.ImportTableDescription{
+		ImportStatus:   types.ImportStatusFailed,
+		FailureCode:    aws.String("S3NoSuchKey"),
+		FailureMessage: aws.String("no objects under prefix"),
+		ErrorCount:     3,
+	}}}
+	imp, err := newTestClient(f).DescribeS3Import(context.Background(), "arn:import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !imp.Done() || !imp.Failed() || imp.FailureCode != "S3NoSuchKey" || imp.ErrorCount != 3 {
+		t.Fatalf("import = %+v", imp)
+	}
+}
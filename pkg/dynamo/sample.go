@@ -0,0 +1,96 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxSampleSegments is the most segments a sample splits a table into;
+// DynamoDB allows far more, but past this each segment of a mid-sized table
+// is mostly empty.
+const MaxSampleSegments = 1000
+
+// pickSegments chooses n distinct segments out of total. It is a variable so
+// tests can make the choice deterministic.
+var pickSegments = func(n, total int) []int {
+	return rand.Perm(total)[:n]
+}
+
+// SampleInput describes a sample: one page from each of Segments segments
+// picked at random out of TotalSegments.
+type SampleInput struct {
+	TableName                string
+	Segments                 int
+	TotalSegments            int
+	Limit                    int32 // items read per segment
+	FilterExpression         string
+	ExpressionAttributeNames map[string]string
+	ExpressionValues         map[string]interface{}
+}
+
+// SampleResult is a sample's items, in segment order
+type SampleResult struct {
+	Items         []map[string]types.AttributeValue
+	Segments      []int
+	TotalSegments int
+	ScannedCount  int64
+}
+
+// SampleScan reads the first page of several random scan segments at once.
+// Each segment starts at a different point in the key space, so the items
+// are spread across the table rather than all coming from its start.
+func (c *Client) SampleScan(ctx context.Context, input SampleInput) (*SampleResult, error) {
+	total := min(max(input.TotalSegments, 1), MaxSampleSegments)
+	segments := pickSegments(min(max(input.Segments, 1), total), total)
+	sort.Ints(segments)
+
+	var values map[string]types.AttributeValue
+	if len(input.ExpressionValues) > 0 {
+		values = make(map[string]types.AttributeValue, len(input.ExpressionValues))
+		for k, v := range input.ExpressionValues {
+			values[k] = interfaceToAttributeValue(v)
+		}
+	}
+
+	pages := make([]*dynamodb.ScanOutput, len(segments))
+	errs := make([]error, len(segments))
+	var wg sync.WaitGroup
+	for i, seg := range segments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			in := &dynamodb.ScanInput{
+				TableName:     aws.String(input.TableName),
+				Segment:       aws.Int32(int32(seg)),
+				TotalSegments: aws.Int32(int32(total)),
+				Limit:         aws.Int32(input.Limit),
+			}
+			if input.FilterExpression != "" {
+				in.FilterExpression = aws.String(input.FilterExpression)
+				if len(input.ExpressionAttributeNames) > 0 {
+					in.ExpressionAttributeNames = input.ExpressionAttributeNames
+				}
+				in.ExpressionAttributeValues = values
+			}
+			pages[i], errs[i] = c.db.Scan(ctx, in)
+		}()
+	}
+	wg.Wait()
+
+	result := &SampleResult{Segments: segments, TotalSegments: total}
+	for i, page := range pages {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to sample segment %d: %w", segments[i], errs[i])
+		}
+		result.Items = append(result.Items, page.Items...)
+		result.ScannedCount += int64(page.ScannedCount)
+	}
+	return result, nil
+}
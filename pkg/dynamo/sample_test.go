@@ -0,0 +1,57 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestSampleScanReadsOnePagePerPickedSegment(t *testing.T) {
+	old := pickSegments
+	pickSegments = func(n, total int) []int {
+		if n != 2 || total != 40 {
+			t.Errorf("picked %d of %d segments, want 2 of 40", n, total)
+		}
+		return []int{31, 7}
+	}
+	t.Cleanup(func() { pickSegments = old })
+
+	f := &segmentFake{pagesPerSegment: 3, failSegment: -1, totals: map[int32]int32{}}
+	res, err := (&Client{db: f}).SampleScan(context.Background(), SampleInput{TableName: "T", Segments: 2, TotalSegments: 40, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, item := range res.Items {
+		ids = append(ids, item["id"].(*types.AttributeValueMemberS).Value)
+	}
+	if len(ids) != 2 || ids[0] != "7-0" || ids[1] != "31-0" {
+		t.Fatalf("items = %v, want the first page of segments 7 and 31", ids)
+	}
+	if f.totals[7] != 40 || f.totals[31] != 40 || res.ScannedCount != 2 {
+		t.Fatalf("totals = %v, scanned = %d", f.totals, res.ScannedCount)
+	}
+}
+
+func TestSampleScanClampsSegments(t *testing.T) {
+	f := &segmentFake{pagesPerSegment: 1, failSegment: -1, totals: map[int32]int32{}}
+	res, err := (&Client{db: f}).SampleScan(context.Background(), SampleInput{TableName: "T", Segments: 8, TotalSegments: 0, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalSegments != 1 || len(res.Segments) != 1 || len(res.Items) != 1 {
+		t.Fatalf("an unknown table size should sample one segment: %+v", res)
+	}
+}
+
+func TestSampleScanReportsFailedSegment(t *testing.T) {
+	old := pickSegments
+	pickSegments = func(n, total int) []int { return []int{0, 1} }
+	t.Cleanup(func() { pickSegments = old })
+
+	f := &segmentFake{pagesPerSegment: 1, failSegment: 1, totals: map[int32]int32{}}
+	if _, err := (&Client{db: f}).SampleScan(context.Background(), SampleInput{TableName: "T", Segments: 2, TotalSegments: 4}); err == nil {
+		t.Fatal("expected the throttled segment's error")
+	}
+}
@@ -0,0 +1,15 @@
+package dynamo
+
+// ScanBudget stops ScanTableContinuous after Pages pages or Scanned records,
+// whichever comes first; zero means no limit. A time budget is the context's
+// deadline. A scan that runs out of budget returns like one that timed out,
+// with TimedOut set and a key to carry on from.
+type ScanBudget struct {
+	Pages   int
+	Scanned int64
+}
+
+// spent reports whether pages and scanned have used up the budget
+func (b ScanBudget) spent(pages int, scanned int64) bool {
+	return (b.Pages > 0 && pages >= b.Pages) || (b.Scanned > 0 && scanned >= b.Scanned)
+}
@@ -0,0 +1,43 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// emptyPages returns n pages that each scan 100 records, match nothing and
+// point at a further page.
+func emptyPages(n int) []*dynamodb.ScanOutput {
+	out := make([]*dynamodb.ScanOutput, n)
+	for i := range out {
+		out[i] = &dynamodb.ScanOutput{
+			ScannedCount:     100,
+			LastEvaluatedKey: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: fmt.Sprint(i)}},
+		}
+	}
+	return out
+}
+
+func TestScanTableContinuousStopsAtBudget(t *testing.T) {
+	for _, tc := range []struct {
+		budget ScanBudget
+		pages  int
+	}{
+		{ScanBudget{Pages: 3}, 3},
+		{ScanBudget{Scanned: 250}, 3},
+		{ScanBudget{Pages: 5, Scanned: 200}, 2},
+	} {
+		f := &fakeAPI{scanOuts: emptyPages(10)}
+		res, err := newTestClient(f).ScanTableContinuous(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{Budget: tc.budget})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.scanCalls != tc.pages || !res.TimedOut || !res.HasMore || res.LastEvaluatedKey == nil {
+			t.Errorf("%+v: read %d pages (timedOut=%v hasMore=%v), want %d", tc.budget, f.scanCalls, res.TimedOut, res.HasMore, tc.pages)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package dynamo
+
+// ScanOptions adjusts how ScanTable and ScanTableContinuous read a table.
+// The zero value reads all of it.
+type ScanOptions struct {
+	// Segment reads only that slice of the table, e.g. to spot-check part
+	// of a huge table or to split work with other workers scanning the
+	// remaining segments
+	Segment *ScanSegment
+
+	// Budget stops ScanTableContinuous early. ScanTable, which reads a
+	// single page, ignores it
+	Budget ScanBudget
+
+	// Progress hears from ScanTableContinuous after every page
+	Progress ScanProgressFunc
+}
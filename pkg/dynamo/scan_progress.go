@@ -0,0 +1,6 @@
+package dynamo
+
+// ScanProgressFunc is told, after every page, how many items a continuous
+// scan has found and how many records it has read so far. It is called from
+// the scanning goroutine.
+type ScanProgressFunc func(found int, scanned int64)
@@ -0,0 +1,27 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestScanTableContinuousReportsProgress(t *testing.T) {
+	f := &fakeAPI{scanOuts: append(emptyPages(2), &dynamodb.ScanOutput{
+		Items:        []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "x"}}},
+		ScannedCount: 100,
+	})}
+	var got []string
+	progress := func(found int, scanned int64) {
+		got = append(got, fmt.Sprintf("%d/%d", found, scanned))
+	}
+	if _, err := newTestClient(f).ScanTableContinuous(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{Progress: progress}); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[0/100 0/200 1/300]" {
+		t.Fatalf("progress = %v", got)
+	}
+}
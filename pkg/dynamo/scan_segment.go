@@ -0,0 +1,35 @@
+package dynamo
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// MaxTotalSegments is the largest TotalSegments DynamoDB accepts
+const MaxTotalSegments = 1000000
+
+// ScanSegment is one slice of a parallel scan: Segment out of Total, 0-based
+type ScanSegment struct {
+	Segment int
+	Total   int
+}
+
+// Validate reports a segment DynamoDB would reject
+func (s ScanSegment) Validate() error {
+	if s.Total < 1 || s.Total > MaxTotalSegments {
+		return fmt.Errorf("total segments must be between 1 and %d", MaxTotalSegments)
+	}
+	if s.Segment < 0 || s.Segment >= s.Total {
+		return fmt.Errorf("segment must be between 0 and %d", s.Total-1)
+	}
+	return nil
+}
+
+func applyScanSegment(seg *ScanSegment, input *dynamodb.ScanInput) {
+	if seg != nil {
+		input.Segment = aws.Int32(int32(seg.Segment))
+		input.TotalSegments = aws.Int32(int32(seg.Total))
+	}
+}
@@ -0,0 +1,41 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+func TestScanSegmentValidate(t *testing.T) {
+	for _, s := range []ScanSegment{{0, 1}, {15, 16}, {0, MaxTotalSegments}} {
+		if err := s.Validate(); err != nil {
+			t.Errorf("%+v: %v", s, err)
+		}
+	}
+	for _, s := range []ScanSegment{{0, 0}, {16, 16}, {-1, 4}, {0, MaxTotalSegments + 1}} {
+		if s.Validate() == nil {
+			t.Errorf("%+v should be rejected", s)
+		}
+	}
+}
+
+func TestScanTableHonoursScanSegment(t *testing.T) {
+	f := &fakeAPI{scanOuts: []*dynamodb.ScanOutput{{}, {}}}
+	c := newTestClient(f)
+	if _, err := c.ScanTable(context.Background(), ScanInput{TableName: "T", Limit: 10}, ScanOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if f.lastScan.Segment != nil || f.lastScan.TotalSegments != nil {
+		t.Fatal("a plain scan should not set a segment")
+	}
+
+	opts := ScanOptions{Segment: &ScanSegment{Segment: 3, Total: 8}}
+	if _, err := c.ScanTableContinuous(context.Background(), ScanInput{TableName: "T", Limit: 10}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToInt32(f.lastScan.Segment) != 3 || aws.ToInt32(f.lastScan.TotalSegments) != 8 {
+		t.Fatalf("segment = %v/%v, want 3/8", f.lastScan.Segment, f.lastScan.TotalSegments)
+	}
+}
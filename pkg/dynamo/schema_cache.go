@@ -0,0 +1,74 @@
+package dynamo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// schemaTTL is how long a DescribeTable result is reused. Key schema and
+// indexes rarely change mid-session, and the query planner asks for them on
+// every scan; item counts and status are refreshed once it has passed.
+const schemaTTL = time.Minute
+
+// schemaCache keeps DescribeTable results for schemaTTL
+type schemaCache struct {
+	mu     sync.Mutex
+	tables map[string]cachedSchema
+}
+
+type cachedSchema struct {
+	info *TableInfo
+	at   time.Time
+}
+
+func (s *schemaCache) get(name string) (*TableInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.tables[name]
+	if !ok || time.Since(cached.at) >= schemaTTL {
+		return nil, false
+	}
+	return cached.info, true
+}
+
+func (s *schemaCache) put(name string, info *TableInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tables == nil {
+		s.tables = make(map[string]cachedSchema)
+	}
+	s.tables[name] = cachedSchema{info: info, at: time.Now()}
+}
+
+func (s *schemaCache) forget(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tables, name)
+}
+
+func (s *schemaCache) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables = nil
+}
+
+// DescribeTable returns table metadata, from the cache when the table was
+// described less than schemaTTL ago. InvalidateSchemaCache forces a fresh
+// DescribeTable.
+func (c *Client) DescribeTable(ctx context.Context, tableName string) (*TableInfo, error) {
+	if info, ok := c.schemas.get(tableName); ok {
+		return info, nil
+	}
+	info, err := c.describeTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	c.schemas.put(tableName, info)
+	return info, nil
+}
+
+// InvalidateSchemaCache drops every cached DescribeTable result
+func (c *Client) InvalidateSchemaCache() {
+	c.schemas.clear()
+}
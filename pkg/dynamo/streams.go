@@ -0,0 +1,221 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+)
+
+// streamsAPI is the subset of the DynamoDB Streams client used here
+type streamsAPI interface {
+	DescribeStream(context.Context, *dynamodbstreams.DescribeStreamInput, ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error)
+	GetShardIterator(context.Context, *dynamodbstreams.GetShardIteratorInput, ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error)
+	GetRecords(context.Context, *dynamodbstreams.GetRecordsInput, ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error)
+}
+
+var _ streamsAPI = (*dynamodbstreams.Client)(nil)
+
+// StreamShard is one shard of a table's stream
+type StreamShard struct {
+	ID       string
+	ParentID string
+	Open     bool // still receiving records; closed shards only hold history
+}
+
+// StreamStart is where reading a stream begins
+type StreamStart int
+
+const (
+	StartTrimHorizon StreamStart = iota // the oldest record kept (24 hours)
+	StartLatest                         // only records written from now on
+	StartAtTimestamp                    // the first record at or after a time
+)
+
+func (s StreamStart) String() string {
+	switch s {
+	case StartLatest:
+		return "LATEST"
+	case StartAtTimestamp:
+		return "AT_TIMESTAMP"
+	default:
+		return "TRIM_HORIZON"
+	}
+}
+
+// StreamRecord is one change read from a stream
+type StreamRecord struct {
+	Shard          string
+	EventName      string // INSERT, MODIFY or REMOVE
+	SequenceNumber string
+	Time           time.Time
+	Keys           map[string]types.AttributeValue
+	NewImage       map[string]types.AttributeValue
+	OldImage       map[string]types.AttributeValue
+}
+
+// ListStreamShards returns every shard of the stream, oldest first
+func (c *Client) ListStreamShards(ctx context.Context, streamArn string) ([]StreamShard, error) {
+	var shards []StreamShard
+	var start *string
+	for {
+		out, err := c.streams.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             aws.String(streamArn),
+			ExclusiveStartShardId: start,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe stream: %w", err)
+		}
+		for _, s := range out.StreamDescription.Shards {
+			shard := StreamShard{ID: aws.ToString(s.ShardId), ParentID: aws.ToString(s.ParentShardId), Open: true}
+			if r := s.SequenceNumberRange; r != nil && r.EndingSequenceNumber != nil {
+				shard.Open = false
+			}
+			shards = append(shards, shard)
+		}
+		start = out.StreamDescription.LastEvaluatedShardId
+		if start == nil {
+			return shards, nil
+		}
+	}
+}
+
+// StreamReader tails a set of shards. It is not safe for concurrent use.
+type StreamReader struct {
+	c      *Client
+	iters  map[string]*string // shard ID to its next iterator; removed once the shard ends
+	after  time.Time          // records before this are skipped (StartAtTimestamp)
+	behind bool
+}
+
+// OpenStream positions a reader on shards. DynamoDB Streams has no
+// timestamp iterator, so StartAtTimestamp reads from the trim horizon and
+// drops the records older than at.
+func (c *Client) OpenStream(ctx context.Context, streamArn string, shards []string, start StreamStart, at time.Time) (*StreamReader, error) {
+	iterType := streamtypes.ShardIteratorTypeTrimHorizon
+	if start == StartLatest {
+		iterType = streamtypes.ShardIteratorTypeLatest
+	}
+	r := &StreamReader{c: c, iters: make(map[string]*string, len(shards))}
+	if start == StartAtTimestamp {
+		r.after = at
+	}
+	for _, shard := range shards {
+		out, err := c.streams.GetShardIterator(ctx, &dynamodbstreams.GetShardIteratorInput{
+			StreamArn:         aws.String(streamArn),
+			ShardId:           aws.String(shard),
+			ShardIteratorType: iterType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open shard %s: %w", shard, err)
+		}
+		r.iters[shard] = out.ShardIterator
+	}
+	return r, nil
+}
+
+// OpenShards is how many shards may still return records
+func (r *StreamReader) OpenShards() int {
+	return len(r.iters)
+}
+
+// Behind reports whether the last Poll read anything, skipped records
+// included, so more may be waiting without the usual poll delay.
+func (r *StreamReader) Behind() bool {
+	return r.behind
+}
+
+// Poll reads the next batch from every shard and returns the records in
+// time order.
+func (r *StreamReader) Poll(ctx context.Context) ([]StreamRecord, error) {
+	var records []StreamRecord
+	r.behind = false
+	for shard, iter := range r.iters {
+		out, err := r.c.streams.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{ShardIterator: iter})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shard %s: %w", shard, err)
+		}
+		r.behind = r.behind || len(out.Records) > 0
+		for _, rec := range out.Records {
+			sr := streamRecord(shard, rec)
+			if sr.Time.Before(r.after) {
+				continue
+			}
+			records = append(records, sr)
+		}
+		if out.NextShardIterator == nil {
+			delete(r.iters, shard)
+		} else {
+			r.iters[shard] = out.NextShardIterator
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].Time.Equal(records[j].Time) {
+			return records[i].Time.Before(records[j].Time)
+		}
+		return records[i].SequenceNumber < records[j].SequenceNumber
+	})
+	return records, nil
+}
+
+func streamRecord(shard string, rec streamtypes.Record) StreamRecord {
+	sr := StreamRecord{Shard: shard, EventName: string(rec.EventName)}
+	if d := rec.Dynamodb; d != nil {
+		sr.SequenceNumber = aws.ToString(d.SequenceNumber)
+		if d.ApproximateCreationDateTime != nil {
+			sr.Time = *d.ApproximateCreationDateTime
+		}
+		sr.Keys = streamItem(d.Keys)
+		sr.NewImage = streamItem(d.NewImage)
+		sr.OldImage = streamItem(d.OldImage)
+	}
+	return sr
+}
+
+// streamItem converts a Streams item to the DynamoDB types used everywhere else
+func streamItem(item map[string]streamtypes.AttributeValue) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+	out := make(map[string]types.AttributeValue, len(item))
+	for k, v := range item {
+		out[k] = streamValue(v)
+	}
+	return out
+}
+
+func streamValue(v streamtypes.AttributeValue) types.AttributeValue {
+	switch v := v.(type) {
+	case *streamtypes.AttributeValueMemberS:
+		return &types.AttributeValueMemberS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberN:
+		return &types.AttributeValueMemberN{Value: v.Value}
+	case *streamtypes.AttributeValueMemberB:
+		return &types.AttributeValueMemberB{Value: v.Value}
+	case *streamtypes.AttributeValueMemberBOOL:
+		return &types.AttributeValueMemberBOOL{Value: v.Value}
+	case *streamtypes.AttributeValueMemberNULL:
+		return &types.AttributeValueMemberNULL{Value: v.Value}
+	case *streamtypes.AttributeValueMemberSS:
+		return &types.AttributeValueMemberSS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberNS:
+		return &types.AttributeValueMemberNS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberBS:
+		return &types.AttributeValueMemberBS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberL:
+		list := make([]types.AttributeValue, len(v.Value))
+		for i, e := range v.Value {
+			list[i] = streamValue(e)
+		}
+		return &types.AttributeValueMemberL{Value: list}
+	case *streamtypes.AttributeValueMemberM:
+		return &types.AttributeValueMemberM{Value: streamItem(v.Value)}
+	default:
+		return &types.AttributeValueMemberNULL{Value: true}
+	}
+}
@@ -0,0 +1,147 @@
+package dynamo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+)
+
+// streamsFake serves the pages of shards and, per shard iterator, one batch
+// of records. Iterators are "<shard>/<batch>".
+type streamsFake struct {
+	shardPages [][]streamtypes.Shard
+	batches    map[string][][]streamtypes.Record // shard ID to its batches
+	iterTypes  map[string]streamtypes.ShardIteratorType
+}
+
+func (f *streamsFake) DescribeStream(_ context.Context, in *dynamodbstreams.DescribeStreamInput, _ ...func(*dynamodbstreams.Options)) (*dynamodbstreams.DescribeStreamOutput, error) {
+	page := 0
+	if in.ExclusiveStartShardId != nil {
+		page = 1
+	}
+	desc := &streamtypes.StreamDescription{Shards: f.shardPages[page]}
+	if page+1 < len(f.shardPages) {
+		desc.LastEvaluatedShardId = f.shardPages[page][len(f.shardPages[page])-1].ShardId
+	}
+	return &dynamodbstreams.DescribeStreamOutput{StreamDescription: desc}, nil
+}
+
+func (f *streamsFake) GetShardIterator(_ context.Context, in *dynamodbstreams.GetShardIteratorInput, _ ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetShardIteratorOutput, error) {
+	f.iterTypes[aws.ToString(in.ShardId)] = in.ShardIteratorType
+	return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(aws.ToString(in.ShardId) + "/0")}, nil
+}
+
+func (f *streamsFake) GetRecords(_ context.Context, in *dynamodbstreams.GetRecordsInput, _ ...func(*dynamodbstreams.Options)) (*dynamodbstreams.GetRecordsOutput, error) {
+	shard, n, _ := strings.Cut(aws.ToString(in.ShardIterator), "/")
+	batch, _ := strconv.Atoi(n)
+	out := &dynamodbstreams.GetRecordsOutput{}
+	if batches := f.batches[shard]; batch < len(batches) {
+		out.Records = batches[batch]
+		if batch+1 < len(batches) {
+			out.NextShardIterator = aws.String(shard + "/" + strconv.Itoa(batch+1))
+		}
+	}
+	return out, nil
+}
+
+func streamRec(event string, at time.Time, id string) streamtypes.Record {
+	return streamtypes.Record{
+		EventName: streamtypes.OperationType(event),
+		Dynamodb: &streamtypes.StreamRecord{
+			ApproximateCreationDateTime: aws.Time(at),
+			SequenceNumber:              aws.String(id),
+			Keys:                        map[string]streamtypes.AttributeValue{"id": &streamtypes.AttributeValueMemberS{Value: id}},
+			NewImage: map[string]streamtypes.AttributeValue{
+				"id":   &streamtypes.AttributeValueMemberS{Value: id},
+				"tags": &streamtypes.AttributeValueMemberL{Value: []streamtypes.AttributeValue{&streamtypes.AttributeValueMemberN{Value: "1"}}},
+				"meta": &streamtypes.AttributeValueMemberM{Value: map[string]streamtypes.AttributeValue{"ok": &streamtypes.AttributeValueMemberBOOL{Value: true}}},
+			},
+		},
+	}
+}
+
+func TestListStreamShardsPaginates(t *testing.T) {
+	f := &streamsFake{shardPages: [][]streamtypes.Shard{
+		{{ShardId: aws.String("a"), SequenceNumberRange: &streamtypes.SequenceNumberRange{EndingSequenceNumber: aws.String("9")}}},
+		{{ShardId: aws.String("b"), ParentShardId: aws.String("a")}},
+	}}
+	shards, err := (&Client{streams: f}).ListStreamShards(context.Background(), "arn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shards) != 2 || shards[0].Open || !shards[1].Open || shards[1].ParentID != "a" {
+		t.Fatalf("shards = %+v", shards)
+	}
+}
+
+func TestStreamReaderReplaysFromTimestamp(t *testing.T) {
+	base := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	f := &streamsFake{
+		iterTypes: map[string]streamtypes.ShardIteratorType{},
+		batches: map[string][][]streamtypes.Record{
+			"a": {{streamRec("INSERT", base, "1"), streamRec("MODIFY", base.Add(2*time.Minute), "3")}},
+			"b": {{streamRec("REMOVE", base.Add(time.Minute), "2")}, {}},
+		},
+	}
+	c := &Client{streams: f}
+	r, err := c.OpenStream(context.Background(), "arn", []string{"a", "b"}, StartAtTimestamp, base.Add(30*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.iterTypes["a"] != streamtypes.ShardIteratorTypeTrimHorizon {
+		t.Fatalf("AT_TIMESTAMP should read from the trim horizon, got %s", f.iterTypes["a"])
+	}
+
+	recs, err := r.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[0].EventName != "REMOVE" || recs[1].SequenceNumber != "3" {
+		t.Fatalf("records = %+v, want 2 then 3 (1 is before the start time)", recs)
+	}
+	if !r.Behind() || r.OpenShards() != 1 {
+		t.Fatalf("behind = %v, open = %d; shard a has ended", r.Behind(), r.OpenShards())
+	}
+	meta := recs[1].NewImage["meta"].(*types.AttributeValueMemberM).Value["ok"].(*types.AttributeValueMemberBOOL)
+	if !meta.Value || recs[1].NewImage["tags"].(*types.AttributeValueMemberL).Value[0].(*types.AttributeValueMemberN).Value != "1" {
+		t.Fatalf("nested values not converted: %+v", recs[1].NewImage)
+	}
+
+	if _, err := r.Poll(context.Background()); err != nil || r.Behind() || r.OpenShards() != 0 {
+		t.Fatalf("second poll: err=%v behind=%v open=%d", err, r.Behind(), r.OpenShards())
+	}
+}
+
+func TestOpenStreamLatest(t *testing.T) {
+	f := &streamsFake{iterTypes: map[string]streamtypes.ShardIteratorType{}}
+	if _, err := (&Client{streams: f}).OpenStream(context.Background(), "arn", []string{"a"}, StartLatest, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if f.iterTypes["a"] != streamtypes.ShardIteratorTypeLatest {
+		t.Fatalf("iterator type = %s", f.iterTypes["a"])
+	}
+}
+
+func TestDescribeTableReportsStream(t *testing.T) {
+	f := &fakeAPI{describe: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+		TableName: aws.String("T"), TableStatus: types.TableStatusActive,
+		ItemCount: aws.Int64(0), TableSizeBytes: aws.Int64(0),
+		StreamSpecification: &types.StreamSpecification{StreamEnabled: aws.Bool(true), StreamViewType: types.StreamViewTypeNewAndOldImages},
+		LatestStreamArn:     aws.String("arn:stream"),
+	}}}
+	info, err := newTestClient(f).DescribeTable(context.Background(), "T")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.StreamArn != "arn:stream" || info.StreamViewType != "NEW_AND_OLD_IMAGES" {
+		t.Fatalf("stream = %q %q", info.StreamArn, info.StreamViewType)
+	}
+}
@@ -0,0 +1,36 @@
+package dynamo
+
+import (
+	"context"
+	"strings"
+)
+
+// Transition describes what is still changing on the table: its own status
+// while CREATING or UPDATING, and indexes being built or backfilled. It is
+// "" once the table and every index are ACTIVE.
+func (t *TableInfo) Transition() string {
+	var parts []string
+	if t.Status != "" && t.Status != "ACTIVE" {
+		parts = append(parts, t.Status)
+	}
+	for _, gsi := range t.GSIs {
+		switch {
+		case gsi.Backfilling:
+			parts = append(parts, gsi.Name+" backfilling")
+		case gsi.Status != "" && gsi.Status != "ACTIVE":
+			parts = append(parts, gsi.Name+" "+gsi.Status)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// RefreshTable describes the table again, bypassing and then updating the
+// cache, so a status change shows up while the table is being watched.
+func (c *Client) RefreshTable(ctx context.Context, tableName string) (*TableInfo, error) {
+	info, err := c.describeTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	c.schemas.put(tableName, info)
+	return info, nil
+}
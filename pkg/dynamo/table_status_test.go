@@ -0,0 +1,37 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestRefreshTableBypassesAndUpdatesCache(t *testing.T) {
+	desc := &types.TableDescription{
+		TableName: aws.String("Users"), TableStatus: types.TableStatusUpdating,
+		ItemCount: aws.Int64(0), TableSizeBytes: aws.Int64(0),
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{
+			{IndexName: aws.String("byEmail"), IndexStatus: types.IndexStatusCreating, Backfilling: aws.Bool(true)},
+		},
+	}
+	f := &fakeAPI{describe: &dynamodb.DescribeTableOutput{Table: desc}}
+	c := newTestClient(f)
+	info, _ := c.DescribeTable(context.Background(), "Users")
+	if got := info.Transition(); got != "UPDATING, byEmail backfilling" {
+		t.Fatalf("transition = %q", got)
+	}
+
+	desc.TableStatus = types.TableStatusActive
+	desc.GlobalSecondaryIndexes[0].IndexStatus = types.IndexStatusActive
+	desc.GlobalSecondaryIndexes[0].Backfilling = aws.Bool(false)
+	info, err := c.RefreshTable(context.Background(), "Users")
+	if err != nil || info.Transition() != "" || f.descCalls != 2 {
+		t.Fatalf("refresh = %+v, %v after %d calls", info, err, f.descCalls)
+	}
+	if cached, _ := c.DescribeTable(context.Background(), "Users"); cached != info || f.descCalls != 2 {
+		t.Fatal("the refreshed description should replace the cached one")
+	}
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// KeyStat is what one partition key value (or group of them) holds of a set
+// of items
+type KeyStat struct {
+	Key   string
+	Items int
+	Bytes int // DynamoDB item size, summed
+}
+
+// Distribution is how a set of items spreads over its partition keys
+type Distribution struct {
+	Keys    []KeyStat // most items first
+	Items   int       // items holding the key
+	Bytes   int
+	Missing int // items without it, e.g. from an index
+}
+
+// KeyDistribution counts items and bytes per value of the partition key
+// attr. A non-nil group maps each value to the bucket it counts towards, a
+// key prefix say; nil counts every value on its own.
+func KeyDistribution(items []map[string]types.AttributeValue, attr string, group func(string) string) Distribution {
+	var d Distribution
+	index := map[string]int{}
+	for _, item := range items {
+		av, ok := item[attr]
+		if !ok {
+			d.Missing++
+			continue
+		}
+		key := FormatValue(av, 0)
+		if group != nil {
+			key = group(key)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(d.Keys)
+			index[key] = i
+			d.Keys = append(d.Keys, KeyStat{Key: key})
+		}
+		size := ItemSize(item)
+		d.Keys[i].Items++
+		d.Keys[i].Bytes += size
+		d.Items++
+		d.Bytes += size
+	}
+	slices.SortStableFunc(d.Keys, func(a, b KeyStat) int {
+		return cmp.Or(cmp.Compare(b.Items, a.Items), cmp.Compare(b.Bytes, a.Bytes), cmp.Compare(a.Key, b.Key))
+	})
+	return d
+}
+
+// BySize is the keys largest first
+func (d Distribution) BySize() []KeyStat {
+	keys := slices.Clone(d.Keys)
+	slices.SortStableFunc(keys, func(a, b KeyStat) int {
+		return cmp.Or(cmp.Compare(b.Bytes, a.Bytes), cmp.Compare(b.Items, a.Items), cmp.Compare(a.Key, b.Key))
+	})
+	return keys
+}
+
+// Hot reports whether k holds an outsized share of the items or bytes: at
+// least a tenth of them and five times a key's average. One key holding
+// every item of several is hot too. It is a heuristic over what was read,
+// not a measure of traffic.
+func (d Distribution) Hot(k KeyStat) bool {
+	if len(d.Keys) == 1 {
+		return d.Items > 1
+	}
+	n := len(d.Keys)
+	outsized := func(part, total int) bool {
+		return total > 0 && part*10 >= total && part*n >= 5*total
+	}
+	return outsized(k.Items, d.Items) || outsized(k.Bytes, d.Bytes)
+}
+
+// keySeparators are the characters single-table designs join key parts with
+const keySeparators = "#:|/"
+
+// KeyPrefix cuts key after its first parts parts, so KeyPrefix("USER#42#2024",
+// 1) is "USER" and 2 is "USER#42". A key with no more parts than that is
+// returned whole.
+func KeyPrefix(key string, parts int) string {
+	for i, r := range key {
+		if strings.ContainsRune(keySeparators, r) {
+			if parts--; parts == 0 {
+				return key[:i]
+			}
+		}
+	}
+	return key
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func keyedItems(counts map[string]int) []map[string]types.AttributeValue {
+	var items []map[string]types.AttributeValue
+	for key, n := range counts {
+		for i := range n {
+			items = append(items, map[string]types.AttributeValue{
+				"pk": &types.AttributeValueMemberS{Value: key},
+				"sk": &types.AttributeValueMemberN{Value: fmt.Sprint(i)},
+			})
+		}
+	}
+	return items
+}
+
+func TestKeyDistributionFlagsHotKeys(t *testing.T) {
+	counts := map[string]int{"tenant#big": 60}
+	for i := range 20 {
+		counts[fmt.Sprintf("tenant#%02d", i)] = 2
+	}
+	items := append(keyedItems(counts), map[string]types.AttributeValue{"other": &types.AttributeValueMemberS{Value: "x"}})
+
+	d := KeyDistribution(items, "pk", nil)
+	if d.Items != 100 || d.Missing != 1 || len(d.Keys) != 21 {
+		t.Fatalf("got %d items, %d missing, %d keys", d.Items, d.Missing, len(d.Keys))
+	}
+	if top := d.Keys[0]; top.Key != "tenant#big" || top.Items != 60 || !d.Hot(top) {
+		t.Errorf("top key %+v should lead and be hot", top)
+	}
+	if d.Hot(d.Keys[1]) {
+		t.Errorf("%+v holds its share and is not hot", d.Keys[1])
+	}
+}
+
+func TestKeyDistributionEvenSpreadIsNotHot(t *testing.T) {
+	counts := map[string]int{}
+	for i := range 10 {
+		counts[fmt.Sprint(i)] = 5
+	}
+	d := KeyDistribution(keyedItems(counts), "pk", nil)
+	for _, k := range d.Keys {
+		if d.Hot(k) {
+			t.Errorf("%+v flagged in an even spread", k)
+		}
+	}
+}
+
+func TestKeyDistributionBySizeAndGroups(t *testing.T) {
+	items := keyedItems(map[string]int{"a#1": 3, "a#2": 1, "b#1": 2})
+	items = append(items, map[string]types.AttributeValue{
+		"pk":   &types.AttributeValueMemberS{Value: "c#1"},
+		"blob": &types.AttributeValueMemberS{Value: strings.Repeat("x", 1000)},
+	})
+
+	d := KeyDistribution(items, "pk", nil)
+	if top := d.BySize()[0]; top.Key != "c#1" || top.Items != 1 {
+		t.Errorf("largest key %+v, want c#1", top)
+	}
+	if d.Keys[0].Key != "a#1" {
+		t.Errorf("BySize reordered the count order: %+v", d.Keys)
+	}
+
+	grouped := KeyDistribution(items, "pk", func(k string) string { return strings.SplitN(k, "#", 2)[0] })
+	if len(grouped.Keys) != 3 || grouped.Keys[0].Key != "a" || grouped.Keys[0].Items != 4 {
+		t.Errorf("grouped keys %+v", grouped.Keys)
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	for _, tc := range []struct {
+		key   string
+		parts int
+		want  string
+	}{
+		{"USER#42#2024", 1, "USER"},
+		{"USER#42#2024", 2, "USER#42"},
+		{"USER#42", 2, "USER#42"},
+		{"tenant:7/orders", 2, "tenant:7"},
+		{"plain", 1, "plain"},
+	} {
+		if got := KeyPrefix(tc.key, tc.parts); got != tc.want {
+			t.Errorf("KeyPrefix(%q, %d) = %q, want %q", tc.key, tc.parts, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package models converts DynamoDB items to and from Go values, JSON,
+// DynamoDB JSON and YAML, and inspects them: sizes, shapes, schema
+// validation and diffs between result sets.
+package models
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// JSONString returns the JSON object or array an S value holds serialized,
+// which is how many tables store documents
+func JSONString(av types.AttributeValue) (json.RawMessage, bool) {
+	s, ok := av.(*types.AttributeValueMemberS)
+	if !ok {
+		return nil, false
+	}
+	t := strings.TrimSpace(s.Value)
+	if t == "" || t[0] != '{' && t[0] != '[' || !json.Valid([]byte(t)) {
+		return nil, false
+	}
+	return json.RawMessage(t), true
+}
+
+// JSONStringAttributes names the attributes of item holding serialized JSON
+func JSONStringAttributes(item map[string]types.AttributeValue) []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(item)) {
+		if _, ok := JSONString(item[name]); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ItemToJSONExpanded is ItemToJSON (indented) with the serialized JSON of
+// the named attributes written out as nested structures, to edit in place.
+// CollapseJSONStrings turns them back into strings.
+func ItemToJSONExpanded(item map[string]types.AttributeValue, names []string) (string, error) {
+	data := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		data[k] = AttributeValueToInterface(v)
+	}
+	for _, name := range names {
+		if raw, ok := JSONString(item[name]); ok {
+			data[name] = raw // its numbers stay as written
+		}
+	}
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal item: %w", err)
+	}
+	return string(out), nil
+}
+
+// CollapseJSONStrings serializes the named attributes of an item's JSON text
+// back into strings, undoing ItemToJSONExpanded of item. An attribute whose
+// value wasn't changed gets item's string back as it was; a changed one is
+// written as compact JSON, its object keys sorted. Attributes that are
+// strings already are left alone.
+func CollapseJSONStrings(text string, item map[string]types.AttributeValue, names []string) (string, error) {
+	decoded, err := decodeNumbers(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	data, ok := decoded.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid JSON: an item must be an object")
+	}
+	for _, name := range names {
+		switch v := data[name].(type) {
+		case map[string]interface{}, []interface{}:
+			if raw, ok := JSONString(item[name]); ok {
+				if was, err := decodeNumbers(string(raw)); err == nil && reflect.DeepEqual(was, v) {
+					data[name] = item[name].(*types.AttributeValueMemberS).Value
+					continue
+				}
+			}
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(v); err != nil {
+				return "", err
+			}
+			data[name] = strings.TrimSuffix(buf.String(), "\n")
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// decodeNumbers decodes JSON text keeping its numbers as written
+func decodeNumbers(text string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
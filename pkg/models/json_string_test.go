@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestJSONStringsExpandAndCollapse(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"id":       &types.AttributeValueMemberS{Value: "1"},
+		"settings": &types.AttributeValueMemberS{Value: `{"theme":"dark","limit":12345678901234567890}`},
+		"tags":     &types.AttributeValueMemberS{Value: ` ["a","b"] `},
+		"note":     &types.AttributeValueMemberS{Value: "{not json"},
+	}
+	names := JSONStringAttributes(item)
+	if !slices.Equal(names, []string{"settings", "tags"}) {
+		t.Fatalf("names = %v", names)
+	}
+
+	text, err := ItemToJSONExpanded(item, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, `"theme": "dark"`) {
+		t.Fatalf("settings should be written out nested:\n%s", text)
+	}
+
+	edited := strings.Replace(text, `"dark"`, `"light"`, 1)
+	back, err := CollapseJSONStrings(edited, item, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(back), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["settings"] != `{"limit":12345678901234567890,"theme":"light"}` || got["note"] != "{not json" {
+		t.Errorf("collapsed to %v", got)
+	}
+	if got["tags"] != ` ["a","b"] ` {
+		t.Errorf("unchanged tags should keep their string as stored, got %q", got["tags"])
+	}
+}
@@ -0,0 +1,279 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a JSON Schema an item's plain JSON must satisfy before it is
+// saved. It understands the keywords that describe a record's shape: type,
+// enum, const, required, properties, additionalProperties, items,
+// minimum/maximum (and their exclusive forms), minLength/maxLength,
+// pattern, minItems/maxItems, and allOf/anyOf/oneOf. Others are ignored,
+// as JSON Schema ignores keywords it doesn't know.
+type Schema struct {
+	Type                 schemaTypes        `json:"type"`
+	Enum                 []interface{}      `json:"enum"`
+	Const                *json.RawMessage   `json:"const"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Pattern              string             `json:"pattern"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	AllOf                []*Schema          `json:"allOf"`
+	AnyOf                []*Schema          `json:"anyOf"`
+	OneOf                []*Schema          `json:"oneOf"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes is "type", given as one name or a list of them
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// additional is additionalProperties: false, true or a schema for the
+// properties "properties" doesn't name
+type additional struct {
+	allowed bool
+	schema  *Schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	if json.Unmarshal(data, &a.allowed) == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// ParseSchema reads a JSON Schema document
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile checks the patterns throughout the schema once, up front
+func (s *Schema) compile() error {
+	if s == nil {
+		return nil
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid JSON Schema pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	subs := slices.Concat(s.AllOf, s.AnyOf, s.OneOf, []*Schema{s.Items})
+	for _, p := range s.Properties {
+		subs = append(subs, p)
+	}
+	if s.AdditionalProperties != nil {
+		subs = append(subs, s.AdditionalProperties.schema)
+	}
+	for _, sub := range subs {
+		if err := sub.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Violation is one way a document breaks a schema. Path locates the value,
+// e.g. "address.zip" or "tags[2]"; it is empty for the document itself.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// ValidateJSON checks the JSON text of an item against s
+func (s *Schema) ValidateJSON(text string) ([]Violation, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(text), &doc); err != nil {
+		return nil, err
+	}
+	return s.Validate(doc), nil
+}
+
+// Validate checks a document decoded by encoding/json against s
+func (s *Schema) Validate(doc interface{}) []Violation {
+	var out []Violation
+	s.validate("", doc, &out)
+	return out
+}
+
+func (s *Schema) validate(path string, v interface{}, out *[]Violation) {
+	if s == nil {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		*out = append(*out, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return isType(v, t) }) {
+		fail("is %s, want %s", jsonType(v), strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e interface{}) bool { return reflect.DeepEqual(e, v) }) {
+		fail("is not one of %s", compactJSON(s.Enum))
+	}
+	if s.Const != nil {
+		var want interface{}
+		if json.Unmarshal(*s.Const, &want) == nil && !reflect.DeepEqual(want, v) {
+			fail("must be %s", compactJSON(want))
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*out = append(*out, Violation{Path: path, Message: "missing required attribute " + name})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			if sub, ok := s.Properties[name]; ok {
+				sub.validate(joinPath(path, name), v[name], out)
+				continue
+			}
+			if a := s.AdditionalProperties; a != nil {
+				if !a.allowed {
+					*out = append(*out, Violation{Path: joinPath(path, name), Message: "is not allowed by the schema"})
+				}
+				a.schema.validate(joinPath(path, name), v[name], out)
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("has %d items, want at least %d", len(v), *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("has %d items, want at most %d", len(v), *s.MaxItems)
+		}
+		for i, e := range v {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), e, out)
+		}
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("is %d characters, want at least %d", n, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("is %d characters, want at most %d", n, *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("does not match %s", s.Pattern)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("is %v, want at least %v", v, *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("is %v, want at most %v", v, *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && v <= *s.ExclusiveMinimum {
+			fail("is %v, want more than %v", v, *s.ExclusiveMinimum)
+		}
+		if s.ExclusiveMaximum != nil && v >= *s.ExclusiveMaximum {
+			fail("is %v, want less than %v", v, *s.ExclusiveMaximum)
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		sub.validate(path, v, out)
+	}
+	if len(s.AnyOf) > 0 && countMatches(s.AnyOf, v) == 0 {
+		fail("matches none of anyOf's %d schemas", len(s.AnyOf))
+	}
+	if len(s.OneOf) > 0 {
+		if n := countMatches(s.OneOf, v); n != 1 {
+			fail("matches %d of oneOf's schemas, want exactly 1", n)
+		}
+	}
+}
+
+func countMatches(schemas []*Schema, v interface{}) int {
+	n := 0
+	for _, s := range schemas {
+		if len(s.Validate(v)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func isType(v interface{}, t string) bool {
+	if t == "integer" {
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	}
+	return jsonType(v) == t
+}
+
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func compactJSON(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
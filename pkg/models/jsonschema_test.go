@@ -0,0 +1,61 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+const orderSchema = `{
+  "type": "object",
+  "required": ["pk", "status", "total"],
+  "additionalProperties": false,
+  "properties": {
+    "pk":     {"type": "string", "pattern": "^ORDER#"},
+    "status": {"enum": ["open", "shipped"]},
+    "total":  {"type": "number", "minimum": 0},
+    "qty":    {"type": "integer"},
+    "tags":   {"type": "array", "maxItems": 2, "items": {"type": "string", "minLength": 1}}
+  }
+}`
+
+func TestSchemaValidate(t *testing.T) {
+	s, err := ParseSchema([]byte(orderSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.ValidateJSON(`{"pk":"ORDER#1","status":"open","total":12.5,"qty":2,"tags":["a"]}`)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("a valid item: %v, %v", got, err)
+	}
+
+	got, _ = s.ValidateJSON(`{"pk":"USER#1","status":"lost","qty":1.5,"tags":["a","","c"],"colour":"red"}`)
+	var msgs []string
+	for _, v := range got {
+		msgs = append(msgs, v.String())
+	}
+	want := []string{
+		"missing required attribute total",
+		`colour: is not allowed by the schema`,
+		"pk: does not match ^ORDER#",
+		"qty: is number, want integer",
+		`status: is not one of ["open","shipped"]`,
+		"tags: has 3 items, want at most 2",
+		"tags[1]: is 0 characters, want at least 1",
+	}
+	if !slices.Equal(msgs, want) {
+		t.Errorf("got\n%q\nwant\n%q", msgs, want)
+	}
+}
+
+func TestParseSchemaRejectsBadPatterns(t *testing.T) {
+	if _, err := ParseSchema([]byte(`{"properties":{"a":{"pattern":"("}}}`)); err == nil {
+		t.Error("an invalid pattern should be reported when the schema loads")
+	}
+	s, err := ParseSchema([]byte(`{"oneOf":[{"type":"string"},{"type":"integer"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Validate(true); len(got) != 1 {
+		t.Errorf("true matches neither oneOf schema: %v", got)
+	}
+}
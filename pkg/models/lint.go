@@ -0,0 +1,122 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/godynamo/pkg/query"
+)
+
+// MaxNesting is how many levels of maps and lists DynamoDB stores; LintItem
+// warns from lintNesting on
+const (
+	MaxNesting  = 32
+	lintNesting = 24
+)
+
+// LintItem looks over an item's JSON text, as the editor saves it, for
+// pitfalls DynamoDB accepts now and makes painful later: attribute names
+// that are reserved words, numbers a float64 can't hold exactly, nesting
+// close to the limit, and sets the edit turns into lists. original is the
+// item before the edit, nil for a new one. Only unparseable text is an
+// error; the warnings don't stop a save.
+func LintItem(text string, original map[string]types.AttributeValue) ([]string, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	l := linter{}
+	l.walk("", doc, 0)
+	if l.depth >= lintNesting {
+		l.warn(l.deepest, fmt.Sprintf("nested %d levels deep; DynamoDB stores at most %d", l.depth, MaxNesting))
+	}
+	for _, name := range slices.Sorted(maps.Keys(doc)) {
+		l.checkSet(name, original[name], doc[name])
+	}
+	return l.warnings, nil
+}
+
+type linter struct {
+	warnings []string
+	depth    int // of the deepest value, and where it is
+	deepest  string
+}
+
+func (l *linter) warn(path, msg string) {
+	l.warnings = append(l.warnings, path+": "+msg)
+}
+
+func (l *linter) walk(path string, v interface{}, depth int) {
+	if depth > l.depth {
+		l.depth, l.deepest = depth, path
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			p := name
+			if path != "" {
+				p = path + "." + name
+			}
+			if query.IsReservedWord(name) {
+				l.warn(p, fmt.Sprintf("%s is a reserved word; expressions will need #%s in ExpressionAttributeNames", name, name))
+			}
+			l.walk(p, v[name], depth+1)
+		}
+	case []interface{}:
+		for i, e := range v {
+			l.walk(fmt.Sprintf("%s[%d]", path, i), e, depth+1)
+		}
+	case json.Number:
+		if stored, ok := storedNumber(string(v)); ok && !sameNumber(string(v), stored) {
+			l.warn(path, fmt.Sprintf("%s will be saved as %s; quote it or trim digits to keep it exact", v, stored))
+		}
+	}
+}
+
+// storedNumber is the N value the editor writes for a JSON number, which it
+// reads as a float64
+func storedNumber(lit string) (string, bool) {
+	f, err := strconv.ParseFloat(lit, 64)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), true
+}
+
+func sameNumber(a, b string) bool {
+	x, okx := new(big.Rat).SetString(a)
+	y, oky := new(big.Rat).SetString(b)
+	return okx && oky && x.Cmp(y) == 0
+}
+
+// checkSet warns when an attribute that was a set is saved from the editor,
+// where sets are plain arrays and become lists
+func (l *linter) checkSet(name string, was types.AttributeValue, now interface{}) {
+	arr, ok := now.([]interface{})
+	if !ok {
+		return
+	}
+	switch was.(type) {
+	case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+	default:
+		return
+	}
+	typ := GetAttributeType(was)
+	l.warn(name, fmt.Sprintf("was a set (%s) and will be saved as a list (L)", typ))
+	if len(arr) == 0 {
+		l.warn(name, "an empty set can't be stored; remove the attribute instead")
+	}
+	if slices.Contains(arr, interface{}("")) {
+		l.warn(name, "a set can't hold an empty string")
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestLintItem(t *testing.T) {
+	original := map[string]types.AttributeValue{
+		"tags": &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+	}
+	got, err := LintItem(`{
+	  "id": "1",
+	  "status": "open",
+	  "amount": 12.50,
+	  "big": 12345678901234567890,
+	  "meta": {"comment": "x"},
+	  "tags": ["a", ""]
+	}`, original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"big: 12345678901234567890 will be saved as 12345678901234567000; quote it or trim digits to keep it exact",
+		"meta.comment: comment is a reserved word; expressions will need #comment in ExpressionAttributeNames",
+		"status: status is a reserved word; expressions will need #status in ExpressionAttributeNames",
+		"tags: was a set (SS) and will be saved as a list (L)",
+		"tags: a set can't hold an empty string",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestLintItemNesting(t *testing.T) {
+	text := strings.Repeat(`{"a":`, 26) + "1" + strings.Repeat("}", 26)
+	got, _ := LintItem(text, nil)
+	if len(got) != 1 || !strings.Contains(got[0], "nested 26 levels deep") {
+		t.Errorf("got %q", got)
+	}
+	if got, _ := LintItem(`{"id": "1", "n": 0.5}`, nil); len(got) != 0 {
+		t.Errorf("a plain item has nothing to warn about: %q", got)
+	}
+}
@@ -0,0 +1,436 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Item represents a DynamoDB item in a displayable format
+type Item struct {
+	Raw        map[string]types.AttributeValue
+	Attributes map[string]interface{}
+}
+
+// NewItem creates an Item from DynamoDB AttributeValue map
+func NewItem(raw map[string]types.AttributeValue) Item {
+	attrs := make(map[string]interface{})
+	for k, v := range raw {
+		attrs[k] = AttributeValueToInterface(v)
+	}
+	return Item{
+		Raw:        raw,
+		Attributes: attrs,
+	}
+}
+
+// AttributeValueToInterface converts an AttributeValue to a Go interface{}
+func AttributeValueToInterface(av types.AttributeValue) interface{} {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		// Parse as integer first so values between 2^53 and 2^63 keep full
+		// precision (ParseFloat would round them). Fall back to float for
+		// decimals, preserving the "whole number → int64" display contract,
+		// then to the raw string if it isn't numeric at all.
+		if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+			if f == float64(int64(f)) {
+				return int64(f)
+			}
+			return f
+		}
+		return v.Value
+	case *types.AttributeValueMemberB:
+		return v.Value
+	case *types.AttributeValueMemberBOOL:
+		return v.Value
+	case *types.AttributeValueMemberNULL:
+		return nil
+	case *types.AttributeValueMemberSS:
+		return v.Value
+	case *types.AttributeValueMemberNS:
+		nums := make([]float64, len(v.Value))
+		for i, n := range v.Value {
+			if f, err := strconv.ParseFloat(n, 64); err == nil {
+				nums[i] = f
+			}
+		}
+		return nums
+	case *types.AttributeValueMemberBS:
+		return v.Value
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, len(v.Value))
+		for i, item := range v.Value {
+			list[i] = AttributeValueToInterface(item)
+		}
+		return list
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{})
+		for k, item := range v.Value {
+			m[k] = AttributeValueToInterface(item)
+		}
+		return m
+	default:
+		return nil
+	}
+}
+
+// InterfaceToAttributeValue converts a Go interface{} to an AttributeValue
+func InterfaceToAttributeValue(v interface{}) types.AttributeValue {
+	switch val := v.(type) {
+	case string:
+		return &types.AttributeValueMemberS{Value: val}
+	case int:
+		return &types.AttributeValueMemberN{Value: strconv.Itoa(val)}
+	case int64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatInt(val, 10)}
+	case float64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatFloat(val, 'f', -1, 64)}
+	case json.Number:
+		return &types.AttributeValueMemberN{Value: string(val)}
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: val}
+	case nil:
+		return &types.AttributeValueMemberNULL{Value: true}
+	case []interface{}:
+		list := make([]types.AttributeValue, len(val))
+		for i, item := range val {
+			list[i] = InterfaceToAttributeValue(item)
+		}
+		return &types.AttributeValueMemberL{Value: list}
+	case map[string]interface{}:
+		m := make(map[string]types.AttributeValue)
+		for k, item := range val {
+			m[k] = InterfaceToAttributeValue(item)
+		}
+		return &types.AttributeValueMemberM{Value: m}
+	case []byte:
+		return &types.AttributeValueMemberB{Value: val}
+	default:
+		return &types.AttributeValueMemberS{Value: fmt.Sprintf("%v", val)}
+	}
+}
+
+// JSONToItem converts a JSON string to a DynamoDB item
+func JSONToItem(jsonStr string) (map[string]types.AttributeValue, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	item := make(map[string]types.AttributeValue)
+	for k, v := range data {
+		item[k] = InterfaceToAttributeValue(v)
+	}
+
+	return item, nil
+}
+
+// ItemToJSON converts a DynamoDB item to JSON string
+func ItemToJSON(item map[string]types.AttributeValue, indent bool) (string, error) {
+	data := make(map[string]interface{})
+	for k, v := range item {
+		data[k] = AttributeValueToInterface(v)
+	}
+
+	var jsonBytes []byte
+	var err error
+
+	if indent {
+		jsonBytes, err = json.MarshalIndent(data, "", "  ")
+	} else {
+		jsonBytes, err = json.Marshal(data)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal item: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// ItemToDynamoJSON converts a DynamoDB item to the typed wire format used by
+// the AWS CLI and SDKs, e.g. {"id": {"S": "1"}}
+func ItemToDynamoJSON(item map[string]types.AttributeValue, indent bool) (string, error) {
+	data := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		data[k] = attributeValueToTyped(v)
+	}
+
+	var jsonBytes []byte
+	var err error
+
+	if indent {
+		jsonBytes, err = json.MarshalIndent(data, "", "  ")
+	} else {
+		jsonBytes, err = json.Marshal(data)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal item: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// attributeValueToTyped wraps a value in its type descriptor. Binary values
+// become base64 strings through encoding/json, as in the AWS wire format.
+func attributeValueToTyped(av types.AttributeValue) map[string]interface{} {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]interface{}{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]interface{}{"N": v.Value}
+	case *types.AttributeValueMemberB:
+		return map[string]interface{}{"B": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]interface{}{"BOOL": v.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]interface{}{"NULL": true}
+	case *types.AttributeValueMemberSS:
+		return map[string]interface{}{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]interface{}{"NS": v.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]interface{}{"BS": v.Value}
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, len(v.Value))
+		for i, item := range v.Value {
+			list[i] = attributeValueToTyped(item)
+		}
+		return map[string]interface{}{"L": list}
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(v.Value))
+		for k, item := range v.Value {
+			m[k] = attributeValueToTyped(item)
+		}
+		return map[string]interface{}{"M": m}
+	default:
+		return map[string]interface{}{"NULL": true}
+	}
+}
+
+// DynamoJSONToItem parses the typed wire format produced by ItemToDynamoJSON
+func DynamoJSONToItem(data []byte) (map[string]types.AttributeValue, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid DynamoDB JSON: %w", err)
+	}
+	item := make(map[string]types.AttributeValue, len(raw))
+	for k, v := range raw {
+		av, err := typedToAttributeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", k, err)
+		}
+		item[k] = av
+	}
+	return item, nil
+}
+
+func typedToAttributeValue(data json.RawMessage) (types.AttributeValue, error) {
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, fmt.Errorf("invalid DynamoDB JSON: %w", err)
+	}
+	if len(typed) != 1 {
+		return nil, fmt.Errorf("expected exactly one type descriptor, got %d", len(typed))
+	}
+	for t, v := range typed {
+		switch t {
+		case "S":
+			var s string
+			err := json.Unmarshal(v, &s)
+			return &types.AttributeValueMemberS{Value: s}, err
+		case "N":
+			var s string
+			err := json.Unmarshal(v, &s)
+			return &types.AttributeValueMemberN{Value: s}, err
+		case "B":
+			var b []byte
+			err := json.Unmarshal(v, &b)
+			return &types.AttributeValueMemberB{Value: b}, err
+		case "BOOL":
+			var b bool
+			err := json.Unmarshal(v, &b)
+			return &types.AttributeValueMemberBOOL{Value: b}, err
+		case "NULL":
+			return &types.AttributeValueMemberNULL{Value: true}, nil
+		case "SS":
+			var ss []string
+			err := json.Unmarshal(v, &ss)
+			return &types.AttributeValueMemberSS{Value: ss}, err
+		case "NS":
+			var ns []string
+			err := json.Unmarshal(v, &ns)
+			return &types.AttributeValueMemberNS{Value: ns}, err
+		case "BS":
+			var bs [][]byte
+			err := json.Unmarshal(v, &bs)
+			return &types.AttributeValueMemberBS{Value: bs}, err
+		case "L":
+			var raw []json.RawMessage
+			if err := json.Unmarshal(v, &raw); err != nil {
+				return nil, err
+			}
+			list := make([]types.AttributeValue, len(raw))
+			for i, e := range raw {
+				av, err := typedToAttributeValue(e)
+				if err != nil {
+					return nil, err
+				}
+				list[i] = av
+			}
+			return &types.AttributeValueMemberL{Value: list}, nil
+		case "M":
+			item, err := DynamoJSONToItem(v)
+			if err != nil {
+				return nil, err
+			}
+			return &types.AttributeValueMemberM{Value: item}, nil
+		default:
+			return nil, fmt.Errorf("unknown type descriptor %q", t)
+		}
+	}
+	return nil, nil
+}
+
+// GetAttributeType returns the DynamoDB type of an AttributeValue
+func GetAttributeType(av types.AttributeValue) string {
+	switch av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S"
+	case *types.AttributeValueMemberN:
+		return "N"
+	case *types.AttributeValueMemberB:
+		return "B"
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *types.AttributeValueMemberNULL:
+		return "NULL"
+	case *types.AttributeValueMemberSS:
+		return "SS"
+	case *types.AttributeValueMemberNS:
+		return "NS"
+	case *types.AttributeValueMemberBS:
+		return "BS"
+	case *types.AttributeValueMemberL:
+		return "L"
+	case *types.AttributeValueMemberM:
+		return "M"
+	default:
+		return "?"
+	}
+}
+
+// FormatValue returns a string representation of an AttributeValue
+func FormatValue(av types.AttributeValue, maxLen int) string {
+	return truncateRunes(formatScalar(av), maxLen)
+}
+
+// formatScalar renders av for a table cell. Strings, numbers and booleans,
+// which make up nearly every cell, skip the interface/JSON round trip.
+func formatScalar(av types.AttributeValue) string {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+			if isCanonicalInt(v.Value) {
+				return v.Value
+			}
+			return strconv.FormatInt(i, 10)
+		}
+	case *types.AttributeValueMemberBOOL:
+		return strconv.FormatBool(v.Value)
+	case *types.AttributeValueMemberNULL:
+		return "null"
+	}
+
+	switch v := AttributeValueToInterface(av).(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	default:
+		jsonBytes, _ := json.Marshal(v)
+		return string(jsonBytes)
+	}
+}
+
+// isCanonicalInt reports whether a string ParseInt accepted is already in
+// the form FormatInt would print (no sign prefix or leading zeros).
+func isCanonicalInt(s string) bool {
+	digits := strings.TrimPrefix(s, "-")
+	if s[0] == '+' || digits == "" {
+		return false
+	}
+	return digits == "0" && s == "0" || digits[0] != '0'
+}
+
+// truncateRunes shortens str to maxLen runes, ending in "..." when there is room
+func truncateRunes(str string, maxLen int) string {
+	if maxLen <= 0 || len(str) <= maxLen {
+		return str // a string never has more runes than bytes
+	}
+	if utf8.RuneCountInString(str) <= maxLen {
+		return str
+	}
+	keep := maxLen
+	if maxLen > 3 {
+		keep = maxLen - 3
+	}
+	cut, n := 0, 0
+	for i := range str {
+		if n == keep {
+			cut = i
+			break
+		}
+		n++
+	}
+	if maxLen <= 3 {
+		return str[:cut] // no room for an ellipsis
+	}
+	return str[:cut] + "..."
+}
+
+// Connection represents saved connection settings
+type Connection struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	AccessKey string `json:"access_key,omitempty"`
+	SecretKey string `json:"secret_key,omitempty"`
+	UseLocal  bool   `json:"use_local"`
+}
+
+// AppState represents the current application state
+type AppState int
+
+const (
+	StateConnecting AppState = iota
+	StateTableList
+	StateTableView
+	StateItemView
+	StateCreateItem
+	StateEditItem
+	StateCreateTable
+	StateQuery
+	StateSettings
+)
+
+
+
+
+
+
+
+
+
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 	"testing"
@@ -211,3 +212,81 @@ func TestFormatValueNonStringMarshals(t *testing.T) {
 		t.Fatalf("bool format: %q", got)
 	}
 }
+
+func TestFormatValueNumberFastPathMatchesJSON(t *testing.T) {
+	for _, n := range []string{"0", "-0", "7", "-12", "+5", "007", "9223372036854775807", "1e3", "2.50", "-0.5", "99999999999999999999", "NaN"} {
+		want := n
+		if v := AttributeValueToInterface(&types.AttributeValueMemberN{Value: n}); v != n {
+			b, _ := json.Marshal(v)
+			want = string(b)
+		}
+		if got := FormatValue(&types.AttributeValueMemberN{Value: n}, 0); got != want {
+			t.Errorf("FormatValue(N %q) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func BenchmarkFormatValue(b *testing.B) {
+	values := []types.AttributeValue{
+		&types.AttributeValueMemberS{Value: "user-12345@example.com"},
+		&types.AttributeValueMemberS{Value: strings.Repeat("long description ", 10)},
+		&types.AttributeValueMemberN{Value: "1700000000"},
+		&types.AttributeValueMemberN{Value: "19.99"},
+		&types.AttributeValueMemberBOOL{Value: true},
+		&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"k": &types.AttributeValueMemberS{Value: "v"}}},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FormatValue(values[i%len(values)], 50)
+	}
+}
+
+func TestItemToDynamoJSON(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"id":  &types.AttributeValueMemberS{Value: "1"},
+		"n":   &types.AttributeValueMemberN{Value: "42"},
+		"ok":  &types.AttributeValueMemberBOOL{Value: true},
+		"nil": &types.AttributeValueMemberNULL{Value: true},
+		"b":   &types.AttributeValueMemberB{Value: []byte("hi")},
+		"m": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberN{Value: "1"}}},
+		}},
+	}
+	got, err := ItemToDynamoJSON(item, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"b":{"B":"aGk="},"id":{"S":"1"},"m":{"M":{"l":{"L":[{"N":"1"}]}}},"n":{"N":"42"},"nil":{"NULL":true},"ok":{"BOOL":true}}`
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestDynamoJSONToItemRoundTrip(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "1"},
+		"b":  &types.AttributeValueMemberB{Value: []byte{0, 1, 2}},
+		"ns": &types.AttributeValueMemberNS{Value: []string{"1", "2.5"}},
+		"m": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberNULL{Value: true},
+				&types.AttributeValueMemberBOOL{Value: false},
+			}},
+		}},
+	}
+	data, err := ItemToDynamoJSON(item, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DynamoJSONToItem([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := ItemToDynamoJSON(got, false)
+	if again != data {
+		t.Fatalf("round trip changed item:\n%s\n%s", data, again)
+	}
+	if _, err := DynamoJSONToItem([]byte(`{"id":{"X":"1"}}`)); err == nil {
+		t.Fatal("expected error for unknown type descriptor")
+	}
+}
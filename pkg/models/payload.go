@@ -0,0 +1,103 @@
+package models
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// maxDecodedPayload caps what DecodePayload inflates, so a compression bomb
+// can't take the item view down with it
+const maxDecodedPayload = 10 << 20
+
+// DecodePayload recognises the ways large values are squeezed into an
+// attribute: gzip or zlib data in a B value, or base64 in an S value,
+// possibly of compressed data. It returns the decoded text and the layers
+// it took off, outermost first ("base64+gzip"); ok is false for values that
+// aren't one of those, or don't decode to text.
+func DecodePayload(av types.AttributeValue) (text, layers string, ok bool) {
+	var data []byte
+	var steps []string
+	switch v := av.(type) {
+	case *types.AttributeValueMemberB:
+		data = v.Value
+	case *types.AttributeValueMemberS:
+		b, ok := decodeBase64(v.Value)
+		if !ok {
+			return "", "", false
+		}
+		data, steps = b, []string{"base64"}
+	default:
+		return "", "", false
+	}
+
+	if inflated, how, ok := inflate(data); ok {
+		data, steps = inflated, append(steps, how)
+	}
+	if len(steps) == 0 || !isText(data) {
+		return "", "", false
+	}
+	return string(data), strings.Join(steps, "+"), true
+}
+
+// decodeBase64 reads s as base64 when it plausibly is: long enough not to be
+// a word, and only base64's alphabet (either flavour, padding optional)
+func decodeBase64(s string) ([]byte, bool) {
+	if len(s) < 16 || strings.ContainsFunc(s, func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || strings.ContainsRune("+/-_=", r))
+	}) {
+		return nil, false
+	}
+	trimmed := strings.TrimRight(s, "=")
+	for _, enc := range []*base64.Encoding{base64.RawStdEncoding, base64.RawURLEncoding} {
+		if b, err := enc.DecodeString(trimmed); err == nil {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+func inflate(data []byte) ([]byte, string, bool) {
+	var r io.ReadCloser
+	var how string
+	var err error
+	switch {
+	case len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b:
+		r, err = gzip.NewReader(bytes.NewReader(data))
+		how = "gzip"
+	case len(data) > 2 && data[0] == 0x78 && (uint(data[0])<<8|uint(data[1]))%31 == 0:
+		r, err = zlib.NewReader(bytes.NewReader(data))
+		how = "zlib"
+	default:
+		return nil, "", false
+	}
+	if err != nil {
+		return nil, "", false
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxDecodedPayload))
+	if err != nil {
+		return nil, "", false
+	}
+	return out, how, true
+}
+
+// isText reports whether data reads as text rather than more binary
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
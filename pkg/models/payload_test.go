@@ -0,0 +1,51 @@
+package models
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestDecodePayload(t *testing.T) {
+	payload := `{"order": 42, "lines": ["a", "b"]}`
+	zipped := gzipped(t, payload)
+	for _, c := range []struct {
+		name   string
+		av     types.AttributeValue
+		layers string
+	}{
+		{"gzip binary", &types.AttributeValueMemberB{Value: zipped}, "gzip"},
+		{"base64 gzip", &types.AttributeValueMemberS{Value: base64.StdEncoding.EncodeToString(zipped)}, "base64+gzip"},
+		{"base64 text", &types.AttributeValueMemberS{Value: base64.URLEncoding.EncodeToString([]byte(payload))}, "base64"},
+	} {
+		text, layers, ok := DecodePayload(c.av)
+		if !ok || text != payload || layers != c.layers {
+			t.Errorf("%s: %q, %q, %v", c.name, text, layers, ok)
+		}
+	}
+
+	for name, av := range map[string]types.AttributeValue{
+		"plain word":   &types.AttributeValueMemberS{Value: "SuperintendentsOfTheRealm"},
+		"binary noise": &types.AttributeValueMemberS{Value: base64.StdEncoding.EncodeToString([]byte{0, 1, 2, 3, 250, 251, 252, 253, 254, 255, 9, 8})},
+		"raw bytes":    &types.AttributeValueMemberB{Value: []byte("not compressed")},
+		"number":       &types.AttributeValueMemberN{Value: "1234567890123456"},
+	} {
+		if _, _, ok := DecodePayload(av); ok {
+			t.Errorf("%s shouldn't decode", name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ResultDiff is how a later set of items differs from an earlier one, item
+// by item. Each list is in key order.
+type ResultDiff struct {
+	Added     []KeyedItem
+	Removed   []KeyedItem
+	Modified  []ModifiedItem
+	Unchanged int
+}
+
+// KeyedItem is an item with the key it was matched by
+type KeyedItem struct {
+	Key  string
+	Item map[string]types.AttributeValue
+}
+
+// ModifiedItem is an item in both sets whose attributes differ
+type ModifiedItem struct {
+	Key     string
+	Changes []AttrChange // by attribute name
+}
+
+// AttrChange is one attribute of a modified item; Before or After is nil
+// when the attribute was added or removed
+type AttrChange struct {
+	Name          string
+	Before, After types.AttributeValue
+}
+
+// DiffResults matches before and after by key and compares what they hold.
+// An item key returns "" for can't be matched and is left out.
+func DiffResults(before, after []map[string]types.AttributeValue, key func(map[string]types.AttributeValue) string) ResultDiff {
+	index := func(items []map[string]types.AttributeValue) map[string]map[string]types.AttributeValue {
+		byKey := make(map[string]map[string]types.AttributeValue, len(items))
+		for _, item := range items {
+			if k := key(item); k != "" {
+				byKey[k] = item
+			}
+		}
+		return byKey
+	}
+	was, is := index(before), index(after)
+
+	var d ResultDiff
+	for _, k := range slices.Sorted(maps.Keys(is)) {
+		old, ok := was[k]
+		if !ok {
+			d.Added = append(d.Added, KeyedItem{k, is[k]})
+			continue
+		}
+		if changes := diffAttributes(old, is[k]); len(changes) > 0 {
+			d.Modified = append(d.Modified, ModifiedItem{k, changes})
+		} else {
+			d.Unchanged++
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(was)) {
+		if _, ok := is[k]; !ok {
+			d.Removed = append(d.Removed, KeyedItem{k, was[k]})
+		}
+	}
+	return d
+}
+
+func diffAttributes(before, after map[string]types.AttributeValue) []AttrChange {
+	names := slices.Collect(maps.Keys(before))
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	var changes []AttrChange
+	for _, name := range names {
+		b, a := before[name], after[name]
+		if b != nil && a != nil && reflect.DeepEqual(AttributeValueToInterface(b), AttributeValueToInterface(a)) {
+			continue
+		}
+		changes = append(changes, AttrChange{Name: name, Before: b, After: a})
+	}
+	return changes
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestDiffResults(t *testing.T) {
+	item := func(id, name string) map[string]types.AttributeValue {
+		it := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+		if name != "" {
+			it["name"] = &types.AttributeValueMemberS{Value: name}
+		}
+		return it
+	}
+	before := []map[string]types.AttributeValue{item("1", "alice"), item("2", "bob"), item("3", "carol")}
+	after := []map[string]types.AttributeValue{item("1", "alice"), item("2", ""), item("4", "dave"), {"nokey": &types.AttributeValueMemberS{Value: "x"}}}
+	after[1]["age"] = &types.AttributeValueMemberN{Value: "30"}
+
+	d := DiffResults(before, after, func(it map[string]types.AttributeValue) string {
+		if id, ok := it["id"].(*types.AttributeValueMemberS); ok {
+			return id.Value
+		}
+		return ""
+	})
+	if len(d.Added) != 1 || d.Added[0].Key != "4" || len(d.Removed) != 1 || d.Removed[0].Key != "3" || d.Unchanged != 1 {
+		t.Fatalf("diff %+v", d)
+	}
+	if len(d.Modified) != 1 || d.Modified[0].Key != "2" {
+		t.Fatalf("modified %+v", d.Modified)
+	}
+	changes := d.Modified[0].Changes
+	if len(changes) != 2 || changes[0].Name != "age" || changes[0].Before != nil || changes[1].Name != "name" || changes[1].After != nil {
+		t.Errorf("changes %+v, want age added and name removed", changes)
+	}
+}
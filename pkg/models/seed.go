@@ -0,0 +1,225 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// SeedTemplate generates synthetic items from a JSON object whose string
+// values may hold placeholders:
+//
+//	{{uuid}}            random UUID v4
+//	{{int 1 100}}       whole number in [1, 100]
+//	{{float 0 5}}       number in [0, 5] with two decimals
+//	{{bool}}            true or false
+//	{{timestamp}}       RFC 3339 time within the last 30 days
+//	{{seq}}             1 for the first item, 2 for the next, ...
+//	{{pick a b c}}      one of the words
+//
+// A value that is a single placeholder keeps its type, so "{{int 1 9}}"
+// becomes a number; placeholders inside longer strings are spliced in.
+type SeedTemplate struct {
+	root interface{} // the parsed JSON, with strings replaced by seedStrings
+}
+
+type seedGen func(r *rand.Rand, seq int) interface{}
+
+// seedString is a template string: literal text and placeholders in order
+type seedString struct {
+	parts []seedGen
+	typed bool // the whole string is one placeholder
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+// ParseSeedTemplate checks every placeholder of a template up front, so a
+// typo fails before anything is written
+func ParseSeedTemplate(text string) (*SeedTemplate, error) {
+	var root interface{}
+	if err := json.Unmarshal([]byte(text), &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, ok := root.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("the template must be a JSON object")
+	}
+	compiled, err := compileSeed(root)
+	if err != nil {
+		return nil, err
+	}
+	return &SeedTemplate{root: compiled}, nil
+}
+
+func compileSeed(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case string:
+		return compileSeedString(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			c, err := compileSeed(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = c
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			c, err := compileSeed(item)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = c
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+func compileSeedString(s string) (interface{}, error) {
+	matches := placeholderRe.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, nil
+	}
+	var out seedString
+	last := 0
+	for _, m := range matches {
+		if lit := s[last:m[0]]; lit != "" {
+			out.parts = append(out.parts, func(*rand.Rand, int) interface{} { return lit })
+		}
+		gen, err := placeholder(s[m[2]:m[3]])
+		if err != nil {
+			return nil, err
+		}
+		out.parts = append(out.parts, gen)
+		last = m[1]
+	}
+	if lit := s[last:]; lit != "" {
+		out.parts = append(out.parts, func(*rand.Rand, int) interface{} { return lit })
+	}
+	out.typed = len(out.parts) == 1
+	return out, nil
+}
+
+func placeholder(expr string) (seedGen, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty placeholder {{}}")
+	}
+	name, args := fields[0], fields[1:]
+	bounds := func() (float64, float64, error) {
+		if len(args) != 2 {
+			return 0, 0, fmt.Errorf("{{%s}} needs a minimum and a maximum, e.g. {{%s 1 100}}", expr, name)
+		}
+		lo, err1 := strconv.ParseFloat(args[0], 64)
+		hi, err2 := strconv.ParseFloat(args[1], 64)
+		if err1 != nil || err2 != nil || math.IsNaN(lo) || math.IsNaN(hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) || lo > hi {
+			return 0, 0, fmt.Errorf("{{%s}}: bounds must be numbers, smallest first", expr)
+		}
+		return lo, hi, nil
+	}
+	noArgs := func(gen seedGen) (seedGen, error) {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("{{%s}} takes no arguments", name)
+		}
+		return gen, nil
+	}
+
+	switch name {
+	case "uuid":
+		return noArgs(func(r *rand.Rand, _ int) interface{} { return seedUUID(r) })
+	case "bool":
+		return noArgs(func(r *rand.Rand, _ int) interface{} { return r.IntN(2) == 1 })
+	case "seq":
+		return noArgs(func(_ *rand.Rand, seq int) interface{} { return int64(seq) })
+	case "timestamp":
+		return noArgs(func(r *rand.Rand, _ int) interface{} {
+			ago := time.Duration(r.Int64N(int64(30 * 24 * time.Hour)))
+			return time.Now().UTC().Add(-ago).Truncate(time.Second).Format(time.RFC3339)
+		})
+	case "int":
+		lo, hi, err := bounds()
+		if err != nil {
+			return nil, err
+		}
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		if lo > hi {
+			return nil, fmt.Errorf("{{%s}}: no whole number in range", expr)
+		}
+		// math.MaxInt64 is 2^63 as a float64, one past the range
+		if lo < math.MinInt64 || hi >= math.MaxInt64 {
+			return nil, fmt.Errorf("{{%s}}: bounds must fit in a 64-bit integer", expr)
+		}
+		a, b := int64(lo), int64(hi)
+		if uint64(b)-uint64(a) >= math.MaxInt64 { // b-a+1 would overflow
+			return nil, fmt.Errorf("{{%s}}: the range is too wide", expr)
+		}
+		return func(r *rand.Rand, _ int) interface{} { return a + r.Int64N(b-a+1) }, nil
+	case "float":
+		lo, hi, err := bounds()
+		if err != nil {
+			return nil, err
+		}
+		return func(r *rand.Rand, _ int) interface{} {
+			return math.Round((lo+r.Float64()*(hi-lo))*100) / 100
+		}, nil
+	case "pick":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("{{pick}} needs words to pick from, e.g. {{pick red green}}")
+		}
+		return func(r *rand.Rand, _ int) interface{} { return args[r.IntN(len(args))] }, nil
+	}
+	return nil, fmt.Errorf("unknown placeholder {{%s}}", expr)
+}
+
+func seedUUID(r *rand.Rand) string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(r.UintN(256))
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Item generates item number seq (counting from 1)
+func (t *SeedTemplate) Item(r *rand.Rand, seq int) map[string]types.AttributeValue {
+	av := InterfaceToAttributeValue(renderSeed(t.root, r, seq))
+	return av.(*types.AttributeValueMemberM).Value
+}
+
+func renderSeed(v interface{}, r *rand.Rand, seq int) interface{} {
+	switch val := v.(type) {
+	case seedString:
+		if val.typed {
+			return val.parts[0](r, seq)
+		}
+		var b strings.Builder
+		for _, part := range val.parts {
+			fmt.Fprint(&b, part(r, seq))
+		}
+		return b.String()
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = renderSeed(item, r, seq)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = renderSeed(item, r, seq)
+		}
+		return out
+	}
+	return v
+}
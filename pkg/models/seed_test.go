@@ -0,0 +1,82 @@
+package models
+
+import (
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestSeedTemplateGeneratesTypedValues(t *testing.T) {
+	tmpl, err := ParseSeedTemplate(`{
+		"id": "{{uuid}}",
+		"name": "user-{{seq}}",
+		"age": "{{int 18 65}}",
+		"score": "{{float 0 5}}",
+		"active": "{{bool}}",
+		"createdAt": "{{timestamp}}",
+		"tier": "{{pick gold silver}}",
+		"tags": ["fixed", "{{seq}}"],
+		"nested": {"n": 7}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	item := tmpl.Item(r, 3)
+
+	if id := item["id"].(*types.AttributeValueMemberS).Value; !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(id) {
+		t.Errorf("uuid = %q", id)
+	}
+	if name := item["name"].(*types.AttributeValueMemberS).Value; name != "user-3" {
+		t.Errorf("name = %q", name)
+	}
+	age, _ := strconv.Atoi(item["age"].(*types.AttributeValueMemberN).Value)
+	if age < 18 || age > 65 {
+		t.Errorf("age = %d", age)
+	}
+	if _, ok := item["score"].(*types.AttributeValueMemberN); !ok {
+		t.Errorf("score = %#v, want a number", item["score"])
+	}
+	if _, ok := item["active"].(*types.AttributeValueMemberBOOL); !ok {
+		t.Errorf("active = %#v, want a bool", item["active"])
+	}
+	ts, err := time.Parse(time.RFC3339, item["createdAt"].(*types.AttributeValueMemberS).Value)
+	if err != nil || time.Since(ts) > 31*24*time.Hour {
+		t.Errorf("timestamp = %v, %v", ts, err)
+	}
+	if tier := item["tier"].(*types.AttributeValueMemberS).Value; tier != "gold" && tier != "silver" {
+		t.Errorf("tier = %q", tier)
+	}
+	tags := item["tags"].(*types.AttributeValueMemberL).Value
+	if tags[1].(*types.AttributeValueMemberN).Value != "3" {
+		t.Errorf("seq in a list = %#v", tags[1])
+	}
+	if item["nested"].(*types.AttributeValueMemberM).Value["n"].(*types.AttributeValueMemberN).Value != "7" {
+		t.Error("plain values should pass through")
+	}
+}
+
+func TestParseSeedTemplateRejectsMistakes(t *testing.T) {
+	for _, text := range []string{
+		`["not", "an object"]`,
+		`{"a": "{{uuid`,
+		`{"a": "{{nope}}"}`,
+		`{"a": "{{int 5}}"}`,
+		`{"a": "{{int 9 1}}"}`,
+		`{"a": "{{int 1.2 1.8}}"}`,
+		`{"a": "{{int 0 1e19}}"}`,
+		`{"a": "{{int -9e18 9e18}}"}`,
+		`{"a": "{{int NaN 5}}"}`,
+		`{"a": "{{float 0 Inf}}"}`,
+		`{"a": "{{uuid 4}}"}`,
+		`{"a": "{{pick}}"}`,
+	} {
+		if _, err := ParseSeedTemplate(text); err == nil {
+			t.Errorf("%s should not parse", text)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Shape is the top-level attributes of a set of items: how many items held
+// each one and with which DynamoDB types
+type Shape struct {
+	Items  int
+	Fields []Field
+}
+
+// Field is one attribute of a Shape
+type Field struct {
+	Name  string
+	Count int            // items holding the attribute
+	Types map[string]int // DynamoDB type (S, N, ...) → items holding it as that type
+
+	fractional bool // some N or NS value is not a whole number
+}
+
+// Optional reports whether some items lack the attribute
+func (f Field) Optional(items int) bool {
+	return f.Count < items
+}
+
+// InferShape reads the shape of items. The attributes named in first (the
+// table's keys, say) lead, in that order; the rest follow by name.
+func InferShape(items []map[string]types.AttributeValue, first ...string) Shape {
+	fields := map[string]*Field{}
+	for _, item := range items {
+		for name, av := range item {
+			f := fields[name]
+			if f == nil {
+				f = &Field{Name: name, Types: map[string]int{}}
+				fields[name] = f
+			}
+			f.Count++
+			f.Types[GetAttributeType(av)]++
+			switch v := av.(type) {
+			case *types.AttributeValueMemberN:
+				f.fractional = f.fractional || !isWhole(v.Value)
+			case *types.AttributeValueMemberNS:
+				for _, n := range v.Value {
+					f.fractional = f.fractional || !isWhole(n)
+				}
+			}
+		}
+	}
+
+	shape := Shape{Items: len(items)}
+	for _, name := range first {
+		if f := fields[name]; f != nil {
+			shape.Fields = append(shape.Fields, *f)
+			delete(fields, name)
+		}
+	}
+	rest := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		rest = append(rest, *f)
+	}
+	slices.SortFunc(rest, func(a, b Field) int { return strings.Compare(a.Name, b.Name) })
+	shape.Fields = append(shape.Fields, rest...)
+	return shape
+}
+
+func isWhole(n string) bool {
+	_, err := strconv.ParseInt(n, 10, 64)
+	return err == nil
+}
+
+// GoStruct writes shape as a Go struct named after name, tagged for
+// attributevalue.MarshalMap. Attributes some items lack are omitempty;
+// scalars that are sometimes NULL become pointers, and an attribute seen
+// with more than one type is an interface{}.
+func GoStruct(name string, shape Shape) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", goIdent(name, "Item"))
+	used := map[string]int{}
+	for _, f := range shape.Fields {
+		field := goIdent(f.Name, "Attr")
+		if used[field]++; used[field] > 1 {
+			field += strconv.Itoa(used[field])
+		}
+		tag := f.Name
+		if f.Optional(shape.Items) {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `dynamodbav:%s`\n", field, f.goType(), strconv.Quote(tag))
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source([]byte("package p\n\n" + b.String()))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(string(src), "package p\n\n"), nil
+}
+
+func (f Field) goType() string {
+	nullable := f.Types["NULL"] > 0
+	var seen []string
+	for t := range f.Types {
+		if t != "NULL" {
+			seen = append(seen, t)
+		}
+	}
+	if len(seen) != 1 {
+		return "interface{}"
+	}
+
+	typ := map[string]string{
+		"S":    "string",
+		"N":    "int64",
+		"B":    "[]byte",
+		"BOOL": "bool",
+		"SS":   "[]string",
+		"NS":   "[]int64",
+		"BS":   "[][]byte",
+		"L":    "[]interface{}",
+		"M":    "map[string]interface{}",
+	}[seen[0]]
+	if f.fractional {
+		typ = strings.Replace(typ, "int64", "float64", 1)
+	}
+	if nullable && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map") {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+// TypeScriptInterface writes shape as a TypeScript interface for items read
+// through the JavaScript SDK's unmarshall. Attributes some items lack are
+// optional and say how often they appear; several types make a union.
+func TypeScriptInterface(name string, shape Shape) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "export interface %s {\n", goIdent(name, "Item"))
+	for _, f := range shape.Fields {
+		prop := f.Name
+		if !isJSIdent(prop) {
+			prop = strconv.Quote(prop)
+		}
+		if f.Optional(shape.Items) {
+			fmt.Fprintf(&b, "  %s?: %s; // in %d of %d items\n", prop, f.tsType(), f.Count, shape.Items)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %s;\n", prop, f.tsType())
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// tsTypes are what unmarshall from @aws-sdk/util-dynamodb returns per type,
+// in the order a union lists them
+var tsTypes = []struct{ dynamo, ts string }{
+	{"S", "string"},
+	{"N", "number"},
+	{"BOOL", "boolean"},
+	{"B", "Uint8Array"},
+	{"SS", "Set<string>"},
+	{"NS", "Set<number>"},
+	{"BS", "Set<Uint8Array>"},
+	{"L", "unknown[]"},
+	{"M", "Record<string, unknown>"},
+	{"NULL", "null"},
+}
+
+func (f Field) tsType() string {
+	var union []string
+	for _, t := range tsTypes {
+		if f.Types[t.dynamo] > 0 {
+			union = append(union, t.ts)
+		}
+	}
+	if len(union) == 0 {
+		return "unknown"
+	}
+	return strings.Join(union, " | ")
+}
+
+func isJSIdent(name string) bool {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || i > 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// goInitialisms are the words Go spells in capitals inside identifiers
+var goInitialisms = map[string]bool{
+	"API": true, "ARN": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"TTL": true, "URL": true, "UUID": true,
+}
+
+// goIdent makes an exported Go identifier of an attribute or table name:
+// order_id and order-id become OrderID. A name with no letters gets prefix.
+func goIdent(name, prefix string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if up := strings.ToUpper(w); goInitialisms[up] {
+			b.WriteString(up)
+			continue
+		}
+		r := []rune(w)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	id := b.String()
+	if id == "" || !unicode.IsLetter([]rune(id)[0]) {
+		id = prefix + id
+	}
+	return id
+}
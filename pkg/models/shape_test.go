@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+var shapeItems = []map[string]types.AttributeValue{
+	{
+		"order_id": &types.AttributeValueMemberS{Value: "o-1"},
+		"total":    &types.AttributeValueMemberN{Value: "12.5"},
+		"qty":      &types.AttributeValueMemberN{Value: "3"},
+		"note":     &types.AttributeValueMemberNULL{Value: true},
+		"tags":     &types.AttributeValueMemberSS{Value: []string{"a"}},
+	},
+	{
+		"order_id": &types.AttributeValueMemberS{Value: "o-2"},
+		"total":    &types.AttributeValueMemberN{Value: "7"},
+		"qty":      &types.AttributeValueMemberN{Value: "1"},
+		"note":     &types.AttributeValueMemberS{Value: "gift"},
+		"2fa":      &types.AttributeValueMemberBOOL{Value: true},
+	},
+}
+
+func TestGoStruct(t *testing.T) {
+	got, err := GoStruct("order-lines", InferShape(shapeItems, "order_id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "type OrderLines struct {\n" +
+		"\tOrderID string   `dynamodbav:\"order_id\"`\n" +
+		"\tAttr2fa bool     `dynamodbav:\"2fa,omitempty\"`\n" +
+		"\tNote    *string  `dynamodbav:\"note\"`\n" +
+		"\tQty     int64    `dynamodbav:\"qty\"`\n" +
+		"\tTags    []string `dynamodbav:\"tags,omitempty\"`\n" +
+		"\tTotal   float64  `dynamodbav:\"total\"`\n" +
+		"}\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInferShapeMixedTypes(t *testing.T) {
+	shape := InferShape([]map[string]types.AttributeValue{
+		{"v": &types.AttributeValueMemberS{Value: "x"}},
+		{"v": &types.AttributeValueMemberN{Value: "1"}},
+	})
+	if f := shape.Fields[0]; f.Count != 2 || f.Optional(shape.Items) || f.goType() != "interface{}" {
+		t.Errorf("field %+v, Go type %s", f, f.goType())
+	}
+}
+
+func TestTypeScriptInterface(t *testing.T) {
+	got := TypeScriptInterface("order-lines", InferShape(shapeItems, "order_id"))
+	want := `export interface OrderLines {
+  order_id: string;
+  "2fa"?: boolean; // in 1 of 2 items
+  note: string | null;
+  qty: number;
+  tags?: Set<string>; // in 1 of 2 items
+  total: number;
+}
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestItemSize(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"id":    &types.AttributeValueMemberS{Value: "héllo"},       // 2 + 6
+		"n":     &types.AttributeValueMemberN{Value: "-00123.4500"}, // 1 + 4: 12345 at two digits a byte, plus one
+		"ok":    &types.AttributeValueMemberBOOL{Value: true},       // 2 + 1
+		"blob":  &types.AttributeValueMemberB{Value: []byte{1, 2}},  // 4 + 2
+		"tags":  &types.AttributeValueMemberSS{Value: []string{"a", "bc"}},
+		"inner": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"k": &types.AttributeValueMemberS{Value: "v"}}},
+		"list":  &types.AttributeValueMemberL{},
+	}
+	// tags 4 + 3; inner 5 + 3 + 1 + 1 + 1; list 4 + 3
+	if got, want := ItemSize(item), 8+5+3+6+7+11+7; got != want {
+		t.Errorf("ItemSize = %d, want %d", got, want)
+	}
+}
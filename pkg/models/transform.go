@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Transform reshapes items on their way to another table. Its spec has one
+// rule per line, applied in order to top-level attributes; blank lines and
+// lines starting with # are ignored:
+//
+//	rename old new     move old's value to new
+//	copy   src dst     set dst to src's value, keeping src
+//	drop   attr        remove attr
+//	set    attr VALUE  set attr to a JSON value: "text", 42, true, {...}
+//
+// rename and copy skip items without the source attribute.
+type Transform struct {
+	rules []transformRule
+}
+
+type transformRule struct {
+	op, attr, to string
+	value        types.AttributeValue // set
+}
+
+// ParseTransform reads a spec; an empty one copies items unchanged
+func ParseTransform(spec string) (*Transform, error) {
+	t := &Transform{}
+	for n, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		rule := transformRule{op: fields[0]}
+		bad := func(usage string) error {
+			return fmt.Errorf("line %d: %q: use %s", n+1, line, usage)
+		}
+		switch rule.op {
+		case "rename", "copy":
+			if len(fields) != 3 {
+				return nil, bad(rule.op + " <attribute> <new attribute>")
+			}
+			rule.attr, rule.to = fields[1], fields[2]
+		case "drop":
+			if len(fields) != 2 {
+				return nil, bad("drop <attribute>")
+			}
+			rule.attr = fields[1]
+		case "set":
+			if len(fields) < 3 {
+				return nil, bad("set <attribute> <JSON value>")
+			}
+			rule.attr = fields[1]
+			// The value is the rest of the line, spaces and all
+			raw := strings.TrimSpace(strings.TrimPrefix(line, "set"))
+			raw = strings.TrimSpace(strings.TrimPrefix(raw, rule.attr))
+			var v interface{}
+			if err := json.Unmarshal([]byte(raw), &v); err != nil {
+				return nil, fmt.Errorf("line %d: %s is not a JSON value (quote strings)", n+1, raw)
+			}
+			rule.value = InterfaceToAttributeValue(v)
+		default:
+			return nil, fmt.Errorf("line %d: unknown rule %q (rename, copy, drop or set)", n+1, rule.op)
+		}
+		t.rules = append(t.rules, rule)
+	}
+	return t, nil
+}
+
+// Empty reports whether the transform leaves items as they are
+func (t *Transform) Empty() bool {
+	return t == nil || len(t.rules) == 0
+}
+
+// Apply returns the transformed copy of item; item itself is not changed
+func (t *Transform) Apply(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	if t.Empty() {
+		return item
+	}
+	out := maps.Clone(item)
+	for _, r := range t.rules {
+		switch r.op {
+		case "rename":
+			if v, ok := out[r.attr]; ok {
+				delete(out, r.attr)
+				out[r.to] = v
+			}
+		case "copy":
+			if v, ok := out[r.attr]; ok {
+				out[r.to] = v
+			}
+		case "drop":
+			delete(out, r.attr)
+		case "set":
+			out[r.attr] = r.value
+		}
+	}
+	return out
+}
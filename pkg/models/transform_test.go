@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestTransformAppliesRulesInOrder(t *testing.T) {
+	tr, err := ParseTransform(`
+# new key schema: pk/sk instead of id
+rename id pk
+copy   createdAt sk
+drop   legacy
+set    migrated true
+set    source "v1 table"
+rename missing other
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := map[string]types.AttributeValue{
+		"id":        &types.AttributeValueMemberS{Value: "u1"},
+		"createdAt": &types.AttributeValueMemberS{Value: "2024-01-01"},
+		"legacy":    &types.AttributeValueMemberN{Value: "1"},
+	}
+	out := tr.Apply(item)
+
+	if _, ok := out["id"]; ok || out["pk"].(*types.AttributeValueMemberS).Value != "u1" {
+		t.Errorf("rename: %v", out)
+	}
+	if out["sk"].(*types.AttributeValueMemberS).Value != "2024-01-01" || out["createdAt"] == nil {
+		t.Errorf("copy: %v", out)
+	}
+	if _, ok := out["legacy"]; ok {
+		t.Error("drop left legacy")
+	}
+	if !out["migrated"].(*types.AttributeValueMemberBOOL).Value || out["source"].(*types.AttributeValueMemberS).Value != "v1 table" {
+		t.Errorf("set: %v", out)
+	}
+	if _, ok := out["other"]; ok {
+		t.Error("renaming a missing attribute should do nothing")
+	}
+	if _, ok := item["pk"]; ok || item["legacy"] == nil {
+		t.Error("the source item must not change")
+	}
+}
+
+func TestParseTransformRejectsBadRules(t *testing.T) {
+	for _, spec := range []string{"rename id", "drop", "set n", "set s unquoted", "upper name"} {
+		if _, err := ParseTransform(spec); err == nil {
+			t.Errorf("%q should not parse", spec)
+		}
+	}
+	if tr, err := ParseTransform("\n# nothing\n"); err != nil || !tr.Empty() {
+		t.Errorf("an empty spec should copy unchanged: %v", err)
+	}
+}
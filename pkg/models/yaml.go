@@ -0,0 +1,74 @@
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ItemToYAML converts a DynamoDB item to a YAML document
+func ItemToYAML(item map[string]types.AttributeValue) (string, error) {
+	data, err := marshalYAML(yamlSafe(NewItem(item).Attributes))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal item: %w", err)
+	}
+	return string(data), nil
+}
+
+// ItemsToYAML converts a list of DynamoDB items to a YAML sequence
+func ItemsToYAML(items []map[string]types.AttributeValue) ([]byte, error) {
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = yamlSafe(NewItem(item).Attributes)
+	}
+	data, err := marshalYAML(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal items: %w", err)
+	}
+	return data, nil
+}
+
+func marshalYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlSafe replaces binary values with base64 strings, the same text JSON
+// output uses, instead of yaml.v3's list-of-bytes encoding.
+func yamlSafe(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []byte:
+		return base64.StdEncoding.EncodeToString(val)
+	case [][]byte:
+		out := make([]string, len(val))
+		for i, b := range val {
+			out[i] = base64.StdEncoding.EncodeToString(b)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = yamlSafe(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = yamlSafe(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
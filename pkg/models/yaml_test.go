@@ -0,0 +1,39 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestItemToYAML(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"id":   &types.AttributeValueMemberS{Value: "1"},
+		"n":    &types.AttributeValueMemberN{Value: "42"},
+		"blob": &types.AttributeValueMemberB{Value: []byte("hi")},
+		"tags": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}}},
+	}
+	got, err := ItemToYAML(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "blob: aGk=\nid: \"1\"\n\"n\": 42\ntags:\n  - a\n"
+	if got != want {
+		t.Fatalf("ItemToYAML =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestItemsToYAMLIsSequence(t *testing.T) {
+	items := []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "a"}},
+		{"id": &types.AttributeValueMemberS{Value: "b"}},
+	}
+	data, err := ItemsToYAML(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); strings.Count(got, "- id:") != 2 {
+		t.Fatalf("expected two sequence entries:\n%s", got)
+	}
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+// Advice says in words what a Plan will do to the table before it runs.
+type Advice struct {
+	FullScan     bool    // a filtered Scan that may read the whole table
+	EstimatedRCU float64 // read capacity for a full scan; 0 when the size is unknown
+	Summary      string  // e.g. `Query index "by-email" (partition key email)`
+	Hint         string  // how the filter could become a Query, when it can
+}
+
+// scanRCUPer4KB is the cost of an eventually consistent read of 4 KB, which
+// is what Scan uses.
+const scanRCUPer4KB = 0.5
+
+// Advise explains plan for the table described by info. filterNames are the
+// attribute names the filter uses (ExpressionAttributeNames values).
+func Advise(info *dynamo.TableInfo, plan Plan, filterNames map[string]string) Advice {
+	if plan.Mode == ModeQuery {
+		key := plan.Names["#pk"]
+		if plan.IndexName == "" {
+			return Advice{Summary: fmt.Sprintf("Query the table (partition key %s)", key)}
+		}
+		return Advice{Summary: fmt.Sprintf("Query index %q (partition key %s)", plan.IndexName, key)}
+	}
+	if plan.FilterExpression == "" {
+		return Advice{Summary: "Scan the first page (no filter)"}
+	}
+
+	a := Advice{FullScan: true, Summary: "full-table Scan (table size unknown)"}
+	if info != nil && info.SizeBytes > 0 {
+		a.EstimatedRCU = math.Ceil(float64(info.SizeBytes)/4096) * scanRCUPer4KB
+		a.Summary = fmt.Sprintf("full-table Scan (est. %s RCUs over %s items)", formatCount(a.EstimatedRCU), formatCount(float64(info.ItemCount)))
+	}
+	if key := queryableKey(info, filterNames); key != "" {
+		a.Hint = fmt.Sprintf("make an = condition on %s the first condition to Query instead", key)
+	}
+	return a
+}
+
+// queryableKey returns a partition key (table or GSI) the filter mentions
+func queryableKey(info *dynamo.TableInfo, filterNames map[string]string) string {
+	if info == nil {
+		return ""
+	}
+	keys := []string{info.PartitionKey}
+	for _, gsi := range info.GSIs {
+		keys = append(keys, gsi.PartitionKey)
+	}
+	used := make(map[string]bool, len(filterNames))
+	for _, n := range filterNames {
+		used[n] = true
+	}
+	for _, k := range keys {
+		if k != "" && used[k] {
+			return k
+		}
+	}
+	return ""
+}
+
+// formatCount renders n with thousands separators, rounding up fractions
+func formatCount(n float64) string {
+	s := fmt.Sprintf("%.0f", math.Ceil(n))
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/godynamo/pkg/dynamo"
+)
+
+func adviceFor(info *dynamo.TableInfo, conds []Condition) Advice {
+	expr, names, values := BuildExpression(conds)
+	return Advise(info, BuildPlan(info, expr, names, values), names)
+}
+
+func TestAdviseNamesQueryTarget(t *testing.T) {
+	info := &dynamo.TableInfo{
+		PartitionKey: "id",
+		GSIs:         []dynamo.IndexInfo{{Name: "by-email", PartitionKey: "email"}},
+	}
+	if a := adviceFor(info, []Condition{{Name: "id", Operator: OpEquals, Value: "1"}}); a.FullScan || a.Summary != "Query the table (partition key id)" {
+		t.Fatalf("table query: %+v", a)
+	}
+	if a := adviceFor(info, []Condition{{Name: "email", Operator: OpEquals, Value: "x"}}); a.Summary != `Query index "by-email" (partition key email)` {
+		t.Fatalf("index query: %+v", a)
+	}
+}
+
+func TestAdviseEstimatesFullScan(t *testing.T) {
+	info := &dynamo.TableInfo{PartitionKey: "id", SizeBytes: 10 << 30, ItemCount: 12_000_000}
+	a := adviceFor(info, []Condition{{Name: "status", Operator: OpEquals, Value: "active"}})
+	if !a.FullScan || a.EstimatedRCU != 1_310_720 {
+		t.Fatalf("advice = %+v", a)
+	}
+	if a.Summary != "full-table Scan (est. 1,310,720 RCUs over 12,000,000 items)" {
+		t.Fatalf("summary = %q", a.Summary)
+	}
+	if a.Hint != "" {
+		t.Fatalf("no key in the filter, so no hint: %q", a.Hint)
+	}
+}
+
+func TestAdviseHintsWhenKeyIsNotFirst(t *testing.T) {
+	a := adviceFor(&dynamo.TableInfo{PartitionKey: "id"}, []Condition{
+		{Name: "status", Operator: OpEquals, Value: "active"},
+		{Name: "id", Operator: OpEquals, Value: "1"},
+	})
+	if !a.FullScan || !strings.Contains(a.Hint, "on id the first condition") {
+		t.Fatalf("advice = %+v", a)
+	}
+	if !strings.Contains(a.Summary, "size unknown") {
+		t.Fatalf("summary = %q", a.Summary)
+	}
+}
+
+func TestAdviseNoFilter(t *testing.T) {
+	if a := Advise(nil, BuildPlan(nil, "", nil, nil), nil); a.FullScan || a.Summary != "Scan the first page (no filter)" {
+		t.Fatalf("advice = %+v", a)
+	}
+}
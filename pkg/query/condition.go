@@ -1,5 +1,6 @@
 // Package query provides UI-agnostic DynamoDB filter-expression building and
-// Query-vs-Scan planning, shared by the terminal TUI and the GUI bridge.
+// Query-vs-Scan planning, shared by the terminal TUI, the GUI bridge and
+// programs that import it.
 package query
 
 import (
@@ -0,0 +1,261 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TokenKind classifies a piece of a PartiQL statement.
+type TokenKind int
+
+const (
+	TokenSpace TokenKind = iota
+	TokenKeyword
+	TokenIdent       // bare identifier: table or attribute name
+	TokenQuotedIdent // "double-quoted" identifier
+	TokenString      // 'single-quoted' string literal
+	TokenNumber
+	TokenPunct
+)
+
+// Token is one lexeme; concatenating every Token's Text gives back the input.
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// partiqlKeywords are the words DynamoDB's PartiQL dialect reserves,
+// including its built-in functions.
+var partiqlKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "AND": true, "OR": true, "NOT": true,
+	"IN": true, "BETWEEN": true, "IS": true, "MISSING": true, "NULL": true,
+	"INSERT": true, "INTO": true, "VALUE": true, "UPDATE": true, "SET": true,
+	"REMOVE": true, "DELETE": true, "RETURNING": true, "ALL": true, "OLD": true,
+	"NEW": true, "MODIFIED": true, "EXISTS": true, "ORDER": true, "BY": true,
+	"ASC": true, "DESC": true, "TRUE": true, "FALSE": true,
+	"BEGINS_WITH": true, "CONTAINS": true, "SIZE": true, "ATTRIBUTE_TYPE": true,
+	"LIST_APPEND": true, "SET_ADD": true, "SET_DELETE": true,
+}
+
+// Keywords returns the reserved words in alphabetical order.
+func Keywords() []string {
+	words := make([]string, 0, len(partiqlKeywords))
+	for w := range partiqlKeywords {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// IsKeyword reports whether word is reserved, in any case.
+func IsKeyword(word string) bool {
+	return partiqlKeywords[strings.ToUpper(word)]
+}
+
+// Tokenize splits a PartiQL statement into tokens. It never fails: an
+// unterminated string or identifier runs to the end of the input, which is
+// what an editor wants while the user is still typing.
+func Tokenize(stmt string) []Token {
+	var tokens []Token
+	rs := []rune(stmt)
+	for i := 0; i < len(rs); {
+		start := i
+		kind := TokenPunct
+		switch r := rs[i]; {
+		case unicode.IsSpace(r):
+			kind = TokenSpace
+			for i < len(rs) && unicode.IsSpace(rs[i]) {
+				i++
+			}
+		case r == '\'' || r == '"':
+			kind = TokenString
+			if r == '"' {
+				kind = TokenQuotedIdent
+			}
+			i++
+			for i < len(rs) {
+				if rs[i] == r {
+					// A doubled quote is an escaped quote inside the literal
+					if i+1 < len(rs) && rs[i+1] == r {
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				i++
+			}
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(rs) && unicode.IsDigit(rs[i+1]) && prevAllowsSign(tokens)):
+			kind = TokenNumber
+			i++
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' || rs[i] == 'e' || rs[i] == 'E') {
+				i++
+			}
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			kind = TokenIdent
+			if partiqlKeywords[strings.ToUpper(string(rs[start:i]))] {
+				kind = TokenKeyword
+			}
+		default:
+			i++
+			// Keep two-character operators together
+			if i < len(rs) && strings.Contains("<>=!", string(r)) && strings.Contains("<>=", string(rs[i])) {
+				i++
+			}
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: string(rs[start:i])})
+	}
+	return tokens
+}
+
+// prevAllowsSign reports whether a '-' after tokens starts a negative number
+// rather than being a subtraction.
+func prevAllowsSign(tokens []Token) bool {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch tokens[i].Kind {
+		case TokenSpace:
+			continue
+		case TokenPunct, TokenKeyword:
+			return tokens[i].Text != ")" && tokens[i].Text != "]"
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Statement is the part of a PartiQL statement the TUI needs to know before
+// running it.
+type Statement struct {
+	Kind  string // SELECT, INSERT, UPDATE, DELETE, or "" if unrecognised
+	Table string // table reference as written, quotes included
+	Where string // condition after WHERE, without any RETURNING clause
+}
+
+// ParseStatement finds the statement kind, target table and WHERE condition.
+func ParseStatement(stmt string) Statement {
+	all := Tokenize(stmt)
+	var words []int // indexes of the non-space tokens in all
+	for i, t := range all {
+		if t.Kind != TokenSpace {
+			words = append(words, i)
+		}
+	}
+	if len(words) == 0 {
+		return Statement{}
+	}
+	text := func(from, to int) string {
+		var b strings.Builder
+		for _, t := range all[from:to] {
+			b.WriteString(t.Text)
+		}
+		return strings.TrimSpace(b.String())
+	}
+
+	var s Statement
+	var tableAfter string
+	switch kind := strings.ToUpper(all[words[0]].Text); kind {
+	case "SELECT", "DELETE":
+		s.Kind, tableAfter = kind, "FROM"
+	case "INSERT":
+		s.Kind, tableAfter = kind, "INTO"
+	case "UPDATE":
+		s.Kind, tableAfter = kind, "UPDATE"
+	default:
+		return s
+	}
+
+	where := -1
+	for n, i := range words {
+		if all[i].Kind != TokenKeyword {
+			continue
+		}
+		switch strings.ToUpper(all[i].Text) {
+		case tableAfter:
+			if s.Table == "" {
+				s.Table = tableRef(all, words[n+1:])
+			}
+		case "WHERE":
+			if where < 0 {
+				where = i + 1
+			}
+		case "RETURNING":
+			if where >= 0 {
+				s.Where = text(where, i)
+				return s
+			}
+		}
+	}
+	if where >= 0 {
+		s.Where = strings.TrimSuffix(text(where, len(all)), ";")
+	}
+	return s
+}
+
+// tableRef reads a table reference, "Table" or "Table"."Index", from the
+// non-space tokens at words.
+func tableRef(all []Token, words []int) string {
+	var b strings.Builder
+	for n, i := range words {
+		t := all[i]
+		switch {
+		case n%2 == 0 && (t.Kind == TokenIdent || t.Kind == TokenQuotedIdent):
+			b.WriteString(t.Text)
+		case n%2 == 1 && t.Text == ".":
+			b.WriteString(t.Text)
+		default:
+			return strings.TrimSuffix(b.String(), ".")
+		}
+	}
+	return strings.TrimSuffix(b.String(), ".")
+}
+
+// IsWrite reports whether the statement may change data. A statement that
+// wasn't recognised counts as a write, so it is never run unchecked.
+func (s Statement) IsWrite() bool {
+	return s.Kind != "SELECT"
+}
+
+// Verb is Kind, or "statement" when the kind wasn't recognised.
+func (s Statement) Verb() string {
+	if s.Kind == "" {
+		return "statement"
+	}
+	return s.Kind
+}
+
+// PreviewSelect returns a SELECT over the items an UPDATE or DELETE would
+// touch, so they can be shown before the write runs. It returns "" for
+// other statements and for writes without a WHERE clause.
+func (s Statement) PreviewSelect() string {
+	if (s.Kind != "UPDATE" && s.Kind != "DELETE") || s.Table == "" || s.Where == "" {
+		return ""
+	}
+	return "SELECT * FROM " + s.Table + " WHERE " + s.Where
+}
+
+// TableName returns the table part of Table without quotes or index.
+func (s Statement) TableName() string {
+	name := s.Table
+	if !strings.HasPrefix(name, `"`) {
+		name, _, _ = strings.Cut(name, ".")
+		return name
+	}
+	var b strings.Builder
+	for i := 1; i < len(name); i++ {
+		if name[i] == '"' {
+			if i+1 < len(name) && name[i+1] == '"' {
+				b.WriteByte('"')
+				i++
+				continue
+			}
+			break
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
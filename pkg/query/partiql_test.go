@@ -0,0 +1,78 @@
+package query
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenizeRoundTrips(t *testing.T) {
+	stmt := `SELECT "name", age FROM "Users"."byAge" WHERE age >= -5 AND note = 'it''s "ok"'`
+	var b strings.Builder
+	for _, tok := range Tokenize(stmt) {
+		b.WriteString(tok.Text)
+	}
+	if b.String() != stmt {
+		t.Fatalf("round trip = %q", b.String())
+	}
+}
+
+func TestTokenizeKinds(t *testing.T) {
+	want := map[string]TokenKind{
+		"select":      TokenKeyword,
+		`"Users"`:     TokenQuotedIdent,
+		"age":         TokenIdent,
+		">=":          TokenPunct,
+		"-5":          TokenNumber,
+		`'it''s'`:     TokenString,
+		"begins_with": TokenKeyword,
+	}
+	for _, tok := range Tokenize(`select * from "Users" where age >= -5 and x = 'it''s' or begins_with(sk, 'a')`) {
+		if k, ok := want[tok.Text]; ok && k != tok.Kind {
+			t.Errorf("%q kind = %d, want %d", tok.Text, tok.Kind, k)
+		}
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	cases := []struct {
+		stmt  string
+		want  Statement
+		table string
+	}{
+		{`SELECT * FROM "Users" WHERE id = 'a'`, Statement{Kind: "SELECT", Table: `"Users"`, Where: "id = 'a'"}, "Users"},
+		{`select * from Orders."byDate"`, Statement{Kind: "SELECT", Table: `Orders."byDate"`}, "Orders"},
+		{`UPDATE "Users" SET age = 3 WHERE id = 'a' AND begins_with(sk, 'x') RETURNING ALL OLD *`,
+			Statement{Kind: "UPDATE", Table: `"Users"`, Where: "id = 'a' AND begins_with(sk, 'x')"}, "Users"},
+		{`DELETE FROM "My""Table" WHERE id = 'where';`, Statement{Kind: "DELETE", Table: `"My""Table"`, Where: "id = 'where'"}, `My"Table`},
+		{`INSERT INTO "Users" VALUE {'id': '1'}`, Statement{Kind: "INSERT", Table: `"Users"`}, "Users"},
+		{`EXISTS(SELECT * FROM "t")`, Statement{}, ""},
+	}
+	for _, c := range cases {
+		got := ParseStatement(c.stmt)
+		if got != c.want || got.TableName() != c.table {
+			t.Errorf("ParseStatement(%q) = %+v (table %q), want %+v (table %q)", c.stmt, got, got.TableName(), c.want, c.table)
+		}
+	}
+}
+
+func TestIsWrite(t *testing.T) {
+	for stmt, want := range map[string]bool{
+		`SELECT * FROM "Users"`:            false,
+		`DELETE FROM "Users" WHERE id = 1`: true,
+		`EXISTS(SELECT * FROM "t")`:        true,
+	} {
+		if got := ParseStatement(stmt).IsWrite(); got != want {
+			t.Errorf("IsWrite(%q) = %t, want %t", stmt, got, want)
+		}
+	}
+}
+
+func TestPreviewSelect(t *testing.T) {
+	s := ParseStatement(`DELETE FROM "Users" WHERE id = '1'`)
+	if got := s.PreviewSelect(); got != `SELECT * FROM "Users" WHERE id = '1'` {
+		t.Fatalf("preview = %q", got)
+	}
+	if ParseStatement(`INSERT INTO "Users" VALUE {'id': '1'}`).PreviewSelect() != "" {
+		t.Fatal("INSERT has nothing to preview")
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/godynamo/internal/dynamo"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 // Mode is the chosen read strategy.
@@ -3,7 +3,7 @@ package query
 import (
 	"testing"
 
-	"github.com/godynamo/internal/dynamo"
+	"github.com/godynamo/pkg/dynamo"
 )
 
 func planFor(t *testing.T, info *dynamo.TableInfo, conds []Condition) Plan {
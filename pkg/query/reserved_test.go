@@ -0,0 +1,15 @@
+package query
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReservedWords(t *testing.T) {
+	if !slices.IsSorted(reservedWords) {
+		t.Fatal("reservedWords must stay sorted for IsReservedWord's binary search")
+	}
+	if !IsReservedWord("status") || !IsReservedWord("Name") || IsReservedWord("email") {
+		t.Error("status and name are reserved, email isn't")
+	}
+}
@@ -0,0 +1,180 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Languages Snippet writes, in the order the TUI offers them
+const (
+	LangGo     = "go"
+	LangPython = "python"
+	LangNode   = "node"
+)
+
+var Languages = []string{LangGo, LangPython, LangNode}
+
+// Snippet writes plan's read of table as a call to the AWS SDK for lang (Go
+// v2, boto3 or the JavaScript v3 client), so a read worked out in the TUI
+// can be pasted into application code. The call assumes a client in scope.
+func Snippet(lang, table string, plan Plan) (string, error) {
+	op := "Scan"
+	if plan.Mode == ModeQuery {
+		op = "Query"
+	}
+	params := []param{{"TableName", table}}
+	if plan.IndexName != "" {
+		params = append(params, param{"IndexName", plan.IndexName})
+	}
+	if plan.KeyConditionExpression != "" {
+		params = append(params, param{"KeyConditionExpression", plan.KeyConditionExpression})
+	}
+	if plan.FilterExpression != "" {
+		params = append(params, param{"FilterExpression", plan.FilterExpression})
+	}
+	names := slices.Sorted(maps.Keys(plan.Names))
+	values := slices.Sorted(maps.Keys(plan.Values))
+
+	switch lang {
+	case LangGo:
+		return goSnippet(op, params, names, values, plan)
+	case LangPython:
+		return pythonSnippet(op, params, names, values, plan), nil
+	case LangNode:
+		return nodeSnippet(op, params, names, values, plan), nil
+	}
+	return "", fmt.Errorf("unknown language %q (have %s)", lang, strings.Join(Languages, ", "))
+}
+
+type param struct{ name, value string }
+
+// attributeValue is the type and text of an expression value, converted the
+// way the TUI converts it when it runs the read
+func attributeValue(v interface{}) (typ, text string) {
+	switch v := v.(type) {
+	case nil:
+		return "NULL", "true"
+	case bool:
+		return "BOOL", strconv.FormatBool(v)
+	case float64:
+		return "N", fmt.Sprintf("%v", v)
+	case int, int64:
+		return "N", fmt.Sprintf("%d", v)
+	case string:
+		return "S", v
+	}
+	return "S", fmt.Sprintf("%v", v)
+}
+
+func goSnippet(op string, params []param, names, values []string, plan Plan) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "out, err := client.%s(ctx, &dynamodb.%sInput{\n", op, op)
+	for _, p := range params {
+		fmt.Fprintf(&b, "%s: aws.String(%s),\n", p.name, strconv.Quote(p.value))
+	}
+	if len(names) > 0 {
+		b.WriteString("ExpressionAttributeNames: map[string]string{\n")
+		for _, k := range names {
+			fmt.Fprintf(&b, "%s: %s,\n", strconv.Quote(k), strconv.Quote(plan.Names[k]))
+		}
+		b.WriteString("},\n")
+	}
+	if len(values) > 0 {
+		b.WriteString("ExpressionAttributeValues: map[string]types.AttributeValue{\n")
+		for _, k := range values {
+			typ, text := attributeValue(plan.Values[k])
+			switch typ {
+			case "NULL", "BOOL":
+				fmt.Fprintf(&b, "%s: &types.AttributeValueMember%s{Value: %s},\n", strconv.Quote(k), typ, text)
+			default:
+				fmt.Fprintf(&b, "%s: &types.AttributeValueMember%s{Value: %s},\n", strconv.Quote(k), typ, strconv.Quote(text))
+			}
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("})\n")
+
+	// gofmt lays it out, inside a function it then leaves
+	src, err := format.Source([]byte("package p\nfunc f() {\n" + b.String() + "}\n"))
+	if err != nil {
+		return "", err
+	}
+	body := strings.TrimPrefix(string(src), "package p\n\nfunc f() {\n")
+	body = strings.TrimSuffix(body, "}\n")
+	return strings.ReplaceAll(strings.TrimPrefix(body, "\t"), "\n\t", "\n"), nil
+}
+
+func pythonSnippet(op string, params []param, names, values []string, plan Plan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "response = client.%s(\n", strings.ToLower(op))
+	for _, p := range params {
+		fmt.Fprintf(&b, "    %s=%s,\n", p.name, quote(p.value))
+	}
+	if len(names) > 0 {
+		b.WriteString("    ExpressionAttributeNames={\n")
+		for _, k := range names {
+			fmt.Fprintf(&b, "        %s: %s,\n", quote(k), quote(plan.Names[k]))
+		}
+		b.WriteString("    },\n")
+	}
+	if len(values) > 0 {
+		b.WriteString("    ExpressionAttributeValues={\n")
+		for _, k := range values {
+			typ, text := attributeValue(plan.Values[k])
+			switch typ {
+			case "NULL", "BOOL":
+				text = strings.ToUpper(text[:1]) + text[1:] // True, False
+			default:
+				text = quote(text)
+			}
+			fmt.Fprintf(&b, "        %s: {%s: %s},\n", quote(k), quote(typ), text)
+		}
+		b.WriteString("    },\n")
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+func nodeSnippet(op string, params []param, names, values []string, plan Plan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "import { %sCommand } from \"@aws-sdk/client-dynamodb\";\n\n", op)
+	fmt.Fprintf(&b, "const response = await client.send(new %sCommand({\n", op)
+	for _, p := range params {
+		fmt.Fprintf(&b, "  %s: %s,\n", p.name, quote(p.value))
+	}
+	if len(names) > 0 {
+		b.WriteString("  ExpressionAttributeNames: {\n")
+		for _, k := range names {
+			fmt.Fprintf(&b, "    %s: %s,\n", quote(k), quote(plan.Names[k]))
+		}
+		b.WriteString("  },\n")
+	}
+	if len(values) > 0 {
+		b.WriteString("  ExpressionAttributeValues: {\n")
+		for _, k := range values {
+			typ, text := attributeValue(plan.Values[k])
+			if typ != "NULL" && typ != "BOOL" {
+				text = quote(text)
+			}
+			fmt.Fprintf(&b, "    %s: { %s: %s },\n", quote(k), typ, text)
+		}
+		b.WriteString("  },\n")
+	}
+	b.WriteString("}));\n")
+	return b.String()
+}
+
+// quote is s as a double-quoted string literal Python and JavaScript both read
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,76 @@
+package query
+
+import "testing"
+
+var snippetPlan = Plan{
+	Mode:                   ModeQuery,
+	IndexName:              "by-customer",
+	KeyConditionExpression: "#pk = :val0",
+	FilterExpression:       "#attr1 > :val1",
+	Names:                  map[string]string{"#pk": "customer", "#attr1": "total"},
+	Values:                 map[string]interface{}{":val0": "c-42", ":val1": 100.5},
+}
+
+func TestSnippetGo(t *testing.T) {
+	got, err := Snippet(LangGo, "orders", snippetPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `out, err := client.Query(ctx, &dynamodb.QueryInput{
+	TableName:              aws.String("orders"),
+	IndexName:              aws.String("by-customer"),
+	KeyConditionExpression: aws.String("#pk = :val0"),
+	FilterExpression:       aws.String("#attr1 > :val1"),
+	ExpressionAttributeNames: map[string]string{
+		"#attr1": "total",
+		"#pk":    "customer",
+	},
+	ExpressionAttributeValues: map[string]types.AttributeValue{
+		":val0": &types.AttributeValueMemberS{Value: "c-42"},
+		":val1": &types.AttributeValueMemberN{Value: "100.5"},
+	},
+})
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSnippetPython(t *testing.T) {
+	got, _ := Snippet(LangPython, "orders", Plan{
+		Mode:             ModeScan,
+		FilterExpression: "#attr0 = :val0",
+		Names:            map[string]string{"#attr0": "active"},
+		Values:           map[string]interface{}{":val0": true},
+	})
+	want := `response = client.scan(
+    TableName="orders",
+    FilterExpression="#attr0 = :val0",
+    ExpressionAttributeNames={
+        "#attr0": "active",
+    },
+    ExpressionAttributeValues={
+        ":val0": {"BOOL": True},
+    },
+)
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSnippetNode(t *testing.T) {
+	got, _ := Snippet(LangNode, "orders", Plan{Mode: ModeScan})
+	want := `import { ScanCommand } from "@aws-sdk/client-dynamodb";
+
+const response = await client.send(new ScanCommand({
+  TableName: "orders",
+}));
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if _, err := Snippet("cobol", "orders", Plan{}); err == nil {
+		t.Error("an unknown language should be refused")
+	}
+}
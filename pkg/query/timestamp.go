@@ -0,0 +1,220 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeEncoding is how an attribute stores points in time: as epoch numbers
+// in some unit, or as strings in some layout
+type TimeEncoding struct {
+	Epoch  time.Duration // unit of an epoch number; 0 for strings
+	Layout string        // time layout of a string
+	Offset int           // seconds east of UTC strings are written at
+}
+
+// Known reports whether the encoding was recognised
+func (e TimeEncoding) Known() bool {
+	return e.Epoch != 0 || e.Layout != ""
+}
+
+func (e TimeEncoding) String() string {
+	switch {
+	case e.Epoch == time.Second:
+		return "epoch seconds"
+	case e.Epoch == time.Millisecond:
+		return "epoch milliseconds"
+	case e.Layout == time.DateOnly:
+		return "ISO 8601 dates"
+	case e.Layout != "":
+		return "ISO 8601 timestamps"
+	}
+	return "unknown"
+}
+
+// Encode writes t the way the attribute stores it, as a filter value
+func (e TimeEncoding) Encode(t time.Time) string {
+	if e.Epoch != 0 {
+		return strconv.FormatInt(t.UnixNano()/int64(e.Epoch), 10)
+	}
+	return t.In(time.FixedZone("", e.Offset)).Format(e.Layout)
+}
+
+// isoLayouts are the string timestamps DetectTimeEncoding recognises. A
+// value must format back exactly, so a filter value compares the way the
+// stored strings do.
+var isoLayouts = []string{
+	time.DateOnly,
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.000Z07:00",
+	"2006-01-02T15:04:05.000000Z07:00",
+	"2006-01-02T15:04:05.000000000Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.000",
+	time.DateTime,
+}
+
+// Epoch seconds and milliseconds from about 2001 to 5138
+const (
+	minEpochSeconds = 1e9
+	maxEpochSeconds = 1e11
+)
+
+// DetectTimeEncoding guesses how an attribute stores time from sample
+// values of it (strings, and numbers as int64 or float64), by the encoding
+// most of the recognisable samples share
+func DetectTimeEncoding(samples []interface{}) TimeEncoding {
+	votes := map[TimeEncoding]int{}
+	var best TimeEncoding
+	for _, v := range samples {
+		e, ok := timeEncodingOf(v)
+		if !ok {
+			continue
+		}
+		if votes[e]++; votes[e] > votes[best] {
+			best = e
+		}
+	}
+	return best
+}
+
+func timeEncodingOf(v interface{}) (TimeEncoding, bool) {
+	var n float64
+	switch v := v.(type) {
+	case int64:
+		n = float64(v)
+	case float64:
+		n = v
+	case string:
+		for _, layout := range isoLayouts {
+			t, err := time.Parse(layout, v)
+			if err != nil || t.Format(layout) != v {
+				continue
+			}
+			_, offset := t.Zone()
+			return TimeEncoding{Layout: layout, Offset: offset}, true
+		}
+		return TimeEncoding{}, false
+	default:
+		return TimeEncoding{}, false
+	}
+	switch {
+	case n >= minEpochSeconds && n < maxEpochSeconds:
+		return TimeEncoding{Epoch: time.Second}, true
+	case n >= minEpochSeconds*1000 && n < maxEpochSeconds*1000:
+		return TimeEncoding{Epoch: time.Millisecond}, true
+	}
+	return TimeEncoding{}, false
+}
+
+// dateLayouts are the ways ParseDate accepts a date, most specific first
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateTime,
+	"2006-01-02 15:04",
+	time.DateOnly,
+	"2006/01/02",
+}
+
+// ParseDate reads a date someone typed, such as 2024-05-01 or
+// 2024-05-01 13:45. Without a zone it is taken as UTC, which is how
+// DynamoDB tables usually keep time.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date: use YYYY-MM-DD, optionally with HH:MM[:SS]", s)
+}
+
+// relativeUnits are the units of a relative time, by the names it can use
+var relativeUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+var (
+	nowOffset  = regexp.MustCompile(`^now\s*([+-])\s*((?:\d+\s*[a-z]+\s*)+)$`)
+	offsetPart = regexp.MustCompile(`(\d+)\s*([a-z]+)`)
+	lastPeriod = regexp.MustCompile(`^(?:last|past)\s+(\d+\s+)?([a-z]+)$`)
+)
+
+// RelativeTime reads a time given relative to now: now, now-24h, now-1d12h,
+// now+30m, last 7 days, last hour, today or yesterday (days start at UTC
+// midnight)
+func RelativeTime(s string, now time.Time) (time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := now.UTC().Truncate(24 * time.Hour)
+	switch s {
+	case "now":
+		return now, true
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.Add(-24 * time.Hour), true
+	}
+
+	if m := nowOffset.FindStringSubmatch(s); m != nil {
+		var d time.Duration
+		for _, part := range offsetPart.FindAllStringSubmatch(m[2], -1) {
+			unit, ok := relativeUnits[part[2]]
+			if !ok {
+				return time.Time{}, false
+			}
+			n, _ := strconv.Atoi(part[1])
+			d += time.Duration(n) * unit
+		}
+		if m[1] == "-" {
+			d = -d
+		}
+		return now.Add(d), true
+	}
+	if m := lastPeriod.FindStringSubmatch(s); m != nil {
+		unit, ok := relativeUnits[m[2]]
+		if !ok {
+			return time.Time{}, false
+		}
+		n := 1
+		if m[1] != "" {
+			n, _ = strconv.Atoi(strings.TrimSpace(m[1]))
+		}
+		return now.Add(-time.Duration(n) * unit), true
+	}
+	return time.Time{}, false
+}
+
+// ExpandTimes writes the relative times in conds' range comparisons (>, <,
+// >= and <=) as of now, the way encoding says their attribute stores time.
+// Other conditions are kept as they are.
+func ExpandTimes(conds []Condition, now time.Time, encoding func(attr string) TimeEncoding) ([]Condition, error) {
+	out := slices.Clone(conds)
+	for i, c := range out {
+		switch c.Operator {
+		case OpGreaterThan, OpLessThan, OpGreaterOrEqual, OpLessOrEqual:
+		default:
+			continue
+		}
+		t, ok := RelativeTime(c.Value, now)
+		if !ok {
+			continue
+		}
+		name := strings.TrimSpace(c.Name)
+		enc := encoding(name)
+		if !enc.Known() {
+			return nil, fmt.Errorf("can't tell how %s stores time to read %q: load items holding it first", name, strings.TrimSpace(c.Value))
+		}
+		out[i].Value = enc.Encode(t)
+	}
+	return out, nil
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectTimeEncoding(t *testing.T) {
+	may1 := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		samples []interface{}
+		want    string
+	}{
+		{[]interface{}{int64(1714521600), int64(1714608000), "n/a"}, "1714521600"},
+		{[]interface{}{int64(1714521600123), float64(1714521600000)}, "1714521600000"},
+		{[]interface{}{"2024-04-30", "2024-05-02"}, "2024-05-01"},
+		{[]interface{}{"2024-04-30T10:00:00Z"}, "2024-05-01T00:00:00Z"},
+		{[]interface{}{"2024-04-30T10:00:00.250Z", "2024-04-30"}, "2024-05-01T00:00:00.000Z"},
+		{[]interface{}{"2024-04-30T10:00:00+02:00"}, "2024-05-01T02:00:00+02:00"},
+	}
+	for _, tc := range cases {
+		enc := DetectTimeEncoding(tc.samples)
+		if got := enc.Encode(may1); got != tc.want {
+			t.Errorf("%v: %s encodes May 1st as %q, want %q", tc.samples, enc, got, tc.want)
+		}
+	}
+}
+
+func TestDetectTimeEncodingUnknown(t *testing.T) {
+	if enc := DetectTimeEncoding([]interface{}{int64(42), "alice", true}); enc.Known() {
+		t.Errorf("detected %s in values that aren't times", enc)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	for in, want := range map[string]time.Time{
+		"2024-05-01":           time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		" 2024-05-01 13:45 ":   time.Date(2024, 5, 1, 13, 45, 0, 0, time.UTC),
+		"2024-05-01T13:45:30Z": time.Date(2024, 5, 1, 13, 45, 30, 0, time.UTC),
+	} {
+		got, err := ParseDate(in)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseDate("yesterday"); err == nil {
+		t.Error("yesterday isn't a date")
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 5, 8, 15, 30, 0, 0, time.UTC)
+	for in, want := range map[string]time.Time{
+		"now":          now,
+		"now-24h":      now.Add(-24 * time.Hour),
+		"NOW - 1d12h":  now.Add(-36 * time.Hour),
+		"now+30m":      now.Add(30 * time.Minute),
+		"last 7 days":  now.Add(-7 * 24 * time.Hour),
+		"last hour":    now.Add(-time.Hour),
+		"past 2 weeks": now.Add(-14 * 24 * time.Hour),
+		"today":        time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC),
+		"yesterday":    time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC),
+	} {
+		got, ok := RelativeTime(in, now)
+		if !ok || !got.Equal(want) {
+			t.Errorf("RelativeTime(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"now-24", "now-3 fortnights", "last", "last 7 parsecs", "2024-05-01", "nowhere"} {
+		if got, ok := RelativeTime(in, now); ok {
+			t.Errorf("RelativeTime(%q) = %v, want no time", in, got)
+		}
+	}
+}
+
+func TestExpandTimes(t *testing.T) {
+	now := time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)
+	encodings := map[string]TimeEncoding{
+		"created": {Epoch: time.Second},
+		"updated": {Layout: time.DateOnly},
+	}
+	conds := []Condition{
+		{Name: "created", Operator: OpGreaterOrEqual, Value: "now-24h"},
+		{Name: "updated", Operator: OpLessThan, Value: "last 7 days"},
+		{Name: "status", Operator: OpEquals, Value: "today"},
+	}
+	got, err := ExpandTimes(conds, now, func(attr string) TimeEncoding { return encodings[attr] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Value != "1715040000" || got[1].Value != "2024-05-01" || got[2].Value != "today" {
+		t.Errorf("expanded %+v", got)
+	}
+	if conds[0].Value != "now-24h" {
+		t.Error("ExpandTimes changed its input")
+	}
+
+	conds[0].Name = "unknown"
+	if _, err := ExpandTimes(conds, now, func(attr string) TimeEncoding { return encodings[attr] }); err == nil {
+		t.Error("a relative time for an attribute of unknown encoding should be refused")
+	}
+}